@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/odo/pkg/odo/util/completion"
 	"github.com/openshift/odo/pkg/preference"
 	segment "github.com/openshift/odo/pkg/segment/context"
+	odoutil "github.com/openshift/odo/pkg/util"
 	"github.com/posener/complete"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -59,6 +60,8 @@ func main() {
 	if err != nil {
 		util.LogErrorAndExit(err, "")
 	}
+	// all the outbound HTTP requests go through the proxy and use the certificates of the preference
+	odoutil.SetHTTPTransportSettings(cfg.GetHTTPTransportSettings())
 
 	// Call commands
 	// checking the value of updatenotification in config
@@ -5,6 +5,8 @@ go 1.15
 require (
 	github.com/Netflix/go-expect v0.0.0-20201125194554-85d881c3777e
 	github.com/blang/semver v3.5.1+incompatible
+	github.com/containerd/containerd v1.4.3
+	github.com/deislabs/oras v0.8.1
 	github.com/devfile/api/v2 v2.1.0
 	github.com/devfile/library v1.0.0
 	github.com/devfile/registry-support/index/generator v0.0.0-20210407161420-cd279527f873
@@ -19,7 +21,6 @@ require (
 	github.com/gobwas/glob v0.2.3
 	github.com/golang/mock v1.5.0
 	github.com/google/go-cmp v0.5.4 // indirect
-	github.com/gregjones/httpcache v0.0.0-20190611155906-901d90724c79
 	github.com/hashicorp/go-multierror v1.1.1 // indirect
 	github.com/hinshun/vt10x v0.0.0-20180809195222-d55458df857c
 	github.com/kubernetes-sigs/service-catalog v0.3.1
@@ -37,6 +38,7 @@ require (
 	github.com/operator-framework/operator-lifecycle-manager v0.17.0
 	github.com/pborman/uuid v1.2.0
 	github.com/pkg/errors v0.9.1
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/posener/complete v1.1.1
 	github.com/redhat-developer/service-binding-operator v0.7.1
 	github.com/securego/gosec/v2 v2.8.0
@@ -48,6 +50,7 @@ require (
 	github.com/stretchr/testify v1.7.0
 	github.com/tidwall/gjson v1.7.5
 	github.com/tidwall/sjson v1.1.6
+	github.com/xeipuuv/gojsonschema v1.2.0
 	github.com/xtgo/uuid v0.0.0-20140804021211-a0b114877d4c // indirect
 	github.com/zalando/go-keyring v0.1.1
 	golang.org/x/crypto v0.0.0-20210220033148-5ea612d1eb83 // indirect
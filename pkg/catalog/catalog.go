@@ -16,8 +16,6 @@ import (
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/occlient"
 
-	indexSchema "github.com/devfile/registry-support/index/generator/schema"
-	registryLibrary "github.com/devfile/registry-support/registry-library/library"
 	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
 	"github.com/openshift/odo/pkg/util"
 	olm "github.com/operator-framework/api/pkg/operators/v1alpha1"
@@ -124,7 +122,7 @@ const indexPath = "/devfiles/index.json"
 
 // getRegistryDevfiles retrieves the registry's index devfile entries
 func getRegistryDevfiles(registry Registry) (registryDevfiles []DevfileComponentType, err error) {
-	var devfileIndex []indexSchema.Schema
+	var devfileIndex []Stack
 
 	if strings.Contains(registry.URL, "github") {
 		// Github-based registry
@@ -145,23 +143,23 @@ func getRegistryDevfiles(registry Registry) (registryDevfiles []DevfileComponent
 			request.Token = token
 		}
 
-		cfg, err := preference.New()
+		request, cacheFor, err := cachedRegistryRequest(registry, request)
 		if err != nil {
 			return nil, err
 		}
 
-		jsonBytes, err := util.HTTPGetRequest(request, cfg.GetRegistryCacheTime())
+		jsonBytes, err := util.HTTPGetRequest(request, cacheFor)
 		if err != nil {
 			return nil, errors.Wrapf(err, "unable to download the devfile index.json from %s", indexLink)
 		}
 
 		err = json.Unmarshal(jsonBytes, &devfileIndex)
 		if err != nil {
-			if err := util.CleanDefaultHTTPCacheDir(); err != nil {
-				log.Warning("Error while cleaning up cache dir.")
+			if err := util.DeleteHTTPCacheEntry(indexLink); err != nil {
+				log.Warningf("Error while deleting the cached index of registry %s.", registry.Name)
 			}
 			// we try once again
-			jsonBytes, err := util.HTTPGetRequest(request, cfg.GetRegistryCacheTime())
+			jsonBytes, err := util.HTTPGetRequest(request, cacheFor)
 			if err != nil {
 				return nil, errors.Wrapf(err, "unable to download the devfile index.json from %s", indexLink)
 			}
@@ -173,7 +171,7 @@ func getRegistryDevfiles(registry Registry) (registryDevfiles []DevfileComponent
 		}
 	} else {
 		// OCI-based registry
-		devfileIndex, err = registryLibrary.GetRegistryStacks(registry.URL)
+		devfileIndex, err = GetRegistryStacks(registry)
 		if err != nil {
 			return nil, err
 		}
@@ -187,7 +185,10 @@ func getRegistryDevfiles(registry Registry) (registryDevfiles []DevfileComponent
 			Link:        devfileIndexEntry.Links["self"],
 			Registry:    registry,
 			Language:    devfileIndexEntry.Language,
+			ProjectType: devfileIndexEntry.ProjectType,
 			Tags:        devfileIndexEntry.Tags,
+			Version:     devfileIndexEntry.DefaultVersion(),
+			Versions:    devfileIndexEntry.Versions,
 		}
 		registryDevfiles = append(registryDevfiles, stackDevfile)
 	}
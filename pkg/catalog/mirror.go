@@ -0,0 +1,417 @@
+package catalog
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/openshift/odo/pkg/devfile"
+	"github.com/openshift/odo/pkg/log"
+	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+const (
+	// MirrorManifestFile is the file of a registry mirror holding its manifest
+	MirrorManifestFile = "mirror.json"
+	// MirrorManifestKind is the kind of the manifest of a registry mirror
+	MirrorManifestKind = "RegistryMirror"
+
+	// mirrorIndexFile is the file of a registry mirror holding the index of the registry
+	mirrorIndexFile = "index.json"
+	// mirrorStacksDir is the directory of a registry mirror holding a directory per stack
+	mirrorStacksDir = "stacks"
+	// mirrorStartersDir is the directory of a stack of a registry mirror holding a zip file per starter project
+	mirrorStartersDir = "starters"
+	// mirrorDevfile is the devfile of a stack of a registry mirror
+	mirrorDevfile = "devfile.yaml"
+)
+
+// MirrorManifest describes the content of a registry mirror, its name is the name of the mirrored registry
+type MirrorManifest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              MirrorManifestSpec `json:"spec"`
+}
+
+// MirrorManifestSpec holds the origin of a registry mirror, and the digests of its files
+type MirrorManifestSpec struct {
+	// RegistryURL is the URL of the mirrored registry
+	RegistryURL string `json:"registryURL"`
+	// Stacks is the number of stacks in the mirror
+	Stacks int `json:"stacks"`
+	// StarterProjects is the number of starter projects in the mirror
+	StarterProjects int `json:"starterProjects"`
+	// Files are the SHA256 digests of the files of the mirror, by their slash separated path relative to the mirror
+	Files map[string]string `json:"files"`
+}
+
+// StarterProjectDownloader downloads the given starter project to the given empty directory
+type StarterProjectDownloader func(starterProject *devfilev1.StarterProject, destDir string) error
+
+// MirrorRegistry downloads the index of the given OCI-based registry, the devfiles and resources of its stacks
+// and their starter projects to the given directory, which can then be used as a file:// registry
+func MirrorRegistry(registry Registry, dir string, downloadStarterProject StarterProjectDownloader) (MirrorManifest, error) {
+	manifest := MirrorManifest{
+		TypeMeta:   metav1.TypeMeta{Kind: MirrorManifestKind, APIVersion: apiVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: registry.Name},
+		Spec: MirrorManifestSpec{
+			RegistryURL: registry.URL,
+		},
+	}
+	if registryUtil.IsGitBasedRegistry(registry.URL) || registryUtil.IsMirrorRegistry(registry.URL) {
+		return manifest, errors.Errorf("registry %s is not an OCI-based registry, only OCI-based registries can be mirrored", registry.Name)
+	}
+
+	index, err := GetRegistryStacks(registry)
+	if err != nil {
+		return manifest, err
+	}
+
+	for i, stack := range index {
+		// only the default version of the stacks is mirrored
+		if defaultVersion, err := stack.GetVersion(""); err == nil && len(stack.Versions) > 0 {
+			defaultVersion.Default = true
+			index[i].Version = defaultVersion.Version
+			index[i].Versions = []StackVersion{defaultVersion}
+		}
+
+		stackDir := filepath.Join(dir, mirrorStacksDir, stack.Name)
+		if err = os.MkdirAll(stackDir, 0750); err != nil {
+			return manifest, err
+		}
+
+		s := log.Spinnerf("Mirroring stack %s", stack.Name)
+		err = PullStackFromRegistry(registry, stack.Name, "", stackDir)
+		s.End(err == nil)
+		if err != nil {
+			return manifest, err
+		}
+		manifest.Spec.Stacks++
+
+		devObj, err := devfile.ParseFromFile(filepath.Join(stackDir, mirrorDevfile))
+		if err != nil {
+			return manifest, errors.Wrapf(err, "unable to parse the devfile of stack %s", stack.Name)
+		}
+		starterProjects, err := devObj.Data.GetStarterProjects(parsercommon.DevfileOptions{})
+		if err != nil {
+			return manifest, err
+		}
+		for i := range starterProjects {
+			err = mirrorStarterProject(&starterProjects[i], filepath.Join(stackDir, mirrorStartersDir), downloadStarterProject)
+			if err != nil {
+				// the stack can still be used without this starter project
+				log.Warningf("Unable to mirror starter project %s of stack %s: %v", starterProjects[i].Name, stack.Name, err)
+				continue
+			}
+			manifest.Spec.StarterProjects++
+		}
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return manifest, errors.Wrap(err, "unable to marshal the index of the registry")
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, mirrorIndexFile), data, 0640); err != nil {
+		return manifest, err
+	}
+
+	manifest.Spec.Files, err = digestFiles(dir)
+	if err != nil {
+		return manifest, err
+	}
+	manifest.CreationTimestamp = metav1.Now()
+	data, err = json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, errors.Wrap(err, "unable to marshal the manifest of the mirror")
+	}
+	return manifest, ioutil.WriteFile(filepath.Join(dir, MirrorManifestFile), data, 0640)
+}
+
+// mirrorStarterProject downloads the given starter project and saves it as a zip file in the given directory
+func mirrorStarterProject(starterProject *devfilev1.StarterProject, startersDir string, downloadStarterProject StarterProjectDownloader) error {
+	tmpDir, err := ioutil.TempDir("", "odo-starter")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = downloadStarterProject(starterProject, tmpDir); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(startersDir, 0750); err != nil {
+		return err
+	}
+	return zipDir(tmpDir, starterProject.Name, filepath.Join(startersDir, starterProject.Name+".zip"))
+}
+
+// ReadMirrorManifest reads the manifest of the registry mirror of the given directory
+func ReadMirrorManifest(dir string) (MirrorManifest, error) {
+	var manifest MirrorManifest
+	data, err := ioutil.ReadFile(filepath.Join(dir, MirrorManifestFile))
+	if err != nil {
+		return manifest, errors.Wrapf(err, "%s is not a registry mirror", dir)
+	}
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return manifest, errors.Wrapf(err, "unable to read the manifest of registry mirror %s", dir)
+	}
+	if manifest.Kind != MirrorManifestKind {
+		return manifest, fmt.Errorf("the manifest of %s is of kind %q, expected %q", dir, manifest.Kind, MirrorManifestKind)
+	}
+	return manifest, nil
+}
+
+// registryMirror is a registry mirror served by a file:// registry
+type registryMirror struct {
+	dir      string
+	manifest MirrorManifest
+}
+
+// openMirror opens the registry mirror of the given file:// registry
+func openMirror(registry Registry) (*registryMirror, error) {
+	dir, err := MirrorDir(registry.URL)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := ReadMirrorManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &registryMirror{dir: dir, manifest: manifest}, nil
+}
+
+// MirrorDir returns the directory of the registry mirror of the given file:// URL
+func MirrorDir(registryURL string) (string, error) {
+	parsedURL, err := url.Parse(registryURL)
+	if err != nil || parsedURL.Scheme != "file" {
+		return "", errors.Errorf("%s is not a file:// URL", registryURL)
+	}
+	dir := parsedURL.Path
+	if runtime.GOOS == "windows" {
+		// file:///C:/mirror has the path /C:/mirror
+		dir = strings.TrimPrefix(dir, "/")
+	}
+	return filepath.FromSlash(dir), nil
+}
+
+// readFile reads the given file of the mirror, verifying its digest matches the manifest
+func (m *registryMirror) readFile(name string) ([]byte, error) {
+	want, ok := m.manifest.Spec.Files[name]
+	if !ok {
+		return nil, errors.Errorf("the file %s is not in the manifest of registry mirror %s", name, m.dir)
+	}
+	data, err := ioutil.ReadFile(filepath.Join(m.dir, filepath.FromSlash(name)))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, errors.Errorf("the file %s of registry mirror %s is corrupted, its digest is %s but the manifest expects %s", name, m.dir, got, want)
+	}
+	return data, nil
+}
+
+// stacks returns the index of the mirrored registry
+func (m *registryMirror) stacks() ([]Stack, error) {
+	data, err := m.readFile(mirrorIndexFile)
+	if err != nil {
+		return nil, err
+	}
+	var index []Stack
+	if err = json.Unmarshal(data, &index); err != nil {
+		return nil, errors.Wrapf(err, "unable to unmarshal the index of registry mirror %s", m.dir)
+	}
+	return index, nil
+}
+
+// pullStack copies the devfile and the resources of the given stack to the destination directory
+func (m *registryMirror) pullStack(stack string, destDir string) error {
+	stackPrefix := path.Join(mirrorStacksDir, stack) + "/"
+	startersPrefix := stackPrefix + mirrorStartersDir + "/"
+	found := false
+	for name := range m.manifest.Spec.Files {
+		if !strings.HasPrefix(name, stackPrefix) || strings.HasPrefix(name, startersPrefix) {
+			continue
+		}
+		found = true
+		data, err := m.readFile(name)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destDir, filepath.FromSlash(strings.TrimPrefix(name, stackPrefix)))
+		if err = os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(target, data, 0640); err != nil {
+			return err
+		}
+	}
+	if !found {
+		return errors.Errorf("stack %s does not exist in the registry mirror %s", stack, m.dir)
+	}
+	return nil
+}
+
+// GetMirroredStarterProject returns the given starter project of the given stack of a file:// registry,
+// pointing to its zip file in the registry mirror after verifying its integrity
+// other registries have their starter projects returned unchanged
+func GetMirroredStarterProject(registry Registry, stack string, starterProject *devfilev1.StarterProject) (*devfilev1.StarterProject, error) {
+	if !registryUtil.IsMirrorRegistry(registry.URL) {
+		return starterProject, nil
+	}
+	mirror, err := openMirror(registry)
+	if err != nil {
+		return nil, err
+	}
+	name := path.Join(mirrorStacksDir, stack, mirrorStartersDir, starterProject.Name+".zip")
+	if _, err = mirror.readFile(name); err != nil {
+		return nil, errors.Wrapf(err, "starter project %s of stack %s is not available in the registry mirror", starterProject.Name, stack)
+	}
+
+	zipPath, err := filepath.Abs(filepath.Join(mirror.dir, filepath.FromSlash(name)))
+	if err != nil {
+		return nil, err
+	}
+	mirrored := devfilev1.StarterProject{
+		Name:        starterProject.Name,
+		Attributes:  starterProject.Attributes,
+		Description: starterProject.Description,
+		ProjectSource: devfilev1.ProjectSource{
+			Zip: &devfilev1.ZipProjectSource{
+				Location: "file://" + filepath.ToSlash(zipPath),
+			},
+		},
+	}
+	return &mirrored, nil
+}
+
+// GetRegistryDevfile returns the devfile of the given version of the given stack of an OCI-based or file:// registry,
+// the devfile of the default version if the version is empty
+func GetRegistryDevfile(registry Registry, stack string, version string) ([]byte, error) {
+	if registryUtil.IsMirrorRegistry(registry.URL) {
+		if version != "" {
+			if err := checkMirroredVersion(registry, stack, version); err != nil {
+				return nil, err
+			}
+		}
+		mirror, err := openMirror(registry)
+		if err != nil {
+			return nil, err
+		}
+		return mirror.readFile(path.Join(mirrorStacksDir, stack, mirrorDevfile))
+	}
+	return getOCIRegistryDevfile(registry, stack, version)
+}
+
+// checkMirroredVersion returns an error if the given version of the given stack isn't the one of the registry mirror
+func checkMirroredVersion(registry Registry, stack string, version string) error {
+	stacks, err := GetRegistryStacks(registry)
+	if err != nil {
+		return err
+	}
+	for _, s := range stacks {
+		if s.Name == stack && s.DefaultVersion() == version {
+			return nil
+		}
+	}
+	return errors.Errorf("version %s of stack %s is not available in the registry mirror", version, stack)
+}
+
+// digestFiles returns the SHA256 digests of the regular files of the given directory, by their slash separated relative path
+func digestFiles(dir string) (map[string]string, error) {
+	digests := make(map[string]string)
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		if rel == MirrorManifestFile {
+			return nil
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		digest := sha256.New()
+		if _, err = io.Copy(digest, f); err != nil {
+			return err
+		}
+		digests[filepath.ToSlash(rel)] = hex.EncodeToString(digest.Sum(nil))
+		return nil
+	})
+	return digests, err
+}
+
+// zipDir writes the regular files of the given directory to the given zip file, under the given top level directory
+// like the archives of git hosting services, as util.Unzip strips the top level directory
+func zipDir(dir string, topLevelDir string, zipFile string) error {
+	var files []string
+	err := filepath.Walk(dir, func(file string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	out, err := os.Create(zipFile)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	zipWriter := zip.NewWriter(out)
+	for _, file := range files {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(topLevelDir, filepath.ToSlash(rel))
+		header.Method = zip.Deflate
+		w, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		in, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, in)
+		in.Close()
+		if err != nil {
+			return err
+		}
+	}
+	klog.V(4).Infof("Wrote %d files of %s to %s", len(files), dir, zipFile)
+	return zipWriter.Close()
+}
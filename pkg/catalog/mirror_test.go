@@ -0,0 +1,213 @@
+package catalog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/openshift/odo/pkg/util"
+)
+
+const mirrorTestDevfile = `schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+starterProjects:
+  - name: nodejs-starter
+    git:
+      remotes:
+        origin: "https://github.com/odo-devfiles/nodejs-ex.git"
+`
+
+// createMirror creates a registry mirror with a nodejs stack and its starter project in the given directory
+func createMirror(t *testing.T, dir string) {
+	files := map[string]string{
+		mirrorIndexFile:                    `[{"name": "nodejs", "displayName": "NodeJS Runtime", "language": "nodejs", "links": {"self": "devfile-catalog/nodejs:latest"}}]`,
+		"stacks/nodejs/devfile.yaml":       mirrorTestDevfile,
+		"stacks/nodejs/resources/app.yaml": "kind: Deployment",
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	starterDir, err := ioutil.TempDir("", "starter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(starterDir)
+	if err = ioutil.WriteFile(filepath.Join(starterDir, "server.js"), []byte("console.log('hello')"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.MkdirAll(filepath.Join(dir, "stacks", "nodejs", mirrorStartersDir), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err = zipDir(starterDir, "nodejs-starter", filepath.Join(dir, "stacks", "nodejs", mirrorStartersDir, "nodejs-starter.zip")); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := MirrorManifest{Spec: MirrorManifestSpec{RegistryURL: "https://registry.devfile.io", Stacks: 1, StarterProjects: 1}}
+	manifest.Kind = MirrorManifestKind
+	manifest.Spec.Files, err = digestFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, MirrorManifestFile), data, 0640); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMirrorRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mirror")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	createMirror(t, dir)
+	registry := Registry{Name: "OfflineRegistry", URL: "file://" + filepath.ToSlash(dir)}
+
+	stacks, err := GetRegistryStacks(registry)
+	if err != nil {
+		t.Fatalf("GetRegistryStacks() unexpected error: %v", err)
+	}
+	if len(stacks) != 1 || stacks[0].Name != "nodejs" || stacks[0].DisplayName != "NodeJS Runtime" {
+		t.Errorf("GetRegistryStacks() = %+v", stacks)
+	}
+
+	devfileData, err := GetRegistryDevfile(registry, "nodejs", "")
+	if err != nil {
+		t.Fatalf("GetRegistryDevfile() unexpected error: %v", err)
+	}
+	if string(devfileData) != mirrorTestDevfile {
+		t.Errorf("GetRegistryDevfile() = %q, want %q", devfileData, mirrorTestDevfile)
+	}
+
+	destDir, err := ioutil.TempDir("", "component")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(destDir)
+	if err = PullStackFromRegistry(registry, "nodejs", "", destDir); err != nil {
+		t.Fatalf("PullStackFromRegistry() unexpected error: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(destDir, "resources", "app.yaml")); err != nil {
+		t.Errorf("PullStackFromRegistry() didn't copy the resources of the stack: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(destDir, mirrorStartersDir)); !os.IsNotExist(err) {
+		t.Errorf("PullStackFromRegistry() copied the starter projects of the stack")
+	}
+	if err = PullStackFromRegistry(registry, "java", "", destDir); err == nil {
+		t.Errorf("PullStackFromRegistry() expected an error for a stack which isn't in the mirror")
+	}
+
+	starterProject, err := GetMirroredStarterProject(registry, "nodejs", &devfilev1.StarterProject{Name: "nodejs-starter", SubDir: "app"})
+	if err != nil {
+		t.Fatalf("GetMirroredStarterProject() unexpected error: %v", err)
+	}
+	if starterProject.Zip == nil || starterProject.Git != nil || starterProject.SubDir != "" {
+		t.Fatalf("GetMirroredStarterProject() = %+v, want a zip starter project", starterProject)
+	}
+	starterDir, err := ioutil.TempDir("", "starter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(starterDir)
+	if err = util.GetAndExtractZip(util.HTTPRequestParams{URL: starterProject.Zip.Location}, starterDir, "/"); err != nil {
+		t.Fatalf("GetAndExtractZip() unexpected error: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(starterDir, "server.js")); err != nil {
+		t.Errorf("the mirrored starter project wasn't extracted: %v", err)
+	}
+	if _, err = GetMirroredStarterProject(registry, "nodejs", &devfilev1.StarterProject{Name: "missing"}); err == nil {
+		t.Errorf("GetMirroredStarterProject() expected an error for a starter project which isn't in the mirror")
+	}
+
+	remoteStarterProject := &devfilev1.StarterProject{Name: "nodejs-starter"}
+	got, err := GetMirroredStarterProject(Registry{Name: "DefaultDevfileRegistry", URL: "https://registry.devfile.io"}, "nodejs", remoteStarterProject)
+	if err != nil || !reflect.DeepEqual(got, remoteStarterProject) {
+		t.Errorf("GetMirroredStarterProject() = %+v, %v, want the starter project of a remote registry unchanged", got, err)
+	}
+}
+
+func TestMirrorRegistryIntegrity(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(dir string) error
+		wantErr string
+	}{
+		{
+			name: "Case 1: modified devfile",
+			corrupt: func(dir string) error {
+				return ioutil.WriteFile(filepath.Join(dir, "stacks", "nodejs", "devfile.yaml"), []byte("schemaVersion: 2.0.0"), 0640)
+			},
+			wantErr: "the file stacks/nodejs/devfile.yaml of registry mirror",
+		},
+		{
+			name: "Case 2: missing manifest",
+			corrupt: func(dir string) error {
+				return os.Remove(filepath.Join(dir, MirrorManifestFile))
+			},
+			wantErr: "is not a registry mirror",
+		},
+		{
+			name: "Case 3: file which is not in the manifest",
+			corrupt: func(dir string) error {
+				manifest, err := ReadMirrorManifest(dir)
+				if err != nil {
+					return err
+				}
+				delete(manifest.Spec.Files, "stacks/nodejs/devfile.yaml")
+				data, err := json.Marshal(manifest)
+				if err != nil {
+					return err
+				}
+				return ioutil.WriteFile(filepath.Join(dir, MirrorManifestFile), data, 0640)
+			},
+			wantErr: "the file stacks/nodejs/devfile.yaml is not in the manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "mirror")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			createMirror(t, dir)
+			if err = tt.corrupt(dir); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = GetRegistryDevfile(Registry{Name: "OfflineRegistry", URL: "file://" + filepath.ToSlash(dir)}, "nodejs", "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("GetRegistryDevfile() error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMirrorDir(t *testing.T) {
+	if _, err := MirrorDir("https://registry.devfile.io"); err == nil {
+		t.Errorf("MirrorDir() expected an error for a URL which isn't a file:// URL")
+	}
+	dir, err := MirrorDir("file:///var/lib/odo/mirror")
+	if err != nil {
+		t.Fatalf("MirrorDir() unexpected error: %v", err)
+	}
+	if want := filepath.FromSlash("/var/lib/odo/mirror"); !strings.HasSuffix(dir, want) {
+		t.Errorf("MirrorDir() = %q, want %q", dir, want)
+	}
+}
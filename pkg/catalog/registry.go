@@ -0,0 +1,255 @@
+package catalog
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/deislabs/oras/pkg/content"
+	orasctx "github.com/deislabs/oras/pkg/context"
+	"github.com/deislabs/oras/pkg/oras"
+	registryLibrary "github.com/devfile/registry-support/registry-library/library"
+	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+const (
+	// archiveFile is the name of the archive of the resources of a stack pulled from an OCI-based registry
+	archiveFile = "archive.tar"
+
+	// versionedIndexPath is the path of the index of a registry listing the versions of the stacks
+	versionedIndexPath = "v2index"
+	// unversionedIndexPath is the path of the index of a registry listing the default version of the stacks
+	unversionedIndexPath = "index"
+)
+
+// GetRegistryStacks returns the index of the stacks of the given OCI-based registry,
+// authenticating with the credentials of the registry, or of the given file:// registry
+// every version of the stacks is listed, if the registry supports multiple versions per stack
+func GetRegistryStacks(registry Registry) ([]Stack, error) {
+	var registryIndex []Stack
+	if registryUtil.IsMirrorRegistry(registry.URL) {
+		mirror, err := openMirror(registry)
+		if err != nil {
+			return nil, err
+		}
+		registryIndex, err = mirror.stacks()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		credentials, err := registryUtil.GetRegistryCredentials(registry.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		// the versions of the stacks are only listed by the v2 index
+		data, err := getRegistryIndex(registry, credentials, versionedIndexPath)
+		if err != nil {
+			klog.V(4).Infof("Unable to get the versioned index of registry %s, falling back to its index: %v", registry.Name, err)
+			data, err = getRegistryIndex(registry, credentials, unversionedIndexPath)
+			if err != nil {
+				return nil, errors.Wrapf(err, "unable to download the index of registry %s", registry.Name)
+			}
+		}
+		err = json.Unmarshal(data, &registryIndex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to unmarshal the index of registry %s", registry.Name)
+		}
+	}
+
+	for i := range registryIndex {
+		registryIndex[i].normalizeVersions()
+	}
+	return registryIndex, nil
+}
+
+// getRegistryIndex downloads the index of the given path of the given OCI-based registry
+func getRegistryIndex(registry Registry, credentials registryUtil.RegistryCredentials, indexPath string) ([]byte, error) {
+	indexURL, err := url.Parse(registry.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the URL %s of registry %s", registry.URL, registry.Name)
+	}
+	indexURL.Path = path.Join(indexURL.Path, indexPath)
+	request, cacheFor, err := cachedRegistryRequest(registry, credentials.RequestParams(indexURL.String()))
+	if err != nil {
+		return nil, err
+	}
+	return util.HTTPGetRequest(request, cacheFor)
+}
+
+// cachedRegistryRequest returns the request whose response is cached with the other responses of the given registry,
+// and for how long the response is cached
+func cachedRegistryRequest(registry Registry, request util.HTTPRequestParams) (util.HTTPRequestParams, int, error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return request, 0, err
+	}
+	request.CacheGroup = registry.Name
+	request.CacheSize = int64(cfg.GetRegistryCacheSize()) * 1024 * 1024
+	return request, cfg.GetRegistryCacheTime(), nil
+}
+
+// PullStackFromRegistry pulls the devfile and all the resources of the given version of the given stack
+// from the given OCI-based registry to the destination directory, authenticating with the credentials of the registry,
+// or copies them from the given file:// registry, the default version is pulled if the version is empty
+func PullStackFromRegistry(registry Registry, stack string, version string, destDir string) error {
+	registryIndex, err := GetRegistryStacks(registry)
+	if err != nil {
+		return err
+	}
+	var stackIndex *Stack
+	for i := range registryIndex {
+		if registryIndex[i].Name == stack {
+			stackIndex = &registryIndex[i]
+			break
+		}
+	}
+	if stackIndex == nil {
+		return errors.Errorf("stack %s does not exist in the registry %s", stack, registry.Name)
+	}
+	stackVersion, err := stackIndex.GetVersion(version)
+	if err != nil {
+		return err
+	}
+
+	if registryUtil.IsMirrorRegistry(registry.URL) {
+		if version != "" && version != stackIndex.DefaultVersion() {
+			return errors.Errorf("only the default version %s of stack %s is available in the registry mirror", stackIndex.DefaultVersion(), stack)
+		}
+		mirror, err := openMirror(registry)
+		if err != nil {
+			return err
+		}
+		return mirror.pullStack(stack, destDir)
+	}
+
+	credentials, err := registryUtil.GetRegistryCredentials(registry.Name)
+	if err != nil {
+		return err
+	}
+	transport, err := util.HTTPTransport(credentials.CAFile)
+	if err != nil {
+		return err
+	}
+	registryURL, err := url.Parse(registry.URL)
+	if err != nil {
+		return errors.Wrapf(err, "unable to parse the URL %s of registry %s", registry.URL, registry.Name)
+	}
+
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		PlainHTTP: registryURL.Scheme != "https",
+		Client: &http.Client{
+			Transport: transport,
+			Timeout:   util.HTTPRequestTimeout,
+		},
+		Credentials: func(host string) (string, string, error) {
+			if host != registryURL.Host {
+				return "", "", nil
+			}
+			if credentials.Username != "" {
+				return credentials.Username, credentials.Password, nil
+			}
+			// an empty username makes the token an identity token
+			return "", credentials.Token, nil
+		},
+	})
+	ref := path.Join(registryURL.Host, stackVersion.Links["self"])
+	fileStore := content.NewFileStore(destDir)
+	defer fileStore.Close()
+
+	_, _, err = oras.Pull(orasctx.Background(), resolver, ref, fileStore, oras.WithAllowedMediaTypes(registryLibrary.DevfileAllMediaTypesList))
+	if err != nil {
+		return errors.Wrapf(err, "unable to pull stack %s from %s", stack, ref)
+	}
+
+	archivePath := filepath.Join(destDir, archiveFile)
+	if _, err := os.Stat(archivePath); err == nil {
+		err = decompress(destDir, archivePath)
+		if err != nil {
+			return errors.Wrapf(err, "unable to extract the resources of stack %s", stack)
+		}
+		return os.RemoveAll(archivePath)
+	}
+	return nil
+}
+
+// getOCIRegistryDevfile downloads the devfile of the given version of the given stack of the given OCI-based registry
+func getOCIRegistryDevfile(registry Registry, stack string, version string) ([]byte, error) {
+	credentials, err := registryUtil.GetRegistryCredentials(registry.Name)
+	if err != nil {
+		return nil, err
+	}
+	devfileURL, err := url.Parse(registry.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to parse the URL %s of registry %s", registry.URL, registry.Name)
+	}
+	devfileURL.Path = path.Join(devfileURL.Path, "devfiles", stack, version)
+	request, cacheFor, err := cachedRegistryRequest(registry, credentials.RequestParams(devfileURL.String()))
+	if err != nil {
+		return nil, err
+	}
+	return util.HTTPGetRequest(request, cacheFor)
+}
+
+// decompress extracts the given gzip compressed tar into the target directory
+func decompress(targetDir string, tarFile string) error {
+	reader, err := os.Open(tarFile)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	gzReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return err
+	}
+	defer gzReader.Close()
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(targetDir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(targetDir)+string(os.PathSeparator)) {
+			return errors.Errorf("invalid path %s in the archive", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			w, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+			// #nosec G110 -- the archive comes from the registry the user chose
+			_, err = io.Copy(w, tarReader)
+			w.Close()
+			if err != nil {
+				return err
+			}
+		default:
+			klog.V(4).Infof("Ignoring unsupported entry %s of type %v of the archive", header.Name, header.Typeflag)
+		}
+	}
+}
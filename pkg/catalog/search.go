@@ -0,0 +1,130 @@
+package catalog
+
+import (
+	"sort"
+	"strings"
+)
+
+// weights of the matches of a search term in the fields of a devfile component
+const (
+	nameExactMatchScore   = 100
+	namePrefixMatchScore  = 60
+	nameMatchScore        = 40
+	displayNameMatchScore = 25
+	tagExactMatchScore    = 20
+	languageMatchScore    = 20
+	projectTypeMatchScore = 20
+	tagMatchScore         = 10
+	descriptionMatchScore = 5
+)
+
+// DevfileSearchFilter restricts the devfile components matched by SearchDevfileComponents,
+// the empty fields don't restrict the components
+type DevfileSearchFilter struct {
+	Language    string
+	ProjectType string
+	// Tags are the tags all the matched components must have
+	Tags []string
+}
+
+// IsEmpty returns true if the filter doesn't restrict the components
+func (f DevfileSearchFilter) IsEmpty() bool {
+	return f.Language == "" && f.ProjectType == "" && len(f.Tags) == 0
+}
+
+// DevfileComponentMatch is a devfile component matched by a search, with the relevance of the match
+type DevfileComponentMatch struct {
+	DevfileComponentType
+	Score int
+}
+
+// SearchDevfileComponents searches the given devfile components for the words of the search term, in their name, display name,
+// description, language, project type and tags, every word has to match; the matches passing the filter are returned
+// sorted by relevance, the components keep their order when the relevance is the same or when the search term is empty
+func SearchDevfileComponents(components []DevfileComponentType, term string, filter DevfileSearchFilter) []DevfileComponentMatch {
+	words := strings.Fields(strings.ToLower(term))
+	var matches []DevfileComponentMatch
+	for _, component := range components {
+		if !filter.matches(component) {
+			continue
+		}
+		score, matched := 0, true
+		for _, word := range words {
+			wordScore := matchScore(component, word)
+			if wordScore == 0 {
+				matched = false
+				break
+			}
+			score += wordScore
+		}
+		if matched {
+			matches = append(matches, DevfileComponentMatch{DevfileComponentType: component, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
+
+func (f DevfileSearchFilter) matches(component DevfileComponentType) bool {
+	if f.Language != "" && !strings.EqualFold(f.Language, component.Language) {
+		return false
+	}
+	if f.ProjectType != "" && !strings.EqualFold(f.ProjectType, component.ProjectType) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !hasTag(component, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchScore returns the relevance of the match of the lower case word in the fields of the component, 0 if it doesn't match
+func matchScore(component DevfileComponentType, word string) int {
+	score := 0
+	name := strings.ToLower(component.Name)
+	switch {
+	case name == word:
+		score += nameExactMatchScore
+	case strings.HasPrefix(name, word):
+		score += namePrefixMatchScore
+	case strings.Contains(name, word):
+		score += nameMatchScore
+	}
+	if strings.Contains(strings.ToLower(component.DisplayName), word) {
+		score += displayNameMatchScore
+	}
+	if strings.ToLower(component.Language) == word {
+		score += languageMatchScore
+	}
+	if strings.ToLower(component.ProjectType) == word {
+		score += projectTypeMatchScore
+	}
+	if hasTag(component, word) {
+		score += tagExactMatchScore
+	} else {
+		for _, tag := range component.Tags {
+			if strings.Contains(strings.ToLower(tag), word) {
+				score += tagMatchScore
+				break
+			}
+		}
+	}
+	if strings.Contains(strings.ToLower(component.Description), word) {
+		score += descriptionMatchScore
+	}
+	return score
+}
+
+func hasTag(component DevfileComponentType, tag string) bool {
+	for _, componentTag := range component.Tags {
+		if strings.EqualFold(componentTag, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchDevfileComponents(t *testing.T) {
+	components := []DevfileComponentType{
+		{
+			Name:        "java-quarkus",
+			DisplayName: "Quarkus Java",
+			Description: "Upstream Quarkus with Java+GraalVM",
+			Language:    "java",
+			ProjectType: "quarkus",
+			Tags:        []string{"Java", "Quarkus"},
+			Registry:    Registry{Name: "DefaultDevfileRegistry"},
+		},
+		{
+			Name:        "java-springboot",
+			DisplayName: "Spring Boot®",
+			Description: "Spring Boot® using Java",
+			Language:    "java",
+			ProjectType: "spring",
+			Tags:        []string{"Java", "Spring"},
+			Registry:    Registry{Name: "DefaultDevfileRegistry"},
+		},
+		{
+			Name:        "nodejs",
+			DisplayName: "NodeJS Runtime",
+			Description: "Stack with NodeJS 12",
+			Language:    "nodejs",
+			ProjectType: "nodejs",
+			Tags:        []string{"NodeJS", "Express"},
+			Registry:    Registry{Name: "DefaultDevfileRegistry"},
+		},
+		{
+			Name:        "quarkus",
+			DisplayName: "Quarkus",
+			Description: "Quarkus stack of another registry",
+			Language:    "java",
+			ProjectType: "quarkus",
+			Tags:        []string{"Quarkus"},
+			Registry:    Registry{Name: "MyRegistry"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		term   string
+		filter DevfileSearchFilter
+		want   []string
+	}{
+		{
+			name: "Case 1: exact name match ranked first",
+			term: "quarkus",
+			want: []string{"quarkus", "java-quarkus"},
+		},
+		{
+			name: "Case 2: every word has to match",
+			term: "java spring",
+			want: []string{"java-springboot"},
+		},
+		{
+			name:   "Case 3: filter on language and tag without search term",
+			filter: DevfileSearchFilter{Language: "Java", Tags: []string{"java"}},
+			want:   []string{"java-quarkus", "java-springboot"},
+		},
+		{
+			name:   "Case 4: search term and project type filter",
+			term:   "java",
+			filter: DevfileSearchFilter{ProjectType: "quarkus"},
+			want:   []string{"java-quarkus", "quarkus"},
+		},
+		{
+			name: "Case 5: match in the description only",
+			term: "express",
+			want: []string{"nodejs"},
+		},
+		{
+			name: "Case 6: no match",
+			term: "python",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, match := range SearchDevfileComponents(components, tt.term, tt.filter) {
+				got = append(got, match.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchDevfileComponents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
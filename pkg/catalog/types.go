@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
 	imagev1 "github.com/openshift/api/image/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -27,7 +28,39 @@ type DevfileComponentType struct {
 	Link        string
 	Registry    Registry
 	Language    string
+	ProjectType string `json:",omitempty"`
 	Tags        []string
+	// Version is the default version of the stack
+	Version string `json:",omitempty"`
+	// Versions are all the versions of the stack offered by the registry
+	Versions []StackVersion `json:",omitempty"`
+}
+
+// StackVersion is a version of a devfile stack offered by a registry
+type StackVersion struct {
+	Version         string            `json:"version"`
+	SchemaVersion   string            `json:"schemaVersion,omitempty"`
+	Default         bool              `json:"default,omitempty"`
+	Links           map[string]string `json:"links,omitempty"`
+	StarterProjects []string          `json:"starterProjects,omitempty"`
+	ArtifactIntegrity
+}
+
+// ArtifactIntegrity holds what the registry provides to verify the artifacts of a version of a stack, by artifact name:
+// "devfile.yaml" for the devfile, "starterProjects/<name>" for the zip archive of a starter project
+type ArtifactIntegrity struct {
+	// Digests are the digests of the artifacts, "sha256:<hex>"
+	Digests map[string]string `json:"digests,omitempty"`
+	// Signatures are the base64 encoded signatures of the artifacts made with the key of the registry, like "cosign sign-blob"
+	Signatures map[string]string `json:"signatures,omitempty"`
+}
+
+// Stack is an entry of the index of a registry, the versions are only listed by registries supporting multiple versions per stack
+type Stack struct {
+	indexSchema.Schema
+	Versions []StackVersion `json:"versions,omitempty"`
+	// ArtifactIntegrity is the integrity of the artifacts of the stack, for registries not listing the versions of the stacks
+	ArtifactIntegrity
 }
 
 // ComponentSpec is the spec for ComponentType
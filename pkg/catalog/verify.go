@@ -0,0 +1,233 @@
+package catalog
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/openshift/odo/pkg/log"
+	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+const (
+	// DevfileArtifact is the name of the devfile in the integrity of the artifacts of a stack
+	DevfileArtifact = "devfile.yaml"
+	// starterProjectArtifactPrefix prefixes the names of the starter projects in the integrity of the artifacts of a stack
+	starterProjectArtifactPrefix = "starterProjects/"
+	// sha256DigestPrefix prefixes the SHA256 digests of the artifacts
+	sha256DigestPrefix = "sha256:"
+)
+
+// StarterProjectArtifact returns the name of the zip archive of the given starter project in the integrity of the artifacts of a stack
+func StarterProjectArtifact(starterProject string) string {
+	return starterProjectArtifactPrefix + starterProject
+}
+
+// VerificationError is returned when an artifact downloaded from a registry can't be verified
+type VerificationError struct {
+	Artifact string
+	Stack    string
+	Registry string
+	Reason   string
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("verification of %s of stack %s from registry %s failed: %s", e.Artifact, e.Stack, e.Registry, e.Reason)
+}
+
+// ArtifactVerifier verifies the artifacts of a version of a stack downloaded from a registry,
+// against the digests and signatures of the index of the registry
+type ArtifactVerifier struct {
+	policy   string
+	registry Registry
+	// stack is the stack and its version, <stack>@<version>
+	stack     string
+	integrity ArtifactIntegrity
+	// publicKey verifies the signatures of the artifacts, they are not verified if nil
+	publicKey crypto.PublicKey
+}
+
+// NewArtifactVerifier returns the verifier of the artifacts of the given version of the given stack of the given registry,
+// of its default version if the version is empty, with the given policy, the one of the preference if the policy is empty
+func NewArtifactVerifier(registry Registry, stack string, version string, policy string) (*ArtifactVerifier, error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return nil, err
+	}
+	if policy == "" {
+		policy = cfg.GetVerify()
+	}
+	if err = preference.ValidateVerifyPolicy(policy); err != nil {
+		return nil, err
+	}
+	verifier := &ArtifactVerifier{
+		policy:   strings.ToLower(policy),
+		registry: registry,
+		stack:    stack,
+	}
+	if verifier.policy == preference.VerifyOff {
+		return verifier, nil
+	}
+
+	// the index of a git-based registry provides no integrity
+	if !registryUtil.IsGitBasedRegistry(registry.URL) {
+		stacks, err := GetRegistryStacks(registry)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stacks {
+			if s.Name != stack {
+				continue
+			}
+			stackVersion, err := s.GetVersion(version)
+			if err != nil {
+				return nil, err
+			}
+			verifier.integrity = stackVersion.ArtifactIntegrity
+			if stackVersion.Version != "" {
+				verifier.stack = stack + "@" + stackVersion.Version
+			}
+		}
+	}
+
+	if r := cfg.GetRegistry(registry.Name); r != nil && r.PublicKey != "" {
+		verifier.publicKey, err = ReadPublicKey(r.PublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the public key of registry %s", registry.Name)
+		}
+	}
+	return verifier, nil
+}
+
+// Verify verifies the given artifact, with the enforce policy a VerificationError naming the artifact is returned
+// if it can't be verified, with the warn policy a warning is displayed instead
+func (v *ArtifactVerifier) Verify(artifact string, data []byte) error {
+	if v == nil || v.policy == preference.VerifyOff {
+		return nil
+	}
+	err := v.check(artifact, data)
+	if err == nil {
+		klog.V(4).Infof("Verified %s of stack %s from registry %s", artifact, v.stack, v.registry.Name)
+		return nil
+	}
+	return v.fail(artifact, err.Error())
+}
+
+// VerifyStarterProject verifies the zip archive of the given starter project before it is extracted,
+// the archive is empty for the starter projects cloned from git, which can't be verified
+func (v *ArtifactVerifier) VerifyStarterProject(starterProject *devfilev1.StarterProject, archive string) error {
+	if v == nil || v.policy == preference.VerifyOff {
+		return nil
+	}
+	artifact := StarterProjectArtifact(starterProject.Name)
+	if registryUtil.IsMirrorRegistry(v.registry.URL) {
+		// the starter projects are archived again by "odo registry mirror",
+		// their integrity is verified with the manifest of the mirror instead
+		klog.V(4).Infof("Verified %s of stack %s with the manifest of registry mirror %s", artifact, v.stack, v.registry.Name)
+		return nil
+	}
+	if archive == "" {
+		return v.fail(artifact, "the starter project is cloned from git, only the zip archives of the starter projects can be verified")
+	}
+	data, err := ioutil.ReadFile(archive)
+	if err != nil {
+		return err
+	}
+	return v.Verify(artifact, data)
+}
+
+// fail returns the VerificationError of the given artifact with the enforce policy, and warns about it with the warn policy
+func (v *ArtifactVerifier) fail(artifact string, reason string) error {
+	err := &VerificationError{
+		Artifact: artifact,
+		Stack:    v.stack,
+		Registry: v.registry.Name,
+		Reason:   reason,
+	}
+	if v.policy == preference.VerifyEnforce {
+		return err
+	}
+	log.Warningf("%v", err)
+	return nil
+}
+
+// check verifies the digest of the given artifact, and its signature if the registry has a public key
+func (v *ArtifactVerifier) check(artifact string, data []byte) error {
+	want, ok := v.integrity.Digests[artifact]
+	if !ok {
+		return errors.New("the registry provides no digest of the artifact")
+	}
+	if !strings.HasPrefix(want, sha256DigestPrefix) {
+		return errors.Errorf("unsupported digest %s, only sha256 digests are supported", want)
+	}
+	sum := sha256.Sum256(data)
+	if got := sha256DigestPrefix + hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+		return errors.Errorf("its digest is %s but the registry expects %s", got, want)
+	}
+
+	if v.publicKey == nil {
+		return nil
+	}
+	encoded, ok := v.integrity.Signatures[artifact]
+	if !ok {
+		return errors.New("the registry provides no signature of the artifact")
+	}
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return errors.Wrap(err, "the signature of the artifact is not base64 encoded")
+	}
+	return verifySignature(v.publicKey, data, signature)
+}
+
+// verifySignature verifies the signature of the data with the public key, an ECDSA or RSA signature of the SHA256 digest of the data
+// or an Ed25519 signature of the data, as made by "cosign sign-blob"
+func verifySignature(publicKey crypto.PublicKey, data []byte, signature []byte) error {
+	digest := sha256.Sum256(data)
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("the signature doesn't match the public key of the registry")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("the signature doesn't match the public key of the registry")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return errors.New("the signature doesn't match the public key of the registry")
+		}
+	default:
+		return errors.Errorf("unsupported public key of type %T", publicKey)
+	}
+	return nil
+}
+
+// ReadPublicKey reads the PEM encoded ECDSA, RSA or Ed25519 public key of the given file
+func ReadPublicKey(file string) (crypto.PublicKey, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.Errorf("no PEM encoded public key found in %s", file)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid public key in %s", file)
+	}
+	return publicKey, nil
+}
@@ -0,0 +1,182 @@
+package catalog
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/openshift/odo/pkg/preference"
+)
+
+func TestArtifactVerifierVerify(t *testing.T) {
+	devfile := []byte("schemaVersion: 2.0.0\n")
+	sum := sha256.Sum256(devfile)
+	digest := sha256DigestPrefix + hex.EncodeToString(sum[:])
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		policy    string
+		integrity ArtifactIntegrity
+		withKey   *ecdsa.PrivateKey
+		wantErr   bool
+	}{
+		{
+			name:      "Case 1: matching digest",
+			policy:    preference.VerifyEnforce,
+			integrity: ArtifactIntegrity{Digests: map[string]string{DevfileArtifact: digest}},
+		},
+		{
+			name:      "Case 2: mismatching digest enforced",
+			policy:    preference.VerifyEnforce,
+			integrity: ArtifactIntegrity{Digests: map[string]string{DevfileArtifact: sha256DigestPrefix + "00"}},
+			wantErr:   true,
+		},
+		{
+			name:      "Case 3: mismatching digest only warned about",
+			policy:    preference.VerifyWarn,
+			integrity: ArtifactIntegrity{Digests: map[string]string{DevfileArtifact: sha256DigestPrefix + "00"}},
+		},
+		{
+			name:    "Case 4: missing digest enforced",
+			policy:  preference.VerifyEnforce,
+			wantErr: true,
+		},
+		{
+			name:   "Case 5: missing digest not verified",
+			policy: preference.VerifyOff,
+		},
+		{
+			name:      "Case 6: unsupported digest algorithm",
+			policy:    preference.VerifyEnforce,
+			integrity: ArtifactIntegrity{Digests: map[string]string{DevfileArtifact: "md5:" + hex.EncodeToString(sum[:16])}},
+			wantErr:   true,
+		},
+		{
+			name:   "Case 7: valid signature",
+			policy: preference.VerifyEnforce,
+			integrity: ArtifactIntegrity{
+				Digests:    map[string]string{DevfileArtifact: digest},
+				Signatures: map[string]string{DevfileArtifact: base64.StdEncoding.EncodeToString(signature)},
+			},
+			withKey: key,
+		},
+		{
+			name:   "Case 8: signature of another key",
+			policy: preference.VerifyEnforce,
+			integrity: ArtifactIntegrity{
+				Digests:    map[string]string{DevfileArtifact: digest},
+				Signatures: map[string]string{DevfileArtifact: base64.StdEncoding.EncodeToString(signature)},
+			},
+			withKey: otherKey,
+			wantErr: true,
+		},
+		{
+			name:      "Case 9: missing signature with a public key",
+			policy:    preference.VerifyEnforce,
+			integrity: ArtifactIntegrity{Digests: map[string]string{DevfileArtifact: digest}},
+			withKey:   key,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := &ArtifactVerifier{
+				policy:    tt.policy,
+				registry:  Registry{Name: "SignedRegistry", URL: "https://registry.example.com"},
+				stack:     "nodejs@1.0.0",
+				integrity: tt.integrity,
+			}
+			if tt.withKey != nil {
+				verifier.publicKey = &tt.withKey.PublicKey
+			}
+			err := verifier.Verify(DevfileArtifact, devfile)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			verificationErr, ok := err.(*VerificationError)
+			if !ok {
+				t.Fatalf("Verify() error is %T, want *VerificationError", err)
+			}
+			if verificationErr.Artifact != DevfileArtifact || verificationErr.Stack != "nodejs@1.0.0" || verificationErr.Registry != "SignedRegistry" {
+				t.Errorf("Verify() error = %+v, want the artifact, stack and registry of the devfile", verificationErr)
+			}
+		})
+	}
+}
+
+func TestArtifactVerifierVerifyStarterProject(t *testing.T) {
+	gitStarter := &devfilev1.StarterProject{Name: "nodejs-starter"}
+	verifier := &ArtifactVerifier{
+		policy:   preference.VerifyEnforce,
+		registry: Registry{Name: "SignedRegistry", URL: "https://registry.example.com"},
+		stack:    "nodejs",
+	}
+	err := verifier.VerifyStarterProject(gitStarter, "")
+	if err == nil {
+		t.Fatalf("VerifyStarterProject() expected an error for a starter project cloned from git")
+	}
+	if want := StarterProjectArtifact("nodejs-starter"); err.(*VerificationError).Artifact != want {
+		t.Errorf("VerifyStarterProject() error names %q, want %q", err.(*VerificationError).Artifact, want)
+	}
+
+	verifier.registry.URL = "file:///path/to/mirror"
+	if err = verifier.VerifyStarterProject(gitStarter, ""); err != nil {
+		t.Errorf("VerifyStarterProject() unexpected error for a starter project of a registry mirror: %v", err)
+	}
+}
+
+func TestNewArtifactVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v2index" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := fmt.Fprint(rw, `[{"name": "nodejs", "versions": [
+			{"version": "1.0.0", "digests": {"devfile.yaml": "sha256:1234"}},
+			{"version": "2.0.0", "default": true, "digests": {"devfile.yaml": "sha256:5678", "starterProjects/nodejs-starter": "sha256:9abc"}}
+		]}]`)
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	verifier, err := NewArtifactVerifier(Registry{Name: "VerifiedRegistry", URL: server.URL}, "nodejs", "", preference.VerifyEnforce)
+	if err != nil {
+		t.Fatalf("NewArtifactVerifier() unexpected error: %v", err)
+	}
+	if verifier.stack != "nodejs@2.0.0" {
+		t.Errorf("NewArtifactVerifier() stack = %q, want the default version nodejs@2.0.0", verifier.stack)
+	}
+	if got := verifier.integrity.Digests[StarterProjectArtifact("nodejs-starter")]; got != "sha256:9abc" {
+		t.Errorf("NewArtifactVerifier() digest of the starter project = %q, want %q", got, "sha256:9abc")
+	}
+
+	if _, err = NewArtifactVerifier(Registry{Name: "VerifiedRegistry", URL: server.URL}, "nodejs", "", "strict"); err == nil {
+		t.Errorf("NewArtifactVerifier() expected an error for an invalid policy")
+	}
+}
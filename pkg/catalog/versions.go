@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SplitStackVersion splits a <stack>@<version> argument into the stack and the version, the version is empty if not specified
+func SplitStackVersion(arg string) (stack string, version string) {
+	if i := strings.LastIndex(arg, "@"); i >= 0 {
+		return arg[:i], arg[i+1:]
+	}
+	return arg, ""
+}
+
+// normalizeVersions lists the single version of a stack of a registry which doesn't support multiple versions per stack
+func (s *Stack) normalizeVersions() {
+	if len(s.Versions) > 0 || s.Version == "" {
+		return
+	}
+	s.Versions = []StackVersion{
+		{
+			Version:           s.Version,
+			Default:           true,
+			Links:             s.Links,
+			StarterProjects:   s.StarterProjects,
+			ArtifactIntegrity: s.ArtifactIntegrity,
+		},
+	}
+}
+
+// DefaultVersion returns the default version of the stack, empty if the registry doesn't version the stack
+func (s Stack) DefaultVersion() string {
+	for _, version := range s.Versions {
+		if version.Default {
+			return version.Version
+		}
+	}
+	if len(s.Versions) > 0 {
+		return s.Versions[0].Version
+	}
+	return s.Version
+}
+
+// GetVersion returns the given version of the stack, the default version if the version is empty
+func (s Stack) GetVersion(version string) (StackVersion, error) {
+	if version == "" {
+		version = s.DefaultVersion()
+		if version == "" {
+			return StackVersion{Links: s.Links, StarterProjects: s.StarterProjects, ArtifactIntegrity: s.ArtifactIntegrity}, nil
+		}
+	}
+	for _, stackVersion := range s.Versions {
+		if stackVersion.Version == version {
+			if len(stackVersion.Links) == 0 {
+				stackVersion.Links = s.Links
+			}
+			return stackVersion, nil
+		}
+	}
+	return StackVersion{}, errors.Errorf("version %s of stack %s doesn't exist, available versions: %s", version, s.Name, strings.Join(versionNames(s.Versions), ", "))
+}
+
+// HasVersion returns true if the given version of the stack is offered by the registry, the empty version always is
+func (d DevfileComponentType) HasVersion(version string) bool {
+	if version == "" {
+		return true
+	}
+	for _, stackVersion := range d.Versions {
+		if stackVersion.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// VersionNames returns the versions of the stack offered by the registry
+func (d DevfileComponentType) VersionNames() []string {
+	return versionNames(d.Versions)
+}
+
+func versionNames(versions []StackVersion) []string {
+	var names []string
+	for _, version := range versions {
+		names = append(names, version.Version)
+	}
+	return names
+}
@@ -0,0 +1,150 @@
+package catalog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	indexSchema "github.com/devfile/registry-support/index/generator/schema"
+)
+
+func TestSplitStackVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		arg         string
+		wantStack   string
+		wantVersion string
+	}{
+		{
+			name:      "Case 1: stack without version",
+			arg:       "nodejs",
+			wantStack: "nodejs",
+		},
+		{
+			name:        "Case 2: stack with version",
+			arg:         "nodejs@1.0.1",
+			wantStack:   "nodejs",
+			wantVersion: "1.0.1",
+		},
+		{
+			name:        "Case 3: stack with empty version",
+			arg:         "nodejs@",
+			wantStack:   "nodejs",
+			wantVersion: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stack, version := SplitStackVersion(tt.arg)
+			if stack != tt.wantStack || version != tt.wantVersion {
+				t.Errorf("SplitStackVersion() = %q, %q, want %q, %q", stack, version, tt.wantStack, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestStackGetVersion(t *testing.T) {
+	stack := Stack{
+		Schema: indexSchema.Schema{Name: "nodejs", Links: map[string]string{"self": "devfile-catalog/nodejs:latest"}},
+		Versions: []StackVersion{
+			{Version: "1.0.0", Links: map[string]string{"self": "devfile-catalog/nodejs:1.0.0"}},
+			{Version: "2.0.0", Default: true},
+		},
+	}
+	tests := []struct {
+		name    string
+		version string
+		want    StackVersion
+		wantErr bool
+	}{
+		{
+			name:    "Case 1: default version",
+			version: "",
+			want:    StackVersion{Version: "2.0.0", Default: true, Links: map[string]string{"self": "devfile-catalog/nodejs:latest"}},
+		},
+		{
+			name:    "Case 2: other version",
+			version: "1.0.0",
+			want:    StackVersion{Version: "1.0.0", Links: map[string]string{"self": "devfile-catalog/nodejs:1.0.0"}},
+		},
+		{
+			name:    "Case 3: version which doesn't exist",
+			version: "3.0.0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stack.GetVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetVersion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRegistryStacksVersions(t *testing.T) {
+	versionedServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v2index" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := rw.Write([]byte(`[{"name": "nodejs", "versions": [{"version": "1.0.0"}, {"version": "1.1.0", "default": true}]}]`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer versionedServer.Close()
+	unversionedServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/index" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := rw.Write([]byte(`[{"name": "nodejs", "version": "1.0.0"}]`))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer unversionedServer.Close()
+
+	tests := []struct {
+		name            string
+		registry        Registry
+		wantVersions    []string
+		wantDefaultName string
+	}{
+		{
+			name:            "Case 1: registry listing the versions of the stacks",
+			registry:        Registry{Name: "VersionedRegistry", URL: versionedServer.URL},
+			wantVersions:    []string{"1.0.0", "1.1.0"},
+			wantDefaultName: "1.1.0",
+		},
+		{
+			name:            "Case 2: registry without versioned index",
+			registry:        Registry{Name: "UnversionedRegistry", URL: unversionedServer.URL},
+			wantVersions:    []string{"1.0.0"},
+			wantDefaultName: "1.0.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stacks, err := GetRegistryStacks(tt.registry)
+			if err != nil {
+				t.Fatalf("GetRegistryStacks() unexpected error: %v", err)
+			}
+			if len(stacks) != 1 {
+				t.Fatalf("GetRegistryStacks() = %+v, want one stack", stacks)
+			}
+			if got := versionNames(stacks[0].Versions); !reflect.DeepEqual(got, tt.wantVersions) {
+				t.Errorf("GetRegistryStacks() versions = %v, want %v", got, tt.wantVersions)
+			}
+			if got := stacks[0].DefaultVersion(); got != tt.wantDefaultName {
+				t.Errorf("DefaultVersion() = %q, want %q", got, tt.wantDefaultName)
+			}
+		})
+	}
+}
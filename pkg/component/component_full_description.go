@@ -11,6 +11,7 @@ import (
 	devfileParser "github.com/devfile/library/pkg/devfile/parser"
 	"github.com/openshift/odo/pkg/config"
 	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/kclient"
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/occlient"
 	"github.com/openshift/odo/pkg/storage"
@@ -214,6 +215,26 @@ func NewComponentFullDescriptionFromClientAndLocalConfig(client *occlient.Client
 	return cfd, nil
 }
 
+// LoadInjectedBindings resolves the data injected into the component by the ServiceBindings defined in the devfile
+func (cfd *ComponentFullDescription) LoadInjectedBindings(client *occlient.Client, devfileObj devfileParser.DevfileObj, showValues bool) error {
+	sbrs, err := service.ListDevfileServiceBindings(devfileObj)
+	if err != nil {
+		return err
+	}
+	var kClient *kclient.Client
+	if client != nil && cfd.Status.State == StateTypePushed {
+		kClient = client.GetKubeClient()
+	}
+	for _, sbr := range sbrs {
+		preview, err := service.GetBindingPreview(kClient, sbr, showValues)
+		if err != nil {
+			return err
+		}
+		cfd.Status.InjectedBindings = append(cfd.Status.InjectedBindings, preview)
+	}
+	return nil
+}
+
 // Print prints the complete information of component onto stdout (Note: long term this function should not need to access any parameters, but just print the information in struct)
 func (cfd *ComponentFullDescription) Print(client *occlient.Client) error {
 	// TODO: remove the need to client here print should just deal with printing
@@ -328,6 +349,11 @@ func (cfd *ComponentFullDescription) Print(client *occlient.Client) error {
 		}
 
 	}
+
+	// Injected bindings
+	if len(cfd.Status.InjectedBindings) > 0 {
+		log.Describef("Injected Bindings:\n", "%s", service.FormatBindingPreviews(cfd.Status.InjectedBindings))
+	}
 	return nil
 }
 
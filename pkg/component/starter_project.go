@@ -2,14 +2,17 @@ package component
 
 import (
 	"io/ioutil"
+	gohttp "net/http"
 	"os"
 	"path/filepath"
+	"sync"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/openshift/odo/pkg/log"
 	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
@@ -25,12 +28,34 @@ const (
 
 var DevfilePath = filepath.Join("./", devFile)
 
-func checkoutProject(subDir, zipURL, path, starterToken string) error {
+var (
+	// gitHTTPClientOnce installs the HTTP client of the git clones, the protocols of go-git are global
+	gitHTTPClientOnce sync.Once
+	gitHTTPClientErr  error
+)
+
+// installGitHTTPClient makes go-git clone over the transport shared by the outbound HTTP requests of odo
+func installGitHTTPClient() error {
+	gitHTTPClientOnce.Do(func() {
+		transport, err := util.HTTPTransport("")
+		if err != nil {
+			gitHTTPClientErr = err
+			return
+		}
+		// no timeout, a clone lasts as long as the repository takes to download
+		httpClient := &gohttp.Client{Transport: transport}
+		client.InstallProtocol("https", http.NewClient(httpClient))
+		client.InstallProtocol("http", http.NewClient(httpClient))
+	})
+	return gitHTTPClientErr
+}
+
+func checkoutProject(subDir string, request util.HTTPRequestParams, path string, verify func(zipFile string) error) error {
 
 	if subDir == "" {
 		subDir = "/"
 	}
-	err := util.GetAndExtractZip(zipURL, path, subDir, starterToken)
+	err := util.GetAndExtractVerifiedZip(request, path, subDir, verify)
 	if err != nil {
 		return errors.Wrap(err, "failed to download and extract project zip folder")
 	}
@@ -71,8 +96,18 @@ func GetStarterProject(projects []devfilev1.StarterProject, projectPassed string
 
 }
 
+// StarterProjectVerifier verifies the zip archive of a starter project before it is extracted,
+// the archive is empty for the starter projects cloned from git
+type StarterProjectVerifier func(starterProject *devfilev1.StarterProject, archive string) error
+
 // DownloadStarterProject Downloads first starter project from list of starter projects in devfile
-func DownloadStarterProject(starterProject *devfilev1.StarterProject, decryptedToken string, contextDir string) error {
+// the credentials of the registry of the devfile are used to access the starter project
+func DownloadStarterProject(starterProject *devfilev1.StarterProject, credentials registryUtil.RegistryCredentials, contextDir string) error {
+	return DownloadVerifiedStarterProject(starterProject, credentials, contextDir, nil)
+}
+
+// DownloadVerifiedStarterProject is DownloadStarterProject verifying the starter project with verify, if not nil
+func DownloadVerifiedStarterProject(starterProject *devfilev1.StarterProject, credentials registryUtil.RegistryCredentials, contextDir string, verify StarterProjectVerifier) error {
 	var path string
 	var err error
 	// Retrieve the working directory in order to clone correctly
@@ -94,7 +129,12 @@ func DownloadStarterProject(starterProject *devfilev1.StarterProject, decryptedT
 	log.Info("\nStarter Project")
 
 	if starterProject.Git != nil {
-		err := downloadGitProject(starterProject, decryptedToken, path)
+		if verify != nil {
+			if err = verify(starterProject, ""); err != nil {
+				return err
+			}
+		}
+		err := downloadGitProject(starterProject, credentials, path)
 
 		if err != nil {
 			return err
@@ -104,7 +144,13 @@ func DownloadStarterProject(starterProject *devfilev1.StarterProject, decryptedT
 		url := starterProject.Zip.Location
 		sparseDir := starterProject.SubDir
 		downloadSpinner := log.Spinnerf("Downloading starter project %s from %s", starterProject.Name, url)
-		err := checkoutProject(sparseDir, url, path, decryptedToken)
+		var verifyZip func(zipFile string) error
+		if verify != nil {
+			verifyZip = func(zipFile string) error {
+				return verify(starterProject, zipFile)
+			}
+		}
+		err := checkoutProject(sparseDir, credentials.RequestParams(url), path, verifyZip)
 		if err != nil {
 			downloadSpinner.End(false)
 			return err
@@ -118,7 +164,7 @@ func DownloadStarterProject(starterProject *devfilev1.StarterProject, decryptedT
 }
 
 // downloadGitProject downloads the git starter projects from devfile.yaml
-func downloadGitProject(starterProject *devfilev1.StarterProject, starterToken, path string) error {
+func downloadGitProject(starterProject *devfilev1.StarterProject, credentials registryUtil.RegistryCredentials, path string) error {
 	remoteName, remoteUrl, revision, err := parsercommon.GetDefaultSource(starterProject.Git.GitLikeProjectSource)
 	if err != nil {
 		return errors.Wrapf(err, "unable to get default project source for starter project %s", starterProject.Name)
@@ -153,13 +199,24 @@ func downloadGitProject(starterProject *devfilev1.StarterProject, starterToken,
 		Depth: 1,
 	}
 
-	if starterToken != "" {
+	if request := credentials.RequestParams(remoteUrl); request.Username != "" {
+		cloneOptions.Auth = &http.BasicAuth{
+			Username: request.Username,
+			Password: request.Password,
+		}
+	} else if credentials.Token != "" {
 		cloneOptions.Auth = &http.BasicAuth{
 			Username: registryUtil.RegistryUser,
-			Password: starterToken,
+			Password: credentials.Token,
 		}
 	}
 
+	// clone through the proxy and with the certificate authorities of the preference
+	err = installGitHTTPClient()
+	if err != nil {
+		return err
+	}
+
 	originalPath := ""
 	if starterProject.SubDir != "" {
 		originalPath = path
@@ -1,6 +1,7 @@
 package component
 
 import (
+	"github.com/openshift/odo/pkg/service"
 	"github.com/openshift/odo/pkg/storage"
 	"github.com/openshift/odo/pkg/url"
 	corev1 "k8s.io/api/core/v1"
@@ -49,6 +50,8 @@ type ComponentStatus struct {
 	Context        string        `json:"context,omitempty"`
 	State          State         `json:"state"`
 	LinkedServices []SecretMount `json:"linkedServices,omitempty"`
+	// InjectedBindings describes the data injected by the ServiceBindings defined in the devfile
+	InjectedBindings []service.BindingPreview `json:"injectedBindings,omitempty"`
 }
 
 // CombinedComponentList is list of s2i and devfile components
@@ -64,10 +64,18 @@ func (lci *LocalConfigInfo) CompleteStorage(storage *localConfigProvider.LocalSt
 
 // ValidateStorage validates the given storage
 func (lci *LocalConfigInfo) ValidateStorage(storage localConfigProvider.LocalStorage) error {
+	if storage.Source != "" {
+		return fmt.Errorf("\"from-file\" and \"from-dir\" flags are not supported for s2i components")
+	}
+
 	if storage.Size == "" || storage.Path == "" {
 		return fmt.Errorf("\"size\" and \"path\" flags are required for s2i components")
 	}
 
+	if storage.StorageClass != "" || storage.AccessMode != "" {
+		return fmt.Errorf("\"storage-class\" and \"access-mode\" flags are not supported for s2i components")
+	}
+
 	configStorage, err := lci.ListStorage()
 	if err != nil {
 		return err
@@ -58,6 +58,14 @@ func (lc *LocalConfig) ValidateURL(url localConfigProvider.LocalURL) error {
 		}
 	}
 
+	if url.Issuer != "" {
+		errorList = append(errorList, "issuer is only available for secure URLs of Ingress kind")
+	}
+
+	if url.Gateway != "" || len(url.Headers) > 0 {
+		errorList = append(errorList, "gateway and header matching are only available for URLs of HTTPRoute kind")
+	}
+
 	if len(errorList) > 0 {
 		return fmt.Errorf(strings.Join(errorList, "\n"))
 	}
@@ -0,0 +1,253 @@
+// Package daemon implements the local server of "odo daemon". It keeps a warm cluster client per project and the parsed
+// devfile and env.yaml of each context directory, and serves the push, status, logs, URL list and catalog operations
+// of the devfile components as a REST API.
+package daemon
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	devfileParser "github.com/devfile/library/pkg/devfile/parser"
+	"github.com/openshift/odo/pkg/devfile"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/occlient"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+const (
+	// DefaultAddress is the default address of the daemon
+	DefaultAddress = "http://127.0.0.1:20000"
+
+	// APIPrefix is the path prefix of the endpoints of the daemon
+	APIPrefix = "/api/v1"
+
+	// tokenBytes is the number of random bytes of the token of the daemon
+	tokenBytes = 32
+)
+
+// Server is the local server of "odo daemon"
+type Server struct {
+	address  string
+	listener net.Listener
+	server   *http.Server
+
+	// token authenticates the requests, as the bearer token of their Authorization header
+	token string
+	// tokenFile is the file the token is written to, readable by the user running the daemon only
+	tokenFile string
+
+	// newClient creates the client of a project, the current project of the kubeconfig when empty
+	newClient func(namespace string) (*occlient.Client, error)
+
+	mu         sync.Mutex
+	clients    map[string]*occlient.Client
+	workspaces map[string]*workspace
+	// pushLocks serialize the pushes of the component of each context directory
+	pushLocks map[string]*sync.Mutex
+}
+
+// workspace is the parsed configuration of a context directory, reloaded when its devfile or env.yaml changes
+type workspace struct {
+	dir     string
+	envInfo *envinfo.EnvSpecificInfo
+	devObj  devfileParser.DevfileObj
+	// modTimes are the modification times of the loaded devfile and env.yaml
+	modTimes [2]time.Time
+}
+
+// New listens on the given address, unix:<path> for a Unix domain socket or http://<loopback host>:<port>,
+// and writes the token the clients authenticate with to the given file
+func New(address string, tokenFile string) (*Server, error) {
+	s := &Server{
+		tokenFile:  tokenFile,
+		newClient:  newClient,
+		clients:    make(map[string]*occlient.Client),
+		workspaces: make(map[string]*workspace),
+		pushLocks:  make(map[string]*sync.Mutex),
+	}
+
+	var err error
+	s.listener, s.address, err = util.ListenLocal(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to listen for the daemon")
+	}
+
+	token := make([]byte, tokenBytes)
+	if _, err = rand.Read(token); err == nil {
+		s.token = hex.EncodeToString(token)
+		err = writeTokenFile(tokenFile, s.token)
+	}
+	if err != nil {
+		_ = s.listener.Close()
+		return nil, errors.Wrap(err, "unable to create the token of the daemon")
+	}
+
+	s.server = &http.Server{Handler: s.authorize(s.handler())}
+	return s, nil
+}
+
+// writeTokenFile writes the token to the given file, replacing the token of a previous run
+func writeTokenFile(tokenFile string, token string) error {
+	if tokenFile == "" {
+		return errors.New("the token file of the daemon is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(tokenFile), 0700); err != nil {
+		return errors.Wrap(err, "unable to create the directory of the token file")
+	}
+	// the file is created again so that a file left by a previous run doesn't keep its permissions
+	if err := os.Remove(tokenFile); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "unable to remove the token file %s", tokenFile)
+	}
+	if err := ioutil.WriteFile(tokenFile, []byte(token+"\n"), 0600); err != nil {
+		return errors.Wrapf(err, "unable to write the token file %s", tokenFile)
+	}
+	return nil
+}
+
+// Address returns the address the clients connect to
+func (s *Server) Address() string {
+	return s.address
+}
+
+// TokenFile returns the file holding the token the clients authenticate with
+func (s *Server) TokenFile() string {
+	return s.tokenFile
+}
+
+// Serve serves the requests until the server is shut down
+func (s *Server) Serve() error {
+	err := s.server.Serve(s.listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops the server, waiting up to the given timeout for the requests in progress
+func (s *Server) Shutdown(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := s.server.Shutdown(ctx)
+	if err == context.DeadlineExceeded {
+		// the streamed logs and statuses only end when their clients disconnect
+		err = s.server.Close()
+	}
+	if strings.HasPrefix(s.address, "unix:") {
+		_ = os.Remove(strings.TrimPrefix(s.address, "unix:"))
+	}
+	_ = os.Remove(s.tokenFile)
+	return err
+}
+
+func newClient(namespace string) (*occlient.Client, error) {
+	client, err := occlient.New()
+	if err != nil {
+		return nil, err
+	}
+	kClient, err := kclient.New()
+	if err != nil {
+		return nil, err
+	}
+	client.SetKubeClient(kClient)
+	if namespace != "" {
+		client.Namespace = namespace
+		kClient.Namespace = namespace
+	}
+	return client, nil
+}
+
+// client returns the client of the given project, created on its first use
+func (s *Server) client(namespace string) (*occlient.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if client, ok := s.clients[namespace]; ok {
+		return client, nil
+	}
+	client, err := s.newClient(namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to connect to the cluster")
+	}
+	s.clients[namespace] = client
+	return client, nil
+}
+
+// workspace returns the configuration of the devfile component of the given context directory,
+// parsed again if its devfile or env.yaml changed since the previous request
+func (s *Server) workspace(dir string) (*workspace, error) {
+	if dir == "" {
+		return nil, errors.New("the context directory of the component is required")
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	envInfoPath := filepath.Join(dir, ".odo", "env", "env.yaml")
+	devfilePath := filepath.Join(dir, "devfile.yaml")
+	var modTimes [2]time.Time
+	for i, path := range []string{devfilePath, envInfoPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Errorf("%s is not the context directory of a devfile component", dir)
+		}
+		modTimes[i] = info.ModTime()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ws, ok := s.workspaces[dir]; ok && ws.modTimes == modTimes {
+		return ws, nil
+	}
+
+	klog.V(4).Infof("Loading the component of %s", dir)
+	envInfo, err := envinfo.NewEnvSpecificInfo(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve configuration information")
+	}
+	devObj, err := devfile.ParseFromFile(devfilePath)
+	if err != nil {
+		return nil, err
+	}
+	envInfo.SetDevfileObj(devObj)
+
+	ws := &workspace{
+		dir:      dir,
+		envInfo:  envInfo,
+		devObj:   devObj,
+		modTimes: modTimes,
+	}
+	s.workspaces[dir] = ws
+	return ws, nil
+}
+
+// clone returns a copy of the workspace for a request, so that the run mode set by a push
+// doesn't modify the configuration shared with the concurrent requests
+func (ws *workspace) clone() *workspace {
+	envInfo := *ws.envInfo
+	clone := *ws
+	clone.envInfo = &envInfo
+	return &clone
+}
+
+// pushLock returns the lock serializing the pushes of the component of the given context directory
+func (s *Server) pushLock(dir string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lock, ok := s.pushLocks[dir]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.pushLocks[dir] = lock
+	}
+	return lock
+}
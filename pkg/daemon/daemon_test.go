@@ -0,0 +1,372 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/occlient"
+	"github.com/openshift/odo/pkg/version"
+)
+
+const testDevfile = `schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+components:
+  - name: runtime
+    container:
+      image: registry.access.redhat.com/ubi8/nodejs-12:1-36
+      memoryLimit: 1024Mi
+      mountSources: true
+commands:
+  - id: run
+    exec:
+      component: runtime
+      commandLine: npm start
+      workingDir: /project
+      group:
+        kind: run
+        isDefault: true
+`
+
+const testEnvInfo = `ComponentSettings:
+  Name: %s
+  Namespace: myproject
+  AppName: app
+`
+
+// newTestComponent creates the context directory of a devfile component with the given name
+func newTestComponent(t *testing.T, name string) string {
+	dir, err := ioutil.TempDir("", "daemon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.MkdirAll(filepath.Join(dir, ".odo", "env"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	writeTestEnvInfo(t, dir, name)
+	if err = ioutil.WriteFile(filepath.Join(dir, "devfile.yaml"), []byte(testDevfile), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeTestEnvInfo(t *testing.T, dir string, name string) {
+	content := []byte(fmt.Sprintf(testEnvInfo, name))
+	if err := ioutil.WriteFile(filepath.Join(dir, ".odo", "env", "env.yaml"), content, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewInvalidAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+	}{
+		{
+			name:    "Case 1: unsupported scheme",
+			address: "tcp://127.0.0.1:0",
+		},
+		{
+			name:    "Case 2: not a loopback address",
+			address: "http://0.0.0.0:0",
+		},
+		{
+			name:    "Case 3: missing socket path",
+			address: "unix:",
+		},
+	}
+	tokenDir, err := ioutil.TempDir("", "daemon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tokenDir)
+	tokenFile := filepath.Join(tokenDir, "daemon-token")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.address, tokenFile)
+			if err == nil {
+				_ = s.Shutdown(time.Second)
+				t.Errorf("expected an error for the address %q", tt.address)
+			}
+			if _, err = os.Stat(tokenFile); !os.IsNotExist(err) {
+				t.Errorf("expected no token file for the address %q", tt.address)
+			}
+		})
+	}
+}
+
+func TestServerEndpoints(t *testing.T) {
+	componentDir := newTestComponent(t, "nodejs")
+	defer os.RemoveAll(componentDir)
+	socketDir, err := ioutil.TempDir("", "daemon")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(socketDir)
+
+	tokenFile := filepath.Join(socketDir, "daemon-token")
+
+	for _, address := range []string{"http://127.0.0.1:0", "unix:" + filepath.Join(socketDir, "odo.sock")} {
+		s, err := New(address, tokenFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Stat(tokenFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+			t.Errorf("expected the token file to be readable by the user only, got %v", info.Mode().Perm())
+		}
+		token, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.newClient = func(namespace string) (*occlient.Client, error) {
+			return nil, fmt.Errorf("no cluster")
+		}
+		go func() {
+			_ = s.Serve()
+		}()
+
+		baseURL := s.Address()
+		httpClient := &http.Client{}
+		if strings.HasPrefix(s.Address(), "unix:") {
+			path := strings.TrimPrefix(s.Address(), "unix:")
+			baseURL = "http://odo"
+			httpClient.Transport = &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", path)
+				},
+			}
+		}
+
+		wrongHostStatus := http.StatusForbidden
+		if strings.HasPrefix(s.Address(), "unix:") {
+			// the Host header of the requests of a Unix domain socket is not checked
+			wrongHostStatus = http.StatusOK
+		}
+
+		tests := []struct {
+			name       string
+			method     string
+			path       string
+			header     map[string]string
+			host       string
+			noToken    bool
+			wantStatus int
+		}{
+			{
+				name:       "Case 1: health",
+				method:     http.MethodGet,
+				path:       "/health",
+				wantStatus: http.StatusOK,
+			},
+			{
+				name:       "Case 2: push with a wrong method",
+				method:     http.MethodGet,
+				path:       "/push?context=" + componentDir,
+				wantStatus: http.StatusMethodNotAllowed,
+			},
+			{
+				name:       "Case 3: missing context",
+				method:     http.MethodGet,
+				path:       "/urls",
+				wantStatus: http.StatusBadRequest,
+			},
+			{
+				name:       "Case 4: context not of a devfile component",
+				method:     http.MethodGet,
+				path:       "/status?context=" + socketDir,
+				wantStatus: http.StatusBadRequest,
+			},
+			{
+				name:       "Case 5: invalid parameter",
+				method:     http.MethodPost,
+				path:       "/push?force=maybe&context=" + componentDir,
+				wantStatus: http.StatusBadRequest,
+			},
+			{
+				name:       "Case 6: cluster not reachable",
+				method:     http.MethodGet,
+				path:       "/logs?context=" + componentDir,
+				wantStatus: http.StatusBadGateway,
+			},
+			{
+				name:       "Case 7: missing token",
+				method:     http.MethodPost,
+				path:       "/push?context=" + componentDir,
+				noToken:    true,
+				wantStatus: http.StatusUnauthorized,
+			},
+			{
+				name:       "Case 8: wrong token",
+				method:     http.MethodPost,
+				path:       "/push?context=" + componentDir,
+				header:     map[string]string{"Authorization": "Bearer wrong"},
+				wantStatus: http.StatusUnauthorized,
+			},
+			{
+				name:       "Case 9: origin of a web page",
+				method:     http.MethodPost,
+				path:       "/push?context=" + componentDir,
+				header:     map[string]string{"Origin": "http://example.com"},
+				wantStatus: http.StatusForbidden,
+			},
+			{
+				name:       "Case 10: origin of the local machine",
+				method:     http.MethodGet,
+				path:       "/health",
+				header:     map[string]string{"Origin": "http://localhost:3000"},
+				wantStatus: http.StatusOK,
+			},
+			{
+				name:       "Case 11: host not a loopback host",
+				method:     http.MethodGet,
+				path:       "/health",
+				host:       "attacker.example.com:20000",
+				wantStatus: wrongHostStatus,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(address+" "+tt.name, func(t *testing.T) {
+				req, err := http.NewRequest(tt.method, baseURL+APIPrefix+tt.path, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !tt.noToken {
+					req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+				}
+				for name, value := range tt.header {
+					req.Header.Set(name, value)
+				}
+				if tt.host != "" {
+					req.Host = tt.host
+				}
+				resp, err := httpClient.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer resp.Body.Close()
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("expected the status %d, got %d", tt.wantStatus, resp.StatusCode)
+				}
+
+				var body map[string]string
+				if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+					t.Fatalf("unable to decode the response: %v", err)
+				}
+				if tt.wantStatus == http.StatusOK && body["version"] != version.VERSION {
+					t.Errorf("expected the version %q, got %q", version.VERSION, body["version"])
+				}
+				if tt.wantStatus != http.StatusOK && body["error"] == "" {
+					t.Errorf("expected an error in the response, got %v", body)
+				}
+			})
+		}
+
+		if err = s.Shutdown(time.Second); err != nil {
+			t.Error(err)
+		}
+		if _, err = os.Stat(tokenFile); !os.IsNotExist(err) {
+			t.Errorf("expected the token file to be removed when the daemon stops")
+		}
+	}
+}
+
+func TestServerWorkspace(t *testing.T) {
+	dir := newTestComponent(t, "nodejs")
+	defer os.RemoveAll(dir)
+	s := &Server{
+		workspaces: make(map[string]*workspace),
+	}
+
+	ws, err := s.workspace(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ws.envInfo.GetName() != "nodejs" || ws.devObj.Data == nil {
+		t.Fatalf("unexpected workspace %+v", ws)
+	}
+
+	cached, err := s.workspace(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached != ws {
+		t.Errorf("expected the workspace to be cached")
+	}
+
+	// the component is renamed
+	writeTestEnvInfo(t, dir, "renamed")
+	later := time.Now().Add(time.Minute)
+	if err = os.Chtimes(filepath.Join(dir, ".odo", "env", "env.yaml"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := s.workspace(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded == ws || reloaded.envInfo.GetName() != "renamed" {
+		t.Errorf("expected the workspace to be reloaded, got the component %q", reloaded.envInfo.GetName())
+	}
+}
+
+func TestWorkspaceClone(t *testing.T) {
+	dir := newTestComponent(t, "nodejs")
+	defer os.RemoveAll(dir)
+	s := &Server{
+		workspaces: make(map[string]*workspace),
+	}
+	ws, err := s.workspace(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := ws.clone()
+	if err = clone.envInfo.SetRunMode(envinfo.Debug); err != nil {
+		t.Fatal(err)
+	}
+	if clone.envInfo.GetRunMode() != envinfo.Debug {
+		t.Errorf("expected the run mode of the clone to be %q, got %q", envinfo.Debug, clone.envInfo.GetRunMode())
+	}
+	if ws.envInfo.GetRunMode() != envinfo.DefaultRunMode {
+		t.Errorf("expected the run mode of the cached workspace to be unchanged, got %q", ws.envInfo.GetRunMode())
+	}
+}
+
+func TestServerClient(t *testing.T) {
+	created := 0
+	s := &Server{
+		clients: make(map[string]*occlient.Client),
+		newClient: func(namespace string) (*occlient.Client, error) {
+			created++
+			return &occlient.Client{Namespace: namespace}, nil
+		},
+	}
+
+	for _, namespace := range []string{"project1", "project1", "project2", "project1"} {
+		client, err := s.client(namespace)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if client.Namespace != namespace {
+			t.Errorf("expected the client of %q, got the client of %q", namespace, client.Namespace)
+		}
+	}
+	if created != 2 {
+		t.Errorf("expected 2 clients to be created, got %d", created)
+	}
+}
@@ -0,0 +1,465 @@
+package daemon
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/openshift/odo/pkg/catalog"
+	"github.com/openshift/odo/pkg/devfile/adapters"
+	"github.com/openshift/odo/pkg/devfile/adapters/common"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/hooks"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/occlient"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/url"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/openshift/odo/pkg/version"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+// DefaultStatusInterval is the default time between two checks of the status of a followed component
+const DefaultStatusInterval = 5 * time.Second
+
+// ComponentStatus is the status of a devfile component returned by the status endpoint
+type ComponentStatus struct {
+	Component   string `json:"component"`
+	Application string `json:"application"`
+	Project     string `json:"project"`
+	// Pushed is true when the component exists on the cluster
+	Pushed bool `json:"pushed"`
+	// RunMode is the mode of the last successful push, run or debug
+	RunMode   string `json:"runMode"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Error is the body of the responses of the failed requests
+type Error struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(APIPrefix+"/health", s.handleHealth)
+	mux.HandleFunc(APIPrefix+"/catalog/components", s.handleCatalogComponents)
+	mux.HandleFunc(APIPrefix+"/urls", s.handleURLs)
+	mux.HandleFunc(APIPrefix+"/push", s.handlePush)
+	mux.HandleFunc(APIPrefix+"/status", s.handleStatus)
+	mux.HandleFunc(APIPrefix+"/logs", s.handleLogs)
+	return mux
+}
+
+// authorize replies with an error to the requests not sent from the local machine by a client knowing the token of the daemon:
+// the requests of the web pages are refused, see util.CheckLocalRequest, and the Authorization header of the requests
+// must be the bearer token of the daemon
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := util.CheckLocalRequest(r, !strings.HasPrefix(s.address, "unix:")); err != nil {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, errors.Errorf("the bearer token of the daemon, written to %s, is required", s.tokenFile))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		klog.V(4).Infof("Unable to write the response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, Error{Error: err.Error()})
+}
+
+// allowMethod replies with an error to the requests not using the given method
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		writeError(w, http.StatusMethodNotAllowed, errors.Errorf("the method must be %s", method))
+		return false
+	}
+	return true
+}
+
+// boolParam returns the boolean value of the given query parameter, false when not set
+func boolParam(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.Errorf("invalid value %q of the %s parameter", value, name)
+	}
+	return b, nil
+}
+
+// lineWriter writes the JSON lines of a streamed response, flushed as they are written
+type lineWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	flusher http.Flusher
+}
+
+func newLineWriter(w http.ResponseWriter) *lineWriter {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	lw := &lineWriter{w: w}
+	lw.flusher, _ = w.(http.Flusher)
+	return lw
+}
+
+func (lw *lineWriter) write(v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		klog.V(4).Infof("Unable to marshal the line: %v", err)
+		return
+	}
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	if _, err := lw.w.Write(append(data, '\n')); err != nil {
+		klog.V(4).Infof("Unable to write the line: %v", err)
+		return
+	}
+	if lw.flusher != nil {
+		lw.flusher.Flush()
+	}
+}
+
+// component returns a copy of the workspace of the context directory of the request and the client of its project,
+// or the status of the response and the error of the failed request
+func (s *Server) component(r *http.Request) (*workspace, *occlient.Client, int, error) {
+	ws, err := s.workspace(r.URL.Query().Get("context"))
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, err
+	}
+	client, err := s.client(ws.envInfo.GetNamespace())
+	if err != nil {
+		return nil, nil, http.StatusBadGateway, err
+	}
+	return ws.clone(), client, http.StatusOK, nil
+}
+
+// adapter returns the adapter of the component of the workspace, reporting the machine readable events to the given logging client
+func (ws *workspace) adapter(client *occlient.Client, loggingClient machineoutput.MachineEventLoggingClient) common.ComponentAdapter {
+	return adapters.NewComponentAdapterWithClient(ws.envInfo.GetName(), ws.dir, ws.envInfo.GetApplication(), ws.devObj, *client, loggingClient)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok", "version": version.VERSION})
+}
+
+func (s *Server) handleCatalogComponents(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	components, err := catalog.ListDevfileComponents(r.URL.Query().Get("registry"))
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, components)
+}
+
+func (s *Server) handleURLs(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	ws, client, status, err := s.component(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	routeSupported, err := client.IsRouteSupported()
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	urls, err := url.NewClient(url.ClientOptions{
+		LocalConfigProvider: ws.envInfo,
+		OCClient:            *client,
+		IsRouteSupported:    routeSupported,
+	}).List()
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, urls)
+}
+
+// pushParameters are the parameters of a push request
+type pushParameters struct {
+	forceBuild bool
+	debug      bool
+	buildCmd   string
+	runCmd     string
+	debugCmd   string
+}
+
+// handlePush pushes the component, and streams the machine readable events of the push as JSON lines.
+// The error of a failed push is the last event.
+func (s *Server) handlePush(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+	var params pushParameters
+	var err error
+	if params.forceBuild, err = boolParam(r, "force"); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if params.debug, err = boolParam(r, "debug"); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	params.buildCmd = strings.ToLower(r.URL.Query().Get("buildCommand"))
+	params.runCmd = strings.ToLower(r.URL.Query().Get("runCommand"))
+	params.debugCmd = strings.ToLower(r.URL.Query().Get("debugCommand"))
+
+	ws, client, status, err := s.component(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	lock := s.pushLock(ws.dir)
+	lock.Lock()
+	defer lock.Unlock()
+
+	lw := newLineWriter(w)
+	loggingClient := machineoutput.NewConsoleMachineEventLoggingClientWithFunction(func(event machineoutput.MachineEventWrapper) {
+		lw.write(event)
+	})
+	if err := push(ws, client, loggingClient, params); err != nil {
+		loggingClient.ReportError(err, machineoutput.TimestampNow())
+	}
+}
+
+// push pushes the component of the workspace the way "odo push" does, running the local lifecycle hooks
+func push(ws *workspace, client *occlient.Client, loggingClient machineoutput.MachineEventLoggingClient, params pushParameters) error {
+	componentName := ws.envInfo.GetName()
+
+	ignores, err := util.GetIgnoreRulesFromDirectory(ws.dir)
+	if err != nil {
+		return errors.Wrap(err, "unable to apply ignore information")
+	}
+	ignores = append(ignores, util.GetIndexFileRelativeToContext(), ".git")
+
+	pushParams := common.PushParameters{
+		Path:            ws.dir,
+		IgnoredFiles:    ignores,
+		ForceBuild:      params.forceBuild,
+		EnvSpecificInfo: *ws.envInfo,
+		DevfileBuildCmd: params.buildCmd,
+		DevfileRunCmd:   params.runCmd,
+		DevfileDebugCmd: params.debugCmd,
+		Debug:           params.debug,
+		DebugPort:       ws.envInfo.GetDebugPort(),
+	}
+
+	var preferenceHooks []hooks.Hook
+	if cfg, err := preference.New(); err == nil {
+		preferenceHooks = cfg.GetHooks()
+	} else {
+		klog.V(4).Infof("Unable to read the hooks of the preference: %v", err)
+	}
+	runner := hooks.NewRunner(ws.dir, preferenceHooks, ws.envInfo.GetHooks())
+	hookParams := hooks.Parameters{
+		Component:   componentName,
+		Application: ws.envInfo.GetApplication(),
+		Project:     client.Namespace,
+		ForceBuild:  params.forceBuild,
+		Debug:       params.debug,
+	}
+	if err = runner.Run(hooks.PrePush, hookParams); err != nil {
+		return err
+	}
+	pushParams.PostSyncHook = func() error {
+		return runner.Run(hooks.PostSync, hookParams)
+	}
+
+	err = ws.adapter(client, loggingClient).Push(pushParams)
+	if err != nil {
+		err = errors.Errorf("Failed to start component with name %q. Error: %v", componentName, err)
+		hookParams.Error = err.Error()
+		_ = runner.Run(hooks.PushFailed, hookParams)
+		return err
+	}
+	if err = runner.Run(hooks.PostPush, hookParams); err != nil {
+		return err
+	}
+
+	runMode := envinfo.Run
+	if params.debug {
+		runMode = envinfo.Debug
+	}
+	return ws.envInfo.SetRunMode(runMode)
+}
+
+// handleStatus returns the status of the component, or streams its changes as JSON lines with follow=true
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	follow, err := boolParam(r, "follow")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	interval := DefaultStatusInterval
+	if value := r.URL.Query().Get("interval"); value != "" {
+		if interval, err = time.ParseDuration(value); err != nil || interval <= 0 {
+			writeError(w, http.StatusBadRequest, errors.Errorf("invalid value %q of the interval parameter", value))
+			return
+		}
+	}
+	ws, client, status, err := s.component(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+	adapter := ws.adapter(client, machineoutput.NewNoOpMachineEventLoggingClient())
+
+	componentStatus, err := getStatus(ws, adapter, client.Namespace)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	if !follow {
+		writeJSON(w, http.StatusOK, componentStatus)
+		return
+	}
+
+	lw := newLineWriter(w)
+	lw.write(componentStatus)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			current, err := getStatus(ws, adapter, client.Namespace)
+			if err != nil {
+				klog.V(4).Infof("Unable to get the status of the component: %v", err)
+				continue
+			}
+			if current.Pushed != componentStatus.Pushed || current.RunMode != componentStatus.RunMode {
+				componentStatus = current
+				lw.write(componentStatus)
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func getStatus(ws *workspace, adapter common.ComponentAdapter, namespace string) (ComponentStatus, error) {
+	componentName := ws.envInfo.GetName()
+	application := ws.envInfo.GetApplication()
+	pushed, err := adapter.DoesComponentExist(componentName, application)
+	if err != nil {
+		return ComponentStatus{}, err
+	}
+	// the run mode is written to env.yaml by the pushes, of the daemon or of "odo push"
+	runMode := envinfo.DefaultRunMode
+	if envInfo, err := envinfo.NewEnvSpecificInfo(ws.dir); err == nil {
+		runMode = envInfo.GetRunMode()
+	}
+	return ComponentStatus{
+		Component:   componentName,
+		Application: application,
+		Project:     namespace,
+		Pushed:      pushed,
+		RunMode:     string(runMode),
+		Timestamp:   machineoutput.TimestampNow(),
+	}, nil
+}
+
+// handleLogs streams the logs of the run command of the component, or of its debug command with debug=true
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	follow, err := boolParam(r, "follow")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	debug, err := boolParam(r, "debug")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	ws, client, status, err := s.component(r)
+	if err != nil {
+		writeError(w, status, err)
+		return
+	}
+
+	var command devfilev1.Command
+	if debug {
+		command, err = common.GetDebugCommand(ws.devObj.Data, "")
+		if err == nil && reflect.DeepEqual(devfilev1.Command{}, command) {
+			err = errors.New("no debug command found in devfile")
+		}
+	} else {
+		command, err = common.GetRunCommand(ws.devObj.Data, "")
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rd, err := ws.adapter(client, machineoutput.NewNoOpMachineEventLoggingClient()).Log(follow, command)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer rd.Close()
+	go func() {
+		// a followed log only ends when the client disconnects
+		<-r.Context().Done()
+		rd.Close()
+	}()
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := rd.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err != nil {
+			if err != io.EOF {
+				klog.V(4).Infof("Unable to read the logs of the component: %v", err)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,323 @@
+// Package dashboard implements the full-screen terminal dashboard of "odo dashboard". It shows the live state of a
+// devfile component from the machine readable events of odo: the states of its pods and containers, the status of the
+// supervisord programs, the reachability of its URLs, the sync and push activity, and the last log lines of each
+// container. Its keybindings push the component, restart its run command, open a shell in a container, or follow the
+// log of a container.
+package dashboard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+	"k8s.io/klog"
+)
+
+// redrawInterval is the time between two draws of the dashboard when nothing changes, to follow the size of the terminal
+const redrawInterval = time.Second
+
+// Options are the title of the dashboard and the operations on the component run by its keybindings
+type Options struct {
+	// Title describes the component, its application and its project
+	Title string
+	// Push pushes the component, reporting the machine readable events of the push to the given function
+	Push func(handle func(event machineoutput.MachineEventWrapper)) error
+	// RestartRunCommand restarts the run command, or the debug command of a component running in debug mode
+	RestartRunCommand func() error
+	// Shell runs an interactive shell in the given container, reading the input of the terminal and writing to the terminal
+	Shell func(pod string, container string, stdin io.Reader, stdout io.Writer) error
+	// Logs follows the log of the given container
+	Logs func(pod string, container string) (io.ReadCloser, error)
+}
+
+// keyAction is what the main loop does after a key is handled
+type keyAction int
+
+const (
+	keyHandled keyAction = iota
+	keyQuit
+	keyShell
+)
+
+// action is an operation on the component run in the background, with the activities reported around it
+type action struct {
+	// busy describes the action in progress
+	busy      string
+	succeeded string
+	failed    string
+	run       func() error
+}
+
+// actionResult is the result of an action, reported to the main loop
+type actionResult struct {
+	action action
+	err    error
+}
+
+// Dashboard is the full-screen terminal dashboard of a devfile component
+type Dashboard struct {
+	options Options
+	state   *State
+	view    view
+
+	results   chan actionResult
+	shellDone chan error
+	// shellInput receives the input of the terminal while a shell is open
+	shellInput *io.PipeWriter
+
+	followMu sync.Mutex
+	// followed are the containers whose log is followed
+	followed map[ContainerRef]bool
+}
+
+// New returns a dashboard running the given operations
+func New(options Options) *Dashboard {
+	return &Dashboard{
+		options:   options,
+		state:     NewState(),
+		view:      view{title: options.Title},
+		results:   make(chan actionResult),
+		shellDone: make(chan error),
+		followed:  make(map[ContainerRef]bool),
+	}
+}
+
+// Handle updates the dashboard with a machine readable event, it is the logging function of the watches of the component
+func (d *Dashboard) Handle(event machineoutput.MachineEventWrapper) {
+	d.state.Handle(event)
+	if event.KubernetesPodStatus != nil {
+		d.followLogs()
+	}
+}
+
+// AddActivity adds a line to the sync and push activity of the dashboard
+func (d *Dashboard) AddActivity(text string, failed bool) {
+	d.state.AddActivity(text, failed)
+}
+
+// followLogs follows the logs of the running containers not followed yet
+func (d *Dashboard) followLogs() {
+	if d.options.Logs == nil {
+		return
+	}
+	for _, ref := range d.state.RunningContainers() {
+		d.followMu.Lock()
+		followed := d.followed[ref]
+		d.followed[ref] = true
+		d.followMu.Unlock()
+		if !followed {
+			go d.followLog(ref)
+		}
+	}
+}
+
+// followLog adds the lines of the log of the container to the state, until the container stops
+func (d *Dashboard) followLog(ref ContainerRef) {
+	// the log is followed again when the container is seen running again
+	defer func() {
+		d.followMu.Lock()
+		delete(d.followed, ref)
+		d.followMu.Unlock()
+	}()
+	reader, err := d.options.Logs(ref.Pod, ref.Container)
+	if err != nil {
+		klog.V(4).Infof("Unable to follow the log of the container %s of the pod %s: %v", ref.Container, ref.Pod, err)
+		return
+	}
+	defer reader.Close()
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		d.state.AddLogLine(ref.Container, scanner.Text())
+	}
+}
+
+// Run shows the dashboard on the given terminal until it is quit
+func (d *Dashboard) Run(in *os.File, out *os.File) error {
+	t, err := openTerminal(in, out)
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	input := t.readInput()
+	ticker := time.NewTicker(redrawInterval)
+	defer ticker.Stop()
+	d.draw(t)
+	for {
+		select {
+		case data, ok := <-input:
+			if !ok {
+				return nil
+			}
+			if d.shellInput != nil {
+				// the pipe is closed once the shell exits
+				_, _ = d.shellInput.Write(data)
+				continue
+			}
+			for _, key := range parseKeys(data) {
+				switch d.handleKey(key) {
+				case keyQuit:
+					return nil
+				case keyShell:
+					d.openShell(t)
+				}
+				if d.shellInput != nil {
+					break
+				}
+			}
+
+		case result := <-d.results:
+			d.view.busy = ""
+			if result.err != nil {
+				d.state.AddActivity(fmt.Sprintf("%s: %v", result.action.failed, result.err), true)
+			} else {
+				d.state.AddActivity(result.action.succeeded, false)
+			}
+
+		case err := <-d.shellDone:
+			d.shellInput = nil
+			t.enterScreen()
+			if err != nil {
+				d.state.AddActivity(fmt.Sprintf("The shell failed: %v", err), true)
+			}
+
+		case <-d.state.Changed():
+		case <-ticker.C:
+		}
+		if d.shellInput == nil {
+			d.draw(t)
+		}
+	}
+}
+
+func (d *Dashboard) draw(t *terminal) {
+	width, height := t.size()
+	t.draw(d.state.render(d.view, width, height))
+}
+
+// handleKey runs the operation bound to the key
+func (d *Dashboard) handleKey(key string) keyAction {
+	switch key {
+	case "q", "ctrl+c":
+		return keyQuit
+
+	case "p":
+		d.start(action{
+			busy:      "pushing",
+			succeeded: "Pushed the component",
+			failed:    "Failed to push the component",
+			run: func() error {
+				return d.options.Push(d.Handle)
+			},
+		})
+
+	case "r":
+		d.start(action{
+			busy:      "restarting the run command",
+			succeeded: "Restarted the run command",
+			failed:    "Failed to restart the run command",
+			run:       d.options.RestartRunCommand,
+		})
+
+	case "s":
+		return keyShell
+
+	case "t":
+		d.view.tail = !d.view.tail
+
+	case "esc":
+		d.view.tail = false
+
+	case "tab", "down", "j":
+		d.selectContainer(1)
+
+	case "shift+tab", "up", "k":
+		d.selectContainer(-1)
+	}
+	return keyHandled
+}
+
+// selectContainer selects the next or the previous container
+func (d *Dashboard) selectContainer(step int) {
+	count := len(d.state.Containers())
+	if count == 0 {
+		d.view.selected = 0
+		return
+	}
+	d.view.selected = ((d.view.selected+step)%count + count) % count
+}
+
+// selectedContainer returns the selected container, nil if there is none
+func (d *Dashboard) selectedContainer() *ContainerRef {
+	containers := d.state.Containers()
+	if d.view.selected < 0 || d.view.selected >= len(containers) {
+		return nil
+	}
+	return &containers[d.view.selected]
+}
+
+// start runs the action in the background, one action runs at a time
+func (d *Dashboard) start(a action) {
+	if d.view.busy != "" {
+		d.state.AddActivity(fmt.Sprintf("Wait for the end of the %s", d.view.busy), true)
+		return
+	}
+	d.view.busy = a.busy
+	go func() {
+		d.results <- actionResult{action: a, err: a.run()}
+	}()
+}
+
+// openShell leaves the screen of the dashboard and runs a shell in the selected container, the input of the
+// terminal is sent to the shell until it exits
+func (d *Dashboard) openShell(t *terminal) {
+	ref := d.selectedContainer()
+	if ref == nil {
+		d.state.AddActivity("No container to open a shell in", true)
+		return
+	}
+	reader, writer := io.Pipe()
+	d.shellInput = writer
+	t.leaveScreen()
+	t.write(fmt.Sprintf("Opening a shell in the container %s, exit the shell to go back to the dashboard\r\n", ref.Container))
+	go func() {
+		err := d.options.Shell(ref.Pod, ref.Container, reader, t.out)
+		_ = reader.Close()
+		d.shellDone <- err
+	}()
+}
+
+// parseKeys returns the names of the keys of the input of the terminal
+func parseKeys(data []byte) []string {
+	var keys []string
+	for i := 0; i < len(data); i++ {
+		switch b := data[i]; {
+		case b == 3:
+			keys = append(keys, "ctrl+c")
+		case b == '\t':
+			keys = append(keys, "tab")
+		case b == 0x1b:
+			if i+2 < len(data) && data[i+1] == '[' {
+				switch data[i+2] {
+				case 'A':
+					keys = append(keys, "up")
+				case 'B':
+					keys = append(keys, "down")
+				case 'Z':
+					keys = append(keys, "shift+tab")
+				}
+				i += 2
+				continue
+			}
+			keys = append(keys, "esc")
+		case b >= ' ' && b < 0x7f:
+			keys = append(keys, string(b))
+		}
+	}
+	return keys
+}
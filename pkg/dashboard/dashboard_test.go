@@ -0,0 +1,207 @@
+package dashboard
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func podStatusEvent(containers ...corev1.ContainerStatus) machineoutput.MachineEventWrapper {
+	return machineoutput.MachineEventWrapper{
+		KubernetesPodStatus: &machineoutput.KubernetesPodStatus{
+			Pods: []machineoutput.KubernetesPodStatusEntry{{
+				Name:       "nodejs-app-5c7f7d8b9-x2x5k",
+				Phase:      "Running",
+				Containers: containers,
+			}},
+		},
+	}
+}
+
+var (
+	runningRuntime = corev1.ContainerStatus{Name: "runtime", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}
+	waitingTools   = corev1.ContainerStatus{Name: "tools", RestartCount: 3, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}}
+)
+
+func contains(lines []string, text string) bool {
+	for _, line := range lines {
+		if strings.Contains(line, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestStateRender(t *testing.T) {
+	s := NewState()
+	s.now = func() time.Time { return time.Date(2021, 6, 1, 12, 30, 15, 0, time.UTC) }
+	s.Handle(podStatusEvent(runningRuntime, waitingTools))
+	s.Handle(machineoutput.MachineEventWrapper{SupervisordStatus: &machineoutput.SupervisordStatus{
+		ProgramStatus: []machineoutput.SupervisordStatusEntry{{Program: "devrun", Status: "RUNNING"}, {Program: "debugrun", Status: "STOPPED"}},
+	}})
+	s.Handle(machineoutput.MachineEventWrapper{URLReachable: &machineoutput.URLReachable{
+		Name: "http-3000", URL: "http://nodejs.example.com", Reachable: true, Probe: &machineoutput.URLProbe{Healthy: true, StatusCode: 200, LatencyMs: 12},
+	}})
+	s.Handle(machineoutput.MachineEventWrapper{FileSync: &machineoutput.FileSync{
+		ChangedFiles: []string{"server.js", "package.json"}, DeletedFiles: []string{"old.js", "README.md"}, Status: machineoutput.FileSyncStarted,
+	}})
+	s.Handle(machineoutput.MachineEventWrapper{FileSync: &machineoutput.FileSync{
+		ChangedFiles: []string{"server.js"}, Status: machineoutput.FileSyncFailed, Error: "exit status 1",
+	}})
+	s.AddLogLine("runtime", "\x1b[32mlistening on port 3000\x1b[0m")
+
+	lines := s.render(view{title: "nodejs", selected: 1}, 120, 40)
+	if len(lines) != 40 {
+		t.Fatalf("expected 40 lines, got %d", len(lines))
+	}
+	for _, text := range []string{
+		"odo dashboard - nodejs",
+		"nodejs-app-5c7f7d8b9-x2x5k  Running",
+		"runtime  Running                     ready: true  restarts: 0",
+		">   tools    Waiting (CrashLoopBackOff)  ready: false  restarts: 3",
+		"devrun RUNNING   debugrun STOPPED",
+		"http-3000  http://nodejs.example.com  reachable  200  12ms",
+		"12:30:15 Syncing server.js, package.json, old.js and 1 more files",
+		"! 12:30:15 Failed to sync server.js: exit status 1",
+		"-- runtime --",
+		"  listening on port 3000",
+	} {
+		if !contains(lines, text) {
+			t.Errorf("expected the dashboard to contain %q, got:\n%s", text, strings.Join(lines, "\n"))
+		}
+	}
+	if footer := lines[len(lines)-1]; !strings.HasPrefix(footer, "[p] push") {
+		t.Errorf("expected the keybindings on the last line, got %q", footer)
+	}
+
+	// the lines are cut to the width of the terminal
+	for _, line := range s.render(view{title: "nodejs"}, 20, 10) {
+		if len([]rune(line)) > 20 {
+			t.Errorf("line %q is wider than the terminal", line)
+		}
+	}
+}
+
+func TestStateRenderTail(t *testing.T) {
+	s := NewState()
+	s.Handle(podStatusEvent(runningRuntime))
+	for i := 0; i < maxLogLines+10; i++ {
+		s.AddLogLine("runtime", "line")
+	}
+	s.AddLogLine("runtime", "last line")
+
+	lines := s.render(view{title: "nodejs", tail: true}, 80, 10)
+	if len(lines) != 10 || lines[2] != "LOGS nodejs-app-5c7f7d8b9-x2x5k/runtime" || lines[8] != "last line" {
+		t.Errorf("expected the last lines of the runtime container, got:\n%s", strings.Join(lines, "\n"))
+	}
+	if len(s.logs["runtime"]) != maxLogLines {
+		t.Errorf("expected %d log lines kept, got %d", maxLogLines, len(s.logs["runtime"]))
+	}
+}
+
+func TestDashboardFollowLogs(t *testing.T) {
+	followed := make(chan string, 10)
+	d := New(Options{
+		Logs: func(pod string, container string) (io.ReadCloser, error) {
+			followed <- container
+			return ioutil.NopCloser(strings.NewReader("started\nlistening\n")), nil
+		},
+	})
+	// only the running containers are followed
+	d.Handle(podStatusEvent(runningRuntime, waitingTools))
+	select {
+	case container := <-followed:
+		if container != "runtime" {
+			t.Errorf("expected the log of the runtime container to be followed, got %s", container)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the log of the runtime container is not followed")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		d.state.mu.Lock()
+		logs := append([]string{}, d.state.logs["runtime"]...)
+		d.state.mu.Unlock()
+		if reflect.DeepEqual(logs, []string{"started", "listening"}) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the log lines of the runtime container, got %v", logs)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case container := <-followed:
+		t.Errorf("unexpected log followed for %s", container)
+	default:
+	}
+}
+
+func TestDashboardHandleKey(t *testing.T) {
+	pushed := make(chan struct{})
+	d := New(Options{
+		Push: func(handle func(event machineoutput.MachineEventWrapper)) error {
+			<-pushed
+			handle(machineoutput.MachineEventWrapper{ReportError: &machineoutput.ReportError{Error: "unable to push"}})
+			return errors.New("exit status 1")
+		},
+		RestartRunCommand: func() error {
+			return nil
+		},
+	})
+	d.Handle(podStatusEvent(runningRuntime, waitingTools))
+
+	if d.handleKey("p") != keyHandled || d.view.busy != "pushing" {
+		t.Fatalf("expected the push to start, busy is %q", d.view.busy)
+	}
+	// one action runs at a time
+	d.handleKey("r")
+	if d.view.busy != "pushing" {
+		t.Errorf("expected the restart to wait for the end of the push, busy is %q", d.view.busy)
+	}
+	close(pushed)
+	result := <-d.results
+	if result.err == nil || result.action.failed != "Failed to push the component" {
+		t.Errorf("expected the failure of the push, got %+v", result)
+	}
+	if !contains(d.state.render(d.view, 120, 40), "unable to push") {
+		t.Errorf("expected the events of the push to be shown")
+	}
+
+	d.handleKey("tab")
+	if ref := d.selectedContainer(); ref == nil || ref.Container != "tools" {
+		t.Errorf("expected the tools container to be selected, got %+v", ref)
+	}
+	d.handleKey("tab")
+	d.handleKey("up")
+	if d.view.selected != 1 {
+		t.Errorf("expected the selection to cycle, got %d", d.view.selected)
+	}
+	d.handleKey("t")
+	if !d.view.tail {
+		t.Errorf("expected the log of the selected container on the whole screen")
+	}
+	d.handleKey("esc")
+	if d.view.tail {
+		t.Errorf("expected esc to leave the log of the container")
+	}
+	if d.handleKey("s") != keyShell || d.handleKey("q") != keyQuit || d.handleKey("ctrl+c") != keyQuit {
+		t.Errorf("unexpected actions of the shell and quit keys")
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("p\t\x1b[A\x1b[B\x1b[Z\x1bq\x03"))
+	want := []string{"p", "tab", "up", "down", "shift+tab", "esc", "q", "ctrl+c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeys() = %v, want %v", got, want)
+	}
+}
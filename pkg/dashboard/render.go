@@ -0,0 +1,220 @@
+package dashboard
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// shownActivities is the number of the last activities shown besides the logs
+	shownActivities = 6
+
+	// activityTimeFormat is the format of the time of the activities
+	activityTimeFormat = "15:04:05"
+)
+
+// escapeSequences matches the terminal escape sequences of the log lines, removed to keep the layout
+var escapeSequences = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// view holds what the dashboard shows besides the state of the component
+type view struct {
+	title string
+	// selected is the index of the selected container
+	selected int
+	// tail is true when the log of the selected container fills the screen
+	tail bool
+	// busy describes the action in progress, empty if there is none
+	busy string
+}
+
+// render returns the lines of the dashboard for a terminal of the given size
+func (s *State) render(v view, width, height int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	title := "odo dashboard - " + v.title
+	if v.busy != "" {
+		title += " - " + v.busy
+	}
+	lines := []string{title, ""}
+	containers := s.containers()
+	var selected *ContainerRef
+	if v.selected >= 0 && v.selected < len(containers) {
+		selected = &containers[v.selected]
+	}
+
+	if v.tail {
+		footer := "[t/esc] back  [tab] next container  [s] shell  [q] quit"
+		if selected == nil {
+			lines = append(lines, "No container to follow")
+			return fit(lines, footer, width, height)
+		}
+		lines = append(lines, fmt.Sprintf("LOGS %s/%s", selected.Pod, selected.Container))
+		logs := s.logs[selected.Container]
+		lines = append(lines, last(logs, height-len(lines)-1)...)
+		return fit(lines, footer, width, height)
+	}
+
+	lines = append(lines, "PODS")
+	if len(s.pods) == 0 {
+		lines = append(lines, "  Waiting for the pods of the component")
+	}
+	nameWidth, stateWidth := 0, 0
+	for _, pod := range s.pods {
+		for _, container := range pod.Containers {
+			nameWidth = max(nameWidth, len(container.Name))
+			stateWidth = max(stateWidth, len(containerState(container)))
+		}
+	}
+	for _, pod := range s.pods {
+		lines = append(lines, fmt.Sprintf("  %s  %s", pod.Name, pod.Phase))
+		for _, container := range pod.InitContainers {
+			if t := container.State.Terminated; t != nil && t.ExitCode == 0 {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("      init %s  %s", container.Name, containerState(container)))
+		}
+		for _, container := range pod.Containers {
+			marker := "  "
+			if selected != nil && selected.Pod == pod.Name && selected.Container == container.Name {
+				marker = "> "
+			}
+			lines = append(lines, fmt.Sprintf("  %s  %-*s  %-*s  ready: %t  restarts: %d", marker,
+				nameWidth, container.Name, stateWidth, containerState(container), container.Ready, container.RestartCount))
+		}
+	}
+
+	lines = append(lines, "", "PROGRAMS")
+	if len(s.programs) == 0 {
+		lines = append(lines, "  No supervisord status yet")
+	} else {
+		var programs []string
+		for _, program := range s.programs {
+			programs = append(programs, fmt.Sprintf("%s %s", program.Program, program.Status))
+		}
+		lines = append(lines, "  "+strings.Join(programs, "   "))
+	}
+
+	lines = append(lines, "", "URLS")
+	if len(s.urls) == 0 {
+		lines = append(lines, "  No URL probed yet")
+	}
+	var names []string
+	for name := range s.urls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		u := s.urls[name]
+		status := "unreachable"
+		if u.Reachable {
+			status = "reachable"
+		}
+		line := fmt.Sprintf("  %s  %s  %s", u.Name, u.URL, status)
+		if u.Probe != nil {
+			if u.Probe.StatusCode != 0 {
+				line += fmt.Sprintf("  %d", u.Probe.StatusCode)
+			}
+			line += fmt.Sprintf("  %dms", u.Probe.LatencyMs)
+			if u.Probe.Error != "" {
+				line += "  " + u.Probe.Error
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", "SYNC AND PUSH")
+	if len(s.activities) == 0 {
+		lines = append(lines, "  No activity yet, run odo watch with --event-stream and --watch-events to follow its syncs")
+	}
+	activities := s.activities
+	if len(activities) > shownActivities {
+		activities = activities[len(activities)-shownActivities:]
+	}
+	for _, activity := range activities {
+		marker := " "
+		if activity.Failed {
+			marker = "!"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s", marker, activity.Time.Format(activityTimeFormat), activity.Text))
+	}
+
+	footer := "[p] push  [r] restart run command  [s] shell  [t] tail  [tab] next container  [q] quit"
+	lines = append(lines, "", "LOGS")
+	if len(containers) == 0 {
+		lines = append(lines, "  No container yet")
+		return fit(lines, footer, width, height)
+	}
+	// the rows left are shared by the containers, each one has a title line
+	rows := (height - len(lines) - 1) / len(containers)
+	for _, ref := range containers {
+		lines = append(lines, fmt.Sprintf("-- %s --", ref.Container))
+		for _, line := range last(s.logs[ref.Container], rows-1) {
+			lines = append(lines, "  "+line)
+		}
+	}
+	return fit(lines, footer, width, height)
+}
+
+// fit cuts the lines to the size of the terminal, and ends them with the footer on the last row
+func fit(lines []string, footer string, width, height int) []string {
+	if height < 1 {
+		return nil
+	}
+	if len(lines) > height-1 {
+		lines = lines[:height-1]
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, footer)
+	for i, line := range lines {
+		lines[i] = truncate(sanitize(line), width)
+	}
+	return lines
+}
+
+// sanitize removes the escape sequences and the control characters of a line, and expands its tabs
+func sanitize(line string) string {
+	line = escapeSequences.ReplaceAllString(line, "")
+	line = strings.ReplaceAll(line, "\t", "    ")
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, line)
+}
+
+// truncate cuts the line to the given number of characters
+func truncate(line string, width int) string {
+	if width < 1 {
+		return ""
+	}
+	runes := []rune(line)
+	if len(runes) > width {
+		return string(runes[:width])
+	}
+	return line
+}
+
+// last returns the last n lines
+func last(lines []string, n int) []string {
+	if n < 0 {
+		n = 0
+	}
+	if len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,213 @@
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// maxLogLines is the number of the last log lines kept per container
+	maxLogLines = 500
+
+	// maxActivities is the number of the last sync, push and command activities kept
+	maxActivities = 100
+)
+
+// Activity is a line of the sync and push activity of the component
+type Activity struct {
+	Time   time.Time
+	Text   string
+	Failed bool
+}
+
+// ContainerRef identifies a container of a pod of the component
+type ContainerRef struct {
+	Pod       string
+	Container string
+}
+
+// State is the state of the component shown by the dashboard, updated by the machine readable events of the
+// pod watcher, the supervisord status watch, the URL probes, the pushes and odo watch
+type State struct {
+	mu       sync.Mutex
+	pods     []machineoutput.KubernetesPodStatusEntry
+	programs []machineoutput.SupervisordStatusEntry
+	// urls are the last reachability of the URLs, by name
+	urls       map[string]machineoutput.URLReachable
+	logs       map[string][]string
+	activities []Activity
+
+	// changed receives a value when the state changes, to draw the dashboard again
+	changed chan struct{}
+	// now returns the current time, replaced by the tests
+	now func() time.Time
+}
+
+// NewState returns an empty state
+func NewState() *State {
+	return &State{
+		urls:    make(map[string]machineoutput.URLReachable),
+		logs:    make(map[string][]string),
+		changed: make(chan struct{}, 1),
+		now:     time.Now,
+	}
+}
+
+// Changed returns the channel receiving a value when the state changes
+func (s *State) Changed() <-chan struct{} {
+	return s.changed
+}
+
+// notify signals a change of the state, the changes not handled yet are merged
+func (s *State) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Handle updates the state with the given machine readable event
+func (s *State) Handle(event machineoutput.MachineEventWrapper) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.notify()
+
+	switch {
+	case event.KubernetesPodStatus != nil:
+		pods := append([]machineoutput.KubernetesPodStatusEntry{}, event.KubernetesPodStatus.Pods...)
+		sort.Slice(pods, func(i, j int) bool {
+			return pods[i].Name < pods[j].Name
+		})
+		s.pods = pods
+
+	case event.SupervisordStatus != nil:
+		s.programs = event.SupervisordStatus.ProgramStatus
+
+	case event.URLReachable != nil:
+		s.urls[event.URLReachable.Name] = *event.URLReachable
+
+	case event.FileSync != nil:
+		fileSync := event.FileSync
+		files := append(append([]string{}, fileSync.ChangedFiles...), fileSync.DeletedFiles...)
+		switch fileSync.Status {
+		case machineoutput.FileSyncStarted:
+			s.addActivity(fmt.Sprintf("Syncing %s", describeFiles(files)), false)
+		case machineoutput.FileSyncSucceeded:
+			s.addActivity(fmt.Sprintf("Synced %s", describeFiles(files)), false)
+		default:
+			s.addActivity(fmt.Sprintf("Failed to sync %s: %s", describeFiles(files), fileSync.Error), true)
+		}
+
+	case event.DevFileCommandExecutionBegin != nil:
+		command := event.DevFileCommandExecutionBegin
+		s.addActivity(fmt.Sprintf("Executing the %s command %s in %s", command.GroupKind, command.CommandID, command.ComponentName), false)
+
+	case event.DevFileCommandExecutionComplete != nil:
+		command := event.DevFileCommandExecutionComplete
+		if command.Error != "" {
+			s.addActivity(fmt.Sprintf("The %s command %s failed: %s", command.GroupKind, command.CommandID, command.Error), true)
+		}
+
+	case event.LogText != nil:
+		s.addActivity("  "+event.LogText.Text, event.LogText.Stream == "stderr")
+
+	case event.ReportError != nil:
+		s.addActivity(event.ReportError.Error, true)
+	}
+}
+
+// describeFiles returns the first changed files, and the number of the other ones
+func describeFiles(files []string) string {
+	const shown = 3
+	switch {
+	case len(files) == 0:
+		return "the component"
+	case len(files) <= shown:
+		return strings.Join(files, ", ")
+	}
+	return fmt.Sprintf("%s and %d more files", strings.Join(files[:shown], ", "), len(files)-shown)
+}
+
+// AddActivity adds a line to the sync and push activity
+func (s *State) AddActivity(text string, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.notify()
+	s.addActivity(text, failed)
+}
+
+func (s *State) addActivity(text string, failed bool) {
+	s.activities = append(s.activities, Activity{Time: s.now(), Text: text, Failed: failed})
+	if len(s.activities) > maxActivities {
+		s.activities = s.activities[len(s.activities)-maxActivities:]
+	}
+}
+
+// AddLogLine adds a line to the log of the given container
+func (s *State) AddLogLine(container string, line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer s.notify()
+	lines := append(s.logs[container], line)
+	if len(lines) > maxLogLines {
+		lines = lines[len(lines)-maxLogLines:]
+	}
+	s.logs[container] = lines
+}
+
+// Containers returns the containers of the pods of the component, by pod and container name
+func (s *State) Containers() []ContainerRef {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.containers()
+}
+
+func (s *State) containers() []ContainerRef {
+	var refs []ContainerRef
+	for _, pod := range s.pods {
+		for _, container := range pod.Containers {
+			refs = append(refs, ContainerRef{Pod: pod.Name, Container: container.Name})
+		}
+	}
+	return refs
+}
+
+// RunningContainers returns the running containers of the pods of the component
+func (s *State) RunningContainers() []ContainerRef {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var refs []ContainerRef
+	for _, pod := range s.pods {
+		for _, container := range pod.Containers {
+			if container.State.Running != nil {
+				refs = append(refs, ContainerRef{Pod: pod.Name, Container: container.Name})
+			}
+		}
+	}
+	return refs
+}
+
+// containerState returns the state of a container, with the reason of a waiting or terminated container
+func containerState(status corev1.ContainerStatus) string {
+	switch {
+	case status.State.Running != nil:
+		return "Running"
+	case status.State.Waiting != nil:
+		if status.State.Waiting.Reason != "" {
+			return fmt.Sprintf("Waiting (%s)", status.State.Waiting.Reason)
+		}
+		return "Waiting"
+	case status.State.Terminated != nil:
+		if status.State.Terminated.Reason != "" {
+			return fmt.Sprintf("Terminated (%s)", status.State.Terminated.Reason)
+		}
+		return "Terminated"
+	}
+	return "Unknown"
+}
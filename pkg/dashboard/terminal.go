@@ -0,0 +1,106 @@
+package dashboard
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+	"k8s.io/klog"
+)
+
+const (
+	// the escape sequences switching to the alternate screen of the terminal and hiding the cursor, and back
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+
+	// defaultWidth and defaultHeight are the size of a terminal not reporting its size
+	defaultWidth  = 80
+	defaultHeight = 24
+)
+
+// terminal is the terminal of the dashboard, in raw mode and on its alternate screen
+type terminal struct {
+	in    *os.File
+	out   *os.File
+	state *term.State
+}
+
+// openTerminal puts the terminal in raw mode and switches to its alternate screen
+func openTerminal(in *os.File, out *os.File) (*terminal, error) {
+	if !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return nil, errors.New("the dashboard must be run in a terminal")
+	}
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to put the terminal in raw mode")
+	}
+	t := &terminal{in: in, out: out, state: state}
+	t.enterScreen()
+	return t, nil
+}
+
+// readInput sends the bytes read from the terminal to the returned channel
+func (t *terminal) readInput() <-chan []byte {
+	input := make(chan []byte)
+	go func() {
+		buf := make([]byte, 256)
+		for {
+			n, err := t.in.Read(buf)
+			if err != nil {
+				klog.V(4).Infof("Unable to read the terminal: %v", err)
+				close(input)
+				return
+			}
+			input <- append([]byte{}, buf[:n]...)
+		}
+	}()
+	return input
+}
+
+func (t *terminal) enterScreen() {
+	t.write(enterScreen)
+}
+
+func (t *terminal) leaveScreen() {
+	t.write(leaveScreen)
+}
+
+// close switches back to the main screen and restores the mode of the terminal
+func (t *terminal) close() {
+	t.leaveScreen()
+	if err := term.Restore(int(t.in.Fd()), t.state); err != nil {
+		klog.V(4).Infof("Unable to restore the terminal: %v", err)
+	}
+}
+
+// size returns the width and the height of the terminal
+func (t *terminal) size() (int, int) {
+	width, height, err := term.GetSize(int(t.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return defaultWidth, defaultHeight
+	}
+	return width, height
+}
+
+// draw replaces the content of the screen with the given lines
+func (t *terminal) draw(lines []string) {
+	var buf bytes.Buffer
+	// move to the top left corner, and clear the end of each line and the rows below the last one
+	buf.WriteString("\x1b[H")
+	for i, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString("\x1b[K")
+		if i < len(lines)-1 {
+			buf.WriteString("\r\n")
+		}
+	}
+	buf.WriteString("\x1b[J")
+	t.write(buf.String())
+}
+
+func (t *terminal) write(s string) {
+	if _, err := t.out.WriteString(s); err != nil {
+		klog.V(4).Infof("Unable to write to the terminal: %v", err)
+	}
+}
@@ -31,6 +31,7 @@ type PushParameters struct {
 	Debug                    bool                    // Runs the component in debug mode
 	DebugPort                int                     // Port used for remote debugging
 	RunModeChanged           bool                    // It determines if run mode is changed from run to debug or vice versa
+	PostSyncHook             func() error            // Optional: PostSyncHook is called once the files are synced, before the devfile commands are executed, an error aborts the push
 }
 
 // SyncParameters is a struct containing the parameters to be used when syncing a devfile component
@@ -53,6 +54,12 @@ func (ci ComponentInfo) IsEmpty() bool {
 	return len(ci.ContainerName) == 0
 }
 
+// StorageMount is the container of a component in which a storage is mounted, and the path it is mounted on
+type StorageMount struct {
+	ComponentInfo
+	Path string
+}
+
 // PushCommandsMap stores the commands to be executed as per their types.
 type PushCommandsMap map[devfilev1.CommandGroupKind]devfilev1.Command
 
@@ -17,6 +17,10 @@ type Adapter struct {
 	componentAdapter common.ComponentAdapter
 }
 
+// DockerContext is the platform context of the components run as local Docker containers
+type DockerContext struct {
+}
+
 // New instantiates a Docker adapter
 func New(adapterContext common.AdapterContext, client lclient.Client) Adapter {
 
@@ -62,6 +66,11 @@ func (d Adapter) Log(follow bool, command devfilev1.Command) (io.ReadCloser, err
 	return d.componentAdapter.Log(follow, command)
 }
 
+// GetStorageMount returns the container in which the given storage is mounted, and its mount path
+func (d Adapter) GetStorageMount(storageName string) (common.StorageMount, error) {
+	return d.componentAdapter.GetStorageMount(storageName)
+}
+
 // Exec executes a command in the component
 func (d Adapter) Exec(command []string) error {
 	return d.componentAdapter.Exec(command)
@@ -16,6 +16,7 @@ import (
 	"github.com/openshift/odo/pkg/devfile/adapters/docker/utils"
 	"github.com/openshift/odo/pkg/lclient"
 	"github.com/openshift/odo/pkg/log"
+	storagelabels "github.com/openshift/odo/pkg/storage/labels"
 	"github.com/openshift/odo/pkg/sync"
 )
 
@@ -145,6 +146,11 @@ func (a Adapter) Push(parameters common.PushParameters) (err error) {
 	if err != nil {
 		return errors.Wrapf(err, "failed to sync to component with name %s", a.ComponentName)
 	}
+	if execRequired && parameters.PostSyncHook != nil {
+		if err = parameters.PostSyncHook(); err != nil {
+			return err
+		}
+	}
 
 	// PostStart events from the devfile will only be executed when the component
 	// didn't previously exist
@@ -386,6 +392,38 @@ func (a Adapter) Exec(command []string) error {
 	return a.ExecuteCommand(componentInfo, command, true, nil, nil)
 }
 
+// GetStorageMount returns the container in which the named volume of the given storage is mounted, and its mount path
+func (a Adapter) GetStorageMount(storageName string) (common.StorageMount, error) {
+	volumes, err := a.Client.GetVolumesByLabel(map[string]string{
+		"component":                       a.ComponentName,
+		storagelabels.DevfileStorageLabel: storageName,
+	})
+	if err != nil {
+		return common.StorageMount{}, err
+	}
+	if len(volumes) != 1 {
+		return common.StorageMount{}, fmt.Errorf("expected exactly one volume for storage %s of component %s, but got %d", storageName, a.ComponentName, len(volumes))
+	}
+
+	containers, err := utils.GetComponentContainers(a.Client, a.ComponentName)
+	if err != nil {
+		return common.StorageMount{}, errors.Wrapf(err, "unable to get the containers of component %s", a.ComponentName)
+	}
+	for _, container := range containers {
+		for _, m := range container.Mounts {
+			if m.Type == mount.TypeVolume && m.Name == volumes[0].Name {
+				return common.StorageMount{
+					ComponentInfo: common.ComponentInfo{
+						ContainerName: container.ID,
+					},
+					Path: m.Destination,
+				}, nil
+			}
+		}
+	}
+	return common.StorageMount{}, fmt.Errorf("storage %s is not mounted in any container of component %s", storageName, a.ComponentName)
+}
+
 //ExecCMDInContainer executes the command in the container with containerID
 func (a Adapter) ExecCMDInContainer(componentInfo common.ComponentInfo, cmd []string, stdout io.Writer, stderr io.Writer, stdin io.Reader, tty bool) error {
 	return a.Client.ExecCMDInContainer(componentInfo.ContainerName, cmd, stdout, stderr, stdin, tty)
@@ -5,8 +5,11 @@ import (
 
 	devfileParser "github.com/devfile/library/pkg/devfile/parser"
 	"github.com/openshift/odo/pkg/devfile/adapters/common"
+	"github.com/openshift/odo/pkg/devfile/adapters/docker"
 	"github.com/openshift/odo/pkg/devfile/adapters/kubernetes"
 	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/lclient"
+	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/occlient"
 )
 
@@ -20,14 +23,30 @@ func NewComponentAdapter(componentName string, context string, appName string, d
 		Devfile:       devObj,
 	}
 
-	kc, ok := platformContext.(kubernetes.KubernetesContext)
-	if !ok {
-		return nil, fmt.Errorf("Error retrieving context for Kubernetes")
+	switch pc := platformContext.(type) {
+	case kubernetes.KubernetesContext:
+		return createKubernetesAdapter(adapterContext, pc.Namespace)
+	case docker.DockerContext:
+		return createDockerAdapter(adapterContext)
 	}
-	return createKubernetesAdapter(adapterContext, kc.Namespace)
+	return nil, fmt.Errorf("Error retrieving context for Kubernetes")
 
 }
 
+// NewComponentAdapterWithClient returns a Devfile adapter for Kubernetes using the given client, for the callers keeping
+// a client across the adapters, and reporting the machine readable events to the given logging client
+func NewComponentAdapterWithClient(componentName string, context string, appName string, devObj devfileParser.DevfileObj, client occlient.Client, loggingClient machineoutput.MachineEventLoggingClient) common.ComponentAdapter {
+
+	adapterContext := common.AdapterContext{
+		ComponentName: componentName,
+		Context:       context,
+		AppName:       appName,
+		Devfile:       devObj,
+	}
+
+	return kubernetes.NewWithLogger(adapterContext, client, loggingClient)
+}
+
 func createKubernetesAdapter(adapterContext common.AdapterContext, namespace string) (common.ComponentAdapter, error) {
 	client, err := occlient.New()
 	if err != nil {
@@ -48,6 +67,14 @@ func createKubernetesAdapter(adapterContext common.AdapterContext, namespace str
 	return newKubernetesAdapter(adapterContext, *client)
 }
 
+func createDockerAdapter(adapterContext common.AdapterContext) (common.ComponentAdapter, error) {
+	client, err := lclient.New()
+	if err != nil {
+		return nil, err
+	}
+	return docker.New(adapterContext, *client), nil
+}
+
 func newKubernetesAdapter(adapterContext common.AdapterContext, client occlient.Client) (common.ComponentAdapter, error) {
 	// Feed the common metadata to the platform-specific adapter
 	kubernetesAdapter := kubernetes.New(adapterContext, client)
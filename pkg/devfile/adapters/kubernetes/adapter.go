@@ -31,6 +31,17 @@ func New(adapterContext common.AdapterContext, client occlient.Client) Adapter {
 	}
 }
 
+// NewWithLogger instantiates a kubernetes adapter reporting the machine readable events to the given logging client
+func NewWithLogger(adapterContext common.AdapterContext, client occlient.Client, loggingClient machineoutput.MachineEventLoggingClient) Adapter {
+
+	compAdapter := component.New(adapterContext, client)
+	compAdapter.SetLogger(loggingClient)
+
+	return Adapter{
+		componentAdapter: &compAdapter,
+	}
+}
+
 // Push creates Kubernetes resources that correspond to the devfile if they don't already exist
 func (k Adapter) Push(parameters common.PushParameters) error {
 
@@ -78,6 +89,11 @@ func (k Adapter) Log(follow bool, command devfilev1.Command) (io.ReadCloser, err
 	return k.componentAdapter.Log(follow, command)
 }
 
+// GetStorageMount returns the container in which the given storage is mounted, and its mount path
+func (k Adapter) GetStorageMount(storageName string) (common.StorageMount, error) {
+	return k.componentAdapter.GetStorageMount(storageName)
+}
+
 // Exec executes a command in the component
 func (k Adapter) Exec(command []string) error {
 	return k.componentAdapter.Exec(command)
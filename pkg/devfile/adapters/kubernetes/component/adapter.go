@@ -36,6 +36,7 @@ import (
 	storagepkg "github.com/openshift/odo/pkg/storage"
 	storagelabels "github.com/openshift/odo/pkg/storage/labels"
 	"github.com/openshift/odo/pkg/sync"
+	urlLabels "github.com/openshift/odo/pkg/url/labels"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
@@ -234,6 +235,11 @@ func (a Adapter) Push(parameters common.PushParameters) (err error) {
 		}
 	}
 
+	err = storage.UpdateSourceStorageOwnerReferences(*a.Client.GetKubeClient(), a.ComponentName, ownerReference)
+	if err != nil {
+		return err
+	}
+
 	err = service.UpdateKubernetesInlineComponentsOwnerReferences(a.Client.GetKubeClient(), k8sComponents, ownerReference)
 	if err != nil {
 		return err
@@ -276,6 +282,11 @@ func (a Adapter) Push(parameters common.PushParameters) (err error) {
 	if err != nil {
 		return errors.Wrapf(err, "Failed to sync to component with name %s", a.ComponentName)
 	}
+	if execRequired && parameters.PostSyncHook != nil {
+		if err = parameters.PostSyncHook(); err != nil {
+			return err
+		}
+	}
 
 	// PostStart events from the devfile will only be executed when the component
 	// didn't previously exist
@@ -403,6 +414,12 @@ func (a *Adapter) createOrUpdateComponent(componentExists bool, ei envinfo.EnvSp
 		return err
 	}
 
+	// create or update the ConfigMaps and Secrets of the storage mounted from local files
+	sourceVolumes, err := storage.HandleSourceStorage(*a.Client.GetKubeClient(), &ei, a.ComponentName, a.AppName, a.Context)
+	if err != nil {
+		return err
+	}
+
 	componentName := a.ComponentName
 
 	componentType := strings.TrimSuffix(a.AdapterContext.Devfile.Data.GetMetadata().Name, "-")
@@ -461,6 +478,10 @@ func (a *Adapter) createOrUpdateComponent(componentExists bool, ei envinfo.EnvSp
 		}
 	}
 
+	for storageName, volInfo := range sourceVolumes {
+		volumeNameToVolInfo[storageName] = volInfo
+	}
+
 	// Get PVC volumes and Volume Mounts
 	pvcVolumes, err := storage.GetVolumesAndVolumeMounts(a.Devfile, containers, volumeNameToVolInfo, parsercommon.DevfileOptions{})
 	if err != nil {
@@ -528,7 +549,7 @@ func (a *Adapter) createOrUpdateComponent(componentExists bool, ei envinfo.EnvSp
 			return err
 		}
 		klog.V(2).Infof("Successfully updated component %v", componentName)
-		oldSvc, err := a.Client.GetKubeClient().GetOneService(a.ComponentName, a.AppName)
+		oldSvc, err := a.Client.GetKubeClient().GetOneServiceFromSelector(urlLabels.GetComponentServiceSelector(a.ComponentName, a.AppName))
 		ownerReference := generator.GetOwnerReference(a.deployment)
 		svc.OwnerReferences = append(svc.OwnerReferences, ownerReference)
 		if err != nil {
@@ -719,6 +740,43 @@ func (a Adapter) Exec(command []string) error {
 	return a.ExecuteCommand(componentInfo, command, true, nil, nil)
 }
 
+// GetStorageMount returns the container of the running pod in which the given storage is mounted, and its mount path
+func (a Adapter) GetStorageMount(storageName string) (common.StorageMount, error) {
+	pod, err := a.Client.GetKubeClient().GetOnePod(a.ComponentName, a.AppName)
+	if err != nil {
+		return common.StorageMount{}, errors.Wrapf(err, "unable to get pod for component %s", a.ComponentName)
+	}
+
+	if pod.Status.Phase != corev1.PodRunning {
+		return common.StorageMount{}, fmt.Errorf("the component %s is not running. Current status=%v", a.ComponentName, pod.Status.Phase)
+	}
+
+	selector := fmt.Sprintf("component=%s,%s=%s", a.ComponentName, storagelabels.DevfileStorageLabel, storageName)
+	pvcs, err := a.Client.GetKubeClient().ListPVCs(selector)
+	if err != nil {
+		return common.StorageMount{}, err
+	}
+	if len(pvcs) != 1 {
+		return common.StorageMount{}, fmt.Errorf("expected exactly one PVC for storage %s of component %s, but got %d", storageName, a.ComponentName, len(pvcs))
+	}
+
+	volumeName := pvcs[0].Name + "-vol"
+	for _, container := range pod.Spec.Containers {
+		for _, volumeMount := range container.VolumeMounts {
+			if volumeMount.Name == volumeName {
+				return common.StorageMount{
+					ComponentInfo: common.ComponentInfo{
+						PodName:       pod.Name,
+						ContainerName: container.Name,
+					},
+					Path: volumeMount.MountPath,
+				}, nil
+			}
+		}
+	}
+	return common.StorageMount{}, fmt.Errorf("storage %s is not mounted in any container of component %s", storageName, a.ComponentName)
+}
+
 func (a Adapter) ExecCMDInContainer(componentInfo common.ComponentInfo, cmd []string, stdout io.Writer, stderr io.Writer, stdin io.Reader, tty bool) error {
 	return a.Client.GetKubeClient().ExecCMDInContainer(componentInfo.ContainerName, componentInfo.PodName, cmd, stdout, stderr, stdin, tty)
 }
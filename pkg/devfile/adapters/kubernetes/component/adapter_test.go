@@ -20,6 +20,7 @@ import (
 	adaptersCommon "github.com/openshift/odo/pkg/devfile/adapters/common"
 	"github.com/openshift/odo/pkg/kclient"
 	"github.com/openshift/odo/pkg/occlient"
+	storagelabels "github.com/openshift/odo/pkg/storage/labels"
 	odoTestingUtil "github.com/openshift/odo/pkg/testingutil"
 
 	v1 "k8s.io/api/apps/v1"
@@ -669,3 +670,96 @@ func TestAdapter_generateDeploymentObjectMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestAdapter_GetStorageMount(t *testing.T) {
+	testComponentName := "nodejs"
+	pvcName := "db-nodejs-app"
+	pvcLabels := map[string]string{
+		"component":                       testComponentName,
+		storagelabels.DevfileStorageLabel: "db",
+	}
+
+	runningPod := func(phase corev1.PodPhase) *corev1.Pod {
+		pod := kclient.FakePodStatus(phase, "nodejs-pod")
+		pod.Labels = componentLabels.GetLabels(testComponentName, "app", false)
+		pod.Spec.Containers = []corev1.Container{
+			{
+				Name: "tools",
+			},
+			{
+				Name: "runtime",
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      pvcName + "-vol",
+						MountPath: "/data",
+					},
+				},
+			},
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name      string
+		pod       *corev1.Pod
+		pvcs      []corev1.PersistentVolumeClaim
+		wantMount adaptersCommon.StorageMount
+		wantErr   bool
+	}{
+		{
+			name: "Case 1: storage mounted in a container of the running pod",
+			pod:  runningPod(corev1.PodRunning),
+			pvcs: []corev1.PersistentVolumeClaim{*odoTestingUtil.FakePVC(pvcName, "1Gi", pvcLabels)},
+			wantMount: adaptersCommon.StorageMount{
+				ComponentInfo: adaptersCommon.ComponentInfo{
+					PodName:       "nodejs-pod",
+					ContainerName: "runtime",
+				},
+				Path: "/data",
+			},
+		},
+		{
+			name:    "Case 2: pod not running",
+			pod:     runningPod(corev1.PodPending),
+			pvcs:    []corev1.PersistentVolumeClaim{*odoTestingUtil.FakePVC(pvcName, "1Gi", pvcLabels)},
+			wantErr: true,
+		},
+		{
+			name:    "Case 3: storage not pushed",
+			pod:     runningPod(corev1.PodRunning),
+			wantErr: true,
+		},
+		{
+			name:    "Case 4: storage not mounted in any container",
+			pod:     runningPod(corev1.PodRunning),
+			pvcs:    []corev1.PersistentVolumeClaim{*odoTestingUtil.FakePVC("cache-nodejs-app", "1Gi", pvcLabels)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapterCtx := adaptersCommon.AdapterContext{
+				ComponentName: testComponentName,
+				AppName:       "app",
+			}
+
+			fkclient, fkclientset := occlient.FakeNew()
+			fkclientset.Kubernetes.PrependReactor("list", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.PodList{Items: []corev1.Pod{*tt.pod}}, nil
+			})
+			fkclientset.Kubernetes.PrependReactor("list", "persistentvolumeclaims", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.PersistentVolumeClaimList{Items: tt.pvcs}, nil
+			})
+
+			componentAdapter := New(adapterCtx, *fkclient)
+			got, err := componentAdapter.GetStorageMount("db")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetStorageMount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.wantMount) {
+				t.Errorf("GetStorageMount() = %v, want %v", got, tt.wantMount)
+			}
+		})
+	}
+}
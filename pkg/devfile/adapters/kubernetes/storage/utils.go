@@ -2,27 +2,46 @@ package storage
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/devfile/library/pkg/devfile/generator"
 	devfileParser "github.com/devfile/library/pkg/devfile/parser"
 	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
 	componentlabels "github.com/openshift/odo/pkg/component/labels"
 	"github.com/openshift/odo/pkg/envinfo"
 	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/preference"
 	"github.com/openshift/odo/pkg/storage"
 	storagelabels "github.com/openshift/odo/pkg/storage/labels"
 	"github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
+// maxSourceSize is the maximum size of the content of a ConfigMap or a Secret created from local files
+const maxSourceSize = 1024 * 1024
+
 // VolumeInfo is a struct to hold the pvc name and the volume name to create a volume.
 // To be moved to devfile/library.
 type VolumeInfo struct {
 	PVCName    string
 	VolumeName string
+	// ConfigMapName is the name of the ConfigMap mounted instead of a PVC, for storage created from local files
+	ConfigMapName string
+	// SecretName is the name of the Secret mounted instead of a PVC, for storage created from local files
+	SecretName string
 }
 
 // GetVolumesAndVolumeMounts gets the PVC volumes and updates the containers with the volume mounts.
@@ -37,7 +56,7 @@ func GetVolumesAndVolumeMounts(devfileObj devfileParser.DevfileObj, containers [
 
 	var pvcVols []corev1.Volume
 	for volName, volInfo := range volumeNameToVolInfo {
-		pvcVols = append(pvcVols, getPVC(volInfo.VolumeName, volInfo.PVCName))
+		pvcVols = append(pvcVols, getVolume(volInfo))
 
 		// containerNameToMountPaths is a map of the Devfile container name to their Devfile Volume Mount Paths for a given Volume Name
 		containerNameToMountPaths := make(map[string][]string)
@@ -54,6 +73,31 @@ func GetVolumesAndVolumeMounts(devfileObj devfileParser.DevfileObj, containers [
 	return pvcVols, nil
 }
 
+// getVolume gets the volume of the given volume info, backed by a ConfigMap, a Secret or a PVC
+func getVolume(volInfo VolumeInfo) corev1.Volume {
+	switch {
+	case volInfo.ConfigMapName != "":
+		return corev1.Volume{
+			Name: volInfo.VolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: volInfo.ConfigMapName},
+				},
+			},
+		}
+	case volInfo.SecretName != "":
+		return corev1.Volume{
+			Name: volInfo.VolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: volInfo.SecretName,
+				},
+			},
+		}
+	}
+	return getPVC(volInfo.VolumeName, volInfo.PVCName)
+}
+
 // getPVC gets a pvc type volume with the given volume name and pvc name.
 // To be moved to devfile/library.
 func getPVC(volumeName, pvcName string) corev1.Volume {
@@ -141,3 +185,241 @@ func HandleEphemeralStorage(client kclient.Client, storageClient storage.Client,
 	}
 	return nil
 }
+
+// HandleSourceStorage creates or updates the ConfigMaps and Secrets holding the local files of the storage
+// mounted from local files, and deletes the ones of the storage which are no longer in the devfile.
+// The files are mounted without subPath, so that the kubelet refreshes them in the running containers when they are updated.
+// It returns a map of the storage name to the volume info of its ConfigMap or Secret.
+func HandleSourceStorage(client kclient.Client, configProvider localConfigProvider.LocalConfigProvider, componentName, appName, contextDir string) (map[string]VolumeInfo, error) {
+	localStorage, err := configProvider.ListStorage()
+	if err != nil {
+		return nil, err
+	}
+
+	selector := fmt.Sprintf("%v=%s,%s", "component", componentName, storagelabels.DevfileStorageLabel)
+	configMaps, err := client.ListConfigMaps(selector)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := client.ListSecrets(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	existingConfigMaps := make(map[string]corev1.ConfigMap)
+	for _, configMap := range configMaps {
+		existingConfigMaps[configMap.Name] = configMap
+	}
+	existingSecrets := make(map[string]corev1.Secret)
+	for _, secret := range secrets {
+		existingSecrets[secret.Name] = secret
+	}
+
+	volumeNameToVolInfo := make(map[string]VolumeInfo)
+	for _, store := range localStorage {
+		// the storage is listed once for each container it is mounted in
+		if _, ok := volumeNameToVolInfo[store.Name]; ok || store.Source == "" {
+			continue
+		}
+
+		name, err := util.NamespaceKubernetesObjectWithTrim(store.Name, componentName)
+		if err != nil {
+			return nil, err
+		}
+		volumeName, err := GenerateVolumeNameFromPVC(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to generate volume name for storage %s", store.Name)
+		}
+
+		files, err := readSourceFiles(filepath.Join(contextDir, filepath.FromSlash(store.Source)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the files of storage %s", store.Name)
+		}
+
+		labels := storagelabels.GetLabels(store.Name, componentName, appName, true)
+		labels["component"] = componentName
+		labels[storagelabels.DevfileStorageLabel] = store.Name
+		objectMeta := generator.GetObjectMeta(name, client.Namespace, labels, map[string]string{storagelabels.SourceAnnotation: store.Source})
+
+		var existed, updated bool
+		if store.SourceKind == envinfo.SourceKindSecret {
+			existing, ok := existingSecrets[name]
+			existed = ok
+			updated, err = pushSourceSecret(client, objectMeta, files, existing, ok)
+			delete(existingSecrets, name)
+			volumeNameToVolInfo[store.Name] = VolumeInfo{VolumeName: volumeName, SecretName: name}
+		} else {
+			existing, ok := existingConfigMaps[name]
+			existed = ok
+			updated, err = pushSourceConfigMap(client, objectMeta, files, existing, ok)
+			delete(existingConfigMaps, name)
+			volumeNameToVolInfo[store.Name] = VolumeInfo{VolumeName: volumeName, ConfigMapName: name}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !existed {
+			log.Successf("Added storage %v from %v to %v", store.Name, store.Source, componentName)
+		} else if updated {
+			log.Successf("Updated storage %v from %v, the running containers see the new files once the kubelet refreshes the volume", store.Name, store.Source)
+		}
+	}
+
+	// delete the ConfigMaps and Secrets of the storage deleted locally, or whose kind changed
+	for name, configMap := range existingConfigMaps {
+		if err := client.DeleteConfigMap(name); err != nil {
+			return nil, errors.Wrapf(err, "unable to delete ConfigMap %s", name)
+		}
+		log.Successf("Deleted storage %v from %v", configMap.Labels[storagelabels.DevfileStorageLabel], componentName)
+	}
+	for name, secret := range existingSecrets {
+		if err := client.DeleteSecret(name); err != nil {
+			return nil, errors.Wrapf(err, "unable to delete secret %s", name)
+		}
+		log.Successf("Deleted storage %v from %v", secret.Labels[storagelabels.DevfileStorageLabel], componentName)
+	}
+
+	return volumeNameToVolInfo, nil
+}
+
+// UpdateSourceStorageOwnerReferences sets the given owner reference on the ConfigMaps and Secrets
+// of the storage mounted from local files which have no owner yet
+func UpdateSourceStorageOwnerReferences(client kclient.Client, componentName string, ownerReference metav1.OwnerReference) error {
+	selector := fmt.Sprintf("%v=%s,%s", "component", componentName, storagelabels.DevfileStorageLabel)
+
+	configMaps, err := client.ListConfigMaps(selector)
+	if err != nil {
+		return err
+	}
+	for _, configMap := range configMaps {
+		if configMap.OwnerReferences != nil || configMap.DeletionTimestamp != nil {
+			continue
+		}
+		configMap.OwnerReferences = []metav1.OwnerReference{ownerReference}
+		if _, err = client.UpdateConfigMap(configMap); err != nil {
+			return err
+		}
+	}
+
+	secrets, err := client.ListSecrets(selector)
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets {
+		if secret.OwnerReferences != nil || secret.DeletionTimestamp != nil {
+			continue
+		}
+		secret.OwnerReferences = []metav1.OwnerReference{ownerReference}
+		if _, err = client.UpdateSecret(secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushSourceConfigMap creates the ConfigMap holding the given files, or updates the existing one if its content differs
+// it returns true if the existing ConfigMap was updated
+func pushSourceConfigMap(client kclient.Client, objectMeta metav1.ObjectMeta, files map[string][]byte, existing corev1.ConfigMap, exists bool) (bool, error) {
+	configMap := corev1.ConfigMap{
+		TypeMeta:   generator.GetTypeMeta("ConfigMap", "v1"),
+		ObjectMeta: objectMeta,
+	}
+	for key, content := range files {
+		if utf8.Valid(content) {
+			if configMap.Data == nil {
+				configMap.Data = make(map[string]string)
+			}
+			configMap.Data[key] = string(content)
+		} else {
+			if configMap.BinaryData == nil {
+				configMap.BinaryData = make(map[string][]byte)
+			}
+			configMap.BinaryData[key] = content
+		}
+	}
+
+	if !exists {
+		_, err := client.CreateConfigMap(configMap)
+		return false, err
+	}
+
+	if reflect.DeepEqual(existing.Data, configMap.Data) && reflect.DeepEqual(existing.BinaryData, configMap.BinaryData) &&
+		reflect.DeepEqual(existing.Annotations, configMap.Annotations) {
+		return false, nil
+	}
+	configMap.ResourceVersion = existing.ResourceVersion
+	configMap.OwnerReferences = existing.OwnerReferences
+	_, err := client.UpdateConfigMap(configMap)
+	return err == nil, err
+}
+
+// pushSourceSecret creates the Secret holding the given files, or updates the existing one if its content differs
+// it returns true if the existing Secret was updated
+func pushSourceSecret(client kclient.Client, objectMeta metav1.ObjectMeta, files map[string][]byte, existing corev1.Secret, exists bool) (bool, error) {
+	secret := corev1.Secret{
+		TypeMeta:   generator.GetTypeMeta("Secret", "v1"),
+		ObjectMeta: objectMeta,
+		Type:       corev1.SecretTypeOpaque,
+		Data:       files,
+	}
+
+	if !exists {
+		_, err := client.CreateSecretFromObject(secret)
+		return false, err
+	}
+
+	if reflect.DeepEqual(existing.Data, secret.Data) && reflect.DeepEqual(existing.Annotations, secret.Annotations) {
+		return false, nil
+	}
+	secret.ResourceVersion = existing.ResourceVersion
+	secret.OwnerReferences = existing.OwnerReferences
+	_, err := client.UpdateSecret(secret)
+	return err == nil, err
+}
+
+// readSourceFiles reads the given file, or the regular files of the given directory, keyed by their file name
+// like "kubectl create configmap --from-file", the subdirectories of a directory are ignored
+func readSourceFiles(path string) (map[string][]byte, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	if stat.IsDir() {
+		fileInfos, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileInfo := range fileInfos {
+			if !fileInfo.Mode().IsRegular() {
+				klog.V(4).Infof("Ignoring %s of %s, it is not a regular file", fileInfo.Name(), path)
+				continue
+			}
+			paths = append(paths, filepath.Join(path, fileInfo.Name()))
+		}
+	} else {
+		paths = append(paths, path)
+	}
+
+	files := make(map[string][]byte)
+	size := 0
+	for _, filePath := range paths {
+		key := filepath.Base(filePath)
+		if errs := k8svalidation.IsConfigMapKey(key); len(errs) > 0 {
+			return nil, fmt.Errorf("the name of file %s cannot be mounted: %s", filePath, strings.Join(errs, ", "))
+		}
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		size += len(content)
+		files[key] = content
+	}
+
+	if size > maxSourceSize {
+		return nil, fmt.Errorf("the files of %s are %d bytes, more than the %d bytes which can be mounted", path, size, maxSourceSize)
+	}
+	return files, nil
+}
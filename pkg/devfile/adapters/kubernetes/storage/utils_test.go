@@ -1,6 +1,11 @@
 package storage
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
@@ -9,8 +14,14 @@ import (
 	devfileParser "github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/devfile/parser/data"
 	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/golang/mock/gomock"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	storagelabels "github.com/openshift/odo/pkg/storage/labels"
 	"github.com/openshift/odo/pkg/testingutil"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetPVC(t *testing.T) {
@@ -355,3 +366,206 @@ func TestGetVolumesAndVolumeMounts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVolume(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		volInfo VolumeInfo
+		want    v1.VolumeSource
+	}{
+		{
+			name:    "Case 1: volume of a PVC",
+			volInfo: VolumeInfo{VolumeName: "myvolume", PVCName: "mypvc"},
+			want:    v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "mypvc"}},
+		},
+		{
+			name:    "Case 2: volume of a ConfigMap",
+			volInfo: VolumeInfo{VolumeName: "myvolume", ConfigMapName: "myconfigmap"},
+			want:    v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "myconfigmap"}}},
+		},
+		{
+			name:    "Case 3: volume of a Secret",
+			volInfo: VolumeInfo{VolumeName: "myvolume", SecretName: "mysecret"},
+			want:    v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "mysecret"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			volume := getVolume(tt.volInfo)
+			if volume.Name != tt.volInfo.VolumeName {
+				t.Errorf("TestGetVolume error: volume name does not match; expected %s got %s", tt.volInfo.VolumeName, volume.Name)
+			}
+			if !reflect.DeepEqual(volume.VolumeSource, tt.want) {
+				t.Errorf("TestGetVolume error: volume source does not match; expected %v got %v", tt.want, volume.VolumeSource)
+			}
+		})
+	}
+}
+
+func TestHandleSourceStorage(t *testing.T) {
+	componentName := "nodejs"
+	sourceLabels := map[string]string{
+		"component":                       componentName,
+		storagelabels.DevfileStorageLabel: "config",
+	}
+
+	tests := []struct {
+		name               string
+		files              map[string]string
+		localStorage       []localConfigProvider.LocalStorage
+		existingConfigMaps []v1.ConfigMap
+		wantVolumes        map[string]VolumeInfo
+		wantConfigMaps     map[string]map[string]string
+		wantSecrets        map[string]map[string][]byte
+		wantErr            bool
+	}{
+		{
+			name:  "Case 1: ConfigMap created from a file",
+			files: map[string]string{"application.properties": "port=8080"},
+			localStorage: []localConfigProvider.LocalStorage{
+				{Name: "config", Path: "/config", Container: "runtime", Source: "application.properties", SourceKind: envinfo.SourceKindConfigMap},
+				{Name: "config", Path: "/config", Container: "tools", Source: "application.properties", SourceKind: envinfo.SourceKindConfigMap},
+				{Name: "data", Size: "1Gi", Path: "/data", Container: "runtime"},
+			},
+			wantVolumes: map[string]VolumeInfo{
+				"config": {VolumeName: "config-nodejs-vol", ConfigMapName: "config-nodejs"},
+			},
+			wantConfigMaps: map[string]map[string]string{
+				"config-nodejs": {"application.properties": "port=8080"},
+			},
+		},
+		{
+			name:  "Case 2: Secret created from the files of a directory",
+			files: map[string]string{"tls/tls.crt": "cert", "tls/tls.key": "key", "tls/ca/ca.crt": "ignored"},
+			localStorage: []localConfigProvider.LocalStorage{
+				{Name: "tls", Path: "/etc/tls", Container: "runtime", Source: "tls", SourceKind: envinfo.SourceKindSecret},
+			},
+			wantVolumes: map[string]VolumeInfo{
+				"tls": {VolumeName: "tls-nodejs-vol", SecretName: "tls-nodejs"},
+			},
+			wantSecrets: map[string]map[string][]byte{
+				"tls-nodejs": {"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+			},
+		},
+		{
+			name:  "Case 3: ConfigMap updated with the changed file",
+			files: map[string]string{"application.properties": "port=9090"},
+			localStorage: []localConfigProvider.LocalStorage{
+				{Name: "config", Path: "/config", Container: "runtime", Source: "application.properties", SourceKind: envinfo.SourceKindConfigMap},
+			},
+			existingConfigMaps: []v1.ConfigMap{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "config-nodejs", Labels: sourceLabels, Annotations: map[string]string{storagelabels.SourceAnnotation: "application.properties"}},
+					Data:       map[string]string{"application.properties": "port=8080"},
+				},
+			},
+			wantVolumes: map[string]VolumeInfo{
+				"config": {VolumeName: "config-nodejs-vol", ConfigMapName: "config-nodejs"},
+			},
+			wantConfigMaps: map[string]map[string]string{
+				"config-nodejs": {"application.properties": "port=9090"},
+			},
+		},
+		{
+			name: "Case 4: ConfigMap of a storage deleted locally is deleted",
+			existingConfigMaps: []v1.ConfigMap{
+				{ObjectMeta: metav1.ObjectMeta{Name: "config-nodejs", Labels: sourceLabels}},
+			},
+			wantVolumes:    map[string]VolumeInfo{},
+			wantConfigMaps: map[string]map[string]string{},
+		},
+		{
+			name:  "Case 5: ConfigMap replaced by a Secret when the kind of the storage changes",
+			files: map[string]string{"application.properties": "port=8080"},
+			localStorage: []localConfigProvider.LocalStorage{
+				{Name: "config", Path: "/config", Container: "runtime", Source: "application.properties", SourceKind: envinfo.SourceKindSecret},
+			},
+			existingConfigMaps: []v1.ConfigMap{
+				{ObjectMeta: metav1.ObjectMeta{Name: "config-nodejs", Labels: sourceLabels}},
+			},
+			wantVolumes: map[string]VolumeInfo{
+				"config": {VolumeName: "config-nodejs-vol", SecretName: "config-nodejs"},
+			},
+			wantConfigMaps: map[string]map[string]string{},
+			wantSecrets: map[string]map[string][]byte{
+				"config-nodejs": {"application.properties": []byte("port=8080")},
+			},
+		},
+		{
+			name: "Case 6: source file does not exist",
+			localStorage: []localConfigProvider.LocalStorage{
+				{Name: "config", Path: "/config", Container: "runtime", Source: "application.properties", SourceKind: envinfo.SourceKindConfigMap},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contextDir, err := ioutil.TempDir("", "odo-source-storage")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(contextDir)
+			for name, content := range tt.files {
+				path := filepath.Join(contextDir, filepath.FromSlash(name))
+				if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err = ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			fakeClient, fakeClientSet := kclient.FakeNew()
+			for i := range tt.existingConfigMaps {
+				_, err = fakeClientSet.Kubernetes.CoreV1().ConfigMaps(fakeClient.Namespace).Create(context.TODO(), &tt.existingConfigMaps[i], metav1.CreateOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockLocalConfig := localConfigProvider.NewMockLocalConfigProvider(ctrl)
+			mockLocalConfig.EXPECT().ListStorage().Return(tt.localStorage, nil)
+
+			volumes, err := HandleSourceStorage(*fakeClient, mockLocalConfig, componentName, "app", contextDir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HandleSourceStorage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(volumes, tt.wantVolumes) {
+				t.Errorf("HandleSourceStorage() volumes = %v, want %v", volumes, tt.wantVolumes)
+			}
+
+			configMaps, err := fakeClient.ListConfigMaps("")
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotConfigMaps := make(map[string]map[string]string)
+			for _, configMap := range configMaps {
+				gotConfigMaps[configMap.Name] = configMap.Data
+			}
+			if tt.wantConfigMaps != nil && !reflect.DeepEqual(gotConfigMaps, tt.wantConfigMaps) {
+				t.Errorf("HandleSourceStorage() ConfigMaps = %v, want %v", gotConfigMaps, tt.wantConfigMaps)
+			}
+
+			secrets, err := fakeClient.ListSecrets("")
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotSecrets := make(map[string]map[string][]byte)
+			for _, secret := range secrets {
+				gotSecrets[secret.Name] = secret.Data
+			}
+			if tt.wantSecrets != nil && !reflect.DeepEqual(gotSecrets, tt.wantSecrets) {
+				t.Errorf("HandleSourceStorage() Secrets = %v, want %v", gotSecrets, tt.wantSecrets)
+			}
+		})
+	}
+}
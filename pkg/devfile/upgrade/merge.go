@@ -0,0 +1,234 @@
+package upgrade
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v2"
+)
+
+// Conflict is a value of the devfile which was changed both locally and by the new version of the stack,
+// the local value is kept in the merged devfile
+type Conflict struct {
+	Path     string      `json:"path"`
+	Local    interface{} `json:"local"`
+	Upstream interface{} `json:"upstream"`
+}
+
+// absentValue marks a key or a list item which doesn't exist in a version of the devfile
+type absentValue struct{}
+
+var absent = absentValue{}
+
+// Merge three-way merges the changes between the base devfile and the upstream devfile into the local devfile
+// maps are merged by key and lists of items by their name or id, a value changed differently
+// on both sides is a conflict and keeps its local value
+func Merge(base, local, upstream []byte) ([]byte, []Conflict, error) {
+	var baseDevfile, localDevfile, upstreamDevfile yaml.MapSlice
+	if err := yaml.Unmarshal(base, &baseDevfile); err != nil {
+		return nil, nil, errors.Wrap(err, "unable to parse the base devfile")
+	}
+	if err := yaml.Unmarshal(local, &localDevfile); err != nil {
+		return nil, nil, errors.Wrap(err, "unable to parse the local devfile")
+	}
+	if err := yaml.Unmarshal(upstream, &upstreamDevfile); err != nil {
+		return nil, nil, errors.Wrap(err, "unable to parse the upstream devfile")
+	}
+
+	merged, conflicts := mergeMaps("", baseDevfile, localDevfile, upstreamDevfile)
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to marshal the merged devfile")
+	}
+	return data, conflicts, nil
+}
+
+// Format formats the devfile the same way Merge formats the merged devfile, so that they can be diffed
+func Format(devfile []byte) ([]byte, error) {
+	var content yaml.MapSlice
+	if err := yaml.Unmarshal(devfile, &content); err != nil {
+		return nil, errors.Wrap(err, "unable to parse the devfile")
+	}
+	return yaml.Marshal(content)
+}
+
+// Diff returns the unified diff between the given versions of a devfile, empty if they are the same
+func Diff(fromName, toName string, from, to []byte) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(from)),
+		B:        difflib.SplitLines(string(to)),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
+
+// merge three-way merges the value at the given path, any of the values can be absent
+func merge(path string, base, local, upstream interface{}) (interface{}, []Conflict) {
+	switch {
+	case equal(local, upstream):
+		return local, nil
+	case equal(base, local):
+		return upstream, nil
+	case equal(base, upstream):
+		return local, nil
+	}
+
+	localMap, localIsMap := local.(yaml.MapSlice)
+	upstreamMap, upstreamIsMap := upstream.(yaml.MapSlice)
+	if localIsMap && upstreamIsMap {
+		baseMap, _ := base.(yaml.MapSlice)
+		return mergeMaps(path, baseMap, localMap, upstreamMap)
+	}
+
+	localList, localIsList := local.([]interface{})
+	upstreamList, upstreamIsList := upstream.([]interface{})
+	if localIsList && upstreamIsList {
+		baseList, _ := base.([]interface{})
+		if isNamedList(baseList) && isNamedList(localList) && isNamedList(upstreamList) {
+			return mergeNamedLists(path, baseList, localList, upstreamList)
+		}
+	}
+
+	return local, []Conflict{{Path: path, Local: conflictValue(local), Upstream: conflictValue(upstream)}}
+}
+
+// mergeMaps merges the maps key by key, the keys are in the local order followed by the keys added upstream
+func mergeMaps(path string, base, local, upstream yaml.MapSlice) (yaml.MapSlice, []Conflict) {
+	keys := make([]interface{}, 0, len(local)+len(upstream))
+	for _, item := range local {
+		keys = append(keys, item.Key)
+	}
+	for _, item := range upstream {
+		if _, ok := lookupKey(local, item.Key); !ok {
+			keys = append(keys, item.Key)
+		}
+	}
+
+	merged := yaml.MapSlice{}
+	var conflicts []Conflict
+	for _, key := range keys {
+		value, keyConflicts := merge(joinPath(path, key), valueOf(base, key), valueOf(local, key), valueOf(upstream, key))
+		conflicts = append(conflicts, keyConflicts...)
+		if value != absent {
+			merged = append(merged, yaml.MapItem{Key: key, Value: value})
+		}
+	}
+	return merged, conflicts
+}
+
+// mergeNamedLists merges the lists item by item, the items are in the local order followed by the items added upstream
+func mergeNamedLists(path string, base, local, upstream []interface{}) ([]interface{}, []Conflict) {
+	names := make([]string, 0, len(local)+len(upstream))
+	for _, item := range local {
+		names = append(names, itemName(item))
+	}
+	for _, item := range upstream {
+		if findItem(local, itemName(item)) == absent {
+			names = append(names, itemName(item))
+		}
+	}
+
+	merged := []interface{}{}
+	var conflicts []Conflict
+	for _, name := range names {
+		value, itemConflicts := merge(fmt.Sprintf("%s[%s]", path, name), findItem(base, name), findItem(local, name), findItem(upstream, name))
+		conflicts = append(conflicts, itemConflicts...)
+		if value != absent {
+			merged = append(merged, value)
+		}
+	}
+	return merged, conflicts
+}
+
+// isNamedList returns true if every item of the list is a map identified by a name or an id
+func isNamedList(list []interface{}) bool {
+	for _, item := range list {
+		if itemName(item) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// itemName returns the name or the id identifying the list item, empty if it has none
+func itemName(item interface{}) string {
+	itemMap, ok := item.(yaml.MapSlice)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"name", "id"} {
+		if name, ok := lookupKey(itemMap, key); ok {
+			if nameString, ok := name.(string); ok {
+				return nameString
+			}
+		}
+	}
+	return ""
+}
+
+func findItem(list []interface{}, name string) interface{} {
+	for _, item := range list {
+		if itemName(item) == name {
+			return item
+		}
+	}
+	return absent
+}
+
+func lookupKey(m yaml.MapSlice, key interface{}) (interface{}, bool) {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value, true
+		}
+	}
+	return nil, false
+}
+
+func valueOf(m yaml.MapSlice, key interface{}) interface{} {
+	if value, ok := lookupKey(m, key); ok {
+		return value
+	}
+	return absent
+}
+
+func joinPath(path string, key interface{}) string {
+	if path == "" {
+		return fmt.Sprint(key)
+	}
+	return fmt.Sprintf("%s.%v", path, key)
+}
+
+func conflictValue(value interface{}) interface{} {
+	if value == absent {
+		return nil
+	}
+	return normalize(value)
+}
+
+// equal compares the values regardless of the order of the keys of their maps
+func equal(a, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// normalize converts the ordered maps of the value to maps
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		m := make(map[string]interface{}, len(v))
+		for _, item := range v {
+			m[fmt.Sprint(item.Key)] = normalize(item.Value)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			list = append(list, normalize(item))
+		}
+		return list
+	default:
+		return value
+	}
+}
@@ -0,0 +1,103 @@
+package upgrade
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const baseDevfile = `schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+  version: 1.0.0
+components:
+- name: runtime
+  container:
+    image: nodejs:12
+    memoryLimit: 1024Mi
+commands:
+- id: run
+  exec:
+    component: runtime
+    commandLine: npm start
+`
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name          string
+		local         string
+		upstream      string
+		want          string
+		wantConflicts []Conflict
+	}{
+		{
+			name:     "Case 1: no local changes",
+			local:    baseDevfile,
+			upstream: strings.Replace(strings.Replace(baseDevfile, "nodejs:12", "nodejs:14", 1), "1.0.0", "1.1.0", 1),
+			want:     strings.Replace(strings.Replace(baseDevfile, "nodejs:12", "nodejs:14", 1), "1.0.0", "1.1.0", 1),
+		},
+		{
+			name:     "Case 2: local and upstream changes of different values",
+			local:    strings.Replace(baseDevfile, "1024Mi", "2048Mi", 1),
+			upstream: strings.Replace(baseDevfile, "nodejs:12", "nodejs:14", 1),
+			want:     strings.Replace(strings.Replace(baseDevfile, "nodejs:12", "nodejs:14", 1), "1024Mi", "2048Mi", 1),
+		},
+		{
+			name:     "Case 3: items added locally and upstream",
+			local:    baseDevfile + "- id: debug\n  exec:\n    component: runtime\n    commandLine: npm run debug\n",
+			upstream: baseDevfile + "- id: test\n  exec:\n    component: runtime\n    commandLine: npm test\n",
+			want: baseDevfile + "- id: debug\n  exec:\n    component: runtime\n    commandLine: npm run debug\n" +
+				"- id: test\n  exec:\n    component: runtime\n    commandLine: npm test\n",
+		},
+		{
+			name:     "Case 4: key removed upstream",
+			local:    strings.Replace(baseDevfile, "npm start", "npm run start", 1),
+			upstream: strings.Replace(baseDevfile, "    memoryLimit: 1024Mi\n", "", 1),
+			want:     strings.Replace(strings.Replace(baseDevfile, "    memoryLimit: 1024Mi\n", "", 1), "npm start", "npm run start", 1),
+		},
+		{
+			name:     "Case 5: value changed locally and upstream",
+			local:    strings.Replace(baseDevfile, "nodejs:12", "mynodejs:12", 1),
+			upstream: strings.Replace(baseDevfile, "nodejs:12", "nodejs:14", 1),
+			want:     strings.Replace(baseDevfile, "nodejs:12", "mynodejs:12", 1),
+			wantConflicts: []Conflict{
+				{Path: "components[runtime].container.image", Local: "mynodejs:12", Upstream: "nodejs:14"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts, err := Merge([]byte(baseDevfile), []byte(tt.local), []byte(tt.upstream))
+			if err != nil {
+				t.Fatalf("Merge() unexpected error: %v", err)
+			}
+			want, err := Format([]byte(tt.want))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Merge() = %s, want %s", got, want)
+			}
+			if !reflect.DeepEqual(conflicts, tt.wantConflicts) {
+				t.Errorf("Merge() conflicts = %+v, want %+v", conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	diff, err := Diff("devfile.yaml", "devfile.yaml (upgraded)", []byte(baseDevfile), []byte(strings.Replace(baseDevfile, "nodejs:12", "nodejs:14", 1)))
+	if err != nil {
+		t.Fatalf("Diff() unexpected error: %v", err)
+	}
+	for _, line := range []string{"--- devfile.yaml\n", "+++ devfile.yaml (upgraded)\n", "-    image: nodejs:12\n", "+    image: nodejs:14\n"} {
+		if !strings.Contains(diff, line) {
+			t.Errorf("Diff() = %s, want a diff containing %q", diff, line)
+		}
+	}
+
+	diff, err = Diff("devfile.yaml", "devfile.yaml (upgraded)", []byte(baseDevfile), []byte(baseDevfile))
+	if err != nil || diff != "" {
+		t.Errorf("Diff() = %q, %v, want an empty diff for the same devfiles", diff, err)
+	}
+}
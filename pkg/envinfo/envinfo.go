@@ -9,6 +9,7 @@ import (
 
 	"github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/openshift/odo/pkg/hooks"
 	"github.com/openshift/odo/pkg/localConfigProvider"
 	"github.com/openshift/odo/pkg/testingutil/filesystem"
 
@@ -43,6 +44,19 @@ type ComponentSettings struct {
 
 	// RunMode indicates the mode of run used for a successful push
 	RunMode *RUNMode `yaml:"RunMode,omitempty" json:"runMode,omitempty"`
+
+	// Stack is the registry stack the devfile of the component was created from
+	Stack *StackSource `yaml:"Stack,omitempty" json:"stack,omitempty"`
+
+	// Hooks are the local commands run at the events of the push, watch and delete of the component
+	Hooks []hooks.Hook `yaml:"Hooks,omitempty" json:"hooks,omitempty"`
+}
+
+// StackSource identifies the version of a registry stack a devfile was created from
+type StackSource struct {
+	Registry string `yaml:"Registry" json:"registry"`
+	Name     string `yaml:"Name" json:"name"`
+	Version  string `yaml:"Version,omitempty" json:"version,omitempty"`
 }
 
 type RUNMode string
@@ -56,6 +70,9 @@ const (
 	envInfoEnvName  = "ENVINFO"
 	envInfoFileName = "env.yaml"
 
+	// stackDevfileName is the file of the env directory holding the devfile of the stack the component was created from
+	stackDevfileName = "stack-devfile.yaml"
+
 	// DefaultDebugPort is the default port used for debugging on remote pod
 	DefaultDebugPort = 5858
 
@@ -363,6 +380,27 @@ func (esi *EnvSpecificInfo) SetRunMode(runMode RUNMode) error {
 	return esi.writeToFile()
 }
 
+// GetStack returns the registry stack the devfile was created from, nil if it wasn't created from a registry stack
+func (ei *EnvInfo) GetStack() *StackSource {
+	return ei.componentSettings.Stack
+}
+
+// SetStack sets the registry stack the devfile was created from, and writes to file
+func (esi *EnvSpecificInfo) SetStack(stack StackSource) error {
+	esi.componentSettings.Stack = &stack
+	return esi.writeToFile()
+}
+
+// GetHooks returns the local lifecycle hooks of the component
+func (ei *EnvInfo) GetHooks() []hooks.Hook {
+	return ei.componentSettings.Hooks
+}
+
+// GetStackDevfilePath returns the path of the unmodified devfile of the stack the component was created from
+func (esi *EnvSpecificInfo) GetStackDevfilePath() string {
+	return filepath.Join(filepath.Dir(esi.Filename), stackDevfileName)
+}
+
 // GetNamespace returns component namespace
 func (ei *EnvInfo) GetNamespace() string {
 	return ei.componentSettings.Project
@@ -2,7 +2,13 @@ package envinfo
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	corev1 "k8s.io/api/core/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
@@ -12,11 +18,36 @@ import (
 const (
 	// DefaultVolumeSize Default volume size for volumes defined in a devfile
 	DefaultVolumeSize = "1Gi"
+
+	// StorageClassAttribute is the attribute of a devfile volume component holding the storage class of its PVC
+	StorageClassAttribute = "storageClass"
+	// AccessModeAttribute is the attribute of a devfile volume component holding the access mode of its PVC
+	AccessModeAttribute = "accessMode"
+	// SourceAttribute is the attribute of a devfile volume component holding the local file or directory mounted in its place
+	SourceAttribute = "source"
+	// SourceKindAttribute is the attribute of a devfile volume component holding the kind of the resource created from its source
+	SourceKindAttribute = "sourceKind"
+
+	// SourceKindConfigMap is the kind of source storage mounted from a ConfigMap
+	SourceKindConfigMap = "ConfigMap"
+	// SourceKindSecret is the kind of source storage mounted from a Secret
+	SourceKindSecret = "Secret"
 )
 
+// supportedAccessModes are the access modes which can be requested for the PVC of a storage
+var supportedAccessModes = []string{
+	string(corev1.ReadWriteOnce),
+	string(corev1.ReadOnlyMany),
+	string(corev1.ReadWriteMany),
+}
+
 // CompleteStorage completes the given storage
 func (ei *EnvInfo) CompleteStorage(storage *localConfigProvider.LocalStorage) {
-	if storage.Size == "" {
+	if storage.Source != "" {
+		if storage.SourceKind == "" {
+			storage.SourceKind = SourceKindConfigMap
+		}
+	} else if storage.Size == "" {
 		storage.Size = DefaultVolumeSize
 	}
 	if storage.Path == "" {
@@ -38,6 +69,22 @@ func (ei *EnvInfo) ValidateStorage(storage localConfigProvider.LocalStorage) err
 		}
 	}
 
+	if storage.StorageClass != "" {
+		if errs := k8svalidation.IsDNS1123Subdomain(storage.StorageClass); len(errs) > 0 {
+			return fmt.Errorf("invalid storage class %q: %s", storage.StorageClass, strings.Join(errs, ", "))
+		}
+	}
+
+	if storage.AccessMode != "" && !isSupportedAccessMode(storage.AccessMode) {
+		return fmt.Errorf("unsupported access mode %q, must be one of %s", storage.AccessMode, strings.Join(supportedAccessModes, "|"))
+	}
+
+	if storage.Source != "" {
+		if err := validateStorageSource(storage); err != nil {
+			return err
+		}
+	}
+
 	if storage.Container == "" {
 		return nil
 	}
@@ -93,6 +140,19 @@ func (ei *EnvInfo) CreateStorage(storage localConfigProvider.LocalStorage) error
 			},
 		},
 	}}
+	if storage.StorageClass != "" || storage.AccessMode != "" || storage.Source != "" {
+		vc[0].Attributes = attributes.Attributes{}
+		if storage.StorageClass != "" {
+			vc[0].Attributes.PutString(StorageClassAttribute, storage.StorageClass)
+		}
+		if storage.AccessMode != "" {
+			vc[0].Attributes.PutString(AccessModeAttribute, storage.AccessMode)
+		}
+		if storage.Source != "" {
+			vc[0].Attributes.PutString(SourceAttribute, filepath.ToSlash(storage.Source))
+			vc[0].Attributes.PutString(SourceKindAttribute, storage.SourceKind)
+		}
+	}
 	volumeExists := false
 	// Get all the containers in the devfile
 	containers, err := ei.GetContainers()
@@ -145,7 +205,7 @@ func (ei *EnvInfo) CreateStorage(storage localConfigProvider.LocalStorage) error
 func (ei *EnvInfo) ListStorage() ([]localConfigProvider.LocalStorage, error) {
 	var storageList []localConfigProvider.LocalStorage
 
-	volumeSizeMap := make(map[string]string)
+	volumeMap := make(map[string]devfilev1.Component)
 	components, err := ei.devfileObj.Data.GetComponents(common.DevfileOptions{})
 	if err != nil {
 		return storageList, err
@@ -155,10 +215,10 @@ func (ei *EnvInfo) ListStorage() ([]localConfigProvider.LocalStorage, error) {
 		if component.Volume == nil {
 			continue
 		}
-		if component.Volume.Size == "" {
+		if component.Volume.Size == "" && component.Attributes.GetString(SourceAttribute, nil) == "" {
 			component.Volume.Size = DefaultVolumeSize
 		}
-		volumeSizeMap[component.Name] = component.Volume.Size
+		volumeMap[component.Name] = component
 	}
 
 	for _, component := range components {
@@ -166,13 +226,17 @@ func (ei *EnvInfo) ListStorage() ([]localConfigProvider.LocalStorage, error) {
 			continue
 		}
 		for _, volumeMount := range component.Container.VolumeMounts {
-			size, ok := volumeSizeMap[volumeMount.Name]
+			volume, ok := volumeMap[volumeMount.Name]
 			if ok {
 				storageList = append(storageList, localConfigProvider.LocalStorage{
-					Name:      volumeMount.Name,
-					Size:      size,
-					Path:      GetVolumeMountPath(volumeMount),
-					Container: component.Name,
+					Name:         volumeMount.Name,
+					Size:         volume.Volume.Size,
+					Path:         GetVolumeMountPath(volumeMount),
+					Container:    component.Name,
+					StorageClass: volume.Attributes.GetString(StorageClassAttribute, nil),
+					AccessMode:   volume.Attributes.GetString(AccessModeAttribute, nil),
+					Source:       volume.Attributes.GetString(SourceAttribute, nil),
+					SourceKind:   volume.Attributes.GetString(SourceKindAttribute, nil),
 				})
 			}
 		}
@@ -235,3 +299,31 @@ func GetVolumeMountPath(volumeMount devfilev1.VolumeMount) string {
 
 	return volumeMount.Path
 }
+
+// isSupportedAccessMode returns true if the given access mode can be requested for the PVC of a storage
+func isSupportedAccessMode(accessMode string) bool {
+	for _, mode := range supportedAccessModes {
+		if mode == accessMode {
+			return true
+		}
+	}
+	return false
+}
+
+// validateStorageSource validates the given storage mounted from a local file or directory
+func validateStorageSource(storage localConfigProvider.LocalStorage) error {
+	if storage.Size != "" || storage.StorageClass != "" || storage.AccessMode != "" {
+		return fmt.Errorf("the size, storage class and access mode cannot be set for storage %s mounted from %s", storage.Name, storage.Source)
+	}
+
+	if storage.SourceKind != SourceKindConfigMap && storage.SourceKind != SourceKindSecret {
+		return fmt.Errorf("unsupported source kind %q, must be one of %s|%s", storage.SourceKind, SourceKindConfigMap, SourceKindSecret)
+	}
+
+	// the source is read relative to the component context when pushing
+	source := filepath.Clean(storage.Source)
+	if filepath.IsAbs(source) || source == ".." || strings.HasPrefix(source, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("the source %s of storage %s must be inside the component directory", storage.Source, storage.Name)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/devfile/library/pkg/devfile/parser/data"
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
 	"github.com/devfile/library/pkg/devfile/parser"
 	"github.com/devfile/library/pkg/testingutil"
 	"github.com/kylelemons/godebug/pretty"
@@ -323,6 +324,56 @@ func TestEnvInfo_ListStorage(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "case 5: list the storage class and the access mode of the volumes",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						volume := testingutil.GetFakeVolumeComponent("volume-0", "5Gi")
+						volume.Attributes = attributes.Attributes{}.FromStringMap(map[string]string{
+							StorageClassAttribute: "fast",
+							AccessModeAttribute:   "ReadWriteMany",
+						})
+						err = devfileData.AddComponents([]devfilev1.Component{
+							{
+								Name: "container-0",
+								ComponentUnion: devfilev1.ComponentUnion{
+									Container: &devfilev1.ContainerComponent{
+										Container: devfilev1.Container{
+											VolumeMounts: []devfilev1.VolumeMount{
+												{
+													Name: "volume-0",
+													Path: "/data",
+												},
+											},
+										},
+									},
+								},
+							},
+							volume,
+						})
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			want: []localConfigProvider.LocalStorage{
+				{
+					Name:         "volume-0",
+					Size:         "5Gi",
+					Path:         "/data",
+					Container:    "container-0",
+					StorageClass: "fast",
+					AccessMode:   "ReadWriteMany",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -447,6 +498,167 @@ func TestEnvInfo_ValidateStorage(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "case 3: storage with a storage class and an access mode",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			args: args{
+				storage: localConfigProvider.LocalStorage{
+					Name:         "volume-0",
+					Size:         "10Gi",
+					Path:         "/data",
+					StorageClass: "fast",
+					AccessMode:   "ReadWriteMany",
+				},
+			},
+		},
+		{
+			name: "case 4: storage with an invalid storage class",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			args: args{
+				storage: localConfigProvider.LocalStorage{
+					Name:         "volume-0",
+					Size:         "10Gi",
+					Path:         "/data",
+					StorageClass: "Fast_SSD",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 5: storage with an unsupported access mode",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			args: args{
+				storage: localConfigProvider.LocalStorage{
+					Name:       "volume-0",
+					Size:       "10Gi",
+					Path:       "/data",
+					AccessMode: "ReadWriteAlways",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 6: storage mounted from a local file",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			args: args{
+				storage: localConfigProvider.LocalStorage{
+					Name:       "config",
+					Path:       "/config",
+					Source:     "config/application.properties",
+					SourceKind: SourceKindConfigMap,
+				},
+			},
+		},
+		{
+			name: "case 7: storage mounted from a local file with a size",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			args: args{
+				storage: localConfigProvider.LocalStorage{
+					Name:       "config",
+					Size:       "1Gi",
+					Path:       "/config",
+					Source:     "config/application.properties",
+					SourceKind: SourceKindConfigMap,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 8: storage mounted from a directory outside of the component directory",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			args: args{
+				storage: localConfigProvider.LocalStorage{
+					Name:       "tls",
+					Path:       "/etc/tls",
+					Source:     "../tls",
+					SourceKind: SourceKindSecret,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 9: storage mounted from a local file with an unsupported kind",
+			fields: fields{
+				devfileObj: parser.DevfileObj{
+					Data: func() data.DevfileData {
+						devfileData, err := data.NewDevfileData(string(data.APISchemaVersion200))
+						if err != nil {
+							t.Error(err)
+						}
+						return devfileData
+					}(),
+				},
+			},
+			args: args{
+				storage: localConfigProvider.LocalStorage{
+					Name:       "config",
+					Path:       "/config",
+					Source:     "config/application.properties",
+					SourceKind: "PersistentVolumeClaim",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
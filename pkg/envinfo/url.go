@@ -13,6 +13,7 @@ import (
 	"github.com/openshift/odo/pkg/odo/util/validation"
 	"github.com/openshift/odo/pkg/util"
 	"github.com/pkg/errors"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
 //getPorts gets the ports from devfile
@@ -213,16 +214,52 @@ func (ei *EnvInfo) ValidateURL(url localConfigProvider.LocalURL) error {
 		errorList = append(errorList, "TLS secret is only available for secure URLs of Ingress kind")
 	}
 
+	if url.Issuer != "" {
+		if url.Kind != localConfigProvider.INGRESS || !url.Secure {
+			errorList = append(errorList, "issuer is only available for secure URLs of Ingress kind")
+		}
+		if url.TLSSecret != "" {
+			errorList = append(errorList, "issuer and TLS secret cannot be used together")
+		}
+		if url.IssuerKind != localConfigProvider.ISSUER && url.IssuerKind != localConfigProvider.CLUSTERISSUER {
+			errorList = append(errorList, fmt.Sprintf("issuer kind must be one of %v|%v", localConfigProvider.ISSUER, localConfigProvider.CLUSTERISSUER))
+		}
+	}
+
+	if url.Kind == localConfigProvider.HTTPROUTE {
+		if url.Gateway == "" {
+			errorList = append(errorList, "gateway must be provided in order to create URLs of HTTPRoute Kind")
+		} else if err := validateGateway(url.Gateway); err != nil {
+			errorList = append(errorList, err.Error())
+		}
+	} else {
+		if url.Gateway != "" {
+			errorList = append(errorList, "gateway is only available for URLs of HTTPRoute Kind")
+		}
+		if len(url.Headers) > 0 {
+			errorList = append(errorList, "header matching is only available for URLs of HTTPRoute Kind")
+		}
+	}
+
+	if localConfigProvider.IsServiceURLKind(url.Kind) && url.Secure {
+		errorList = append(errorList, fmt.Sprintf("secure URLs are not supported for URLs of %s Kind, TLS must be handled by the endpoint", url.Kind))
+	}
+
 	// check if a host is provided for route based URLs
 	if len(url.Host) > 0 {
 		if url.Kind == localConfigProvider.ROUTE {
 			errorList = append(errorList, "host is not supported for URLs of Route Kind")
 		}
+		if localConfigProvider.IsServiceURLKind(url.Kind) {
+			errorList = append(errorList, fmt.Sprintf("host is not supported for URLs of %s Kind, the address is provided by the cluster", url.Kind))
+		}
 		if err := validation.ValidateHost(url.Host); err != nil {
 			errorList = append(errorList, err.Error())
 		}
 	} else if url.Kind == localConfigProvider.INGRESS {
 		errorList = append(errorList, "host must be provided in order to create URLS of Ingress Kind")
+	} else if url.Kind == localConfigProvider.HTTPROUTE {
+		errorList = append(errorList, "host must be provided in order to create URLs of HTTPRoute Kind")
 	}
 
 	// check the protocol of the URL
@@ -301,7 +338,7 @@ func (esi *EnvSpecificInfo) CreateURL(url localConfigProvider.LocalURL) error {
 		}
 	}
 
-	err := esi.SetConfiguration("url", localConfigProvider.LocalURL{Name: url.Name, Host: url.Host, TLSSecret: url.TLSSecret, Kind: url.Kind})
+	err := esi.SetConfiguration("url", localConfigProvider.LocalURL{Name: url.Name, Host: url.Host, TLSSecret: url.TLSSecret, Issuer: url.Issuer, IssuerKind: url.IssuerKind, Gateway: url.Gateway, Headers: url.Headers, Kind: url.Kind})
 	if err != nil {
 		return errors.Wrapf(err, "failed to persist the component settings to env file")
 	}
@@ -356,7 +393,19 @@ func (ei *EnvInfo) ListURLs() ([]localConfigProvider.LocalURL, error) {
 			if envInfoURL, exist := envMap[localEndpoint.Name]; exist {
 				url.Host = envInfoURL.Host
 				url.TLSSecret = envInfoURL.TLSSecret
+				url.Issuer = envInfoURL.Issuer
+				url.IssuerKind = envInfoURL.IssuerKind
+				url.Gateway = envInfoURL.Gateway
+				url.Headers = envInfoURL.Headers
 				url.Kind = envInfoURL.Kind
+				if localConfigProvider.IsServiceURLKind(url.Kind) {
+					// the transport protocol of the service exposing the endpoint
+					url.Protocol = string(devfilev1.TCPEndpointProtocol)
+					if localEndpoint.Protocol == devfilev1.UDPEndpointProtocol {
+						url.Protocol = string(devfilev1.UDPEndpointProtocol)
+					}
+					url.Path = ""
+				}
 			} else {
 				url.Kind = localConfigProvider.ROUTE
 			}
@@ -501,3 +550,17 @@ func findInvalidEndpoint(ei *EnvInfo, port int) (localConfigProvider.LocalURL, e
 	}
 	return localConfigProvider.LocalURL{}, nil
 }
+
+// validateGateway validates the [namespace/]name reference of a Gateway API gateway
+func validateGateway(gateway string) error {
+	parts := strings.Split(gateway, "/")
+	if len(parts) > 2 {
+		return fmt.Errorf("gateway %q must be of the form [namespace/]name", gateway)
+	}
+	for _, part := range parts {
+		if errs := k8svalidation.IsDNS1123Subdomain(part); len(errs) > 0 {
+			return fmt.Errorf("invalid gateway %q: %s", gateway, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
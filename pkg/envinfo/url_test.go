@@ -614,6 +614,177 @@ func TestEnvInfo_ValidateURL(t *testing.T) {
 			updateURL: true,
 			wantErr:   false,
 		},
+		{
+			name: "case 14: issuer used for a secure ingress url",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:       "http-3000",
+					Secure:     true,
+					Issuer:     "letsencrypt",
+					IssuerKind: localConfigProvider.CLUSTERISSUER,
+					Host:       "com",
+					Kind:       localConfigProvider.INGRESS,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "case 15: issuer used for a non secure url",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:       "http-3000",
+					Issuer:     "letsencrypt",
+					IssuerKind: localConfigProvider.CLUSTERISSUER,
+					Host:       "com",
+					Kind:       localConfigProvider.INGRESS,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 16: issuer used along with a tls secret",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:       "http-3000",
+					Secure:     true,
+					TLSSecret:  "blah",
+					Issuer:     "letsencrypt",
+					IssuerKind: localConfigProvider.ISSUER,
+					Host:       "com",
+					Kind:       localConfigProvider.INGRESS,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 17: invalid issuer kind",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:       "http-3000",
+					Secure:     true,
+					Issuer:     "letsencrypt",
+					IssuerKind: "blah",
+					Host:       "com",
+					Kind:       localConfigProvider.INGRESS,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 18: httproute url attached to a gateway with header matching",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:    "http-3000",
+					Host:    "com",
+					Gateway: "infra/gateway",
+					Headers: map[string]string{"X-Version": "v2"},
+					Kind:    localConfigProvider.HTTPROUTE,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "case 19: gateway not provided for httproute url",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name: "http-3000",
+					Host: "com",
+					Kind: localConfigProvider.HTTPROUTE,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 20: invalid gateway reference",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:    "http-3000",
+					Host:    "com",
+					Gateway: "infra/gateway/https",
+					Kind:    localConfigProvider.HTTPROUTE,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 21: header matching used for an ingress url",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:    "http-3000",
+					Host:    "com",
+					Headers: map[string]string{"X-Version": "v2"},
+					Kind:    localConfigProvider.INGRESS,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 22: tcp url exposed through a nodeport service",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:     "http-3000",
+					Protocol: "tcp",
+					Kind:     localConfigProvider.NODEPORT,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "case 23: host used for a loadbalancer url",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:     "http-3000",
+					Host:     "com",
+					Protocol: "udp",
+					Kind:     localConfigProvider.LOADBALANCER,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 24: secure nodeport url",
+			fields: fields{
+				devfileObj: odoTestingUtil.GetTestDevfileObj(fs),
+			},
+			args: args{
+				url: localConfigProvider.LocalURL{
+					Name:   "http-3000",
+					Secure: true,
+					Kind:   localConfigProvider.NODEPORT,
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
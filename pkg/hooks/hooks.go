@@ -0,0 +1,187 @@
+// Package hooks runs the local lifecycle hooks of the components, the commands declared
+// in env.yaml and in the preference for the events of the push, watch and delete of a component.
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+// Event is a point of the lifecycle of a component where hooks are run
+type Event string
+
+const (
+	// PrePush hooks are run before a push, a failing hook vetoes the push
+	PrePush Event = "pre-push"
+	// PostSync hooks are run once the files of a devfile component are synced, before the devfile commands are executed,
+	// a failing hook aborts the push
+	PostSync Event = "post-sync"
+	// PostPush hooks are run after a successful push
+	PostPush Event = "post-push"
+	// PushFailed hooks are run after a failed push
+	PushFailed Event = "push-failed"
+	// PreDelete hooks are run before the component is deleted, a failing hook vetoes the deletion
+	PreDelete Event = "pre-delete"
+	// PostDelete hooks are run after the component is deleted
+	PostDelete Event = "post-delete"
+)
+
+// Events are the events hooks can be declared for
+var Events = []Event{PrePush, PostSync, PostPush, PushFailed, PreDelete, PostDelete}
+
+// vetoes returns true if a failing hook of the event stops the operation
+func (e Event) vetoes() bool {
+	return e == PrePush || e == PostSync || e == PreDelete
+}
+
+// Hook is a command run by the shell for an event, in the context directory of the component
+type Hook struct {
+	Event   Event  `yaml:"Event" json:"event"`
+	Command string `yaml:"Command" json:"command"`
+}
+
+// Validate checks the event of the hook and that it has a command
+func (h Hook) Validate() error {
+	if h.Command == "" {
+		return errors.Errorf("the %s hook has no command", h.Event)
+	}
+	for _, event := range Events {
+		if h.Event == event {
+			return nil
+		}
+	}
+	return errors.Errorf("unknown hook event %q, the events are %v", h.Event, Events)
+}
+
+// Parameters describe the operation to the hooks, they are written as JSON to the standard input of the hooks
+type Parameters struct {
+	Event       Event  `json:"event"`
+	Component   string `json:"component"`
+	Application string `json:"application,omitempty"`
+	Project     string `json:"project,omitempty"`
+	// Context is the absolute path of the context directory of the component, the directory of the runner by default
+	Context string `json:"context"`
+
+	// ForceBuild, Debug and Watch describe a push, Watch is true for the pushes of "odo watch"
+	ForceBuild bool `json:"forceBuild,omitempty"`
+	Debug      bool `json:"debug,omitempty"`
+	Watch      bool `json:"watch,omitempty"`
+	// ChangedFiles and DeletedFiles are the files changed and deleted since the previous push, when known
+	ChangedFiles []string `json:"changedFiles,omitempty"`
+	DeletedFiles []string `json:"deletedFiles,omitempty"`
+	// Error is the error of a failed push
+	Error string `json:"error,omitempty"`
+}
+
+// VetoError is returned when a hook vetoes an operation
+type VetoError struct {
+	Event   Event
+	Command string
+	Err     error
+}
+
+func (e *VetoError) Error() string {
+	return fmt.Sprintf("%s hook %q failed: %v", e.Event, e.Command, e.Err)
+}
+
+// Runner runs the hooks of a component
+type Runner struct {
+	hooks []Hook
+	// dir is the context directory of the component, the working directory of the hooks
+	dir string
+}
+
+// NewRunner returns the runner of the given lists of hooks, run in the given context directory in the order of the lists,
+// the invalid hooks are ignored with a warning
+func NewRunner(dir string, hookLists ...[]Hook) *Runner {
+	r := &Runner{dir: dir}
+	for _, hooks := range hookLists {
+		for _, hook := range hooks {
+			if err := hook.Validate(); err != nil {
+				log.Warningf("Ignoring a hook: %v", err)
+				continue
+			}
+			r.hooks = append(r.hooks, hook)
+		}
+	}
+	return r
+}
+
+// Has returns true if hooks are declared for the given event
+func (r *Runner) Has(event Event) bool {
+	if r == nil {
+		return false
+	}
+	for _, hook := range r.hooks {
+		if hook.Event == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WarnUnsupported warns that the hooks of the given event, if any, are not run for the S2I components
+func (r *Runner) WarnUnsupported(event Event) {
+	if r.Has(event) {
+		log.Warningf("The %s hooks are only run for the devfile components, they are ignored for the S2I components", event)
+	}
+}
+
+// Run runs the hooks of the given event in their declaration order. A failing hook of an event vetoing
+// the operation stops the hooks and returns a VetoError, the failures of the hooks of the other events are warned about.
+func (r *Runner) Run(event Event, params Parameters) error {
+	if r == nil {
+		return nil
+	}
+	params.Event = event
+	if params.Context == "" {
+		params.Context = r.dir
+	}
+	for _, hook := range r.hooks {
+		if hook.Event != event {
+			continue
+		}
+		err := r.run(hook, params)
+		if err == nil {
+			continue
+		}
+		if event.vetoes() {
+			return &VetoError{Event: event, Command: hook.Command, Err: err}
+		}
+		log.Warningf("%s hook %q failed: %v", event, hook.Command, err)
+	}
+	return nil
+}
+
+func (r *Runner) run(hook Hook, params Parameters) error {
+	input, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	klog.V(4).Infof("Running %s hook %q", hook.Event, hook.Command)
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", hook.Command) // #nosec G204 -- the hooks are declared by the user
+	} else {
+		cmd = exec.Command("sh", "-c", hook.Command) // #nosec G204 -- the hooks are declared by the user
+	}
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(), "ODO_HOOK_EVENT="+string(hook.Event))
+	cmd.Stdin = bytes.NewReader(input)
+	// the output of the hooks must not mix with the machine readable output
+	cmd.Stdout = log.GetStdout()
+	if log.IsJSON() {
+		cmd.Stdout = log.GetStderr()
+	}
+	cmd.Stderr = log.GetStderr()
+	return cmd.Run()
+}
@@ -0,0 +1,167 @@
+package hooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestHookValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		hook    Hook
+		wantErr bool
+	}{
+		{
+			name: "Case 1: valid hook",
+			hook: Hook{Event: PrePush, Command: "make generate"},
+		},
+		{
+			name:    "Case 2: unknown event",
+			hook:    Hook{Event: "before-push", Command: "make generate"},
+			wantErr: true,
+		},
+		{
+			name:    "Case 3: no command",
+			hook:    Hook{Event: PostPush},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.hook.Validate(); tt.wantErr != (err != nil) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunnerRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hooks of the test are shell commands")
+	}
+
+	tests := []struct {
+		name    string
+		hooks   []Hook
+		event   Event
+		wantErr bool
+		// wantRun are the markers of the hooks expected to run, in order
+		wantRun []string
+	}{
+		{
+			name:    "Case 1: hooks of the event run in order",
+			hooks:   []Hook{{Event: PrePush, Command: "echo first >> run"}, {Event: PostPush, Command: "echo post >> run"}, {Event: PrePush, Command: "echo second >> run"}},
+			event:   PrePush,
+			wantRun: []string{"first", "second"},
+		},
+		{
+			name:    "Case 2: failing pre-push hook vetoes the push",
+			hooks:   []Hook{{Event: PrePush, Command: "exit 3"}, {Event: PrePush, Command: "echo second >> run"}},
+			event:   PrePush,
+			wantErr: true,
+		},
+		{
+			name:    "Case 3: failing post-push hook is only warned about",
+			hooks:   []Hook{{Event: PostPush, Command: "exit 3"}, {Event: PostPush, Command: "echo second >> run"}},
+			event:   PostPush,
+			wantRun: []string{"second"},
+		},
+		{
+			name:    "Case 4: failing pre-delete hook vetoes the deletion",
+			hooks:   []Hook{{Event: PreDelete, Command: "false"}},
+			event:   PreDelete,
+			wantErr: true,
+		},
+		{
+			name:    "Case 5: invalid hooks are ignored",
+			hooks:   []Hook{{Event: "pre-pushh", Command: "false"}, {Event: PrePush, Command: "echo valid >> run"}},
+			event:   PrePush,
+			wantRun: []string{"valid"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "hooks")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			err = NewRunner(dir, tt.hooks).Run(tt.event, Parameters{Component: "nodejs"})
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if _, ok := err.(*VetoError); !ok {
+					t.Errorf("Run() error is %T, want *VetoError", err)
+				}
+			}
+
+			var got []string
+			if data, err := ioutil.ReadFile(filepath.Join(dir, "run")); err == nil {
+				got = strings.Fields(string(data))
+			}
+			if len(got) != len(tt.wantRun) {
+				t.Fatalf("Run() ran %v, want %v", got, tt.wantRun)
+			}
+			for i := range got {
+				if got[i] != tt.wantRun[i] {
+					t.Errorf("Run() ran %v, want %v", got, tt.wantRun)
+				}
+			}
+		})
+	}
+}
+
+func TestRunnerRunParameters(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hooks of the test are shell commands")
+	}
+	dir, err := ioutil.TempDir("", "hooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	runner := NewRunner(dir, []Hook{{Event: PushFailed, Command: "cat > params.json"}})
+	err = runner.Run(PushFailed, Parameters{Component: "nodejs", Project: "myproject", ChangedFiles: []string{"server.js"}, Error: "build failed"})
+	if err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "params.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Parameters
+	if err = json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("the standard input of the hook is not JSON: %v", err)
+	}
+	if got.Event != PushFailed || got.Context != dir || got.Component != "nodejs" || got.Error != "build failed" || len(got.ChangedFiles) != 1 {
+		t.Errorf("the hook got the parameters %+v", got)
+	}
+}
+
+func TestNilRunner(t *testing.T) {
+	var runner *Runner
+	if err := runner.Run(PrePush, Parameters{}); err != nil {
+		t.Errorf("Run() of a nil runner returned %v", err)
+	}
+	if runner.Has(PostSync) {
+		t.Errorf("Has() of a nil runner returned true")
+	}
+}
+
+func TestRunnerHas(t *testing.T) {
+	runner := NewRunner("", []Hook{{Event: PrePush, Command: "make lint"}}, []Hook{{Event: PostSync, Command: "make notify"}})
+	for _, event := range Events {
+		if want := event == PrePush || event == PostSync; runner.Has(event) != want {
+			t.Errorf("Has(%s) = %v, want %v", event, !want, want)
+		}
+	}
+}
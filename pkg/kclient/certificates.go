@@ -0,0 +1,97 @@
+package kclient
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog"
+)
+
+const (
+	CertManagerGroup    = "cert-manager.io"
+	CertManagerVersion  = "v1"
+	CertificateResource = "certificates"
+
+	// IssuerAnnotation and ClusterIssuerAnnotation are used by the cert-manager ingress-shim
+	// to create a Certificate for the TLS hosts of an Ingress
+	IssuerAnnotation        = "cert-manager.io/issuer"
+	ClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+)
+
+// IsCertManagerSupported checks if the cert-manager Certificate resource is present on the cluster
+func (c *Client) IsCertManagerSupported() (bool, error) {
+	return c.IsResourceSupported(CertManagerGroup, CertManagerVersion, CertificateResource)
+}
+
+// WaitForCertificateReady waits for the cert-manager Certificate with the given name to be Ready
+func (c *Client) WaitForCertificateReady(name string, timeout time.Duration) error {
+	klog.V(3).Infof("Waiting for certificate %s to become ready", name)
+
+	certificateRes := schema.GroupVersionResource{Group: CertManagerGroup, Version: CertManagerVersion, Resource: CertificateResource}
+	w, err := c.DynamicClient.Resource(certificateRes).Namespace(c.Namespace).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.Set{"metadata.name": name}.AsSelector().String(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to watch certificate")
+	}
+	defer w.Stop()
+
+	timeoutChannel := time.After(timeout)
+	for {
+		select {
+		case val, ok := <-w.ResultChan():
+			if !ok {
+				return errors.Errorf("unknown error while waiting for certificate '%s'", name)
+			}
+			u, ok := val.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			ready, message := isCertificateReady(u)
+			if ready {
+				klog.V(3).Infof("Certificate %s is ready", name)
+				return nil
+			}
+			klog.V(4).Infof("Certificate %s not ready yet: %s", name, message)
+		case <-timeoutChannel:
+			return errors.Errorf("timeout while waiting for certificate '%s' to become ready", name)
+		}
+	}
+}
+
+// isCertificateReady returns true if the Ready condition of the given Certificate is True,
+// along with the message of this condition
+func isCertificateReady(certificate *unstructured.Unstructured) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		return condition["status"] == "True", message
+	}
+	return false, ""
+}
+
+// GetCertificateExpiry returns the expiry date of the certificate stored in the given TLS Secret
+func GetCertificateExpiry(secret *corev1.Secret) (time.Time, error) {
+	block, _ := pem.Decode(secret.Data[corev1.TLSCertKey])
+	if block == nil {
+		return time.Time{}, fmt.Errorf("no PEM encoded certificate found in secret %s", secret.Name)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "unable to parse the certificate of secret %s", secret.Name)
+	}
+	return certificate.NotAfter, nil
+}
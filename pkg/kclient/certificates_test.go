@@ -0,0 +1,95 @@
+package kclient
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetCertificateExpiry(t *testing.T) {
+	selfSignedCert, err := GenerateSelfSignedCertificate("example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		secret  *corev1.Secret
+		wantErr bool
+	}{
+		{
+			name: "Case 1: secret with a valid certificate",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls"},
+				Data:       map[string][]byte{corev1.TLSCertKey: selfSignedCert.CertPem},
+			},
+		},
+		{
+			name: "Case 2: secret without certificate",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls"},
+				Data:       map[string][]byte{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiry, err := GetCertificateExpiry(tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetCertificateExpiry() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			// the self-signed certificates of odo are valid for 10 years
+			if !tt.wantErr && expiry.Before(time.Now().AddDate(9, 0, 0)) {
+				t.Errorf("unexpected expiry date %v", expiry)
+			}
+		})
+	}
+}
+
+func TestIsCertificateReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []interface{}
+		want       bool
+	}{
+		{
+			name:       "Case 1: certificate without conditions",
+			conditions: nil,
+			want:       false,
+		},
+		{
+			name: "Case 2: certificate being issued",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Issuing", "status": "True"},
+				map[string]interface{}{"type": "Ready", "status": "False", "message": "Issuing certificate as Secret does not exist"},
+			},
+			want: false,
+		},
+		{
+			name: "Case 3: certificate ready",
+			conditions: []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True", "message": "Certificate is up to date and has not expired"},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certificate := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tt.conditions != nil {
+				err := unstructured.SetNestedSlice(certificate.Object, tt.conditions, "status", "conditions")
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got, _ := isCertificateReady(certificate); got != tt.want {
+				t.Errorf("isCertificateReady() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package kclient
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateConfigMap creates the given ConfigMap in the namespace of the client
+func (c *Client) CreateConfigMap(configMap corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	createdConfigMap, err := c.KubeClient.CoreV1().ConfigMaps(c.Namespace).Create(context.TODO(), &configMap, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create ConfigMap %s", configMap.Name)
+	}
+	return createdConfigMap, nil
+}
+
+// UpdateConfigMap updates the given ConfigMap
+func (c *Client) UpdateConfigMap(configMap corev1.ConfigMap) (*corev1.ConfigMap, error) {
+	updatedConfigMap, err := c.KubeClient.CoreV1().ConfigMaps(c.Namespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to update ConfigMap %s", configMap.Name)
+	}
+	return updatedConfigMap, nil
+}
+
+// ListConfigMaps returns the ConfigMaps matching the given selector
+func (c *Client) ListConfigMaps(selector string) ([]corev1.ConfigMap, error) {
+	configMapList, err := c.KubeClient.CoreV1().ConfigMaps(c.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get ConfigMaps for selector: %v", selector)
+	}
+	return configMapList.Items, nil
+}
+
+// DeleteConfigMap deletes the ConfigMap of the given name
+func (c *Client) DeleteConfigMap(name string) error {
+	return c.KubeClient.CoreV1().ConfigMaps(c.Namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
@@ -0,0 +1,56 @@
+package kclient
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListConfigMaps(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		selector  string
+		wantNames []string
+	}{
+		{
+			name:      "Case 1: ConfigMaps of a component",
+			selector:  "component=nodejs",
+			wantNames: []string{"config-nodejs"},
+		},
+		{
+			name:      "Case 2: no ConfigMap matches the selector",
+			selector:  "component=python",
+			wantNames: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, _ := FakeNew()
+			fakeClient.Namespace = "default"
+
+			for _, configMap := range []corev1.ConfigMap{
+				{ObjectMeta: metav1.ObjectMeta{Name: "config-nodejs", Labels: map[string]string{"component": "nodejs"}}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "config-java", Labels: map[string]string{"component": "java"}}},
+			} {
+				if _, err := fakeClient.CreateConfigMap(configMap); err != nil {
+					t.Fatalf("CreateConfigMap() unexpected error: %v", err)
+				}
+			}
+
+			configMaps, err := fakeClient.ListConfigMaps(tt.selector)
+			if err != nil {
+				t.Fatalf("ListConfigMaps() unexpected error: %v", err)
+			}
+			var gotNames []string
+			for _, configMap := range configMaps {
+				gotNames = append(gotNames, configMap.Name)
+			}
+			if len(gotNames) != len(tt.wantNames) || (len(gotNames) > 0 && gotNames[0] != tt.wantNames[0]) {
+				t.Errorf("ListConfigMaps() = %v, want %v", gotNames, tt.wantNames)
+			}
+		})
+	}
+}
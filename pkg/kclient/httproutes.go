@@ -0,0 +1,222 @@
+package kclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog"
+)
+
+const (
+	GatewayAPIGroup   = "gateway.networking.k8s.io"
+	GatewayAPIVersion = "v1"
+	HTTPRouteResource = "httproutes"
+	HTTPRouteKind     = "HTTPRoute"
+	GatewayResource   = "gateways"
+
+	// PathPrefixMatch matches the prefix of the request path
+	PathPrefixMatch = "PathPrefix"
+	// HeaderExactMatch matches the exact value of a request header
+	HeaderExactMatch = "Exact"
+	// HTTPSProtocol is the protocol of the Gateway listeners terminating TLS
+	HTTPSProtocol = "HTTPS"
+)
+
+// HTTPRoute is the subset of the Gateway API HTTPRoute used by odo
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              HTTPRouteSpec `json:"spec"`
+}
+
+// HTTPRouteSpec is the subset of the Gateway API HTTPRouteSpec used by odo
+type HTTPRouteSpec struct {
+	ParentRefs []ParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string          `json:"hostnames,omitempty"`
+	Rules      []HTTPRouteRule   `json:"rules,omitempty"`
+}
+
+// ParentReference references the Gateway, and optionally the listener of the Gateway, a route attaches to
+type ParentReference struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// HTTPRouteRule matches requests and forwards them to the backends
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch `json:"matches,omitempty"`
+	BackendRefs []HTTPBackendRef `json:"backendRefs,omitempty"`
+}
+
+// HTTPRouteMatch matches requests on their path and headers
+type HTTPRouteMatch struct {
+	Path    *HTTPPathMatch    `json:"path,omitempty"`
+	Headers []HTTPHeaderMatch `json:"headers,omitempty"`
+}
+
+// HTTPPathMatch matches the path of a request
+type HTTPPathMatch struct {
+	Type  string `json:"type,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// HTTPHeaderMatch matches a header of a request
+type HTTPHeaderMatch struct {
+	Type  string `json:"type,omitempty"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HTTPBackendRef references the service receiving the requests
+type HTTPBackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port,omitempty"`
+}
+
+// Gateway is the subset of the Gateway API Gateway used by odo
+type Gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GatewaySpec `json:"spec"`
+}
+
+// GatewaySpec is the subset of the Gateway API GatewaySpec used by odo
+type GatewaySpec struct {
+	Listeners []GatewayListener `json:"listeners,omitempty"`
+}
+
+// GatewayListener is a listener of a Gateway
+type GatewayListener struct {
+	Name     string `json:"name"`
+	Hostname string `json:"hostname,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+var (
+	httpRouteGVR = schema.GroupVersionResource{Group: GatewayAPIGroup, Version: GatewayAPIVersion, Resource: HTTPRouteResource}
+	gatewayGVR   = schema.GroupVersionResource{Group: GatewayAPIGroup, Version: GatewayAPIVersion, Resource: GatewayResource}
+)
+
+// IsHTTPRouteSupported checks if the Gateway API HTTPRoute resource is present on the cluster
+func (c *Client) IsHTTPRouteSupported() (bool, error) {
+	return c.IsResourceSupported(GatewayAPIGroup, GatewayAPIVersion, HTTPRouteResource)
+}
+
+// CreateHTTPRoute creates the given HTTPRoute in the namespace of the client
+func (c *Client) CreateHTTPRoute(route HTTPRoute) (*HTTPRoute, error) {
+	if route.GetName() == "" {
+		return nil, fmt.Errorf("cannot create an httproute without a name")
+	}
+	route.APIVersion = GatewayAPIGroup + "/" + GatewayAPIVersion
+	route.Kind = HTTPRouteKind
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&route)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert the httproute")
+	}
+	klog.V(4).Infof("Creating httproute %s", route.GetName())
+	created, err := c.DynamicClient.Resource(httpRouteGVR).Namespace(c.Namespace).Create(context.TODO(), &unstructured.Unstructured{Object: content}, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create httproute %s", route.GetName())
+	}
+	return fromUnstructuredHTTPRoute(created)
+}
+
+// ListHTTPRoutes lists the HTTPRoutes matching the given label selector
+func (c *Client) ListHTTPRoutes(labelSelector string) ([]HTTPRoute, error) {
+	list, err := c.DynamicClient.Resource(httpRouteGVR).Namespace(c.Namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list httproutes")
+	}
+	var routes []HTTPRoute
+	for i := range list.Items {
+		route, err := fromUnstructuredHTTPRoute(&list.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, *route)
+	}
+	return routes, nil
+}
+
+// GetOneHTTPRouteFromSelector gets the only HTTPRoute matching the given label selector
+func (c *Client) GetOneHTTPRouteFromSelector(selector string) (*HTTPRoute, error) {
+	routes, err := c.ListHTTPRoutes(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if num := len(routes); num == 0 {
+		return nil, fmt.Errorf("no httproute was found for the selector: %v", selector)
+	} else if num > 1 {
+		return nil, fmt.Errorf("multiple httproutes exist for the selector: %v. Only one must be present", selector)
+	}
+
+	return &routes[0], nil
+}
+
+// DeleteHTTPRoute deletes the HTTPRoute with the given name
+func (c *Client) DeleteHTTPRoute(name string) error {
+	err := c.DynamicClient.Resource(httpRouteGVR).Namespace(c.Namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "unable to delete httproute %s", name)
+	}
+	return nil
+}
+
+// GetGateway gets the Gateway with the given name, in the given namespace or in the namespace of the client if empty
+func (c *Client) GetGateway(namespace, name string) (*Gateway, error) {
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+	u, err := c.DynamicClient.Resource(gatewayGVR).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get gateway %s/%s", namespace, name)
+	}
+	var gateway Gateway
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), &gateway)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to convert gateway %s/%s", namespace, name)
+	}
+	return &gateway, nil
+}
+
+// GetHTTPSListener returns the name of the first HTTPS listener of the Gateway accepting the given hostname
+func (g Gateway) GetHTTPSListener(hostname string) (string, bool) {
+	for _, listener := range g.Spec.Listeners {
+		if listener.Protocol == HTTPSProtocol && listenerHostnameMatches(listener.Hostname, hostname) {
+			return listener.Name, true
+		}
+	}
+	return "", false
+}
+
+// listenerHostnameMatches checks if the hostname of a listener, which can be empty or a wildcard, accepts the given hostname
+func listenerHostnameMatches(listenerHostname, hostname string) bool {
+	if listenerHostname == "" || listenerHostname == hostname {
+		return true
+	}
+	if strings.HasPrefix(listenerHostname, "*.") {
+		return strings.HasSuffix(hostname, listenerHostname[1:])
+	}
+	return false
+}
+
+func fromUnstructuredHTTPRoute(u *unstructured.Unstructured) (*HTTPRoute, error) {
+	var route HTTPRoute
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), &route)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to convert httproute %s", u.GetName())
+	}
+	return &route, nil
+}
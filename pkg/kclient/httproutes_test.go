@@ -0,0 +1,87 @@
+package kclient
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGatewayGetHTTPSListener(t *testing.T) {
+	gateway := Gateway{
+		Spec: GatewaySpec{
+			Listeners: []GatewayListener{
+				{Name: "http", Port: 80, Protocol: "HTTP"},
+				{Name: "https-example", Hostname: "*.example.com", Port: 443, Protocol: HTTPSProtocol},
+				{Name: "https-other", Hostname: "other.org", Port: 443, Protocol: HTTPSProtocol},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		hostname     string
+		wantListener string
+		wantFound    bool
+	}{
+		{
+			name:         "Case 1: hostname matching a wildcard listener",
+			hostname:     "myurl.example.com",
+			wantListener: "https-example",
+			wantFound:    true,
+		},
+		{
+			name:         "Case 2: hostname matching an exact listener",
+			hostname:     "other.org",
+			wantListener: "https-other",
+			wantFound:    true,
+		},
+		{
+			name:      "Case 3: hostname not matching any HTTPS listener",
+			hostname:  "myurl.unknown.net",
+			wantFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener, found := gateway.GetHTTPSListener(tt.hostname)
+			if found != tt.wantFound {
+				t.Errorf("expected found to be %v, got %v", tt.wantFound, found)
+			}
+			if listener != tt.wantListener {
+				t.Errorf("expected listener %q, got %q", tt.wantListener, listener)
+			}
+		})
+	}
+}
+
+func TestFromUnstructuredHTTPRoute(t *testing.T) {
+	route := HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-nodejs-app", Labels: map[string]string{"app": "app"}},
+		Spec: HTTPRouteSpec{
+			ParentRefs: []ParentReference{{Name: "gateway", Namespace: "infra", SectionName: "https"}},
+			Hostnames:  []string{"example.com"},
+			Rules: []HTTPRouteRule{{
+				Matches: []HTTPRouteMatch{{
+					Path:    &HTTPPathMatch{Type: PathPrefixMatch, Value: "/"},
+					Headers: []HTTPHeaderMatch{{Type: HeaderExactMatch, Name: "X-Version", Value: "v2"}},
+				}},
+				BackendRefs: []HTTPBackendRef{{Name: "nodejs-app", Port: 8080}},
+			}},
+		},
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&route)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := fromUnstructuredHTTPRoute(&unstructured.Unstructured{Object: content})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(*got, route) {
+		t.Errorf("expected %+v, got %+v", route, *got)
+	}
+}
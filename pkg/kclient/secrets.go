@@ -178,6 +178,29 @@ func (c *Client) ListSecrets(labelSelector string) ([]corev1.Secret, error) {
 	return secretList.Items, nil
 }
 
+// CreateSecretFromObject creates the given Secret in the namespace of the client
+func (c *Client) CreateSecretFromObject(secret corev1.Secret) (*corev1.Secret, error) {
+	createdSecret, err := c.KubeClient.CoreV1().Secrets(c.Namespace).Create(context.TODO(), &secret, metav1.CreateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create secret %s", secret.Name)
+	}
+	return createdSecret, nil
+}
+
+// UpdateSecret updates the given Secret
+func (c *Client) UpdateSecret(secret corev1.Secret) (*corev1.Secret, error) {
+	updatedSecret, err := c.KubeClient.CoreV1().Secrets(c.Namespace).Update(context.TODO(), &secret, metav1.UpdateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to update secret %s", secret.Name)
+	}
+	return updatedSecret, nil
+}
+
+// DeleteSecret deletes the Secret of the given name
+func (c *Client) DeleteSecret(name string) error {
+	return c.KubeClient.CoreV1().Secrets(c.Namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
 // WaitAndGetSecret blocks and waits until the secret is available
 func (c *Client) WaitAndGetSecret(name string, namespace string) (*corev1.Secret, error) {
 	klog.V(3).Infof("Waiting for secret %s to become available", name)
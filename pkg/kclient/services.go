@@ -3,11 +3,14 @@ package kclient
 import (
 	"context"
 	"fmt"
+	"time"
 
 	componentlabels "github.com/openshift/odo/pkg/component/labels"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/klog"
 )
 
 // GetService retrieves the service with the given name
@@ -82,3 +85,51 @@ func (c *Client) GetOneServiceFromSelector(selector string) (*corev1.Service, er
 
 	return &services[0], nil
 }
+
+// WaitForServiceLoadBalancerIngress waits for the LoadBalancer service with the given name to be assigned an external address
+func (c *Client) WaitForServiceLoadBalancerIngress(name string, timeout time.Duration) (*corev1.Service, error) {
+	klog.V(3).Infof("Waiting for the external address of service %s", name)
+
+	w, err := c.KubeClient.CoreV1().Services(c.Namespace).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.Set{"metadata.name": name}.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to watch service")
+	}
+	defer w.Stop()
+
+	timeoutChannel := time.After(timeout)
+	for {
+		select {
+		case val, ok := <-w.ResultChan():
+			if !ok {
+				return nil, errors.Errorf("unknown error while waiting for the external address of service '%s'", name)
+			}
+			if service, ok := val.Object.(*corev1.Service); ok && len(service.Status.LoadBalancer.Ingress) > 0 {
+				klog.V(3).Infof("Service %s has an external address", name)
+				return service, nil
+			}
+		case <-timeoutChannel:
+			return nil, errors.Errorf("timeout while waiting for the external address of service '%s'", name)
+		}
+	}
+}
+
+// GetNodeAddress returns the address of a node of the cluster, through which the NodePort services can be reached
+// The external IP of a node is preferred over its internal IP
+func (c *Client) GetNodeAddress() (string, error) {
+	nodes, err := c.KubeClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to list nodes")
+	}
+	for _, addressType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		for _, node := range nodes.Items {
+			for _, address := range node.Status.Addresses {
+				if address.Type == addressType {
+					return address.Address, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("no address found for the nodes of the cluster")
+}
@@ -319,3 +319,51 @@ func TestClient_GetOneServiceFromSelector(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodeAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   corev1.NodeList
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "case 1: external IP preferred over internal IP",
+			nodes: corev1.NodeList{Items: []corev1.Node{
+				{Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}}}},
+				{Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeHostName, Address: "node-2"}, {Type: corev1.NodeExternalIP, Address: "203.0.113.2"}}}},
+			}},
+			want: "203.0.113.2",
+		},
+		{
+			name: "case 2: internal IP when no node has an external IP",
+			nodes: corev1.NodeList{Items: []corev1.Node{
+				{Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeHostName, Address: "node-1"}, {Type: corev1.NodeInternalIP, Address: "10.0.0.1"}}}},
+			}},
+			want: "10.0.0.1",
+		},
+		{
+			name:    "case 3: no node address",
+			nodes:   corev1.NodeList{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fkclient, fkclientset := FakeNew()
+
+			fkclientset.Kubernetes.PrependReactor("list", "nodes", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &tt.nodes, nil
+			})
+
+			got, err := fkclient.GetNodeAddress()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNodeAddress() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetNodeAddress() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,11 +2,16 @@ package kclient
 
 import (
 	"context"
+	"time"
 
 	"github.com/devfile/library/pkg/devfile/generator"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/klog"
 )
 
 // constants for volumes
@@ -111,3 +116,75 @@ func (c *Client) UpdateStorageOwnerReference(pvc *corev1.PersistentVolumeClaim,
 	}
 	return nil
 }
+
+// GetStorageClass returns the storage class of the given name
+func (c *Client) GetStorageClass(name string) (*storagev1.StorageClass, error) {
+	return c.KubeClient.StorageV1().StorageClasses().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
+// ExpandPVC sets the requested size of the given PVC to the given size
+// the storage class of the PVC must allow volume expansion
+func (c *Client) ExpandPVC(pvcName string, size resource.Quantity) (*corev1.PersistentVolumeClaim, error) {
+	pvc, err := c.GetPVCFromName(pvcName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get PVC %s", pvcName)
+	}
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	updatedPVC, err := c.KubeClient.CoreV1().PersistentVolumeClaims(c.Namespace).Update(context.TODO(), pvc, metav1.UpdateOptions{FieldManager: FieldManager})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to expand PVC %s", pvcName)
+	}
+	return updatedPVC, nil
+}
+
+// WaitForPVCResize waits for the volume of the given PVC to be resized to the given size
+// it returns true if the file system of the volume is only resized when the pod using it restarts
+func (c *Client) WaitForPVCResize(pvcName string, size resource.Quantity, timeout time.Duration) (bool, error) {
+	klog.V(3).Infof("Waiting for the volume of PVC %s to be resized to %s", pvcName, size.String())
+
+	w, err := c.KubeClient.CoreV1().PersistentVolumeClaims(c.Namespace).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.Set{"metadata.name": pvcName}.AsSelector().String(),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to watch PVC")
+	}
+	defer w.Stop()
+
+	timeoutChannel := time.After(timeout)
+	for {
+		select {
+		case val, ok := <-w.ResultChan():
+			if !ok {
+				return false, errors.Errorf("unknown error while waiting for the resize of PVC '%s'", pvcName)
+			}
+			pvc, ok := val.Object.(*corev1.PersistentVolumeClaim)
+			if !ok {
+				continue
+			}
+			if IsPVCFileSystemResizePending(pvc) {
+				klog.V(3).Infof("The file system of PVC %s is resized when its pod restarts", pvcName)
+				return true, nil
+			}
+			if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok && capacity.Cmp(size) >= 0 {
+				klog.V(3).Infof("The volume of PVC %s is resized", pvcName)
+				return false, nil
+			}
+		case <-timeoutChannel:
+			return false, errors.Errorf("timeout while waiting for the resize of PVC '%s'", pvcName)
+		}
+	}
+}
+
+// IsPVCFileSystemResizePending returns true if the volume of the given PVC is resized
+// but its file system is waiting for the pod using it to restart to be resized
+func IsPVCFileSystemResizePending(pvc *corev1.PersistentVolumeClaim) bool {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == corev1.PersistentVolumeClaimFileSystemResizePending && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/pkg/errors"
+	"k8s.io/klog"
 )
 
 // GetContainersByComponent returns the list of Docker containers that matches the specified component label
@@ -127,6 +128,17 @@ func (dc *Client) ExecCMDInContainer(containerName string, cmd []string, stdout
 
 	errorCh := make(chan error)
 
+	// write the input, closing the write side of the connection to signal the end of the input
+	if stdin != nil {
+		go func() {
+			_, err := io.Copy(hresp.Conn, stdin)
+			if err != nil {
+				klog.V(4).Infof("unable to write the input of the command: %v", err)
+			}
+			_ = hresp.CloseWrite()
+		}()
+	}
+
 	// read the output
 	go func() {
 		_, err = stdcopy.StdCopy(stdout, stderr, hresp.Reader)
@@ -140,6 +152,14 @@ func (dc *Client) ExecCMDInContainer(containerName string, cmd []string, stdout
 
 	hresp.Close()
 
+	inspect, err := dc.Client.ContainerExecInspect(dc.Context, resp.ID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return errors.Errorf("command %q exited with code %d in container %s", strings.Join(cmd, " "), inspect.ExitCode, containerName)
+	}
+
 	return nil
 }
 
@@ -1,8 +1,11 @@
 package lclient
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"reflect"
 	"strings"
 	"testing"
@@ -402,6 +405,46 @@ func TestExecCMDInContainer(t *testing.T) {
 	}
 }
 
+func TestExecCMDInContainerExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		exitCode int
+		wantErr  bool
+	}{
+		{
+			name:     "Case 1: the command succeeds",
+			exitCode: 0,
+			wantErr:  false,
+		},
+		{
+			name:     "Case 2: the command exits with a non-zero code",
+			exitCode: 2,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			client, mockDockerClient := FakeNewMockClient(ctrl)
+			server, conn := net.Pipe()
+			defer server.Close()
+
+			mockDockerClient.EXPECT().ContainerExecCreate(gomock.Any(), gomock.Eq("container"), gomock.Any()).Return(types.IDResponse{ID: "execid"}, nil)
+			mockDockerClient.EXPECT().ContainerExecAttach(gomock.Any(), gomock.Eq("execid"), gomock.Any()).Return(types.HijackedResponse{
+				Reader: bufio.NewReader(strings.NewReader("")),
+				Conn:   conn,
+			}, nil)
+			mockDockerClient.EXPECT().ContainerExecInspect(gomock.Any(), gomock.Eq("execid")).Return(types.ContainerExecInspect{ExecID: "execid", ExitCode: tt.exitCode}, nil)
+
+			err := client.ExecCMDInContainer("container", []string{"tar", "cf", "-", "/data"}, ioutil.Discard, ioutil.Discard, nil, false)
+			if tt.wantErr != (err != nil) {
+				t.Errorf("got %v, wanted error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestWaitForContainer(t *testing.T) {
 	fakeClient := FakeNew()
 	fakeErrorClient := FakeErrorNew()
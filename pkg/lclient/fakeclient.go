@@ -300,6 +300,12 @@ func (m *mockDockerClient) ContainerExecAttach(ctx context.Context, execID strin
 	}, nil
 }
 
+func (m *mockDockerClient) ContainerExecInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error) {
+	return types.ContainerExecInspect{
+		ExecID: execID,
+	}, nil
+}
+
 func (m *mockDockerClient) CopyToContainer(ctx context.Context, container, path string, content io.Reader, options types.CopyToContainerOptions) error {
 	return nil
 }
@@ -332,6 +338,7 @@ var errVolumeList = errors.New("error listing volume")
 var errRemoveVolume = errors.New("error removing volume")
 var errContainerExecCreate = errors.New("error creating container exec")
 var errContainerExecAttach = errors.New("error attach container exec")
+var errContainerExecInspect = errors.New("error inspect container exec")
 var errCopyToContainer = errors.New("error copying to container")
 var errContainerLogs = errors.New("error showing log from container")
 
@@ -426,6 +433,10 @@ func (m *mockDockerErrorClient) ContainerExecAttach(ctx context.Context, execID
 	return types.HijackedResponse{}, errContainerExecAttach
 }
 
+func (m *mockDockerErrorClient) ContainerExecInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error) {
+	return types.ContainerExecInspect{}, errContainerExecInspect
+}
+
 func (m *mockDockerErrorClient) CopyToContainer(ctx context.Context, container, path string, content io.Reader, options types.CopyToContainerOptions) error {
 	return errCopyToContainer
 }
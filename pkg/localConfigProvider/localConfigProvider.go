@@ -1,11 +1,28 @@
 package localConfigProvider
 
-// URLKind is an enum to indicate the type of the URL i.e ingress/route
+// URLKind is an enum to indicate the type of the URL i.e ingress/route/httproute/nodeport/loadbalancer
 type URLKind string
 
 const (
-	INGRESS URLKind = "ingress"
-	ROUTE   URLKind = "route"
+	INGRESS      URLKind = "ingress"
+	ROUTE        URLKind = "route"
+	HTTPROUTE    URLKind = "httproute"
+	NODEPORT     URLKind = "nodeport"
+	LOADBALANCER URLKind = "loadbalancer"
+)
+
+// IsServiceURLKind returns true for the kinds of URLs exposed through a dedicated NodePort or LoadBalancer service,
+// which support any TCP or UDP endpoint
+func IsServiceURLKind(kind URLKind) bool {
+	return kind == NODEPORT || kind == LOADBALANCER
+}
+
+// IssuerKind is an enum to indicate the kind of the cert-manager issuer i.e Issuer/ClusterIssuer
+type IssuerKind string
+
+const (
+	ISSUER        IssuerKind = "Issuer"
+	CLUSTERISSUER IssuerKind = "ClusterIssuer"
 )
 
 // LocalURL holds URL related information
@@ -20,6 +37,14 @@ type LocalURL struct {
 	Host string `yaml:"Host,omitempty" json:"host,omitempty"`
 	// TLS secret name to create ingress to provide a secure URL
 	TLSSecret string `yaml:"TLSSecret,omitempty" json:"tlsSecret,omitempty"`
+	// Issuer is the name of the cert-manager issuer providing the certificate of a secure URL
+	Issuer string `yaml:"Issuer,omitempty" json:"issuer,omitempty"`
+	// IssuerKind is the kind of the cert-manager issuer, Issuer or ClusterIssuer
+	IssuerKind IssuerKind `yaml:"IssuerKind,omitempty" json:"issuerKind,omitempty"`
+	// Gateway is the Gateway API gateway, as [namespace/]name, to which an URL of HTTPRoute kind is attached
+	Gateway string `yaml:"Gateway,omitempty" json:"gateway,omitempty"`
+	// Headers are the request headers an URL of HTTPRoute kind must match
+	Headers map[string]string `yaml:"Headers,omitempty" json:"headers,omitempty"`
 	// Exposed port number for docker container, required for local scenarios
 	ExposedPort int `yaml:"ExposedPort,omitempty" json:"exposedPort,omitempty"`
 	// Kind is the kind of the URL
@@ -42,6 +67,14 @@ type LocalStorage struct {
 	Path string `yaml:"Path,omitempty"`
 	// Container is the container name on which this storage is mounted
 	Container string `yaml:"-" json:"-"`
+	// StorageClass is the storage class of the PVC of the storage, the default storage class of the cluster if empty
+	StorageClass string `yaml:"StorageClass,omitempty"`
+	// AccessMode is the access mode of the PVC of the storage, ReadWriteOnce if empty
+	AccessMode string `yaml:"AccessMode,omitempty"`
+	// Source is the local file or directory, relative to the component context, whose content is mounted instead of a PVC
+	Source string `yaml:"Source,omitempty"`
+	// SourceKind is the kind of the resource holding the content of Source, ConfigMap or Secret
+	SourceKind string `yaml:"SourceKind,omitempty"`
 }
 
 // LocalContainer holds the container related information
@@ -383,16 +383,21 @@ func ExplicitSpinner(status string, preventSpinning bool) *Status {
 	return s
 }
 
-// IsJSON returns true if we are in machine output mode..
-// under NO circumstances should we output any logging.. as we are only outputting json
+// IsJSON returns true if we are in machine output mode, -o json or any other machine readable output format..
+// under NO circumstances should we output any logging.. as we are only outputting machine readable output
 func IsJSON() bool {
+	return OutputFormat() != ""
+}
+
+// OutputFormat returns the machine readable output format passed with -o, empty for the human readable output
+func OutputFormat() string {
 
 	flag := pflag.Lookup("o")
 	if flag != nil && flag.Changed {
-		return strings.Contains(pflag.Lookup("o").Value.String(), "json")
+		return flag.Value.String()
 	}
 
-	return false
+	return ""
 }
 
 // IsDebug returns true if we are debugging (-v is set to anything but 0)
@@ -24,8 +24,12 @@ func TimestampNow() string {
 	return FormatTime(time.Now())
 }
 
-// NewMachineEventLoggingClient creates the appropriate client based on whether we are in machine logging mode or not
+// NewMachineEventLoggingClient creates the appropriate client based on whether the events are streamed,
+// and whether we are in machine logging mode or not
 func NewMachineEventLoggingClient() MachineEventLoggingClient {
+	if stream := GetEventStream(); stream != nil {
+		return NewStreamMachineEventLoggingClient(stream)
+	}
 	if log.IsJSON() {
 		return NewConsoleMachineEventLoggingClient()
 	}
@@ -80,7 +84,7 @@ func (c *NoOpMachineEventLoggingClient) ContainerStatus(statuses []ContainerStat
 }
 
 // URLReachable ignores the provided event.
-func (c *NoOpMachineEventLoggingClient) URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, timestamp string) {
+func (c *NoOpMachineEventLoggingClient) URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, probe *URLProbe, timestamp string) {
 
 }
 
@@ -89,6 +93,11 @@ func (c *NoOpMachineEventLoggingClient) KubernetesPodStatus(pods []KubernetesPod
 
 }
 
+// FileSync ignores the provided event.
+func (c *NoOpMachineEventLoggingClient) FileSync(changedFiles []string, deletedFiles []string, status string, errorVal error, timestamp string) {
+
+}
+
 // NewConsoleMachineEventLoggingClient creates a new instance of ConsoleMachineEventLoggingClient,
 // which will output events as JSON to the console.
 func NewConsoleMachineEventLoggingClient() *ConsoleMachineEventLoggingClient {
@@ -154,8 +163,8 @@ func (c *ConsoleMachineEventLoggingClient) DevFileCommandExecutionComplete(comma
 // Returned channels will each contain a single nil entry once the underlying reader has closed.
 func (c *ConsoleMachineEventLoggingClient) CreateContainerOutputWriter() (*io.PipeWriter, chan interface{}, *io.PipeWriter, chan interface{}) {
 
-	stdoutWriter, stdoutChannel := createWriterAndChannel(false)
-	stderrWriter, stderrChannel := createWriterAndChannel(true)
+	stdoutWriter, stdoutChannel := createWriterAndChannel(false, c.outputJSON)
+	stderrWriter, stderrChannel := createWriterAndChannel(true, c.outputJSON)
 
 	return stdoutWriter, stdoutChannel, stderrWriter, stderrChannel
 
@@ -199,7 +208,7 @@ func (c *ConsoleMachineEventLoggingClient) ContainerStatus(statuses []ContainerS
 }
 
 // URLReachable outputs the provided event as JSON to the console.
-func (c *ConsoleMachineEventLoggingClient) URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, timestamp string) {
+func (c *ConsoleMachineEventLoggingClient) URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, probe *URLProbe, timestamp string) {
 	json := MachineEventWrapper{
 		URLReachable: &URLReachable{
 			Name:             name,
@@ -208,6 +217,7 @@ func (c *ConsoleMachineEventLoggingClient) URLReachable(name string, url string,
 			Secure:           secure,
 			Kind:             kind,
 			Reachable:        reachable,
+			Probe:            probe,
 			AbstractLogEvent: AbstractLogEvent{Timestamp: timestamp},
 		},
 	}
@@ -227,7 +237,26 @@ func (c *ConsoleMachineEventLoggingClient) KubernetesPodStatus(pods []Kubernetes
 	c.outputJSON(json)
 }
 
+// FileSync outputs the provided event as JSON to the console.
+func (c *ConsoleMachineEventLoggingClient) FileSync(changedFiles []string, deletedFiles []string, status string, errorVal error, timestamp string) {
+	errorStr := ""
+	if errorVal != nil {
+		errorStr = errorVal.Error()
+	}
+	json := MachineEventWrapper{
+		FileSync: &FileSync{
+			ChangedFiles:     changedFiles,
+			DeletedFiles:     deletedFiles,
+			Status:           status,
+			Error:            errorStr,
+			AbstractLogEvent: AbstractLogEvent{Timestamp: timestamp},
+		},
+	}
+	c.outputJSON(json)
+}
+
 func (c *ConsoleMachineEventLoggingClient) outputJSON(machineOutput MachineEventWrapper) {
+	machineOutput.APIVersion = APIVersion
 
 	if c.logFunc != nil {
 		c.logFunc(machineOutput)
@@ -265,6 +294,9 @@ func (w MachineEventWrapper) GetEntry() (MachineEventLogEntry, error) {
 	} else if w.URLReachable != nil {
 		return w.URLReachable, nil
 
+	} else if w.FileSync != nil {
+		return w.FileSync, nil
+
 	} else {
 		return nil, errors.New("unexpected machine event log entry")
 	}
@@ -302,6 +334,9 @@ func (c URLReachable) GetType() MachineEventLogEntryType { return TypeURLReachab
 // GetType returns the event type for this event.
 func (c KubernetesPodStatus) GetType() MachineEventLogEntryType { return TypeKubernetesPodStatus }
 
+// GetType returns the event type for this event.
+func (c FileSync) GetType() MachineEventLogEntryType { return TypeFileSync }
+
 // MachineEventLogEntryType indicates the machine-readable event type from an ODO operation
 type MachineEventLogEntryType int
 
@@ -322,6 +357,8 @@ const (
 	TypeURLReachable MachineEventLogEntryType = 6
 	// TypeKubernetesPodStatus is the entry type for that event.
 	TypeKubernetesPodStatus MachineEventLogEntryType = 7
+	// TypeFileSync is the entry type for that event.
+	TypeFileSync MachineEventLogEntryType = 8
 )
 
 // GetCommandName returns a command if the MLE supports that field (otherwise empty string is returned).
@@ -339,7 +376,8 @@ func GetCommandName(entry MachineEventLogEntry) string {
 }
 
 // createWriterAndChannel is similar to the exec.CreateConsoleOutputWriterAndChannel(); see that function's comment for details.
-func createWriterAndChannel(stderr bool) (*io.PipeWriter, chan interface{}) {
+// The log text events are output with the given function.
+func createWriterAndChannel(stderr bool, output func(machineOutput MachineEventWrapper)) (*io.PipeWriter, chan interface{}) {
 	reader, writer := io.Pipe()
 
 	closeChannel := make(chan interface{})
@@ -369,7 +407,7 @@ func createWriterAndChannel(stderr bool) (*io.PipeWriter, chan interface{}) {
 					Stream:           stream,
 				},
 			}
-			OutputSuccessUnindented(json)
+			output(json)
 		}
 
 		// Output a single nil event on the channel to inform that the last line of text has been
@@ -0,0 +1,443 @@
+package machineoutput
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+const (
+	// DefaultEventReplay is the default number of the last events replayed to a new subscriber
+	DefaultEventReplay = 100
+
+	// EventStreamPath is the path of the server-sent events endpoint of an HTTP event stream
+	EventStreamPath = "/events"
+
+	// subscriberBuffer is the number of events buffered for a subscriber, a subscriber not keeping up is disconnected
+	subscriberBuffer = 1024
+)
+
+// streamEvent is a published event, one JSON line
+type streamEvent struct {
+	id   int64
+	data []byte
+}
+
+// EventStream publishes the machine readable events to the subscribers attached to a Unix domain socket,
+// as JSON lines, or to a local HTTP server-sent events endpoint. The last events are replayed to the new subscribers.
+type EventStream struct {
+	address string
+	replay  int
+
+	listener net.Listener
+	server   *http.Server
+
+	mu          sync.Mutex
+	closed      bool
+	lastID      int64
+	events      []streamEvent
+	subscribers map[chan streamEvent]struct{}
+	// handlers tracks the connections of the subscribers, to deliver the last events before closing
+	handlers sync.WaitGroup
+}
+
+// NewEventStream listens on the given address, unix:<path> for a Unix domain socket or http://<loopback host>:<port>
+// for a server-sent events endpoint at EventStreamPath, and replays the given number of the last events to the new subscribers
+func NewEventStream(address string, replay int) (*EventStream, error) {
+	if replay < 0 {
+		return nil, errors.Errorf("the number of replayed events must not be negative, got %d", replay)
+	}
+	s := &EventStream{
+		replay:      replay,
+		subscribers: make(map[chan streamEvent]struct{}),
+	}
+
+	var err error
+	s.listener, s.address, err = util.ListenLocal(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to listen for the event stream")
+	}
+	if strings.HasPrefix(s.address, "unix:") {
+		go s.serveSocket()
+		return s, nil
+	}
+
+	s.address += EventStreamPath
+	mux := http.NewServeMux()
+	mux.HandleFunc(EventStreamPath, s.serveSSE)
+	s.server = &http.Server{Handler: mux}
+	go func() {
+		if err := s.server.Serve(s.listener); err != nil && err != http.ErrServerClosed {
+			klog.V(4).Infof("The event stream server stopped: %v", err)
+		}
+	}()
+	return s, nil
+}
+
+// Address returns the address the subscribers attach to
+func (s *EventStream) Address() string {
+	return s.address
+}
+
+// Publish sends the event to the subscribers, and keeps it to replay it to the new subscribers
+func (s *EventStream) Publish(event MachineEventWrapper) {
+	event.APIVersion = APIVersion
+	data, err := json.Marshal(event)
+	if err != nil {
+		klog.V(4).Infof("Unable to marshal the event: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.lastID++
+	e := streamEvent{id: s.lastID, data: data}
+	if s.replay > 0 {
+		if len(s.events) == s.replay {
+			s.events = s.events[1:]
+		}
+		s.events = append(s.events, e)
+	}
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- e:
+		default:
+			klog.V(4).Infof("Disconnecting an event stream subscriber not keeping up with the events")
+			delete(s.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+// subscribe registers a subscriber, and returns the kept events published after the given event
+func (s *EventStream) subscribe(afterID int64) (chan streamEvent, []streamEvent, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, nil, false
+	}
+	var replay []streamEvent
+	for _, e := range s.events {
+		if e.id > afterID {
+			replay = append(replay, e)
+		}
+	}
+	subscriber := make(chan streamEvent, subscriberBuffer)
+	s.subscribers[subscriber] = struct{}{}
+	s.handlers.Add(1)
+	return subscriber, replay, true
+}
+
+func (s *EventStream) unsubscribe(subscriber chan streamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscribers[subscriber]; ok {
+		delete(s.subscribers, subscriber)
+		close(subscriber)
+	}
+}
+
+func (s *EventStream) serveSocket() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			// the listener is closed
+			return
+		}
+		go func() {
+			defer conn.Close()
+			subscriber, replay, ok := s.subscribe(0)
+			if !ok {
+				return
+			}
+			defer s.handlers.Done()
+			defer s.unsubscribe(subscriber)
+
+			w := bufio.NewWriter(conn)
+			write := func(e streamEvent) error {
+				// the data of the event is shared by the subscribers
+				if _, err := w.Write(e.data); err != nil {
+					return err
+				}
+				if err := w.WriteByte('\n'); err != nil {
+					return err
+				}
+				return w.Flush()
+			}
+			for _, e := range replay {
+				if err := write(e); err != nil {
+					return
+				}
+			}
+			for e := range subscriber {
+				if err := write(e); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// serveSSE sends the events as server-sent events, a subscriber reconnecting with the Last-Event-ID header
+// gets the kept events published after this event. The requests of the web pages are refused, see util.CheckLocalRequest
+func (s *EventStream) serveSSE(w http.ResponseWriter, r *http.Request) {
+	if err := util.CheckLocalRequest(r, true); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	var afterID int64
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		afterID, _ = strconv.ParseInt(lastEventID, 10, 64)
+	}
+	subscriber, replay, ok := s.subscribe(afterID)
+	if !ok {
+		http.Error(w, "the event stream is closed", http.StatusServiceUnavailable)
+		return
+	}
+	defer s.handlers.Done()
+	defer s.unsubscribe(subscriber)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	write := func(e streamEvent) error {
+		if _, err := fmt.Fprintf(w, "id: %d\ndata: %s\n\n", e.id, e.data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+	for _, e := range replay {
+		if err := write(e); err != nil {
+			return
+		}
+	}
+	for {
+		select {
+		case e, ok := <-subscriber:
+			if !ok {
+				return
+			}
+			if err := write(e); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// Close stops accepting subscribers, and waits up to the given timeout for the events to be delivered to the attached subscribers
+func (s *EventStream) Close(timeout time.Duration) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	for subscriber := range s.subscribers {
+		delete(s.subscribers, subscriber)
+		close(subscriber)
+	}
+	s.mu.Unlock()
+	_ = s.listener.Close()
+
+	done := make(chan struct{})
+	go func() {
+		s.handlers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		klog.V(4).Infof("Timed out delivering the last events to the event stream subscribers")
+	}
+	if s.server != nil {
+		_ = s.server.Close()
+	}
+	if strings.HasPrefix(s.address, "unix:") {
+		_ = os.Remove(strings.TrimPrefix(s.address, "unix:"))
+	}
+}
+
+// ReadEventStream subscribes to the event stream of another odo command at the given address, unix:<path> or
+// http://127.0.0.1:<port>/events, and calls handle with each event until the stream ends or stop is closed
+func ReadEventStream(address string, stop <-chan struct{}, handle func(event MachineEventWrapper)) error {
+	parsedURL, err := url.Parse(address)
+	if err != nil {
+		return errors.Wrapf(err, "invalid event stream address %q", address)
+	}
+
+	var body io.ReadCloser
+	// data returns the JSON event of a line of the stream, the server-sent events hold it in their data field
+	data := func(line string) string { return line }
+	switch parsedURL.Scheme {
+	case "unix":
+		path := parsedURL.Path
+		if path == "" {
+			path = parsedURL.Opaque
+		}
+		body, err = net.Dial("unix", path)
+		if err != nil {
+			return err
+		}
+
+	case "http":
+		if parsedURL.Path == "" {
+			parsedURL.Path = EventStreamPath
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-stop:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return errors.Errorf("unable to subscribe to the event stream %s: %s", address, resp.Status)
+		}
+		body = resp.Body
+		data = func(line string) string {
+			if !strings.HasPrefix(line, "data:") {
+				return ""
+			}
+			return strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		}
+
+	default:
+		return errors.Errorf("invalid event stream address %q, it must be unix:<path> or http://127.0.0.1:<port>/events", address)
+	}
+	defer body.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stop:
+			_ = body.Close()
+		case <-done:
+		}
+	}()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := data(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var event MachineEventWrapper
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			klog.V(4).Infof("Ignoring the invalid event %q: %v", line, err)
+			continue
+		}
+		handle(event)
+	}
+	select {
+	case <-stop:
+		return nil
+	default:
+		return scanner.Err()
+	}
+}
+
+// eventStream is the event stream of the command, if the events are streamed
+var (
+	eventStreamMutex sync.Mutex
+	eventStream      *EventStream
+)
+
+// StartEventStream starts streaming the events of the command on the given address, see NewEventStream
+func StartEventStream(address string, replay int) (*EventStream, error) {
+	eventStreamMutex.Lock()
+	defer eventStreamMutex.Unlock()
+	if eventStream != nil {
+		return nil, errors.Errorf("the events are already streamed on %s", eventStream.Address())
+	}
+	stream, err := NewEventStream(address, replay)
+	if err != nil {
+		return nil, err
+	}
+	eventStream = stream
+	return stream, nil
+}
+
+// GetEventStream returns the event stream of the command, nil if the events are not streamed
+func GetEventStream() *EventStream {
+	eventStreamMutex.Lock()
+	defer eventStreamMutex.Unlock()
+	return eventStream
+}
+
+// StopEventStream delivers the last events to the subscribers of the event stream of the command and closes it
+func StopEventStream() {
+	eventStreamMutex.Lock()
+	stream := eventStream
+	eventStream = nil
+	eventStreamMutex.Unlock()
+	if stream != nil {
+		stream.Close(5 * time.Second)
+	}
+}
+
+// StopEventStreamWithError reports the error of the command to the subscribers of the event stream of the command, and closes it
+func StopEventStreamWithError(err error) {
+	if stream := GetEventStream(); stream != nil {
+		stream.Publish(MachineEventWrapper{
+			ReportError: &ReportError{
+				Error:            err.Error(),
+				AbstractLogEvent: AbstractLogEvent{Timestamp: TimestampNow()},
+			},
+		})
+	}
+	StopEventStream()
+}
+
+// NewStreamMachineEventLoggingClient creates a new instance of StreamMachineEventLoggingClient,
+// which will publish the events to the subscribers of the given event stream
+func NewStreamMachineEventLoggingClient(stream *EventStream) *StreamMachineEventLoggingClient {
+	c := &StreamMachineEventLoggingClient{stream: stream}
+	c.logFunc = func(machineOutput MachineEventWrapper) {
+		c.stream.Publish(machineOutput)
+		if log.IsJSON() {
+			OutputSuccessUnindented(machineOutput)
+		}
+	}
+	return c
+}
+
+var _ MachineEventLoggingClient = &StreamMachineEventLoggingClient{}
@@ -0,0 +1,219 @@
+package machineoutput
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func logTextEvent(text string) MachineEventWrapper {
+	return MachineEventWrapper{LogText: &LogText{Text: text, Stream: "stdout", AbstractLogEvent: AbstractLogEvent{Timestamp: TimestampNow()}}}
+}
+
+func readLogTexts(t *testing.T, reader *bufio.Reader, count int, parse func(line string) (string, bool)) []string {
+	var texts []string
+	for len(texts) < count {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("unable to read the event stream after %v: %v", texts, err)
+		}
+		data, ok := parse(strings.TrimSpace(line))
+		if !ok {
+			continue
+		}
+		var event MachineEventWrapper
+		if err = json.Unmarshal([]byte(data), &event); err != nil {
+			t.Fatalf("invalid event %q: %v", data, err)
+		}
+		texts = append(texts, event.LogText.Text)
+	}
+	return texts
+}
+
+func TestEventStreamSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "odo.sock")
+
+	stream, err := NewEventStream("unix:"+socket, 2)
+	if err != nil {
+		t.Fatalf("NewEventStream() unexpected error: %v", err)
+	}
+	for _, text := range []string{"one", "two", "three"} {
+		stream.Publish(logTextEvent(text))
+	}
+
+	// each subscriber gets the last 2 events, then the new ones
+	var readers []*bufio.Reader
+	for i := 0; i < 2; i++ {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		readers = append(readers, bufio.NewReader(conn))
+	}
+	jsonLine := func(line string) (string, bool) { return line, line != "" }
+	for _, reader := range readers {
+		if got := readLogTexts(t, reader, 2, jsonLine); strings.Join(got, ",") != "two,three" {
+			t.Errorf("replayed events = %v, want [two three]", got)
+		}
+	}
+	stream.Publish(logTextEvent("four"))
+	stream.Close(time.Second)
+	for _, reader := range readers {
+		if got := readLogTexts(t, reader, 1, jsonLine); got[0] != "four" {
+			t.Errorf("published event = %v, want [four]", got)
+		}
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("the socket is not removed once the stream is closed")
+	}
+}
+
+func TestEventStreamSSE(t *testing.T) {
+	stream, err := NewEventStream("http://127.0.0.1:0", 10)
+	if err != nil {
+		t.Fatalf("NewEventStream() unexpected error: %v", err)
+	}
+	defer stream.Close(time.Second)
+	for i := 1; i <= 3; i++ {
+		stream.Publish(logTextEvent(fmt.Sprintf("event%d", i)))
+	}
+
+	req, err := http.NewRequest(http.MethodGet, stream.Address(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a subscriber reconnecting after the first event gets the following ones
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", contentType)
+	}
+	sseData := func(line string) (string, bool) {
+		return strings.TrimPrefix(line, "data: "), strings.HasPrefix(line, "data: ")
+	}
+	reader := bufio.NewReader(resp.Body)
+	if got := readLogTexts(t, reader, 2, sseData); strings.Join(got, ",") != "event2,event3" {
+		t.Errorf("replayed events = %v, want [event2 event3]", got)
+	}
+	stream.Publish(logTextEvent("event4"))
+	if got := readLogTexts(t, reader, 1, sseData); got[0] != "event4" {
+		t.Errorf("published event = %v, want [event4]", got)
+	}
+}
+
+func TestEventStreamSSEWebPage(t *testing.T) {
+	stream, err := NewEventStream("http://127.0.0.1:0", 10)
+	if err != nil {
+		t.Fatalf("NewEventStream() unexpected error: %v", err)
+	}
+	defer stream.Close(time.Second)
+
+	tests := []struct {
+		name   string
+		host   string
+		origin string
+	}{
+		{
+			name:   "Case 1: origin of a web page",
+			origin: "http://example.com",
+		},
+		{
+			name: "Case 2: host of a DNS rebinding",
+			host: "attacker.example.com:8080",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, stream.Address(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("expected the status %d, got %d", http.StatusForbidden, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestNewEventStreamInvalidAddress(t *testing.T) {
+	for _, address := range []string{"tcp://127.0.0.1:0", "http://0.0.0.0:0", "unix:"} {
+		if stream, err := NewEventStream(address, DefaultEventReplay); err == nil {
+			stream.Close(time.Second)
+			t.Errorf("NewEventStream(%q) expected an error", address)
+		}
+	}
+}
+
+func TestReadEventStream(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, address := range []string{"unix:" + filepath.Join(dir, "odo.sock"), "http://127.0.0.1:0"} {
+		t.Run(address, func(t *testing.T) {
+			stream, err := NewEventStream(address, 10)
+			if err != nil {
+				t.Fatalf("NewEventStream() unexpected error: %v", err)
+			}
+			stream.Publish(logTextEvent("one"))
+			stream.Publish(MachineEventWrapper{FileSync: &FileSync{ChangedFiles: []string{"server.js"}, Status: FileSyncStarted}})
+
+			events := make(chan MachineEventWrapper, 10)
+			stop := make(chan struct{})
+			result := make(chan error, 1)
+			go func() {
+				result <- ReadEventStream(stream.Address(), stop, func(event MachineEventWrapper) {
+					events <- event
+				})
+			}()
+
+			if event := <-events; event.LogText == nil || event.LogText.Text != "one" {
+				t.Errorf("first event = %+v, want the log text one", event)
+			}
+			if event := <-events; event.FileSync == nil || event.FileSync.ChangedFiles[0] != "server.js" {
+				t.Errorf("second event = %+v, want the sync of server.js", event)
+			}
+			close(stop)
+			select {
+			case err := <-result:
+				if err != nil {
+					t.Errorf("ReadEventStream() unexpected error: %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Errorf("ReadEventStream() did not return once stopped")
+			}
+			stream.Close(time.Second)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package machineoutput
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// the machine readable output formats of -o, a template follows the prefix of the template formats
+const (
+	OutputFormatJSON          = "json"
+	OutputFormatYAML          = "yaml"
+	OutputFormatJSONPath      = "jsonpath="
+	OutputFormatGoTemplate    = "go-template="
+	OutputFormatCustomColumns = "custom-columns="
+)
+
+// OutputFormats describes the supported output formats, for the help and the errors
+const OutputFormats = "json, yaml, jsonpath=<template>, go-template=<template>, custom-columns=<header>:<json path>[,<header>:<json path>...]"
+
+// printFunc prints an object in an output format
+type printFunc func(w io.Writer, obj interface{}) error
+
+// newPrinter returns the function printing the objects in the given output format, an error if the format
+// or its template is invalid
+func newPrinter(format string) (printFunc, error) {
+	switch {
+	case format == OutputFormatJSON:
+		return func(w io.Writer, obj interface{}) error {
+			data, err := marshalJSONIndented(obj)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(w, "%s\n", data)
+			return err
+		}, nil
+
+	case format == OutputFormatYAML:
+		return func(w io.Writer, obj interface{}) error {
+			data, err := marshalJSON(obj)
+			if err != nil {
+				return err
+			}
+			if data, err = yaml.JSONToYAML(data); err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		}, nil
+
+	case strings.HasPrefix(format, OutputFormatJSONPath):
+		j, err := parseJSONPath(strings.TrimPrefix(format, OutputFormatJSONPath))
+		if err != nil {
+			return nil, err
+		}
+		return func(w io.Writer, obj interface{}) error {
+			data, err := toGeneric(obj)
+			if err != nil {
+				return err
+			}
+			if err = j.Execute(w, data); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(w)
+			return err
+		}, nil
+
+	case strings.HasPrefix(format, OutputFormatGoTemplate):
+		text := strings.TrimPrefix(format, OutputFormatGoTemplate)
+		if text == "" {
+			return nil, errors.New("the go-template output format requires a template")
+		}
+		t, err := template.New("output").Parse(text)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid go-template")
+		}
+		return func(w io.Writer, obj interface{}) error {
+			data, err := toGeneric(obj)
+			if err != nil {
+				return err
+			}
+			return t.Execute(w, data)
+		}, nil
+
+	case strings.HasPrefix(format, OutputFormatCustomColumns):
+		return newCustomColumnsPrinter(strings.TrimPrefix(format, OutputFormatCustomColumns))
+
+	default:
+		return nil, errors.Errorf("invalid output format %q, the supported formats are %s", format, OutputFormats)
+	}
+}
+
+// ValidateOutputFormat checks the given output format and its template, the empty format of the human readable output is valid
+func ValidateOutputFormat(format string) error {
+	if format == "" {
+		return nil
+	}
+	_, err := newPrinter(format)
+	return err
+}
+
+// PrintObject writes the object to the given writer in the given machine readable output format
+func PrintObject(w io.Writer, format string, obj interface{}) error {
+	p, err := newPrinter(format)
+	if err != nil {
+		return err
+	}
+	return p(w, obj)
+}
+
+func parseJSONPath(text string) (*jsonpath.JSONPath, error) {
+	if text == "" {
+		return nil, errors.New("the jsonpath output format requires a template")
+	}
+	j := jsonpath.New("output")
+	j.AllowMissingKeys(true)
+	if err := j.Parse(text); err != nil {
+		return nil, errors.Wrap(err, "invalid jsonpath template")
+	}
+	return j, nil
+}
+
+// toGeneric returns the JSON representation of the object as maps and slices, so the templates
+// refer to the fields by their JSON names, as in the JSON output
+func toGeneric(obj interface{}) (interface{}, error) {
+	data, err := marshalJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	err = json.Unmarshal(data, &generic)
+	return generic, err
+}
+
+// column is a column of the custom-columns output format
+type column struct {
+	header string
+	path   *jsonpath.JSONPath
+}
+
+// newCustomColumnsPrinter returns the function printing the objects as a table of the given columns,
+// <header>:<json path> separated by commas. The items of a list are printed one per row.
+func newCustomColumnsPrinter(spec string) (printFunc, error) {
+	if spec == "" {
+		return nil, errors.New("the custom-columns output format requires columns, <header>:<json path>[,<header>:<json path>...]")
+	}
+	var columns []column
+	for _, columnSpec := range strings.Split(spec, ",") {
+		parts := strings.SplitN(columnSpec, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("invalid custom column %q, expected <header>:<json path>", columnSpec)
+		}
+		j, err := parseJSONPath(relaxedJSONPath(parts[1]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid custom column %q", columnSpec)
+		}
+		columns = append(columns, column{header: parts[0], path: j})
+	}
+
+	return func(w io.Writer, obj interface{}) error {
+		data, err := toGeneric(obj)
+		if err != nil {
+			return err
+		}
+		rows := []interface{}{data}
+		if object, ok := data.(map[string]interface{}); ok {
+			if items, ok := object["items"]; ok {
+				// null for an empty list
+				rows, _ = items.([]interface{})
+			}
+		}
+
+		tw := tabwriter.NewWriter(w, 5, 2, 3, ' ', 0)
+		headers := make([]string, len(columns))
+		for i, c := range columns {
+			headers[i] = c.header
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+		for _, row := range rows {
+			cells := make([]string, len(columns))
+			for i, c := range columns {
+				cells[i], err = columnValue(c.path, row)
+				if err != nil {
+					return err
+				}
+			}
+			fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+		return tw.Flush()
+	}, nil
+}
+
+// relaxedJSONPath accepts the json paths of the custom columns without the braces and the leading dot, as kubectl does
+func relaxedJSONPath(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	if !strings.HasPrefix(path, ".") {
+		path = "." + path
+	}
+	return "{" + path + "}"
+}
+
+// columnValue returns the values of the json path in the row separated by commas, <none> if there is no value
+func columnValue(path *jsonpath.JSONPath, row interface{}) (string, error) {
+	results, err := path.FindResults(row)
+	if err != nil {
+		return "", err
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			if value.Kind() == reflect.Interface && value.IsNil() {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := path.PrintResults(&buf, []reflect.Value{value}); err != nil {
+				return "", err
+			}
+			values = append(values, buf.String())
+		}
+	}
+	if len(values) == 0 {
+		return "<none>", nil
+	}
+	return strings.Join(values, ","), nil
+}
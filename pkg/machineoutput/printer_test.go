@@ -0,0 +1,171 @@
+package machineoutput
+
+import (
+	"bytes"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testItem struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              testItemSpec `json:"spec"`
+}
+
+type testItemSpec struct {
+	Port   int      `json:"port"`
+	Secure bool     `json:"secure"`
+	Paths  []string `json:"paths,omitempty"`
+}
+
+type testList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []testItem `json:"items"`
+}
+
+func TestPrintObject(t *testing.T) {
+	list := testList{
+		TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: APIVersion},
+		Items: []testItem{
+			{
+				TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: APIVersion},
+				ObjectMeta: metav1.ObjectMeta{Name: "http-3000"},
+				Spec:       testItemSpec{Port: 3000, Paths: []string{"/", "/api"}},
+			},
+			{
+				TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: APIVersion},
+				ObjectMeta: metav1.ObjectMeta{Name: "https-8443"},
+				Spec:       testItemSpec{Port: 8443, Secure: true},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		obj     interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Case 1: json",
+			format: "json",
+			obj:    list.Items[1],
+			want: `{
+	"kind": "url",
+	"apiVersion": "odo.dev/v1alpha1",
+	"metadata": {
+		"name": "https-8443",
+		"creationTimestamp": null
+	},
+	"spec": {
+		"port": 8443,
+		"secure": true
+	}
+}
+`,
+		},
+		{
+			name:   "Case 2: yaml",
+			format: "yaml",
+			obj:    list.Items[1],
+			want: `apiVersion: odo.dev/v1alpha1
+kind: url
+metadata:
+  creationTimestamp: null
+  name: https-8443
+spec:
+  port: 8443
+  secure: true
+`,
+		},
+		{
+			name:   "Case 3: jsonpath",
+			format: "jsonpath={.items[*].metadata.name}",
+			obj:    list,
+			want:   "http-3000 https-8443\n",
+		},
+		{
+			name:   "Case 4: jsonpath with a missing key",
+			format: "jsonpath={.metadata.namespace}",
+			obj:    list.Items[0],
+			want:   "\n",
+		},
+		{
+			name:   "Case 5: go-template",
+			format: `go-template={{range .items}}{{.metadata.name}}={{.spec.port}}{{"\n"}}{{end}}`,
+			obj:    list,
+			want:   "http-3000=3000\nhttps-8443=8443\n",
+		},
+		{
+			name:   "Case 6: custom-columns of a list",
+			format: "custom-columns=NAME:.metadata.name,PORT:spec.port,SECURE:{.spec.secure},PATHS:.spec.paths[*]",
+			obj:    list,
+			want: "NAME         PORT   SECURE   PATHS\n" +
+				"http-3000    3000   false    /,/api\n" +
+				"https-8443   8443   true     <none>\n",
+		},
+		{
+			name:   "Case 7: custom-columns of an object",
+			format: "custom-columns=NAME:.metadata.name",
+			obj:    list.Items[0],
+			want:   "NAME\nhttp-3000\n",
+		},
+		{
+			name:   "Case 8: custom-columns of an empty list",
+			format: "custom-columns=NAME:.metadata.name",
+			obj:    testList{TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: APIVersion}},
+			want:   "NAME\n",
+		},
+		{
+			name:    "Case 9: unknown format",
+			format:  "xml",
+			obj:     list,
+			wantErr: true,
+		},
+		{
+			name:    "Case 10: invalid jsonpath",
+			format:  "jsonpath={.items[",
+			obj:     list,
+			wantErr: true,
+		},
+		{
+			name:    "Case 11: invalid go-template",
+			format:  "go-template={{.items",
+			obj:     list,
+			wantErr: true,
+		},
+		{
+			name:    "Case 12: invalid custom column",
+			format:  "custom-columns=NAME",
+			obj:     list,
+			wantErr: true,
+		},
+		{
+			name:    "Case 13: missing template",
+			format:  "jsonpath=",
+			obj:     list,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validateErr := ValidateOutputFormat(tt.format)
+			if (validateErr != nil) != tt.wantErr {
+				t.Errorf("unexpected validation error %v, wantErr %v", validateErr, tt.wantErr)
+			}
+
+			var out bytes.Buffer
+			err := PrintObject(&out, tt.format, tt.obj)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && out.String() != tt.want {
+				t.Errorf("expected the output\n%q\ngot\n%q", tt.want, out.String())
+			}
+		})
+	}
+}
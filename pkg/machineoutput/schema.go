@@ -0,0 +1,352 @@
+package machineoutput
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// JSONSchemaDraft is the JSON Schema draft the schemas of the machine readable output follow
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// SchemaBaseURL is the base of the $id of the schemas, followed by the version of APIVersion and the kind
+const SchemaBaseURL = "https://odo.dev/schemas/"
+
+// SchemaVersion returns the version of the schemas, the version of APIVersion
+func SchemaVersion() string {
+	return APIVersion[strings.LastIndex(APIVersion, "/")+1:]
+}
+
+// SchemaID returns the $id of the schema of a kind of machine readable output
+func SchemaID(kind string) string {
+	return SchemaBaseURL + SchemaVersion() + "/" + kind + ".json"
+}
+
+// JSONSchema is a JSON Schema describing a machine readable output
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Const                interface{}            `json:"const,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+	Definitions          map[string]*JSONSchema `json:"definitions,omitempty"`
+}
+
+// SchemaKind is a kind of machine readable output, and the Go type it is marshalled from
+type SchemaKind struct {
+	// Name is the name of the kind, as passed to "odo schema"
+	Name string
+	// Description describes the output
+	Description string
+	// Commands are the commands outputting the kind
+	Commands []string
+	// Object is a value of the Go type of the output
+	Object interface{}
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// GenerateSchema returns the JSON Schema of the kind, generated from the json tags of its Go type, the schema of a slice
+// describing the List object wrapping it. The schema requires the apiVersion stamped on every machine readable output,
+// and allows additional properties so that fields can be added without a new version.
+func GenerateSchema(kind SchemaKind) *JSONSchema {
+	g := &schemaGenerator{
+		definitions: map[string]*JSONSchema{},
+		names:       map[reflect.Type]string{},
+	}
+
+	t := reflect.TypeOf(kind.Object)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var root *JSONSchema
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		// the arrays are wrapped in a List object
+		root = &JSONSchema{
+			Type: "object",
+			Properties: map[string]*JSONSchema{
+				"kind":  {Type: "string", Const: ListKind},
+				"items": {Type: "array", Items: g.typeSchema(t.Elem())},
+			},
+			Required: []string{"items", "kind"},
+		}
+	default:
+		root = g.typeSchema(t)
+		if t.Kind() == reflect.Struct {
+			// the root is inlined rather than referenced from the definitions
+			name := g.names[t]
+			root = g.definitions[name]
+			delete(g.definitions, name)
+		}
+	}
+	if root.Properties == nil {
+		root.Properties = map[string]*JSONSchema{}
+	}
+	root.Properties["apiVersion"] = &JSONSchema{Type: "string", Const: APIVersion}
+	if !containsString(root.Required, "apiVersion") {
+		root.Required = append(root.Required, "apiVersion")
+		sort.Strings(root.Required)
+	}
+
+	root.Schema = JSONSchemaDraft
+	root.ID = SchemaID(kind.Name)
+	root.Title = kind.Name
+	root.Description = kind.Description
+	if len(g.definitions) > 0 {
+		root.Definitions = g.definitions
+	}
+	return root
+}
+
+// schemaGenerator generates the schemas of Go types, keeping the schemas of the structs as definitions
+type schemaGenerator struct {
+	definitions map[string]*JSONSchema
+	names       map[reflect.Type]string
+}
+
+// typeSchema returns the schema of the values of the type as marshalled by encoding/json
+func (g *schemaGenerator) typeSchema(t reflect.Type) *JSONSchema {
+	switch t {
+	case reflect.TypeOf(metav1.Time{}), reflect.TypeOf(time.Time{}):
+		// metav1.Time is marshalled as null when zero
+		return &JSONSchema{Type: []string{"string", "null"}}
+	case reflect.TypeOf(resource.Quantity{}):
+		return &JSONSchema{Type: "string"}
+	case reflect.TypeOf(intstr.IntOrString{}):
+		return &JSONSchema{Type: []string{"integer", "string"}}
+	}
+
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface {
+		// the custom marshalling can output any value
+		if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+			return &JSONSchema{}
+		}
+		if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+			return &JSONSchema{Type: "string"}
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Ptr:
+		return nullable(g.typeSchema(t.Elem()))
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is marshalled as a base64 string
+			return &JSONSchema{Type: []string{"string", "null"}}
+		}
+		return &JSONSchema{Type: []string{"array", "null"}, Items: g.typeSchema(t.Elem())}
+	case reflect.Array:
+		return &JSONSchema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: []string{"object", "null"}, AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		// interfaces can hold any value
+		return &JSONSchema{}
+	}
+}
+
+// structSchema adds the schema of the struct to the definitions, and returns a reference to it
+func (g *schemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	if name, ok := g.names[t]; ok {
+		return &JSONSchema{Ref: "#/definitions/" + name}
+	}
+
+	name := g.definitionName(t)
+	g.names[t] = name
+	// registered before the fields are generated, for the recursive types
+	schema := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}}
+	g.definitions[name] = schema
+
+	required := map[string]bool{}
+	g.addFields(schema, required, t, true)
+	for field := range required {
+		schema.Required = append(schema.Required, field)
+	}
+	sort.Strings(schema.Required)
+	return &JSONSchema{Ref: "#/definitions/" + name}
+}
+
+// addFields adds the properties of the fields of the struct to the schema, the fields of the embedded structs
+// are inlined as encoding/json does. The fields of the embedded pointers may be absent.
+func (g *schemaGenerator) addFields(schema *JSONSchema, required map[string]bool, t reflect.Type, mayBeRequired bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options := parseTag(tag)
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			embedded := fieldType
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.addFields(schema, required, embedded, mayBeRequired && fieldType.Kind() != reflect.Ptr)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		var property *JSONSchema
+		if options["string"] && isScalar(fieldType) {
+			property = &JSONSchema{Type: "string"}
+		} else {
+			property = g.typeSchema(fieldType)
+		}
+		schema.Properties[name] = property
+
+		// the structs are never omitted
+		if mayBeRequired && (!options["omitempty"] || fieldType.Kind() == reflect.Struct) {
+			required[name] = true
+		} else {
+			delete(required, name)
+		}
+	}
+}
+
+// definitionName returns a unique name for the definition of the struct, <package>.<type>
+func (g *schemaGenerator) definitionName(t reflect.Type) string {
+	pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+	typeName := t.Name()
+	if typeName == "" {
+		typeName = "anonymous"
+	}
+	// the generic types have their parameters in their names
+	typeName = strings.NewReplacer("[", "_", "]", "", "/", "_", "*", "").Replace(typeName)
+
+	base := typeName
+	if pkg != "" {
+		base = pkg + "." + typeName
+	}
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := g.definitions[name]; !taken {
+			return name
+		}
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+}
+
+// nullable returns the schema also accepting null
+func nullable(schema *JSONSchema) *JSONSchema {
+	switch typ := schema.Type.(type) {
+	case string:
+		schema.Type = []string{typ, "null"}
+		return schema
+	case []string:
+		if !containsString(typ, "null") {
+			schema.Type = append(typ, "null")
+		}
+		return schema
+	}
+	if schema.Ref != "" {
+		return &JSONSchema{AnyOf: []*JSONSchema{schema, {Type: "null"}}}
+	}
+	// no type, any value
+	return schema
+}
+
+func parseTag(tag string) (string, map[string]bool) {
+	parts := strings.Split(tag, ",")
+	options := map[string]bool{}
+	for _, option := range parts[1:] {
+		options[option] = true
+	}
+	return parts[0], options
+}
+
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ListKind is the kind of the object the arrays of the machine readable output are wrapped in
+const ListKind = "List"
+
+// listOutput is the object wrapping an array of the machine readable output, so that it can carry the apiVersion
+type listOutput struct {
+	Kind       string          `json:"kind"`
+	APIVersion string          `json:"apiVersion"`
+	Items      json.RawMessage `json:"items"`
+}
+
+// stampAPIVersion sets the apiVersion of the JSON object, replacing a different one, so that every machine readable
+// output refers to the version of its schema. A JSON array is wrapped in a List object with the array as its items.
+func stampAPIVersion(data []byte) ([]byte, error) {
+	parsed := gjson.ParseBytes(data)
+	if parsed.IsArray() {
+		return json.Marshal(listOutput{Kind: ListKind, APIVersion: APIVersion, Items: data})
+	}
+	if !parsed.IsObject() {
+		return data, nil
+	}
+	return sjson.SetBytes(data, "apiVersion", APIVersion)
+}
+
+// marshalJSON returns the json representation of obj, stamped with the apiVersion
+func marshalJSON(obj interface{}) ([]byte, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	if v := reflect.ValueOf(obj); v.Kind() == reflect.Slice && v.IsNil() && v.Type().Elem().Kind() != reflect.Uint8 {
+		// a nil slice is an empty list rather than null
+		data = []byte("[]")
+	}
+	return stampAPIVersion(data)
+}
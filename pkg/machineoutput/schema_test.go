@@ -0,0 +1,179 @@
+package machineoutput
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/xeipuuv/gojsonschema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type schemaTestSpec struct {
+	Name     string            `json:"name"`
+	Port     int               `json:"port,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Parent   *schemaTestSpec   `json:"parent,omitempty"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+type schemaTestObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              schemaTestSpec `json:"spec,omitempty"`
+	Count             int64          `json:"count,string"`
+}
+
+func TestGenerateSchema(t *testing.T) {
+	schema := GenerateSchema(SchemaKind{Name: "test", Object: schemaTestObject{}})
+
+	if schema.ID != "https://odo.dev/schemas/v1alpha1/test.json" {
+		t.Errorf("unexpected $id %q", schema.ID)
+	}
+	if !reflect.DeepEqual(schema.Required, []string{"apiVersion", "count", "metadata", "spec"}) {
+		t.Errorf("unexpected required properties %v", schema.Required)
+	}
+	if schema.Properties["count"].Type != "string" {
+		t.Errorf("expected the string option to output a string, got %v", schema.Properties["count"].Type)
+	}
+	if _, ok := schema.Properties["kind"]; !ok {
+		t.Errorf("expected the fields of the embedded TypeMeta to be inlined")
+	}
+
+	spec, ok := schema.Definitions["machineoutput.schemaTestSpec"]
+	if !ok {
+		t.Fatalf("expected a definition of the spec, got %v", schema.Definitions)
+	}
+	if !reflect.DeepEqual(spec.Required, []string{"name"}) {
+		t.Errorf("unexpected required properties of the spec %v", spec.Required)
+	}
+	for _, name := range []string{"Ignored", "internal"} {
+		if _, ok := spec.Properties[name]; ok {
+			t.Errorf("unexpected property %q", name)
+		}
+	}
+	if parent := spec.Properties["parent"]; len(parent.AnyOf) != 2 || parent.AnyOf[0].Ref != "#/definitions/machineoutput.schemaTestSpec" {
+		t.Errorf("expected the recursive pointer to reference the definition or be null, got %+v", parent)
+	}
+}
+
+func TestSchemaValidatesOutput(t *testing.T) {
+	data, err := json.Marshal(GenerateSchema(SchemaKind{Name: "test", Object: schemaTestObject{}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		t.Fatalf("the generated schema is invalid: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		obj   interface{}
+		valid bool
+	}{
+		{
+			name: "Case 1: output stamped with the apiVersion",
+			obj: schemaTestObject{
+				TypeMeta:   metav1.TypeMeta{Kind: "Test"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec:       schemaTestSpec{Name: "nodejs", Parent: &schemaTestSpec{Name: "parent", Port: 3000}},
+			},
+			valid: true,
+		},
+		{
+			name:  "Case 2: output of another apiVersion, stamped with the current one",
+			obj:   schemaTestObject{TypeMeta: metav1.TypeMeta{APIVersion: "odo.dev/v1"}, Spec: schemaTestSpec{Name: "nodejs"}},
+			valid: true,
+		},
+		{
+			name:  "Case 3: output missing a required field",
+			obj:   map[string]interface{}{"metadata": map[string]interface{}{}, "spec": map[string]interface{}{}, "count": "1"},
+			valid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := PrintObject(&out, OutputFormatJSON, tt.obj); err != nil {
+				t.Fatal(err)
+			}
+			result, err := schema.Validate(gojsonschema.NewBytesLoader(out.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Valid() != tt.valid {
+				t.Errorf("expected valid %v, got the errors %v for\n%s", tt.valid, result.Errors(), out.String())
+			}
+		})
+	}
+}
+
+func TestSchemaValidatesListOutput(t *testing.T) {
+	data, err := json.Marshal(GenerateSchema(SchemaKind{Name: "test-list", Object: []schemaTestSpec{}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		t.Fatalf("the generated schema is invalid: %v", err)
+	}
+
+	for _, obj := range [][]schemaTestSpec{nil, {{Name: "nodejs"}, {Name: "python", Port: 8080}}} {
+		var out bytes.Buffer
+		if err := PrintObject(&out, OutputFormatJSON, obj); err != nil {
+			t.Fatal(err)
+		}
+		result, err := schema.Validate(gojsonschema.NewBytesLoader(out.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Valid() {
+			t.Errorf("expected the List object to be valid, got the errors %v for\n%s", result.Errors(), out.String())
+		}
+	}
+}
+
+func TestStampAPIVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "Case 1: object without apiVersion",
+			data: `{"kind":"List","items":[]}`,
+			want: `{"kind":"List","items":[],"apiVersion":"odo.dev/v1alpha1"}`,
+		},
+		{
+			name: "Case 2: object with another apiVersion",
+			data: `{"kind":"List","apiVersion":"v1"}`,
+			want: `{"kind":"List","apiVersion":"odo.dev/v1alpha1"}`,
+		},
+		{
+			name: "Case 3: array",
+			data: `["a","b"]`,
+			want: `{"kind":"List","apiVersion":"odo.dev/v1alpha1","items":["a","b"]}`,
+		},
+		{
+			name: "Case 4: neither an object nor an array",
+			data: `"a"`,
+			want: `"a"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stampAPIVersion([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
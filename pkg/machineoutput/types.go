@@ -1,8 +1,10 @@
 package machineoutput
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"os"
 	"sync"
 
 	"github.com/openshift/odo/pkg/log"
@@ -37,7 +39,7 @@ var unindentedMutex = &sync.Mutex{}
 
 // OutputSuccessUnindented outputs a "successful" machine-readable output format in unindented json
 func OutputSuccessUnindented(machineOutput interface{}) {
-	printableOutput, err := json.Marshal(machineOutput)
+	printableOutput, err := marshalJSON(machineOutput)
 
 	unindentedMutex.Lock()
 	defer unindentedMutex.Unlock()
@@ -50,8 +52,17 @@ func OutputSuccessUnindented(machineOutput interface{}) {
 	}
 }
 
-// OutputSuccess outputs a "successful" machine-readable output format in json
+// OutputSuccess outputs a "successful" machine-readable output format in json, or in the output format passed with -o
 func OutputSuccess(machineOutput interface{}) {
+	if format := log.OutputFormat(); format != "" && format != OutputFormatJSON {
+		if err := PrintObject(log.GetStdout(), format, machineOutput); err != nil {
+			// the templates are executed on the output, the error can't be reported before
+			fmt.Fprintf(log.GetStderr(), "Unable to output the result: %s\n", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	printableOutput, err := marshalJSONIndented(machineOutput)
 
 	// If we error out... there's no way to output it (since we disable logging when using -o json)
@@ -62,8 +73,16 @@ func OutputSuccess(machineOutput interface{}) {
 	}
 }
 
-// OutputError outputs a "successful" machine-readable output format in json
+// OutputError outputs a "successful" machine-readable output format in json, or in yaml with -o yaml.
+// The errors are not printed with the templates of the other output formats.
 func OutputError(machineOutput interface{}) {
+	if log.OutputFormat() == OutputFormatYAML {
+		if err := PrintObject(log.GetStderr(), OutputFormatYAML, machineOutput); err != nil {
+			fmt.Fprintf(log.GetStderr(), "Unable to output the error: %s\n", err.Error())
+		}
+		return
+	}
+
 	printableOutput, err := marshalJSONIndented(machineOutput)
 
 	// If we error out... there's no way to output it (since we disable logging when using -o json)
@@ -74,7 +93,13 @@ func OutputError(machineOutput interface{}) {
 	}
 }
 
-// marshalJSONIndented returns indented json representation of obj
+// marshalJSONIndented returns indented json representation of obj, stamped with the apiVersion
 func marshalJSONIndented(obj interface{}) ([]byte, error) {
-	return json.MarshalIndent(obj, "", "	")
+	data, err := marshalJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+	var indented bytes.Buffer
+	err = json.Indent(&indented, data, "", "	")
+	return indented.Bytes(), err
 }
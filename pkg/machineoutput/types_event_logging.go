@@ -19,10 +19,12 @@ type MachineEventLoggingClient interface {
 	SupervisordStatus(statuses []SupervisordStatusEntry, timestamp string)
 	ContainerStatus(statuses []ContainerStatusEntry, timestamp string)
 
-	URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, timestamp string)
+	URLReachable(name string, url string, port int, secure bool, kind string, reachable bool, probe *URLProbe, timestamp string)
 
 	KubernetesPodStatus(pods []KubernetesPodStatusEntry, timestamp string)
 
+	FileSync(changedFiles []string, deletedFiles []string, status string, errorVal error, timestamp string)
+
 	// CreateContainerOutputWriter is used to capture output from container processes, and synchronously write it to the screen as LogText. See implementation comments for details.
 	CreateContainerOutputWriter() (*io.PipeWriter, chan interface{}, *io.PipeWriter, chan interface{})
 }
@@ -30,6 +32,8 @@ type MachineEventLoggingClient interface {
 // MachineEventWrapper - a single line of machine-readable event console output must contain only one
 // of these commands; the MachineEventWrapper is used to create (and parse, for tests) these lines.
 type MachineEventWrapper struct {
+	// APIVersion is the version of the schema of the events, stamped when the event is output
+	APIVersion                      string                           `json:"apiVersion,omitempty"`
 	DevFileCommandExecutionBegin    *DevFileCommandExecutionBegin    `json:"devFileCommandExecutionBegin,omitempty"`
 	DevFileCommandExecutionComplete *DevFileCommandExecutionComplete `json:"devFileCommandExecutionComplete,omitempty"`
 	LogText                         *LogText                         `json:"logText,omitempty"`
@@ -38,6 +42,7 @@ type MachineEventWrapper struct {
 	ContainerStatus                 *ContainerStatus                 `json:"containerStatus,omitempty"`
 	URLReachable                    *URLReachable                    `json:"urlReachable,omitempty"`
 	KubernetesPodStatus             *KubernetesPodStatus             `json:"kubernetesPodStatus,omitempty"`
+	FileSync                        *FileSync                        `json:"fileSync,omitempty"`
 }
 
 // DevFileCommandExecutionBegin is the JSON event that is emitted when a dev file command begins execution.
@@ -100,9 +105,22 @@ type URLReachable struct {
 	Secure    bool   `json:"secure"`
 	Kind      string `json:"kind"`
 	Reachable bool   `json:"reachable"`
+	// Probe holds the details of the response received when probing the URL
+	Probe *URLProbe `json:"probe,omitempty"`
 	AbstractLogEvent
 }
 
+// URLProbe is the result of the HTTP(S) probe of a URL
+type URLProbe struct {
+	Healthy    bool     `json:"healthy"`
+	StatusCode int      `json:"statusCode,omitempty"`
+	LatencyMs  int64    `json:"latencyMs"`
+	TLSValid   *bool    `json:"tlsValid,omitempty"`
+	TLSError   string   `json:"tlsError,omitempty"`
+	Redirects  []string `json:"redirects,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
 // KubernetesPodStatus is the JSON event that emitted to indicate the status of pods in an odo-managed deployment
 type KubernetesPodStatus struct {
 	Pods []KubernetesPodStatusEntry `json:"pods"`
@@ -124,6 +142,25 @@ type KubernetesPodStatusEntry struct {
 	// vast majority are useful.
 }
 
+const (
+	// FileSyncStarted is the status of a sync of changed files starting
+	FileSyncStarted = "started"
+	// FileSyncSucceeded is the status of a sync of changed files and of its push which succeeded
+	FileSyncSucceeded = "succeeded"
+	// FileSyncFailed is the status of a sync of changed files or of its push which failed
+	FileSyncFailed = "failed"
+)
+
+// FileSync is the JSON event that is emitted when odo watch syncs the changed files to the component, once when the
+// sync starts and once when the push ends
+type FileSync struct {
+	ChangedFiles []string `json:"changedFiles,omitempty"`
+	DeletedFiles []string `json:"deletedFiles,omitempty"`
+	Status       string   `json:"status"`
+	Error        string   `json:"error,omitempty"`
+	AbstractLogEvent
+}
+
 // AbstractLogEvent is the base struct for all events; all events must at a minimum contain a timestamp.
 type AbstractLogEvent struct {
 	Timestamp string `json:"timestamp"`
@@ -138,6 +175,7 @@ var _ MachineEventLogEntry = &SupervisordStatus{}
 var _ MachineEventLogEntry = &ContainerStatus{}
 var _ MachineEventLogEntry = &URLReachable{}
 var _ MachineEventLogEntry = &KubernetesPodStatus{}
+var _ MachineEventLogEntry = &FileSync{}
 
 // MachineEventLogEntry contains the expected methods for every event that is emitted.
 // (This is mainly used for test purposes.)
@@ -156,3 +194,10 @@ type ConsoleMachineEventLoggingClient struct {
 	// logFunc is an optional function that can be used instead of writing via the standard machine out logic
 	logFunc func(machineOutput MachineEventWrapper)
 }
+
+// StreamMachineEventLoggingClient will publish all events to the subscribers of an EventStream,
+// and output them to the console as JSON in machine logging mode
+type StreamMachineEventLoggingClient struct {
+	ConsoleMachineEventLoggingClient
+	stream *EventStream
+}
@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/openshift/odo/pkg/odo/util"
+
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// RecommendedCommandName is the recommended cache command name
+const RecommendedCommandName = "cache"
+
+var cacheLongDesc = ktemplates.LongDesc(`Manage the cache of the responses of the devfile registries
+
+	The responses are used without contacting the registry for RegistryCacheTime minutes,
+	they are then revalidated with the registry, and used when the registry can't be reached.
+	The responses of each registry are limited to RegistryCacheSize MiB, see 'odo preference view'.`)
+
+// NewCmdCache implements the cache odo command
+func NewCmdCache(name, fullName string) *cobra.Command {
+	cacheListCmd := NewCmdList(listCommandName, util.GetFullName(fullName, listCommandName))
+	cacheCleanCmd := NewCmdClean(cleanCommandName, util.GetFullName(fullName, cleanCommandName))
+	cacheCmd := &cobra.Command{
+		Use:   name,
+		Short: "Manage the cache of the devfile registries",
+		Long:  cacheLongDesc,
+		Example: fmt.Sprintf("%s\n\n%s",
+			cacheListCmd.Example,
+			cacheCleanCmd.Example,
+		),
+	}
+
+	cacheCmd.AddCommand(cacheListCmd, cacheCleanCmd)
+	cacheCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	cacheCmd.Annotations = map[string]string{"command": "utility"}
+
+	return cacheCmd
+}
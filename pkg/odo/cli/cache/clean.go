@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/util"
+
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const cleanCommandName = "clean"
+
+var (
+	cleanLongDesc = ktemplates.LongDesc(`Delete the cached responses of the devfile registries`)
+
+	cleanExample = ktemplates.Examples(`# Delete all the cached responses
+	%[1]s
+
+	# Delete the cached responses of the registry MyRegistry
+	%[1]s --registry MyRegistry
+	`)
+)
+
+// CleanOptions encapsulates the options for the odo cache clean command
+type CleanOptions struct {
+	registry string
+}
+
+// NewCleanOptions creates a new CleanOptions instance
+func NewCleanOptions() *CleanOptions {
+	return &CleanOptions{}
+}
+
+// Complete completes CleanOptions after they've been created
+func (o *CleanOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	return nil
+}
+
+// Validate validates the CleanOptions based on completed values
+func (o *CleanOptions) Validate() (err error) {
+	return nil
+}
+
+// Run contains the logic for the odo cache clean command
+func (o *CleanOptions) Run(cmd *cobra.Command) (err error) {
+	deleted, err := util.CleanHTTPCache(o.registry)
+	if err != nil {
+		return err
+	}
+	if o.registry != "" {
+		log.Successf("Deleted %d cached responses of registry %s", deleted, o.registry)
+		return nil
+	}
+	log.Successf("Deleted %d cached responses", deleted)
+	return nil
+}
+
+// NewCmdClean implements the odo cache clean command
+func NewCmdClean(name, fullName string) *cobra.Command {
+	o := NewCleanOptions()
+	cacheCleanCmd := &cobra.Command{
+		Use:     name,
+		Short:   "Delete the cached responses of the devfile registries",
+		Long:    cleanLongDesc,
+		Example: fmt.Sprintf(cleanExample, fullName),
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	cacheCleanCmd.Flags().StringVar(&o.registry, "registry", "", "Only delete the cached responses of the given registry")
+	return cacheCleanCmd
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/util"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const listCommandName = "list"
+
+var (
+	listLongDesc = ktemplates.LongDesc(`List the cached responses of the devfile registries`)
+
+	listExample = ktemplates.Examples(`# List the cached responses
+	%[1]s
+	`)
+)
+
+// CacheList is the machine readable output of "odo cache list"
+type CacheList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []util.HTTPCacheEntry `json:"items"`
+}
+
+// ListOptions encapsulates the options for the odo cache list command
+type ListOptions struct {
+	entries []util.HTTPCacheEntry
+}
+
+// NewListOptions creates a new ListOptions instance
+func NewListOptions() *ListOptions {
+	return &ListOptions{}
+}
+
+// Complete completes ListOptions after they've been created
+func (o *ListOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.entries, err = util.ListHTTPCache()
+	return err
+}
+
+// Validate validates the ListOptions based on completed values
+func (o *ListOptions) Validate() (err error) {
+	return nil
+}
+
+// Run contains the logic for the odo cache list command
+func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(CacheList{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "List",
+				APIVersion: machineoutput.APIVersion,
+			},
+			Items: o.entries,
+		})
+		return nil
+	}
+
+	if len(o.entries) == 0 {
+		log.Info("The cache is empty")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "REGISTRY", "\t", "URL", "\t", "SIZE", "\t", "AGE")
+	for _, entry := range o.entries {
+		registry := entry.Group
+		if registry == "" {
+			registry = "-"
+		}
+		fmt.Fprintln(w, registry, "\t", entry.URL, "\t", formatSize(entry.Size), "\t", duration.HumanDuration(time.Since(entry.Stored)))
+	}
+	w.Flush()
+	return nil
+}
+
+// formatSize formats the size in bytes with a binary unit
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	value, exp := float64(size)/unit, 0
+	for value >= unit && exp < 2 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", value, "KMG"[exp])
+}
+
+// NewCmdList implements the odo cache list command
+func NewCmdList(name, fullName string) *cobra.Command {
+	o := NewListOptions()
+	cacheListCmd := &cobra.Command{
+		Use:         name,
+		Short:       "List the cached responses of the devfile registries",
+		Long:        listLongDesc,
+		Example:     fmt.Sprintf(listExample, fullName),
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	return cacheListCmd
+}
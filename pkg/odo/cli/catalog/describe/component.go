@@ -2,9 +2,7 @@ package describe
 
 import (
 	"fmt"
-	"net/url"
 	"os"
-	"path"
 	"strings"
 	"text/tabwriter"
 
@@ -33,10 +31,13 @@ const componentRecommendedCommandName = "component"
 
 var (
 	componentExample = ktemplates.Examples(`  # Describe a component
-    %[1]s nodejs`)
+    %[1]s nodejs
+
+  # Describe the version 1.0.0 of a devfile component
+    %[1]s nodejs@1.0.0`)
 
 	componentLongDesc = ktemplates.LongDesc(`Describe a component type.
-This describes the component and its associated starter projects.
+This describes the component, the versions offered by the registries and its associated starter projects.
 `)
 )
 
@@ -44,6 +45,8 @@ This describes the component and its associated starter projects.
 type DescribeComponentOptions struct {
 	// name of the component to describe, from command arguments
 	componentName string
+	// version of the devfile component to describe, from command arguments, the default version if empty
+	version string
 	// if devfile components with name that matches arg[0]
 	devfileComponents []catalog.DevfileComponentType
 	// if componentName is a classic/odov1 component
@@ -59,7 +62,7 @@ func NewDescribeComponentOptions() *DescribeComponentOptions {
 
 // Complete completes DescribeComponentOptions after they've been created
 func (o *DescribeComponentOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
-	o.componentName = args[0]
+	o.componentName, o.version = catalog.SplitStackVersion(args[0])
 	tasks := util.NewConcurrentTasks(2)
 
 	o.Context, err = genericclioptions.NewContext(cmd, true)
@@ -99,6 +102,9 @@ func (o *DescribeComponentOptions) Complete(name string, cmd *cobra.Command, arg
 // Validate validates the DescribeComponentOptions based on completed values
 func (o *DescribeComponentOptions) Validate() (err error) {
 	if len(o.devfileComponents) == 0 && o.component == "" {
+		if o.version != "" {
+			return errors.Errorf("No components with the name \"%s\" and the version \"%s\" found", o.componentName, o.version)
+		}
 		return errors.Wrapf(err, "No components with the name \"%s\" found", o.componentName)
 	}
 
@@ -108,8 +114,10 @@ func (o *DescribeComponentOptions) Validate() (err error) {
 // DevfileComponentDescription represents the JSON output of Devfile component description
 // used in odo catalog describe component <name> -o json
 type DevfileComponentDescription struct {
-	RegistryName string           `json:"RegistryName"`
-	Devfile      data.DevfileData `json:"Devfile"`
+	RegistryName string                 `json:"RegistryName"`
+	Version      string                 `json:"Version,omitempty"`
+	Versions     []catalog.StackVersion `json:"Versions,omitempty"`
+	Devfile      data.DevfileData       `json:"Devfile"`
 }
 
 // Run contains the logic for the command associated with DescribeComponentOptions
@@ -120,11 +128,16 @@ func (o *DescribeComponentOptions) Run(cmd *cobra.Command) (err error) {
 			out := []DevfileComponentDescription{}
 
 			for _, devfileComponent := range o.devfileComponents {
-				devObj, err := GetDevfile(devfileComponent)
+				devObj, err := GetDevfile(devfileComponent, o.version)
 				if err != nil {
 					return err
 				}
-				out = append(out, DevfileComponentDescription{RegistryName: devfileComponent.Registry.Name, Devfile: devObj.Data})
+				out = append(out, DevfileComponentDescription{
+					RegistryName: devfileComponent.Registry.Name,
+					Version:      o.describedVersion(devfileComponent),
+					Versions:     devfileComponent.Versions,
+					Devfile:      devObj.Data,
+				})
 			}
 			machineoutput.OutputSuccess(out)
 		}
@@ -137,8 +150,12 @@ func (o *DescribeComponentOptions) Run(cmd *cobra.Command) (err error) {
 
 			for _, devfileComponent := range o.devfileComponents {
 				fmt.Fprintln(w, "\n* Registry: "+devfileComponent.Registry.Name)
+				if len(devfileComponent.Versions) > 0 {
+					fmt.Fprintln(w, "* Versions: "+formatVersions(devfileComponent))
+					fmt.Fprintln(w, "* Described version: "+o.describedVersion(devfileComponent))
+				}
 
-				devObj, err := GetDevfile(devfileComponent)
+				devObj, err := GetDevfile(devfileComponent, o.version)
 				if err != nil {
 					return err
 				}
@@ -187,32 +204,60 @@ func NewCmdCatalogDescribeComponent(name, fullName string) *cobra.Command {
 // GetDevfileComponentsByName gets all the devfiles that have the same name as the specified components
 func (o *DescribeComponentOptions) GetDevfileComponentsByName(catalogDevfileList catalog.DevfileComponentTypeList) {
 	for _, devfileComponent := range catalogDevfileList.Items {
-		if devfileComponent.Name == o.componentName {
+		if devfileComponent.Name == o.componentName && devfileComponent.HasVersion(o.version) {
 			o.devfileComponents = append(o.devfileComponents, devfileComponent)
 		}
 	}
 }
 
-// GetDevfile downloads the devfile in memory and return the devfile object
-func GetDevfile(devfileComponent catalog.DevfileComponentType) (parser.DevfileObj, error) {
+// describedVersion returns the version of the devfile component which is described
+func (o *DescribeComponentOptions) describedVersion(devfileComponent catalog.DevfileComponentType) string {
+	if o.version != "" {
+		return o.version
+	}
+	return devfileComponent.Version
+}
+
+// formatVersions returns the versions of the devfile component, marking the default one
+func formatVersions(devfileComponent catalog.DevfileComponentType) string {
+	var versions []string
+	for _, version := range devfileComponent.Versions {
+		if version.Default {
+			versions = append(versions, version.Version+" (default)")
+		} else {
+			versions = append(versions, version.Version)
+		}
+	}
+	return strings.Join(versions, ", ")
+}
+
+// GetDevfile downloads the devfile of the given version in memory and return the devfile object, the default version if the version is empty
+func GetDevfile(devfileComponent catalog.DevfileComponentType, version string) (parser.DevfileObj, error) {
 	var devObj parser.DevfileObj
 	var err error
 
 	if strings.Contains(devfileComponent.Registry.URL, "github") {
-		devObj, err = devfile.ParseFromURL(devfileComponent.Registry.URL + devfileComponent.Link)
+		if version != "" {
+			return devObj, errors.Errorf("Github-based registries don't support versions of devfile component: %s", devfileComponent.Name)
+		}
+		// download through the proxy and with the certificate authorities of the preference
+		devfileData, err := util.DownloadFileInMemory(util.HTTPRequestParams{URL: devfileComponent.Registry.URL + devfileComponent.Link})
 		if err != nil {
 			return devObj, errors.Wrapf(err, "Failed to download devfile.yaml from Github-based registry for devfile component: %s", devfileComponent.Name)
 		}
-	} else {
-		registryURL, err := url.Parse(devfileComponent.Registry.URL)
+		devObj, err = devfile.ParseFromData(devfileData)
 		if err != nil {
-			return devObj, errors.Wrapf(err, "Failed to parse registry URL for devfile component: %s", devfileComponent.Name)
+			return devObj, errors.Wrapf(err, "Failed to parse devfile.yaml of devfile component: %s", devfileComponent.Name)
 		}
-		registryURL.Path = path.Join(registryURL.Path, "devfiles", devfileComponent.Name)
-		devObj, err = devfile.ParseFromURL(registryURL.String())
+	} else {
+		devfileData, err := catalog.GetRegistryDevfile(devfileComponent.Registry, devfileComponent.Name, version)
 		if err != nil {
 			return devObj, errors.Wrapf(err, "Failed to download devfile.yaml from OCI-based registry for devfile component: %s", devfileComponent.Name)
 		}
+		devObj, err = devfile.ParseFromData(devfileData)
+		if err != nil {
+			return devObj, errors.Wrapf(err, "Failed to parse devfile.yaml of devfile component: %s", devfileComponent.Name)
+		}
 	}
 
 	err = validate.ValidateDevfileData(devObj.Data)
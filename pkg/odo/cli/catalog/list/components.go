@@ -86,7 +86,8 @@ func (o *ListComponentsOptions) Validate() (err error) {
 	return err
 }
 
-type combinedCatalogList struct {
+// CombinedCatalogList is the machine readable output of "odo catalog list components"
+type CombinedCatalogList struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 	S2iItems          []catalog.ComponentType        `json:"s2iItems,omitempty"`
@@ -101,7 +102,7 @@ func (o *ListComponentsOptions) Run(cmd *cobra.Command) (err error) {
 			supported, _ := catalog.SliceSupportedTags(image)
 			o.catalogList.Items[i].Spec.SupportedTags = supported
 		}
-		combinedList := combinedCatalogList{
+		combinedList := CombinedCatalogList{
 			TypeMeta: metav1.TypeMeta{
 				Kind:       "List",
 				APIVersion: "odo.dev/v1alpha1",
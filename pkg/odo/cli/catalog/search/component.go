@@ -2,26 +2,53 @@ package search
 
 import (
 	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/openshift/odo/pkg/catalog"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/odo/cli/catalog/util"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	odoutil "github.com/openshift/odo/pkg/util"
 	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
 )
 
 const componentRecommendedCommandName = "component"
 
 var componentExample = `  # Search for a component
-  %[1]s python`
+  %[1]s python
+
+  # Search for the Java components of the registry MyRegistry having the quarkus tag
+  %[1]s --language java --tag quarkus --registry MyRegistry
+
+  # List the components of the registry MyRegistry
+  %[1]s --registry MyRegistry`
 
 // SearchComponentOptions encapsulates the options for the odo catalog describe service command
 type SearchComponentOptions struct {
 	searchTerm string
 	components []string
+	// devfile components matching the search, sorted by relevance
+	devfileComponents []catalog.DevfileComponentMatch
+	// filters of the devfile components
+	filter   catalog.DevfileSearchFilter
+	registry string
 	// generic context options common to all commands
 	*genericclioptions.Context
 }
 
+// searchComponentList is the machine readable output of the odo catalog search component command
+type searchComponentList struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	S2iItems          []string                        `json:"s2iItems,omitempty"`
+	DevfileItems      []catalog.DevfileComponentMatch `json:"devfileItems,omitempty"`
+}
+
 // NewSearchComponentOptions creates a new SearchComponentOptions instance
 func NewSearchComponentOptions() *SearchComponentOptions {
 	return &SearchComponentOptions{}
@@ -29,20 +56,42 @@ func NewSearchComponentOptions() *SearchComponentOptions {
 
 // Complete completes SearchComponentOptions after they've been created
 func (o *SearchComponentOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
-	o.Context, err = genericclioptions.NewContext(cmd)
+	if len(args) > 0 {
+		o.searchTerm = args[0]
+	}
+	// the registry filters the components, listing the components of a registry doesn't require a search term
+	if o.searchTerm == "" && o.filter.IsEmpty() && o.registry == "" {
+		return fmt.Errorf("please provide a search term or a filter, refer `odo catalog search component --help`")
+	}
+
+	devfileList, err := catalog.ListDevfileComponents(o.registry)
 	if err != nil {
 		return err
 	}
-	o.searchTerm = args[0]
+	o.devfileComponents = catalog.SearchDevfileComponents(devfileList.Items, o.searchTerm, o.filter)
 
-	o.components, err = catalog.SearchComponent(o.Client, o.searchTerm)
+	// the S2I components have no language, tags or registry to filter on
+	if o.searchTerm == "" || !o.filter.IsEmpty() || o.registry != "" || !odoutil.IsValidKubeConfigPath() {
+		return nil
+	}
+	o.Context, err = genericclioptions.NewContext(cmd)
+	if err != nil {
+		return err
+	}
+	supported, err := o.Client.IsImageStreamSupported()
+	if err != nil {
+		klog.V(4).Info("ignoring error while checking imagestream support:", err.Error())
+	}
+	if supported {
+		o.components, err = catalog.SearchComponent(o.Client, o.searchTerm)
+	}
 	return err
 }
 
 // Validate validates the SearchComponentOptions based on completed values
 func (o *SearchComponentOptions) Validate() (err error) {
-	if len(o.components) == 0 {
-		return fmt.Errorf("no component matched the query: %s", o.searchTerm)
+	if len(o.components) == 0 && len(o.devfileComponents) == 0 {
+		return fmt.Errorf("no component matched the query: %s", o.query())
 	}
 
 	return
@@ -50,25 +99,83 @@ func (o *SearchComponentOptions) Validate() (err error) {
 
 // Run contains the logic for the command associated with SearchComponentOptions
 func (o *SearchComponentOptions) Run(cmd *cobra.Command) (err error) {
-	util.DisplayComponents(o.components)
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(searchComponentList{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "List",
+				APIVersion: machineoutput.APIVersion,
+			},
+			S2iItems:     o.components,
+			DevfileItems: o.devfileComponents,
+		})
+		return
+	}
+
+	if len(o.devfileComponents) != 0 {
+		w := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
+		fmt.Fprintln(w, "Odo Devfile Components:")
+		fmt.Fprintln(w, "NAME", "\t", "DESCRIPTION", "\t", "LANGUAGE", "\t", "TAGS", "\t", "REGISTRY")
+		for _, component := range o.devfileComponents {
+			fmt.Fprintln(w, component.Name, "\t", odoutil.TruncateString(component.Description, 60, "..."), "\t", component.Language, "\t", strings.Join(component.Tags, ","), "\t", component.Registry.Name)
+		}
+		w.Flush()
+	}
+	if len(o.components) != 0 {
+		if len(o.devfileComponents) != 0 {
+			fmt.Println()
+		}
+		fmt.Println("Odo S2I Components:")
+		util.DisplayComponents(o.components)
+	}
 	return
 }
 
+// query describes the search term and the filters of the search
+func (o *SearchComponentOptions) query() string {
+	var query []string
+	if o.searchTerm != "" {
+		query = append(query, o.searchTerm)
+	}
+	if o.filter.Language != "" {
+		query = append(query, "language="+o.filter.Language)
+	}
+	if o.filter.ProjectType != "" {
+		query = append(query, "project-type="+o.filter.ProjectType)
+	}
+	for _, tag := range o.filter.Tags {
+		query = append(query, "tag="+tag)
+	}
+	if o.registry != "" {
+		query = append(query, "registry="+o.registry)
+	}
+	return strings.Join(query, " ")
+}
+
 // NewCmdCatalogSearchComponent implements the odo catalog search component command
 func NewCmdCatalogSearchComponent(name, fullName string) *cobra.Command {
 	o := NewSearchComponentOptions()
-	return &cobra.Command{
-		Use:   name,
+	componentSearchCmd := &cobra.Command{
+		Use:   fmt.Sprintf("%s [search term]", name),
 		Short: "Search component type in catalog",
 		Long: `Search component type in catalog.
 
-This searches for a partial match for the given search term in all the available
-components.
+This searches for the words of the search term in the name, display name, description,
+language, project type and tags of all the available devfile components, and for a partial
+match of the search term in the names of the S2I components. The devfile components are
+sorted by relevance, and can be filtered by language, project type, tags and registry.
 `,
-		Args:    cobra.ExactArgs(1),
-		Example: fmt.Sprintf(componentExample, fullName),
+		Args:        cobra.MaximumNArgs(1),
+		Example:     fmt.Sprintf(componentExample, fullName),
+		Annotations: map[string]string{"machineoutput": "json"},
 		Run: func(cmd *cobra.Command, args []string) {
 			genericclioptions.GenericRun(o, cmd, args)
 		},
 	}
+
+	componentSearchCmd.Flags().StringVar(&o.filter.Language, "language", "", "Only match the devfile components of the given language")
+	componentSearchCmd.Flags().StringVar(&o.filter.ProjectType, "project-type", "", "Only match the devfile components of the given project type")
+	componentSearchCmd.Flags().StringSliceVar(&o.filter.Tags, "tag", []string{}, "Only match the devfile components having the given tag, can be specified multiple times")
+	componentSearchCmd.Flags().StringVar(&o.registry, "registry", "", "Only match the devfile components of the given registry")
+
+	return componentSearchCmd
 }
@@ -8,11 +8,15 @@ import (
 
 	"github.com/openshift/odo/pkg/odo/cli/telemetry"
 
+	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/odo/cli/application"
+	"github.com/openshift/odo/pkg/odo/cli/cache"
 	"github.com/openshift/odo/pkg/odo/cli/catalog"
 	"github.com/openshift/odo/pkg/odo/cli/component"
 	"github.com/openshift/odo/pkg/odo/cli/config"
+	"github.com/openshift/odo/pkg/odo/cli/daemon"
 	"github.com/openshift/odo/pkg/odo/cli/debug"
+	"github.com/openshift/odo/pkg/odo/cli/devfile"
 	"github.com/openshift/odo/pkg/odo/cli/env"
 	"github.com/openshift/odo/pkg/odo/cli/login"
 	"github.com/openshift/odo/pkg/odo/cli/logout"
@@ -20,6 +24,7 @@ import (
 	"github.com/openshift/odo/pkg/odo/cli/preference"
 	"github.com/openshift/odo/pkg/odo/cli/project"
 	"github.com/openshift/odo/pkg/odo/cli/registry"
+	"github.com/openshift/odo/pkg/odo/cli/schema"
 	"github.com/openshift/odo/pkg/odo/cli/service"
 	"github.com/openshift/odo/pkg/odo/cli/storage"
 	"github.com/openshift/odo/pkg/odo/cli/url"
@@ -148,7 +153,7 @@ func odoRootCmd(name, fullName string) *cobra.Command {
 	// We use "flag" in order to make this accessible throughtout ALL of odo, rather than the
 	// above traditional "persistentflags" usage that does not make it a pointer within the 'pflag'
 	// package
-	flag.CommandLine.String("o", "", "Specify output format, supported format: json")
+	flag.CommandLine.String("o", "", "Specify output format, supported formats: "+machineoutput.OutputFormats)
 
 	// Here we add the necessary "logging" flags.. However, we choose to hide some of these from the user
 	// as they are not necessarily needed and more for advanced debugging
@@ -191,6 +196,7 @@ func odoRootCmd(name, fullName string) *cobra.Command {
 		component.NewCmdUpdate(component.UpdateRecommendedCommandName, util.GetFullName(fullName, component.UpdateRecommendedCommandName)),
 		component.NewCmdWatch(component.WatchRecommendedCommandName, util.GetFullName(fullName, component.WatchRecommendedCommandName)),
 		component.NewCmdStatus(component.StatusRecommendedCommandName, util.GetFullName(fullName, component.StatusRecommendedCommandName)),
+		component.NewCmdDashboard(component.DashboardRecommendedCommandName, util.GetFullName(fullName, component.DashboardRecommendedCommandName)),
 		component.NewCmdExec(component.ExecRecommendedCommandName, util.GetFullName(fullName, component.ExecRecommendedCommandName)),
 		login.NewCmdLogin(login.RecommendedCommandName, util.GetFullName(fullName, login.RecommendedCommandName)),
 		logout.NewCmdLogout(logout.RecommendedCommandName, util.GetFullName(fullName, logout.RecommendedCommandName)),
@@ -206,7 +212,12 @@ func odoRootCmd(name, fullName string) *cobra.Command {
 		registry.NewCmdRegistry(registry.RecommendedCommandName, util.GetFullName(fullName, registry.RecommendedCommandName)),
 		component.NewCmdTest(component.TestRecommendedCommandName, util.GetFullName(fullName, component.TestRecommendedCommandName)),
 		env.NewCmdEnv(env.RecommendedCommandName, util.GetFullName(fullName, env.RecommendedCommandName)),
-		telemetry.NewCmdTelemetry(telemetry.RecommendedCommandName),
+		devfile.NewCmdDevfile(devfile.RecommendedCommandName, util.GetFullName(fullName, devfile.RecommendedCommandName)),
+		cache.NewCmdCache(cache.RecommendedCommandName, util.GetFullName(fullName, cache.RecommendedCommandName)),
+		plugins.NewCmdPlugin(plugins.RecommendedCommandName, util.GetFullName(fullName, plugins.RecommendedCommandName)),
+		daemon.NewCmdDaemon(daemon.RecommendedCommandName, util.GetFullName(fullName, daemon.RecommendedCommandName)),
+		schema.NewCmdSchema(schema.RecommendedCommandName, util.GetFullName(fullName, schema.RecommendedCommandName)),
+		telemetry.NewCmdTelemetry(telemetry.RecommendedCommandName, util.GetFullName(fullName, telemetry.RecommendedCommandName)),
 	)
 
 	// Add all subcommands to base commands
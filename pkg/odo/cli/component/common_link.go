@@ -13,6 +13,7 @@ import (
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/secret"
 	svc "github.com/openshift/odo/pkg/service"
+	urlLabels "github.com/openshift/odo/pkg/url/labels"
 	"github.com/openshift/odo/pkg/util"
 	servicebinding "github.com/redhat-developer/service-binding-operator/api/v1alpha1"
 	"github.com/spf13/cobra"
@@ -32,6 +33,10 @@ type commonLinkOptions struct {
 	isTargetAService bool
 	name             string
 	bindAsFiles      bool
+	dryRun           bool
+
+	// showBindingValues shows the values of the binding secret in the dry-run output instead of masking them
+	showBindingValues bool
 
 	devfilePath string
 
@@ -177,12 +182,16 @@ func (o *commonLinkOptions) run() (err error) {
 	var component string
 	if o.Context.EnvSpecificInfo != nil {
 		component = o.EnvSpecificInfo.GetName()
-		err = o.operation(o.secretName, component, o.Application)
 	} else {
 		component = o.Component()
-		err = o.operation(o.secretName, component, o.Application)
 	}
 
+	if o.dryRun {
+		return o.previewSecretLink(component)
+	}
+
+	err = o.operation(o.secretName, component, o.Application)
+
 	if err != nil {
 		return err
 	}
@@ -268,6 +277,40 @@ func (o *commonLinkOptions) waitForLinkToComplete() (err error) {
 	return err
 }
 
+// previewSecretLink displays the environment variables, or the files with --bind-as-files, that linking the secret
+// would add to the component, without linking it
+func (o *commonLinkOptions) previewSecretLink(component string) error {
+	secret, err := o.Client.GetKubeClient().GetSecret(o.secretName, o.Project)
+	if err != nil {
+		return err
+	}
+
+	if len(secret.Data) == 0 {
+		log.Infof("There are no secret environment variables to expose within the %s %s", o.getLinkType(), o.suppliedName)
+		return nil
+	}
+
+	sbr := servicebinding.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: o.getServiceBindingName(component)},
+		Spec:       servicebinding.ServiceBindingSpec{BindAsFiles: o.bindAsFiles},
+	}
+	preview := svc.NewSecretBindingPreview(sbr, secret, o.showBindingValues)
+
+	if o.bindAsFiles {
+		log.Infof("Linking the %s %s would mount the below secret files in %s in the '%s' component:\n", o.getLinkType(), o.suppliedName, preview.MountPath, component)
+	} else {
+		log.Infof("Linking the %s %s would add the below secret environment variables to the '%s' component:\n", o.getLinkType(), o.suppliedName, component)
+	}
+	for _, binding := range preview.Bindings {
+		if binding.AsFile {
+			fmt.Printf("· %v=%v (file %s, from %s)\n", binding.Name, binding.Value, binding.MountPath, binding.Source)
+		} else {
+			fmt.Printf("· %v=%v (from %s)\n", binding.Name, binding.Value, binding.Source)
+		}
+	}
+	return nil
+}
+
 // getServiceBindingName creates a name to be used for creation/deletion of SBR during link/unlink operations
 func (o *commonLinkOptions) getServiceBindingName(componentName string) string {
 	if len(o.name) > 0 {
@@ -366,7 +409,7 @@ func (o *commonLinkOptions) validateForOperator() (err error) {
 
 		// TODO find the service using an app name to link components in other apps
 		// requires modification of the app flag or finding some other way
-		service, err := o.Context.Client.GetKubeClient().GetOneService(o.suppliedName, o.EnvSpecificInfo.GetApplication())
+		service, err := o.Context.Client.GetKubeClient().GetOneServiceFromSelector(urlLabels.GetComponentServiceSelector(o.suppliedName, o.EnvSpecificInfo.GetApplication()))
 		if kerrors.IsNotFound(err) {
 			return fmt.Errorf("couldn't find component named %q. Refer %q to see list of running components", o.suppliedName, "odo list")
 		}
@@ -459,6 +502,17 @@ func (o *commonLinkOptions) linkOperator() (err error) {
 	if found {
 		return fmt.Errorf("component %q is already linked with the %s %q", o.Context.EnvSpecificInfo.GetName(), o.getLinkType(), o.suppliedName)
 	}
+
+	if o.dryRun {
+		preview, err := svc.GetBindingPreview(o.KClient, *o.serviceBinding, o.showBindingValues)
+		if err != nil {
+			return err
+		}
+		log.Infof("Linking component %q with %s %q would inject the following bindings:", o.Context.EnvSpecificInfo.GetName(), o.getLinkType(), o.suppliedName)
+		fmt.Println(svc.FormatBindingPreviews([]svc.BindingPreview{preview}))
+		return nil
+	}
+
 	err = svc.AddKubernetesComponentToDevfile(string(yamlDesc), o.serviceBinding.Name, o.EnvSpecificInfo.GetDevfileObj())
 	if err != nil {
 		return err
@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/odo/pkg/component"
 	"github.com/openshift/odo/pkg/config"
 	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/hooks"
 	"github.com/openshift/odo/pkg/kclient"
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
@@ -173,8 +174,34 @@ func (cpo *CommonPushOptions) SetSourceInfo() (err error) {
 	return
 }
 
-// Push pushes changes as per set options
+// Push pushes changes as per set options, running the pre-push, post-push and push-failed hooks around the push
 func (cpo *CommonPushOptions) Push() (err error) {
+	if cpo.componentContext == "" {
+		cpo.componentContext = strings.TrimSuffix(filepath.Dir(cpo.LocalConfigInfo.Filename), ".odo")
+	}
+
+	runner := newHookRunner(cpo.componentContext, cpo.EnvSpecificInfo)
+	hookParams := hooks.Parameters{
+		Component:   cpo.LocalConfigInfo.GetName(),
+		Application: cpo.LocalConfigInfo.GetApplication(),
+		Project:     cpo.LocalConfigInfo.GetProject(),
+		ForceBuild:  cpo.forceBuild,
+	}
+	if err = runner.Run(hooks.PrePush, hookParams); err != nil {
+		return err
+	}
+	// the S2I components have no post-sync point between the sync of the files and the build
+	runner.WarnUnsupported(hooks.PostSync)
+
+	if err = cpo.push(); err != nil {
+		hookParams.Error = err.Error()
+		_ = runner.Run(hooks.PushFailed, hookParams)
+		return err
+	}
+	return runner.Run(hooks.PostPush, hookParams)
+}
+
+func (cpo *CommonPushOptions) push() (err error) {
 
 	deletedFiles := []string{}
 	changedFiles := []string{}
@@ -190,10 +217,6 @@ func (cpo *CommonPushOptions) Push() (err error) {
 	// force write the content to resolvePath
 	forceWrite := false
 
-	if cpo.componentContext == "" {
-		cpo.componentContext = strings.TrimSuffix(filepath.Dir(cpo.LocalConfigInfo.Filename), ".odo")
-	}
-
 	err = cpo.createCmpIfNotExistsAndApplyCmpConfig(stdout)
 	if err != nil {
 		return
@@ -11,7 +11,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/zalando/go-keyring"
 
-	registryLibrary "github.com/devfile/registry-support/registry-library/library"
 	"github.com/openshift/odo/pkg/catalog"
 	"github.com/openshift/odo/pkg/component"
 	"github.com/openshift/odo/pkg/config"
@@ -67,11 +66,14 @@ type DevfileMetadata struct {
 	devfileSupport     bool
 	devfileLink        string
 	devfileRegistry    catalog.Registry
+	stackVersion       string
 	devfilePath        devfilePath
 	userCreatedDevfile bool
 	starter            string
 	token              string
 	starterToken       string
+	// verify is the verification policy of the artifacts of the stack, the one of the preference if empty
+	verify string
 }
 
 // CreateRecommendedCommandName is the recommended watch command name
@@ -108,6 +110,9 @@ odo catalog list components
 # Download an example devfile and application before deploying
 %[1]s nodejs --starter
 
+# Create a component from the version 1.0.0 of the nodejs devfile stack
+%[1]s nodejs@1.0.0
+
 # Using a specific devfile
 %[1]s mynodejs --devfile ./devfile.yaml
 %[1]s mynodejs --devfile https://raw.githubusercontent.com/odo-devfiles/registry/master/devfiles/nodejs/devfile.yaml
@@ -326,6 +331,8 @@ func (co *CreateOptions) checkConflictingDevfileFlags() error {
 			flagName = "token"
 		} else if len(co.devfileMetadata.starter) != 0 {
 			flagName = "starter"
+		} else if len(co.devfileMetadata.verify) != 0 {
+			flagName = "verify"
 		}
 
 		if len(flagName) != 0 {
@@ -502,7 +509,8 @@ func (co *CreateOptions) Complete(name string, cmd *cobra.Command, args []string
 				// Download devfile from registry
 
 				// Component type: Get from full command's first argument (mandatory in direct mode)
-				componentType = args[0]
+				// the version of the devfile stack can be pinned with <type>@<version>
+				componentType, co.devfileMetadata.stackVersion = catalog.SplitStackVersion(args[0])
 
 				// Component name: Get from full command's second argument (optional in direct mode), by default it is a generated name if second arg is not provided
 				if len(args) == 2 {
@@ -591,6 +599,12 @@ func (co *CreateOptions) Complete(name string, cmd *cobra.Command, args []string
 
 		for _, devfileComponent := range catalogDevfileList.Items {
 			if co.devfileMetadata.componentType == devfileComponent.Name {
+				if !devfileComponent.HasVersion(co.devfileMetadata.stackVersion) {
+					return errors.Errorf("version %s of devfile component %s doesn't exist in registry %s, available versions: %s", co.devfileMetadata.stackVersion, devfileComponent.Name, devfileComponent.Registry.Name, strings.Join(devfileComponent.VersionNames(), ", "))
+				}
+				if co.devfileMetadata.stackVersion == "" {
+					co.devfileMetadata.stackVersion = devfileComponent.Version
+				}
 				hasComponent = true
 				co.devfileMetadata.devfileSupport = true
 				co.devfileMetadata.devfileLink = devfileComponent.Link
@@ -718,6 +732,13 @@ func (co *CreateOptions) Validate() (err error) {
 			return err
 		}
 
+		if co.devfileMetadata.verify != "" {
+			err = preference.ValidateVerifyPolicy(co.devfileMetadata.verify)
+			if err != nil {
+				return errors.Wrap(err, "invalid --verify")
+			}
+		}
+
 		spinner.End(true)
 
 		return nil
@@ -770,6 +791,10 @@ func (co *CreateOptions) s2iRun() (err error) {
 // Run has the logic to perform the required actions as part of command
 func (co *CreateOptions) devfileRun(cmd *cobra.Command) (err error) {
 	var devfileData []byte
+	// stackDir holds the resources of the stack pulled from an OCI-based or file:// registry
+	var stackDir string
+	// verifier verifies the artifacts of the stack downloaded from the registry
+	var verifier *catalog.ArtifactVerifier
 	devfileExist := util.CheckPathExists(DevfilePath)
 	// Use existing devfile directly from --devfile flag
 	if co.devfileMetadata.devfilePath.value != "" {
@@ -799,11 +824,14 @@ func (co *CreateOptions) devfileRun(cmd *cobra.Command) (err error) {
 			}
 		} else {
 			// Download devfile from registry
-			var params util.HTTPRequestParams
+			verifier, err = catalog.NewArtifactVerifier(co.devfileMetadata.devfileRegistry, co.devfileMetadata.componentType, co.devfileMetadata.stackVersion, co.devfileMetadata.verify)
+			if err != nil {
+				return err
+			}
 
 			if strings.Contains(co.devfileMetadata.devfileRegistry.URL, "github") {
 				// Github-based registry
-				params = util.HTTPRequestParams{
+				params := util.HTTPRequestParams{
 					URL: co.devfileMetadata.devfileRegistry.URL + co.devfileMetadata.devfileLink,
 				}
 				if registryUtil.IsSecure(co.devfileMetadata.devfileRegistry.Name) {
@@ -813,26 +841,38 @@ func (co *CreateOptions) devfileRun(cmd *cobra.Command) (err error) {
 					}
 					params.Token = token
 				}
+
+				cfg, err := preference.New()
+				if err != nil {
+					return err
+				}
+				params.CacheGroup = co.devfileMetadata.devfileRegistry.Name
+				params.CacheSize = int64(cfg.GetRegistryCacheSize()) * 1024 * 1024
+				devfileData, err = util.DownloadFileInMemoryWithCache(params, cfg.GetRegistryCacheTime())
+				if err != nil {
+					return errors.Wrapf(err, "failed to download devfile for devfile component from %s", co.devfileMetadata.devfileRegistry.URL+co.devfileMetadata.devfileLink)
+				}
 			} else {
-				err = registryLibrary.PullStackFromRegistry(co.devfileMetadata.devfileRegistry.URL, co.devfileMetadata.componentType, co.componentContext)
+				// the stack is pulled aside, and only copied to the context once its devfile is verified
+				stackDir, err = ioutil.TempDir("", "odo-stack")
 				if err != nil {
 					return err
 				}
+				defer os.RemoveAll(stackDir)
+				err = catalog.PullStackFromRegistry(co.devfileMetadata.devfileRegistry, co.devfileMetadata.componentType, co.devfileMetadata.stackVersion, stackDir)
+				if err != nil {
+					return err
+				}
+				devfileData, err = ioutil.ReadFile(filepath.Join(stackDir, devFile))
+				if err != nil {
+					return errors.Wrapf(err, "failed to read the devfile of stack %s", co.devfileMetadata.componentType)
+				}
 			}
 
-			cfg, err := preference.New()
+			err = verifier.Verify(catalog.DevfileArtifact, devfileData)
 			if err != nil {
 				return err
 			}
-
-			if strings.Contains(co.devfileMetadata.devfileRegistry.URL, "github") {
-				devfileData, err = util.DownloadFileInMemoryWithCache(params, cfg.GetRegistryCacheTime())
-			} else {
-				devfileData, err = ioutil.ReadFile(DevfilePath)
-			}
-			if err != nil {
-				return errors.Wrapf(err, "failed to download devfile for devfile component from %s", co.devfileMetadata.devfileRegistry.URL+co.devfileMetadata.devfileLink)
-			}
 		}
 	}
 	// TODO: this should be replaced with github.com/openshift/odo/pkg/devile.ParseFromFile(DevfilePath)
@@ -853,15 +893,15 @@ func (co *CreateOptions) devfileRun(cmd *cobra.Command) (err error) {
 		return err
 	}
 
-	if co.devfileMetadata.starterToken == "" && registryUtil.IsSecure(co.devfileMetadata.devfileRegistry.Name) {
-		token, err := keyring.Get(fmt.Sprintf("%s%s", util.CredentialPrefix, co.devfileMetadata.devfileRegistry.Name), registryUtil.RegistryUser)
+	starterCredentials := registryUtil.RegistryCredentials{Token: co.devfileMetadata.starterToken}
+	if co.devfileMetadata.starterToken == "" && co.devfileMetadata.devfileRegistry.Name != "" {
+		starterCredentials, err = registryUtil.GetRegistryCredentials(co.devfileMetadata.devfileRegistry.Name)
 		if err != nil {
-			return errors.Wrap(err, "unable to get secure registry credential from keyring")
+			return err
 		}
-		co.devfileMetadata.starterToken = token
 	}
 
-	err = decideAndDownloadStarterProject(devObj, co.devfileMetadata.starter, co.devfileMetadata.starterToken, co.interactive, co.componentContext)
+	err = decideAndDownloadStarterProject(devObj, co.devfileMetadata.starter, starterCredentials, co.interactive, co.componentContext, co.devfileMetadata.devfileRegistry, co.devfileMetadata.componentType, verifier)
 	if err != nil {
 		return errors.Wrap(err, "failed to download project for devfile component")
 	}
@@ -872,23 +912,40 @@ func (co *CreateOptions) devfileRun(cmd *cobra.Command) (err error) {
 	if err != nil {
 		return errors.Wrapf(err, "unable to save devfile to %s", DevfilePath)
 	}
-	if co.devfileMetadata.devfilePath.value == "" && !devfileExist && !strings.Contains(co.devfileMetadata.devfileRegistry.URL, "github") {
-		err = registryLibrary.PullStackFromRegistry(co.devfileMetadata.devfileRegistry.URL, co.devfileMetadata.componentType, co.componentContext)
+	if stackDir != "" {
+		err = util.CopyDirWithFS(stackDir, filepath.Dir(DevfilePath))
 		if err != nil {
-			return err
+			return errors.Wrapf(err, "unable to save the resources of stack %s", co.devfileMetadata.componentType)
 		}
 	}
 
 	// Generate env file
-	err = co.EnvSpecificInfo.SetComponentSettings(envinfo.ComponentSettings{
+	componentSettings := envinfo.ComponentSettings{
 		Name:               co.devfileMetadata.componentName,
 		Project:            co.devfileMetadata.componentNamespace,
 		AppName:            co.appName,
 		UserCreatedDevfile: co.devfileMetadata.userCreatedDevfile,
-	})
+	}
+	fromRegistryStack := co.devfileMetadata.devfilePath.value == "" && !devfileExist
+	if fromRegistryStack {
+		// record the stack so that the devfile can be upgraded to another version of the stack
+		componentSettings.Stack = &envinfo.StackSource{
+			Registry: co.devfileMetadata.devfileRegistry.Name,
+			Name:     co.devfileMetadata.componentType,
+			Version:  co.devfileMetadata.stackVersion,
+		}
+	}
+	err = co.EnvSpecificInfo.SetComponentSettings(componentSettings)
 	if err != nil {
 		return errors.Wrap(err, "failed to create env file for devfile component")
 	}
+	if fromRegistryStack {
+		// keep the unmodified devfile of the stack as the base of the merge of the devfile upgrades
+		err = ioutil.WriteFile(co.EnvSpecificInfo.GetStackDevfilePath(), devfileData, 0640)
+		if err != nil {
+			return errors.Wrapf(err, "unable to save the devfile of stack %s", co.devfileMetadata.componentType)
+		}
+	}
 
 	sourcePath, err := util.GetAbsPath(co.componentContext)
 	if err != nil {
@@ -1035,6 +1092,7 @@ func NewCmdCreate(name, fullName string) *cobra.Command {
 	componentCreateCmd.Flags().StringVar(&co.devfileMetadata.devfilePath.value, "devfile", "", "Path to the user specified devfile")
 	componentCreateCmd.Flags().StringVar(&co.devfileMetadata.token, "token", "", "Token to be used when downloading devfile from the devfile path that is specified via --devfile")
 	componentCreateCmd.Flags().StringVar(&co.devfileMetadata.starterToken, "starter-token", "", "Token to be used when downloading starter project")
+	componentCreateCmd.Flags().StringVar(&co.devfileMetadata.verify, "verify", "", "Verification of the devfile and the starter project downloaded from the registry against the digests and signatures of the registry: off, warn or enforce (Default: the Verify preference)")
 	componentCreateCmd.Flags().BoolVar(&co.forceS2i, "s2i", false, "Enforce S2I type components")
 
 	componentCreateCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
@@ -5,10 +5,12 @@ import (
 	"github.com/devfile/api/v2/pkg/devfile"
 	"github.com/devfile/library/pkg/devfile/parser"
 	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/openshift/odo/pkg/catalog"
 	"github.com/openshift/odo/pkg/component"
 	"github.com/openshift/odo/pkg/config"
 	"github.com/openshift/odo/pkg/envinfo"
 	"github.com/openshift/odo/pkg/machineoutput"
+	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 )
 
@@ -46,8 +48,9 @@ func (co *CreateOptions) SetComponentSettings(args []string) error {
 }
 
 // decideAndDownloadStarterProject decides the starter project from the value passed by the user and
-// downloads it
-func decideAndDownloadStarterProject(devObj parser.DevfileObj, projectPassed string, token string, interactive bool, contextDir string) error {
+// downloads it, from the registry mirror if the devfile comes from a file:// registry,
+// verifying it with the verifier of the stack if not nil
+func decideAndDownloadStarterProject(devObj parser.DevfileObj, projectPassed string, credentials registryUtil.RegistryCredentials, interactive bool, contextDir string, registry catalog.Registry, componentType string, verifier *catalog.ArtifactVerifier) error {
 	if projectPassed == "" && !interactive {
 		return nil
 	}
@@ -72,7 +75,15 @@ func decideAndDownloadStarterProject(devObj parser.DevfileObj, projectPassed str
 		return nil
 	}
 
-	return component.DownloadStarterProject(starterProject, token, contextDir)
+	starterProject, err = catalog.GetMirroredStarterProject(registry, componentType, starterProject)
+	if err != nil {
+		return err
+	}
+
+	if verifier == nil {
+		return component.DownloadStarterProject(starterProject, credentials, contextDir)
+	}
+	return component.DownloadVerifiedStarterProject(starterProject, credentials, contextDir, verifier.VerifyStarterProject)
 }
 
 // DevfileJSON creates the full json description of a devfile component is prints it
@@ -0,0 +1,314 @@
+package component
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/openshift/odo/pkg/dashboard"
+	"github.com/openshift/odo/pkg/devfile"
+	"github.com/openshift/odo/pkg/devfile/adapters"
+	"github.com/openshift/odo/pkg/devfile/adapters/common"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/occlient"
+	appCmd "github.com/openshift/odo/pkg/odo/cli/application"
+	projectCmd "github.com/openshift/odo/pkg/odo/cli/project"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	odoutil "github.com/openshift/odo/pkg/odo/util"
+	"github.com/openshift/odo/pkg/url"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// DashboardRecommendedCommandName is the recommended dashboard command name
+const DashboardRecommendedCommandName = "dashboard"
+
+// watchEventsRetryInterval is the time between two attempts to follow the events of odo watch
+const watchEventsRetryInterval = 5 * time.Second
+
+// shellCommand runs bash in the container, or sh if bash is not installed
+var shellCommand = []string{"/bin/sh", "-c", "if command -v bash > /dev/null 2>&1; then exec bash; else exec sh; fi"}
+
+var dashboardLongDesc = ktemplates.LongDesc(`Show the live state of the component in a full-screen terminal dashboard
+
+	The dashboard shows the states of the pods and containers of the component, the status of the supervisord
+	programs running the devfile commands, the reachability of the URLs, the sync and push activity, and the last
+	log lines of each container.
+
+	To follow the syncs of odo watch, run it with --event-stream and give the same address to --watch-events.
+
+	Keybindings:
+
+	* p: push the component
+	* r: restart the run command, or the debug command in debug mode
+	* s: open a shell in the selected container
+	* t: follow the log of the selected container on the whole screen, t or esc to go back
+	* tab, j, k: select the next or the previous container
+	* q, Ctrl+c: quit`)
+
+var dashboardExample = ktemplates.Examples(`  # Show the dashboard of the component of the current directory
+  %[1]s
+
+  # Show the dashboard and the syncs of odo watch
+  odo watch --event-stream unix:/tmp/odo-watch.sock
+  %[1]s --watch-events unix:/tmp/odo-watch.sock
+	`)
+
+// DashboardOptions contains the options of the dashboard command
+type DashboardOptions struct {
+	componentContext string
+	watchEvents      string
+	probeInterval    time.Duration
+
+	componentName  string
+	devfilePath    string
+	devObj         parser.DevfileObj
+	devfileHandler common.ComponentAdapter
+	client         *occlient.Client
+
+	EnvSpecificInfo *envinfo.EnvSpecificInfo
+	localConfig     localConfigProvider.LocalConfigProvider
+	dashboard       *dashboard.Dashboard
+	*genericclioptions.Context
+}
+
+// NewDashboardOptions returns new instance of DashboardOptions
+func NewDashboardOptions() *DashboardOptions {
+	return &DashboardOptions{}
+}
+
+// Complete completes the dashboard options
+func (do *DashboardOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	do.devfilePath = filepath.Join(do.componentContext, DevfilePath)
+	if !util.CheckPathExists(do.devfilePath) {
+		return errors.New("the dashboard command is only supported for devfiles")
+	}
+
+	do.EnvSpecificInfo, err = envinfo.NewEnvSpecificInfo(do.componentContext)
+	if err != nil {
+		return errors.Wrap(err, "unable to retrieve configuration information")
+	}
+	do.Context, err = genericclioptions.NewDevfileContext(cmd)
+	if err != nil {
+		return err
+	}
+	do.componentName = do.EnvSpecificInfo.GetName()
+
+	do.devObj, err = devfile.ParseFromFile(do.devfilePath)
+	if err != nil {
+		return err
+	}
+	do.EnvSpecificInfo.SetDevfileObj(do.devObj)
+	do.localConfig = do.EnvSpecificInfo
+
+	do.dashboard = dashboard.New(dashboard.Options{
+		Title:             fmt.Sprintf("%s (application: %s, project: %s)", do.componentName, do.Application, do.KClient.Namespace),
+		Push:              do.push,
+		RestartRunCommand: do.restartRunCommand,
+		Shell:             do.shell,
+		Logs: func(pod string, container string) (io.ReadCloser, error) {
+			return do.KClient.GetPodLogs(pod, container, true)
+		},
+	})
+
+	// The namespace was retrieved from the --project flag (or from the kube client if not set) and stored in kclient when initializing the context
+	do.client, err = occlient.New()
+	if err != nil {
+		return err
+	}
+	do.client.SetKubeClient(do.KClient)
+	do.client.Namespace = do.KClient.Namespace
+
+	// the events of the watches are shown by the dashboard
+	loggingClient := machineoutput.NewConsoleMachineEventLoggingClientWithFunction(do.dashboard.Handle)
+	do.devfileHandler = adapters.NewComponentAdapterWithClient(do.componentName, do.componentContext, do.Application, do.devObj, *do.client, loggingClient)
+	return nil
+}
+
+// Validate validates the dashboard options
+func (do *DashboardOptions) Validate() (err error) {
+	if log.IsJSON() {
+		return errors.New("the dashboard command doesn't support the machine readable output")
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("the dashboard command must be run in a terminal")
+	}
+	if do.probeInterval <= 0 {
+		return fmt.Errorf("the probe interval must be positive")
+	}
+	return nil
+}
+
+// Run shows the dashboard until it is quit
+func (do *DashboardOptions) Run(cmd *cobra.Command) (err error) {
+	loggingClient := machineoutput.NewConsoleMachineEventLoggingClientWithFunction(do.dashboard.Handle)
+
+	do.devfileHandler.StartSupervisordCtlStatusWatch()
+	do.devfileHandler.StartContainerStatusWatch()
+
+	// occlient is required so that we can report the status for route URLs (eg in addition to our already testing ingress URLs for k8s)
+	url.StartURLHttpRequestStatusWatchForK8S(do.client, do.KClient, &do.localConfig, loggingClient, url.ProbeOptions{Timeout: url.DefaultProbeTimeout}, do.probeInterval)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	if do.watchEvents != "" {
+		go do.followWatchEvents(stop)
+	}
+
+	return do.dashboard.Run(os.Stdin, os.Stdout)
+}
+
+// followWatchEvents shows the events of odo watch, subscribing again to its event stream when it ends
+func (do *DashboardOptions) followWatchEvents(stop chan struct{}) {
+	reported := false
+	for {
+		err := machineoutput.ReadEventStream(do.watchEvents, stop, do.dashboard.Handle)
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		if err != nil && !reported {
+			do.dashboard.AddActivity(fmt.Sprintf("Unable to follow the events of odo watch on %s, retrying: %v", do.watchEvents, err), true)
+		}
+		reported = err != nil
+		select {
+		case <-stop:
+			return
+		case <-time.After(watchEventsRetryInterval):
+		}
+	}
+}
+
+// push runs odo push with the machine readable output, and reports its events to the dashboard
+func (do *DashboardOptions) push(handle func(event machineoutput.MachineEventWrapper)) error {
+	binary, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	contextDir, err := filepath.Abs(do.componentContext)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(binary, PushRecommendedCommandName, "-o", "json", "--context", contextDir)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event machineoutput.MachineEventWrapper
+		// the result of the push is not an event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err == nil {
+			handle(event)
+		}
+	}
+	if err = cmd.Wait(); err != nil {
+		var pushError machineoutput.GenericError
+		if json.Unmarshal(stderr.Bytes(), &pushError) == nil && pushError.Message != "" {
+			return errors.New(pushError.Message)
+		}
+		return errors.Wrapf(err, "odo push failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// restartRunCommand restarts the supervisord program of the run command, or of the debug command in debug mode
+func (do *DashboardOptions) restartRunCommand() error {
+	var command devfilev1.Command
+	var err error
+	program := string(common.DefaultDevfileRunCommand)
+	if do.EnvSpecificInfo.GetRunMode() == envinfo.Debug {
+		command, err = common.GetDebugCommand(do.devObj.Data, "")
+		program = string(common.DefaultDevfileDebugCommand)
+	} else {
+		command, err = common.GetRunCommand(do.devObj.Data, "")
+	}
+	if err != nil {
+		return err
+	}
+	if command.Exec == nil {
+		return errors.Errorf("the command %s is not an exec command", command.Id)
+	}
+
+	pod, err := do.KClient.GetOnePod(do.componentName, do.Application)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get pod for component %s", do.componentName)
+	}
+	info := common.ComponentInfo{
+		PodName:       pod.Name,
+		ContainerName: command.Exec.Component,
+	}
+	for _, ctlCommand := range []string{"stop", "start"} {
+		var output bytes.Buffer
+		cmd := []string{common.SupervisordBinaryPath, common.SupervisordCtlSubCommand, ctlCommand, program}
+		if err := do.devfileHandler.ExecCMDInContainer(info, cmd, &output, &output, nil, false); err != nil {
+			return errors.Wrapf(err, "unable to %s the program %s: %s", ctlCommand, program, strings.TrimSpace(output.String()))
+		}
+	}
+	return nil
+}
+
+// shell runs an interactive shell in the container
+func (do *DashboardOptions) shell(pod string, container string, stdin io.Reader, stdout io.Writer) error {
+	info := common.ComponentInfo{
+		PodName:       pod,
+		ContainerName: container,
+	}
+	// the standard error is written to the standard output of the terminal
+	return do.devfileHandler.ExecCMDInContainer(info, shellCommand, stdout, nil, stdin, true)
+}
+
+// NewCmdDashboard implements the dashboard odo command
+func NewCmdDashboard(name, fullName string) *cobra.Command {
+	o := NewDashboardOptions()
+
+	var dashboardCmd = &cobra.Command{
+		Use:         name,
+		Short:       "Show the live state of the component in a full-screen terminal dashboard",
+		Long:        dashboardLongDesc,
+		Example:     fmt.Sprintf(dashboardExample, fullName),
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{"command": "component"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	dashboardCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
+
+	// Adding context flag
+	genericclioptions.AddContextFlag(dashboardCmd, &o.componentContext)
+
+	dashboardCmd.Flags().StringVar(&o.watchEvents, "watch-events", "", "Event stream of odo watch to follow its syncs, the address given to its --event-stream flag")
+	dashboardCmd.Flags().DurationVar(&o.probeInterval, "probe-interval", url.URLFailureWaitTime, "Time between two probes of a URL")
+
+	//Adding `--application` flag
+	appCmd.AddApplicationFlag(dashboardCmd)
+
+	//Adding `--project` flag
+	projectCmd.AddProjectFlag(dashboardCmd)
+
+	return dashboardCmd
+}
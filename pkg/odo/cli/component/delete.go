@@ -14,6 +14,7 @@ import (
 
 	"github.com/openshift/odo/pkg/component"
 	"github.com/openshift/odo/pkg/config"
+	"github.com/openshift/odo/pkg/hooks"
 	"github.com/openshift/odo/pkg/log"
 	appCmd "github.com/openshift/odo/pkg/odo/cli/application"
 	projectCmd "github.com/openshift/odo/pkg/odo/cli/project"
@@ -150,11 +151,23 @@ func (do *DeleteOptions) s2iRun() (err error) {
 		}
 
 		if do.componentForceDeleteFlag || ui.Proceed(fmt.Sprintf("Are you sure you want to delete %v from %v?", do.componentName, do.Application)) {
+			runner := newHookRunner(do.componentContext, nil)
+			hookParams := hooks.Parameters{
+				Component:   do.componentName,
+				Application: do.Application,
+				Project:     do.Context.Project,
+			}
+			if err = runner.Run(hooks.PreDelete, hookParams); err != nil {
+				return err
+			}
 			err = component.Delete(do.Client, do.componentDeleteWaitFlag, do.componentName, do.Application)
 			if err != nil {
 				return err
 			}
 			log.Successf("Component %s from application %s has been deleted", do.componentName, do.Application)
+			if err = runner.Run(hooks.PostDelete, hookParams); err != nil {
+				return err
+			}
 
 		} else {
 			return fmt.Errorf("Aborting deletion of component: %v", do.componentName)
@@ -27,13 +27,14 @@ var describeExample = ktemplates.Examples(`  # Describe nodejs component
 
 // DescribeOptions is a dummy container to attach complete, validate and run pattern
 type DescribeOptions struct {
-	componentContext string
+	componentContext  string
+	showBindingValues bool
 	*ComponentOptions
 }
 
 // NewDescribeOptions returns new instance of ListOptions
 func NewDescribeOptions() *DescribeOptions {
-	return &DescribeOptions{"", &ComponentOptions{}}
+	return &DescribeOptions{"", false, &ComponentOptions{}}
 }
 
 // Complete completes describe args
@@ -63,6 +64,13 @@ func (do *DescribeOptions) Run(cmd *cobra.Command) (err error) {
 		return err
 	}
 
+	if do.EnvSpecificInfo != nil {
+		err = cfd.LoadInjectedBindings(do.Context.Client, do.EnvSpecificInfo.GetDevfileObj(), do.showBindingValues)
+		if err != nil {
+			return err
+		}
+	}
+
 	if log.IsJSON() {
 		machineoutput.OutputSuccess(cfd)
 	} else {
@@ -90,6 +98,7 @@ func NewCmdDescribe(name, fullName string) *cobra.Command {
 		},
 	}
 
+	describeCmd.Flags().BoolVar(&do.showBindingValues, "show-binding-values", false, "Show the values injected by the links of the component instead of masking them")
 	describeCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
 	completion.RegisterCommandHandler(describeCmd, completion.ComponentNameCompletionHandler)
 	// Adding --context flag
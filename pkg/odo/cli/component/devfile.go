@@ -10,6 +10,7 @@ import (
 
 	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/hooks"
 	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/util"
@@ -41,13 +42,14 @@ func (po *PushOptions) DevfilePush() error {
 	err := po.devfilePushInner()
 
 	if err != nil && log.IsJSON() {
-		eventLoggingClient := machineoutput.NewConsoleMachineEventLoggingClient()
+		eventLoggingClient := machineoutput.NewMachineEventLoggingClient()
 		eventLoggingClient.ReportError(err, machineoutput.TimestampNow())
 
 		// Suppress the error to prevent it from being output by the generic machine-readable handler (which will produce invalid JSON for our purposes)
 		err = nil
 
 		// os.Exit(1) since we are suppressing the generic machine-readable handler's exit code logic
+		machineoutput.StopEventStream()
 		os.Exit(1)
 	}
 
@@ -112,6 +114,21 @@ func (po *PushOptions) devfilePushInner() (err error) {
 		return err
 	}
 
+	runner := newHookRunner(po.sourcePath, po.EnvSpecificInfo)
+	hookParams := hooks.Parameters{
+		Component:   componentName,
+		Application: po.Application,
+		Project:     po.KClient.Namespace,
+		ForceBuild:  po.forceBuild,
+		Debug:       po.debugRun,
+	}
+	if err = runner.Run(hooks.PrePush, hookParams); err != nil {
+		return err
+	}
+	pushParams.PostSyncHook = func() error {
+		return runner.Run(hooks.PostSync, hookParams)
+	}
+
 	// Start or update the component
 	err = devfileHandler.Push(pushParams)
 	if err != nil {
@@ -119,9 +136,12 @@ func (po *PushOptions) devfilePushInner() (err error) {
 			componentName,
 			err,
 		)
+		hookParams.Error = err.Error()
+		_ = runner.Run(hooks.PushFailed, hookParams)
 	} else {
 		log.Infof("\nPushing devfile component %q", componentName)
 		log.Success("Changes successfully pushed to component")
+		err = runner.Run(hooks.PostPush, hookParams)
 	}
 
 	return
@@ -198,7 +218,19 @@ func (do *DeleteOptions) DevfileComponentDelete() error {
 		return err
 	}
 
-	return devfileHandler.Delete(labels, do.show, do.componentDeleteWaitFlag)
+	runner := newHookRunner(do.componentContext, do.EnvSpecificInfo)
+	hookParams := hooks.Parameters{
+		Component:   componentName,
+		Application: do.EnvSpecificInfo.GetApplication(),
+		Project:     do.namespace,
+	}
+	if err = runner.Run(hooks.PreDelete, hookParams); err != nil {
+		return err
+	}
+	if err = devfileHandler.Delete(labels, do.show, do.componentDeleteWaitFlag); err != nil {
+		return err
+	}
+	return runner.Run(hooks.PostDelete, hookParams)
 }
 
 // RunTestCommand runs the specific test command in devfile
@@ -0,0 +1,30 @@
+package component
+
+import (
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/hooks"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/util"
+	"k8s.io/klog"
+)
+
+// newHookRunner returns the runner of the local lifecycle hooks of the component of the given context directory,
+// the hooks of the preference are run before the ones of env.yaml
+func newHookRunner(componentContext string, envInfo *envinfo.EnvSpecificInfo) *hooks.Runner {
+	dir, err := util.GetAbsPath(componentContext)
+	if err != nil {
+		klog.V(4).Infof("Unable to get the absolute path of %s: %v", componentContext, err)
+		dir = componentContext
+	}
+
+	var preferenceHooks, envHooks []hooks.Hook
+	if cfg, err := preference.New(); err == nil {
+		preferenceHooks = cfg.GetHooks()
+	} else {
+		klog.V(4).Infof("Unable to read the hooks of the preference: %v", err)
+	}
+	if envInfo != nil {
+		envHooks = envInfo.GetHooks()
+	}
+	return hooks.NewRunner(dir, preferenceHooks, envHooks)
+}
@@ -42,7 +42,10 @@ var (
 
 # Link the current component to the 'EtcdCluster' named 'myetcd'
 # and make the secrets accessible as files in the '/bindings/etcd/' directory
-%[1]s EtcdCluster/myetcd  --bind-as-files --name etcd`)
+%[1]s EtcdCluster/myetcd  --bind-as-files --name etcd
+
+# Display the bindings that linking the current component to the 'EtcdCluster' named 'myetcd' would inject, without linking
+%[1]s EtcdCluster/myetcd --dry-run`)
 
 	linkLongDesc = `Link component to a service (backed by an Operator or Service Catalog) or component (works only with s2i components)
 
@@ -53,6 +56,9 @@ The source component can then consume the entries of the secret as environment v
 Using the '--bind-as-files' flag, secrets will be accessible as files instead of environment variables.
 The value of the '--name' flag indicates the name of the directory under '/bindings/' containing the secrets files.
 
+Using the '--dry-run' flag, the bindings that would be injected into the component are displayed, and the link is not created.
+Their values are masked, unless the '--show-binding-values' flag is used.
+
 For example:
 
 We have created a frontend application called 'frontend' using:
@@ -170,6 +176,8 @@ func NewCmdLink(name, fullName string) *cobra.Command {
 	linkCmd.PersistentFlags().BoolVar(&o.waitForTarget, "wait-for-target", false, "If enabled, the link command will wait for the service to be provisioned (has no effect when linking to a component)")
 	linkCmd.PersistentFlags().StringVar(&o.name, "name", "", "Name of the created ServiceBinding resource")
 	linkCmd.PersistentFlags().BoolVar(&o.bindAsFiles, "bind-as-files", false, "If enabled, configuration values will be mounted as files, instead of declared as environment variables")
+	linkCmd.PersistentFlags().BoolVar(&o.dryRun, "dry-run", false, "If enabled, display the bindings that would be injected into the component without creating the link")
+	linkCmd.PersistentFlags().BoolVar(&o.showBindingValues, "show-binding-values", false, "Show the values of the bindings displayed with --dry-run instead of masking them")
 	linkCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
 
 	//Adding `--project` flag
@@ -284,7 +284,7 @@ func NewCmdPush(name, fullName string) *cobra.Command {
 	annotations := map[string]string{"command": "component"}
 
 	pushCmdExampleText := pushCmdExample
-	annotations["machineoutput"] = "json"
+	annotations["machineoutput"] = odoutil.MachineOutputEvents
 	pushCmdExampleText += pushCmdExampleExperimentalOnly
 
 	var pushCmd = &cobra.Command{
@@ -300,6 +300,7 @@ func NewCmdPush(name, fullName string) *cobra.Command {
 	}
 
 	genericclioptions.AddContextFlag(pushCmd, &po.componentContext)
+	genericclioptions.AddEventStreamFlags(pushCmd)
 	pushCmd.Flags().BoolVar(&po.show, "show-log", false, "If enabled, logs will be shown when built")
 	pushCmd.Flags().StringSliceVar(&po.ignores, "ignore", []string{}, "Files or folders to be ignored via glob expressions.")
 	pushCmd.Flags().BoolVar(&po.pushConfig, "config", false, "Use config flag to only apply config on to cluster")
@@ -318,6 +319,9 @@ func NewCmdPush(name, fullName string) *cobra.Command {
 	pushCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
 	completion.RegisterCommandHandler(pushCmd, completion.ComponentNameCompletionHandler)
 	completion.RegisterCommandFlagHandler(pushCmd, "context", completion.FileCompletionHandler)
+	completion.RegisterLocalCommandFlagHandler(pushCmd, "build-command", completion.BuildCommandCompletionHandler)
+	completion.RegisterLocalCommandFlagHandler(pushCmd, "run-command", completion.RunCommandCompletionHandler)
+	completion.RegisterLocalCommandFlagHandler(pushCmd, "debug-command", completion.DebugCommandCompletionHandler)
 
 	return pushCmd
 }
@@ -35,6 +35,9 @@ const StatusRecommendedCommandName = "status"
 
 var statusExample = ktemplates.Examples(`  # Get the status for the nodejs component
 %[1]s nodejs -o json --follow
+
+# Get the status for the nodejs component, probing the /health path of its URLs every 10 seconds and expecting a 200 status
+%[1]s nodejs -o json --follow --probe-path /health --probe-status 200 --probe-interval 10s
 `)
 
 // StatusOptions contains status options
@@ -49,6 +52,8 @@ type StatusOptions struct {
 	devObj parser.DevfileObj
 
 	logFollow       bool
+	probeOptions    url.ProbeOptions
+	probeInterval   time.Duration
 	EnvSpecificInfo *envinfo.EnvSpecificInfo
 	localConfig     localConfigProvider.LocalConfigProvider
 	*genericclioptions.Context
@@ -113,6 +118,10 @@ func (so *StatusOptions) Validate() (err error) {
 		return fmt.Errorf("this command must be called with --follow")
 	}
 
+	if so.probeInterval <= 0 {
+		return fmt.Errorf("the probe interval must be positive")
+	}
+
 	return
 }
 
@@ -123,13 +132,13 @@ func (so *StatusOptions) Run(cmd *cobra.Command) (err error) {
 		return errors.New("the status command is only supported for devfiles")
 	}
 
-	if !log.IsJSON() {
-		return errors.New("this command only supports the '-o json' output format")
+	if !log.IsJSON() && machineoutput.GetEventStream() == nil {
+		return errors.New("this command only supports the '-o json' output format, or streaming the events with --event-stream")
 	}
 	so.devfileHandler.StartSupervisordCtlStatusWatch()
 	so.devfileHandler.StartContainerStatusWatch()
 
-	loggingClient := machineoutput.NewConsoleMachineEventLoggingClient()
+	loggingClient := machineoutput.NewMachineEventLoggingClient()
 
 	// occlient is required so that we can report the status for route URLs (eg in addition to our already testing ingress URLs for k8s)
 	oclient, err := occlient.New()
@@ -140,7 +149,7 @@ func (so *StatusOptions) Run(cmd *cobra.Command) (err error) {
 		oclient.Namespace = so.KClient.Namespace
 	}
 
-	url.StartURLHttpRequestStatusWatchForK8S(oclient, so.KClient, &so.localConfig, loggingClient)
+	url.StartURLHttpRequestStatusWatchForK8S(oclient, so.KClient, &so.localConfig, loggingClient, so.probeOptions, so.probeInterval)
 
 	// You can call Run() any time you like, but you can never leave.
 	for {
@@ -153,7 +162,7 @@ func (so *StatusOptions) Run(cmd *cobra.Command) (err error) {
 func NewCmdStatus(name, fullName string) *cobra.Command {
 	o := NewStatusOptions()
 
-	annotations := map[string]string{"command": "component", "machineoutput": "json"}
+	annotations := map[string]string{"command": "component", "machineoutput": odoutil.MachineOutputEvents}
 
 	var statusCmd = &cobra.Command{
 		Use:         fmt.Sprintf("%s [component_name]", name),
@@ -171,8 +180,13 @@ func NewCmdStatus(name, fullName string) *cobra.Command {
 
 	// Adding context flag
 	genericclioptions.AddContextFlag(statusCmd, &o.componentContext)
+	genericclioptions.AddEventStreamFlags(statusCmd)
 
 	statusCmd.Flags().BoolVarP(&o.logFollow, "follow", "f", false, "Follow the component and report all changes")
+	statusCmd.Flags().StringVar(&o.probeOptions.Path, "probe-path", "", "Path appended to the URLs of the component when probing them")
+	statusCmd.Flags().IntVar(&o.probeOptions.ExpectedStatus, "probe-status", 0, "Status code expected from a healthy URL, any 2xx or 3xx status by default")
+	statusCmd.Flags().DurationVar(&o.probeOptions.Timeout, "probe-timeout", url.DefaultProbeTimeout, "Time to wait for the response of a probed URL")
+	statusCmd.Flags().DurationVar(&o.probeInterval, "probe-interval", url.URLFailureWaitTime, "Time between two probes of a URL")
 
 	//Adding `--application` flag
 	appCmd.AddApplicationFlag(statusCmd)
@@ -97,5 +97,6 @@ func NewCmdTest(name, fullName string) *cobra.Command {
 	// Adding `--app` flag
 	appCmd.AddApplicationFlag(testCmd)
 	completion.RegisterCommandHandler(testCmd, completion.ComponentNameCompletionHandler)
+	completion.RegisterLocalCommandFlagHandler(testCmd, "test-command", completion.TestCommandCompletionHandler)
 	return testCmd
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/odo/pkg/devfile/adapters/common"
 	"github.com/openshift/odo/pkg/devfile/adapters/kubernetes"
 	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/occlient"
 	appCmd "github.com/openshift/odo/pkg/odo/cli/application"
 	projectCmd "github.com/openshift/odo/pkg/odo/cli/project"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/openshift/odo/pkg/component"
 	odoutil "github.com/openshift/odo/pkg/odo/util"
+	"github.com/openshift/odo/pkg/odo/util/completion"
 	"github.com/openshift/odo/pkg/util"
 	"github.com/openshift/odo/pkg/watch"
 	"github.com/spf13/cobra"
@@ -218,6 +220,9 @@ func (wo *WatchOptions) Run(cmd *cobra.Command) (err error) {
 				DevfileRunCmd:       strings.ToLower(wo.devfileRunCommand),
 				DevfileDebugCmd:     strings.ToLower(wo.devfileDebugCommand),
 				EnvSpecificInfo:     wo.EnvSpecificInfo,
+				Hooks:               newHookRunner(wo.componentContext, wo.EnvSpecificInfo),
+				ProjectName:         wo.namespace,
+				Logger:              machineoutput.NewMachineEventLoggingClient(),
 			},
 		)
 		if err != nil {
@@ -240,6 +245,8 @@ func (wo *WatchOptions) Run(cmd *cobra.Command) (err error) {
 			DevfileWatchHandler: nil,
 			WatchHandler:        component.PushLocal,
 			Show:                wo.show,
+			Hooks:               newHookRunner(wo.componentContext, nil),
+			ProjectName:         wo.Context.Project,
 		},
 	)
 	if err != nil {
@@ -278,9 +285,13 @@ func NewCmdWatch(name, fullName string) *cobra.Command {
 	watchCmd.Flags().StringVar(&wo.devfileBuildCommand, "build-command", "", "Devfile Build Command to execute")
 	watchCmd.Flags().StringVar(&wo.devfileRunCommand, "run-command", "", "Devfile Run Command to execute")
 	watchCmd.Flags().StringVar(&wo.devfileDebugCommand, "debug-command", "", "Devfile Debug Command to execute")
+	completion.RegisterLocalCommandFlagHandler(watchCmd, "build-command", completion.BuildCommandCompletionHandler)
+	completion.RegisterLocalCommandFlagHandler(watchCmd, "run-command", completion.RunCommandCompletionHandler)
+	completion.RegisterLocalCommandFlagHandler(watchCmd, "debug-command", completion.DebugCommandCompletionHandler)
 
 	// Adding context flag
 	genericclioptions.AddContextFlag(watchCmd, &wo.componentContext)
+	genericclioptions.AddEventStreamFlags(watchCmd)
 
 	//Adding `--application` flag
 	appCmd.AddApplicationFlag(watchCmd)
@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/openshift/odo/pkg/daemon"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/odo/util"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// RecommendedCommandName is the recommended daemon command name
+const RecommendedCommandName = "daemon"
+
+var daemonLongDesc = ktemplates.LongDesc(`Run a local server for the editors and the tools driving odo
+
+	The server keeps a client of the cluster per project and the parsed devfile and env.yaml of each
+	context directory across the requests, the configuration is parsed again when it changes.
+	It listens on a loopback address, or on a Unix domain socket with --address unix:<path>.
+
+	The requests are authenticated with a token generated for each run of the daemon, written to a file readable
+	by the user only, ~/.odo/daemon-token by default: the clients send it as a bearer token in the Authorization
+	header. The requests with a Host or an Origin header that is not localhost or a loopback address are refused.
+
+	The endpoints of the devfile components take the context directory of the component as the context parameter:
+
+	  GET  /api/v1/health                                        the version of odo
+	  GET  /api/v1/catalog/components[?registry=<name>]          the devfile components of the registries
+	  GET  /api/v1/urls?context=<dir>                            the URLs of the component, as 'odo url list -o json'
+	  POST /api/v1/push?context=<dir>[&force&debug&buildCommand&runCommand&debugCommand]
+	                                                             push the component, streaming the events of 'odo push -o json'
+	                                                             as JSON lines, the error of a failed push is the last event
+	  GET  /api/v1/status?context=<dir>[&follow=true&interval=5s]
+	                                                             the status of the component, followed as JSON lines
+	  GET  /api/v1/logs?context=<dir>[&follow=true&debug=true]   the logs of the component, as 'odo log'
+
+	The failed requests are answered with a JSON object with the error.`)
+
+var daemonExample = ktemplates.Examples(`  # Run the daemon on the default address
+  %[1]s
+
+  # Run the daemon on a Unix domain socket, and push a component with it
+  %[1]s --address unix:/tmp/odo.sock
+  curl --unix-socket /tmp/odo.sock -H "Authorization: Bearer $(cat ~/.odo/daemon-token)" -X POST "http://odo/api/v1/push?context=$PWD"
+	`)
+
+// DaemonOptions encapsulates the options for the odo daemon command
+type DaemonOptions struct {
+	address   string
+	tokenFile string
+	server    *daemon.Server
+}
+
+// NewDaemonOptions creates a new DaemonOptions instance
+func NewDaemonOptions() *DaemonOptions {
+	return &DaemonOptions{}
+}
+
+// Complete completes DaemonOptions after they've been created
+func (o *DaemonOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	if o.tokenFile == "" {
+		cfg, err := preference.New()
+		if err != nil {
+			return err
+		}
+		o.tokenFile = cfg.GetDaemonTokenFile()
+	}
+	return
+}
+
+// Validate validates the DaemonOptions based on completed values
+func (o *DaemonOptions) Validate() (err error) {
+	o.server, err = daemon.New(o.address, o.tokenFile)
+	return err
+}
+
+// Run contains the logic for the odo daemon command
+func (o *DaemonOptions) Run(cmd *cobra.Command) (err error) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-signals
+		log.Info("\nStopping the daemon")
+		if err := o.server.Shutdown(5 * time.Second); err != nil {
+			log.Warningf("Unable to stop the daemon cleanly: %v", err)
+		}
+	}()
+
+	log.Infof("The daemon is listening on %s, press Ctrl+c to stop it", o.server.Address())
+	log.Infof("The token of the requests is written to %s", o.server.TokenFile())
+	if err = o.server.Serve(); err != nil {
+		return err
+	}
+	// the requests in progress are completed
+	<-stopped
+	return nil
+}
+
+// NewCmdDaemon implements the daemon odo command
+func NewCmdDaemon(name, fullName string) *cobra.Command {
+	o := NewDaemonOptions()
+	daemonCmd := &cobra.Command{
+		Use:     name,
+		Short:   "Run a local server for the editors and the tools driving odo",
+		Long:    daemonLongDesc,
+		Example: fmt.Sprintf(daemonExample, fullName),
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	daemonCmd.Annotations = map[string]string{"command": "utility"}
+	daemonCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	daemonCmd.Flags().StringVar(&o.address, "address", daemon.DefaultAddress, "Address of the daemon, http://<loopback address>:<port> or unix:<path>")
+	daemonCmd.Flags().StringVar(&o.tokenFile, "token-file", "", "File the token of the requests is written to, ~/.odo/daemon-token by default")
+
+	return daemonCmd
+}
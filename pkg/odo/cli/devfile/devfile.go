@@ -0,0 +1,30 @@
+package devfile
+
+import (
+	"github.com/openshift/odo/pkg/odo/util"
+
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// RecommendedCommandName is the recommended devfile command name
+const RecommendedCommandName = "devfile"
+
+var devfileLongDesc = ktemplates.LongDesc(`Manage the devfile of the component`)
+
+// NewCmdDevfile implements the devfile odo command
+func NewCmdDevfile(name, fullName string) *cobra.Command {
+	devfileUpgradeCmd := NewCmdUpgrade(upgradeCommandName, util.GetFullName(fullName, upgradeCommandName))
+	devfileCmd := &cobra.Command{
+		Use:     name,
+		Short:   "Manage the devfile of the component",
+		Long:    devfileLongDesc,
+		Example: devfileUpgradeCmd.Example,
+	}
+
+	devfileCmd.AddCommand(devfileUpgradeCmd)
+	devfileCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	devfileCmd.Annotations = map[string]string{"command": "main"}
+
+	return devfileCmd
+}
@@ -0,0 +1,279 @@
+package devfile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/odo/pkg/catalog"
+	"github.com/openshift/odo/pkg/devfile"
+	"github.com/openshift/odo/pkg/devfile/upgrade"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/preference"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const (
+	upgradeCommandName = "upgrade"
+
+	devfileName = "devfile.yaml"
+	upgradeKind = "DevfileUpgrade"
+)
+
+var (
+	upgradeLongDesc = ktemplates.LongDesc(`Upgrade the devfile of the component to another version of its registry stack
+
+	The changes between the version of the stack the devfile was created from and the new version
+	are merged into the devfile, keeping the local changes. A value changed both locally and by the new
+	version is a conflict, its local value is kept and the conflict is reported.
+	The comments of the devfile are not kept.`)
+
+	upgradeExample = ktemplates.Examples(`# Upgrade the devfile to the default version of its stack
+	%[1]s
+
+	# Show the changes of an upgrade to the version 2.0.0 of the stack without applying them
+	%[1]s --version 2.0.0 --dry-run
+	`)
+)
+
+// DevfileUpgrade is the machine readable output of "odo devfile upgrade"
+type DevfileUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DevfileUpgradeSpec `json:"spec"`
+}
+
+// DevfileUpgradeSpec describes the upgrade of a devfile to another version of its stack
+type DevfileUpgradeSpec struct {
+	Registry    string             `json:"registry"`
+	Stack       string             `json:"stack"`
+	FromVersion string             `json:"fromVersion"`
+	ToVersion   string             `json:"toVersion"`
+	Diff        string             `json:"diff"`
+	Conflicts   []upgrade.Conflict `json:"conflicts,omitempty"`
+	Applied     bool               `json:"applied"`
+}
+
+// UpgradeOptions encapsulates the options for the odo devfile upgrade command
+type UpgradeOptions struct {
+	componentContext string
+	version          string
+	dryRun           bool
+	// verify is the verification policy of the devfile of the stack, the one of the preference if empty
+	verify string
+
+	envInfo  *envinfo.EnvSpecificInfo
+	stack    envinfo.StackSource
+	registry catalog.Registry
+}
+
+// NewUpgradeOptions creates a new UpgradeOptions instance
+func NewUpgradeOptions() *UpgradeOptions {
+	return &UpgradeOptions{}
+}
+
+// Complete completes UpgradeOptions after they've been created
+func (o *UpgradeOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.envInfo, err = envinfo.NewEnvSpecificInfo(o.componentContext)
+	if err != nil {
+		return errors.Wrap(err, "failed to load environment file")
+	}
+	return nil
+}
+
+// Validate validates the UpgradeOptions based on completed values
+func (o *UpgradeOptions) Validate() (err error) {
+	if o.verify != "" {
+		if err = preference.ValidateVerifyPolicy(o.verify); err != nil {
+			return errors.Wrap(err, "invalid --verify")
+		}
+	}
+	if !o.envInfo.Exists() {
+		return errors.Errorf("the context directory doesn't contain a component, please refer `odo create --help` to create a component")
+	}
+	stack := o.envInfo.GetStack()
+	if stack == nil {
+		return errors.New("the environment file doesn't record the registry stack the devfile was created from, only the devfiles created from a registry stack with `odo create <stack>` can be upgraded")
+	}
+	o.stack = *stack
+
+	registries, err := catalog.GetDevfileRegistries(o.stack.Registry)
+	if err != nil {
+		return err
+	}
+	if len(registries) == 0 {
+		return errors.Errorf("registry %s of stack %s doesn't exist", o.stack.Registry, o.stack.Name)
+	}
+	o.registry = registries[0]
+	if registryUtil.IsGitBasedRegistry(o.registry.URL) {
+		return errors.Errorf("registry %s is a GitHub based registry, which doesn't version its stacks", o.registry.Name)
+	}
+
+	stacks, err := catalog.GetRegistryStacks(o.registry)
+	if err != nil {
+		return err
+	}
+	for _, s := range stacks {
+		if s.Name != o.stack.Name {
+			continue
+		}
+		if o.version == "" {
+			o.version = s.DefaultVersion()
+		}
+		_, err = s.GetVersion(o.version)
+		return err
+	}
+	return errors.Errorf("stack %s does not exist in the registry %s", o.stack.Name, o.registry.Name)
+}
+
+// Run contains the logic for the odo devfile upgrade command
+func (o *UpgradeOptions) Run(cmd *cobra.Command) (err error) {
+	if o.version == o.stack.Version && !log.IsJSON() {
+		log.Infof("The devfile is already at version %s of stack %s", o.version, o.stack.Name)
+		return nil
+	}
+
+	s := log.Spinnerf("Downloading version %s of stack %s", o.version, o.stack.Name)
+	upstream, err := catalog.GetRegistryDevfile(o.registry, o.stack.Name, o.version)
+	s.End(err == nil)
+	if err != nil {
+		return err
+	}
+	verifier, err := catalog.NewArtifactVerifier(o.registry, o.stack.Name, o.version, o.verify)
+	if err != nil {
+		return err
+	}
+	if err = verifier.Verify(catalog.DevfileArtifact, upstream); err != nil {
+		return err
+	}
+	base, err := o.baseDevfile()
+	if err != nil {
+		return err
+	}
+	devfilePath := filepath.Join(o.componentContext, devfileName)
+	local, err := ioutil.ReadFile(devfilePath)
+	if err != nil {
+		return errors.Wrap(err, "unable to read the devfile of the component")
+	}
+
+	merged, conflicts, err := upgrade.Merge(base, local, upstream)
+	if err != nil {
+		return err
+	}
+	if _, err = devfile.ParseFromData(merged); err != nil {
+		return errors.Wrapf(err, "the devfile upgraded to version %s of stack %s is invalid", o.version, o.stack.Name)
+	}
+	formattedLocal, err := upgrade.Format(local)
+	if err != nil {
+		return err
+	}
+	diff, err := upgrade.Diff(devfileName, fmt.Sprintf("%s (%s %s)", devfileName, o.stack.Name, o.version), formattedLocal, merged)
+	if err != nil {
+		return err
+	}
+
+	if !o.dryRun {
+		if err = o.apply(devfilePath, merged, upstream); err != nil {
+			return err
+		}
+	}
+
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(DevfileUpgrade{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       upgradeKind,
+				APIVersion: machineoutput.APIVersion,
+			},
+			Spec: DevfileUpgradeSpec{
+				Registry:    o.registry.Name,
+				Stack:       o.stack.Name,
+				FromVersion: o.stack.Version,
+				ToVersion:   o.version,
+				Diff:        diff,
+				Conflicts:   conflicts,
+				Applied:     !o.dryRun,
+			},
+		})
+		return nil
+	}
+
+	if diff == "" {
+		log.Info("The devfile is unchanged")
+	} else {
+		fmt.Print(diff)
+	}
+	for _, conflict := range conflicts {
+		log.Warningf("%s was changed both locally and by version %s of stack %s, keeping the local value %v instead of %v", conflict.Path, o.version, o.stack.Name, conflict.Local, conflict.Upstream)
+	}
+	if o.dryRun {
+		log.Italicf("Run the command without --dry-run to upgrade the devfile")
+		return nil
+	}
+	log.Successf("Upgraded the devfile from version %s to version %s of stack %s", o.stack.Version, o.version, o.stack.Name)
+	return nil
+}
+
+// baseDevfile returns the unmodified devfile of the version of the stack the devfile was created from
+func (o *UpgradeOptions) baseDevfile() ([]byte, error) {
+	base, err := ioutil.ReadFile(o.envInfo.GetStackDevfilePath())
+	if err == nil {
+		return base, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	base, err = catalog.GetRegistryDevfile(o.registry, o.stack.Name, o.stack.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get version %s of stack %s the devfile was created from", o.stack.Version, o.stack.Name)
+	}
+	return base, nil
+}
+
+// apply writes the merged devfile, and records the new version of the stack as the base of the next upgrade
+func (o *UpgradeOptions) apply(devfilePath string, merged []byte, upstream []byte) error {
+	info, err := os.Stat(devfilePath)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(devfilePath, merged, info.Mode()); err != nil {
+		return errors.Wrap(err, "unable to write the upgraded devfile")
+	}
+	if err = ioutil.WriteFile(o.envInfo.GetStackDevfilePath(), upstream, 0640); err != nil {
+		return errors.Wrapf(err, "unable to save the devfile of stack %s", o.stack.Name)
+	}
+	stack := o.stack
+	stack.Version = o.version
+	return o.envInfo.SetStack(stack)
+}
+
+// NewCmdUpgrade implements the odo devfile upgrade command
+func NewCmdUpgrade(name, fullName string) *cobra.Command {
+	o := NewUpgradeOptions()
+	upgradeCmd := &cobra.Command{
+		Use:         name,
+		Short:       "Upgrade the devfile to another version of its stack",
+		Long:        upgradeLongDesc,
+		Example:     fmt.Sprintf(upgradeExample, fullName),
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	upgradeCmd.Flags().StringVar(&o.version, "version", "", "Version of the stack to upgrade the devfile to, the default version of the stack if not specified")
+	upgradeCmd.Flags().BoolVar(&o.dryRun, "dry-run", false, "Show the changes of the upgrade without applying them")
+	upgradeCmd.Flags().StringVar(&o.verify, "verify", "", "Verification of the devfile of the stack against the digests and signatures of the registry: off, warn or enforce (Default: the Verify preference)")
+	genericclioptions.AddContextFlag(upgradeCmd, &o.componentContext)
+
+	return upgradeCmd
+}
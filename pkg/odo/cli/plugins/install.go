@@ -0,0 +1,129 @@
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/plugins"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/version"
+
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const installCommandName = "install"
+
+var (
+	installLongDesc = ktemplates.LongDesc(`Install a plugin from the plugin index, or from a directory holding its manifest plugin.yaml and its executable odo-<name>
+
+	The digest of the executable downloaded from the plugin index is verified before the plugin is installed.
+	An installed version of the plugin is replaced.`)
+
+	installExample = ktemplates.Examples(`# Install the latest version of a plugin from the plugin index
+	%[1]s hello
+
+	# Install a version of a plugin from a plugin index
+	%[1]s hello@1.0.0 --index https://example.com/plugins/index.json
+
+	# Install the plugin of a directory
+	%[1]s ./odo-hello
+	`)
+)
+
+// InstallOptions encapsulates the options for the odo plugin install command
+type InstallOptions struct {
+	indexFlag string
+
+	manager *plugins.Manager
+	// dir is the directory of the plugin to install, empty when installed from the plugin index
+	dir   string
+	entry plugins.IndexEntry
+}
+
+// NewInstallOptions creates a new InstallOptions instance
+func NewInstallOptions() *InstallOptions {
+	return &InstallOptions{}
+}
+
+// Complete completes InstallOptions after they've been created
+func (o *InstallOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return err
+	}
+	o.manager = plugins.NewManager(cfg.GetPluginsDir())
+
+	if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+		o.dir = args[0]
+		return nil
+	}
+
+	pluginName, pluginVersion := args[0], ""
+	if i := strings.LastIndex(args[0], "@"); i >= 0 {
+		pluginName, pluginVersion = args[0][:i], args[0][i+1:]
+	}
+	index, err := getIndex(cfg, o.indexFlag)
+	if err != nil {
+		return err
+	}
+	o.entry, err = plugins.FindInIndex(index, pluginName, pluginVersion)
+	return err
+}
+
+// Validate validates the InstallOptions based on completed values
+func (o *InstallOptions) Validate() (err error) {
+	if o.dir != "" {
+		if o.indexFlag != "" {
+			return fmt.Errorf("--index can't be used to install the plugin of a directory")
+		}
+		// checked before the installed version of the plugin is replaced
+		manifest, err := plugins.ReadDirManifest(o.dir)
+		if err != nil {
+			return err
+		}
+		return manifest.CheckOdoVersion(version.VERSION)
+	}
+	return o.entry.CheckOdoVersion(version.VERSION)
+}
+
+// Run contains the logic for the odo plugin install command
+func (o *InstallOptions) Run(cmd *cobra.Command) (err error) {
+	var plugin plugins.Plugin
+	if o.dir != "" {
+		plugin, err = o.manager.InstallDir(o.dir)
+	} else {
+		s := log.Spinnerf("Installing plugin %s %s", o.entry.Name, o.entry.Version)
+		defer s.End(false)
+		plugin, err = o.manager.Install(o.entry)
+		if err == nil {
+			s.End(true)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Successf("Plugin %s %s installed, run it with 'odo %s'", plugin.Name, plugin.Version, plugin.Name)
+	return nil
+}
+
+// NewCmdInstall implements the odo plugin install command
+func NewCmdInstall(name, fullName string) *cobra.Command {
+	o := NewInstallOptions()
+	pluginInstallCmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s <plugin name>[@<version>] | <directory>", name),
+		Short:   "Install a plugin",
+		Long:    installLongDesc,
+		Example: fmt.Sprintf(installExample, fullName),
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	pluginInstallCmd.Flags().StringVar(&o.indexFlag, "index", "", "URL of the plugin index, overriding the PluginIndex preference")
+	return pluginInstallCmd
+}
@@ -0,0 +1,186 @@
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"text/tabwriter"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/plugins"
+	"github.com/openshift/odo/pkg/preference"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const listCommandName = "list"
+
+var (
+	listLongDesc = ktemplates.LongDesc(`List the installed plugins and the plugins found in the PATH, or the plugins available in the plugin index`)
+
+	listExample = ktemplates.Examples(`# List the installed plugins and the plugins found in the PATH
+	%[1]s
+
+	# List the plugins available for this platform in the plugin index
+	%[1]s --available
+	`)
+)
+
+// PluginList is the machine readable output of "odo plugin list"
+type PluginList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []plugins.Plugin `json:"items"`
+}
+
+// PluginIndexList is the machine readable output of "odo plugin list --available"
+type PluginIndexList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []plugins.IndexEntry `json:"items"`
+}
+
+// ListOptions encapsulates the options for the odo plugin list command
+type ListOptions struct {
+	availableFlag bool
+	indexFlag     string
+
+	manager   *plugins.Manager
+	available []plugins.IndexEntry
+}
+
+// NewListOptions creates a new ListOptions instance
+func NewListOptions() *ListOptions {
+	return &ListOptions{}
+}
+
+// Complete completes ListOptions after they've been created
+func (o *ListOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return err
+	}
+	o.manager = plugins.NewManager(cfg.GetPluginsDir())
+	if !o.availableFlag {
+		return nil
+	}
+
+	index, err := getIndex(cfg, o.indexFlag)
+	if err != nil {
+		return err
+	}
+	for _, entry := range index {
+		if _, err := entry.Platform(runtime.GOOS, runtime.GOARCH); err == nil {
+			o.available = append(o.available, entry)
+		}
+	}
+	return nil
+}
+
+// Validate validates the ListOptions based on completed values
+func (o *ListOptions) Validate() (err error) {
+	if o.indexFlag != "" && !o.availableFlag {
+		return fmt.Errorf("--index can only be used with --available")
+	}
+	return nil
+}
+
+// Run contains the logic for the odo plugin list command
+func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
+	if o.availableFlag {
+		return o.listAvailable()
+	}
+
+	list, err := o.manager.List()
+	if err != nil {
+		return err
+	}
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(PluginList{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "List",
+				APIVersion: machineoutput.APIVersion,
+			},
+			Items: list,
+		})
+		return nil
+	}
+
+	if len(list) == 0 {
+		log.Info("No plugins found, install plugins with 'odo plugin install'")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "NAME", "\t", "VERSION", "\t", "SOURCE", "\t", "DESCRIPTION")
+	for _, plugin := range list {
+		fmt.Fprintln(w, plugin.Name, "\t", orDash(plugin.Version), "\t", plugin.Source, "\t", orDash(plugin.Description))
+	}
+	w.Flush()
+	return nil
+}
+
+func (o *ListOptions) listAvailable() error {
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(PluginIndexList{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "List",
+				APIVersion: machineoutput.APIVersion,
+			},
+			Items: o.available,
+		})
+		return nil
+	}
+
+	if len(o.available) == 0 {
+		log.Infof("No plugins available for %s/%s in the plugin index", runtime.GOOS, runtime.GOARCH)
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "NAME", "\t", "VERSION", "\t", "MIN ODO VERSION", "\t", "DESCRIPTION")
+	for _, entry := range o.available {
+		fmt.Fprintln(w, entry.Name, "\t", entry.Version, "\t", orDash(entry.MinOdoVersion), "\t", orDash(entry.Description))
+	}
+	w.Flush()
+	return nil
+}
+
+// getIndex downloads the plugin index of the given URL, of the PluginIndex preference if the URL is empty
+func getIndex(cfg *preference.PreferenceInfo, indexURL string) ([]plugins.IndexEntry, error) {
+	if indexURL == "" {
+		indexURL = cfg.GetPluginIndex()
+	}
+	if indexURL == "" {
+		return nil, fmt.Errorf("no plugin index configured, use the --index flag or set it with 'odo preference set %s <URL>'", preference.PluginIndexSetting)
+	}
+	return plugins.GetIndex(indexURL)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// NewCmdList implements the odo plugin list command
+func NewCmdList(name, fullName string) *cobra.Command {
+	o := NewListOptions()
+	pluginListCmd := &cobra.Command{
+		Use:         name,
+		Short:       "List the odo plugins",
+		Long:        listLongDesc,
+		Example:     fmt.Sprintf(listExample, fullName),
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	pluginListCmd.Flags().BoolVar(&o.availableFlag, "available", false, "List the plugins available for this platform in the plugin index instead")
+	pluginListCmd.Flags().StringVar(&o.indexFlag, "index", "", "URL of the plugin index, overriding the PluginIndex preference")
+	return pluginListCmd
+}
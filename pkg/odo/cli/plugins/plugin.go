@@ -0,0 +1,64 @@
+package plugins
+
+import (
+	"fmt"
+
+	"github.com/openshift/odo/pkg/odo/util"
+
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// RecommendedCommandName is the recommended plugin command name
+const RecommendedCommandName = "plugin"
+
+var pluginLongDesc = ktemplates.LongDesc(`Manage the odo plugins
+
+	A plugin is an executable named odo-<name>, run by "odo <name>". The plugins are found
+	in the plugins directory of odo, where "odo plugin install" installs them, then in the PATH.
+
+	An installed plugin is described by the manifest plugin.yaml:
+
+	  name: hello            # the name of the plugin, the executable is odo-hello
+	  version: 1.0.0         # the semantic version of the plugin
+	  description: Greets    # optional
+	  minOdoVersion: 2.1.0   # optional, the oldest version of odo the plugin works with
+
+	A plugin index is a JSON array of the versions of the plugins, hosted at an http(s):// or file:// URL,
+	each version has the fields of the manifest and the executables of the platforms:
+
+	  [{"name": "hello", "version": "1.0.0", "description": "Greets", "minOdoVersion": "2.1.0",
+	    "platforms": [{"os": "linux", "arch": "amd64", "url": "https://example.com/odo-hello", "digest": "sha256:<hex>"}]}]
+
+	The plugins are run in the current directory with the following environment variables, empty when not resolved:
+
+	  ODO_BINARY        the path of the odo executable
+	  ODO_VERSION       the version of odo
+	  ODO_CONTEXT       the absolute path of the current directory, the component context
+	  ODO_DEVFILE       the absolute path of the devfile of the component, for devfile components
+	  ODO_COMPONENT     the name of the component
+	  ODO_APPLICATION   the application of the component
+	  ODO_PROJECT       the project of the component, the current project otherwise`)
+
+// NewCmdPlugin implements the plugin odo command
+func NewCmdPlugin(name, fullName string) *cobra.Command {
+	pluginListCmd := NewCmdList(listCommandName, util.GetFullName(fullName, listCommandName))
+	pluginInstallCmd := NewCmdInstall(installCommandName, util.GetFullName(fullName, installCommandName))
+	pluginRemoveCmd := NewCmdRemove(removeCommandName, util.GetFullName(fullName, removeCommandName))
+	pluginCmd := &cobra.Command{
+		Use:   name,
+		Short: "Manage the odo plugins",
+		Long:  pluginLongDesc,
+		Example: fmt.Sprintf("%s\n\n%s\n\n%s",
+			pluginListCmd.Example,
+			pluginInstallCmd.Example,
+			pluginRemoveCmd.Example,
+		),
+	}
+
+	pluginCmd.AddCommand(pluginListCmd, pluginInstallCmd, pluginRemoveCmd)
+	pluginCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	pluginCmd.Annotations = map[string]string{"command": "utility"}
+
+	return pluginCmd
+}
@@ -7,31 +7,62 @@ import (
 	"runtime"
 	"strings"
 	"syscall"
+
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/plugins"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/version"
 )
 
 // HandleCommand receives a PluginHandler and command-line arguments and attempts to find
-// a plugin executable on the PATH that satisfies the given arguments.
+// a plugin executable that satisfies the given arguments, the plugin is executed with the
+// ODO_* environment variables describing the context resolved from the working directory
 func HandleCommand(handler PluginHandler, args []string) error {
 	foundBinary, remaining := findBinary(handler, args)
 	if foundBinary == "" {
 		return nil
 	}
 
-	if err := handler.Execute(foundBinary, args[len(remaining):], os.Environ()); err != nil {
+	if err := handler.Execute(foundBinary, args[len(remaining):], pluginEnv()); err != nil {
 		return err
 	}
 	return nil
 }
 
+// pluginEnv returns the environment of odo with the ODO_* variables describing the resolved context,
+// the values inherited from the environment of odo are dropped so that the ones of the context are not shadowed
+func pluginEnv() []string {
+	var env []string
+	for _, variable := range os.Environ() {
+		if !isPluginEnvVar(variable) {
+			env = append(env, variable)
+		}
+	}
+	return append(env, genericclioptions.NewPluginContext("").PluginEnv()...)
+}
+
+func isPluginEnvVar(variable string) bool {
+	for _, name := range genericclioptions.PluginEnvVars {
+		if strings.HasPrefix(variable, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 type execFunc func(string, []string, []string) (err error)
 
 // NewExecHandler creates and returns a new ExecHandler configured with
-// the prefix.
+// the prefix, finding the plugins installed with "odo plugin install" before the ones on the PATH.
 func NewExecHandler(prefix string) *ExecHandler {
-	return &ExecHandler{
+	handler := &ExecHandler{
 		Prefix: prefix,
 		Exec:   syscall.Exec,
 	}
+	if cfg, err := preference.New(); err == nil {
+		handler.Plugins = plugins.NewManager(cfg.GetPluginsDir())
+	}
+	return handler
 }
 
 // PluginHandler provides functionality for finding and executing external
@@ -49,11 +80,18 @@ type PluginHandler interface {
 type ExecHandler struct {
 	Prefix string
 	Exec   execFunc
+	// Plugins are the plugins installed with "odo plugin install"
+	Plugins *plugins.Manager
 }
 
-// Lookup implements PluginHandler, using
+// Lookup implements PluginHandler, looking for an installed plugin, then using
 // https://golang.org/pkg/os/exec/#LookPath to search for the command.
 func (h *ExecHandler) Lookup(command string) string {
+	if h.Plugins != nil {
+		if path := h.Plugins.Lookup(command); path != "" {
+			return path
+		}
+	}
 	if runtime.GOOS == "windows" {
 		command = command + ".exe"
 	}
@@ -64,8 +102,13 @@ func (h *ExecHandler) Lookup(command string) string {
 	return ""
 }
 
-// Execute implements PluginHandler.Execute
+// Execute implements PluginHandler.Execute, refusing the installed plugins requiring a newer odo
 func (h *ExecHandler) Execute(filename string, args, env []string) error {
+	if h.Plugins != nil {
+		if err := h.Plugins.CheckOdoVersion(filename, version.VERSION); err != nil {
+			return err
+		}
+	}
 	// Windows does not support exec syscall.
 	if runtime.GOOS == "windows" {
 		cmd := exec.Command(filename, args...)
@@ -0,0 +1,78 @@
+package plugins
+
+import (
+	"fmt"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/plugins"
+	"github.com/openshift/odo/pkg/preference"
+
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const removeCommandName = "remove"
+
+var (
+	removeLongDesc = ktemplates.LongDesc(`Remove a plugin installed with 'odo plugin install'`)
+
+	removeExample = ktemplates.Examples(`# Remove an installed plugin
+	%[1]s hello
+	`)
+)
+
+// RemoveOptions encapsulates the options for the odo plugin remove command
+type RemoveOptions struct {
+	pluginName string
+	manager    *plugins.Manager
+}
+
+// NewRemoveOptions creates a new RemoveOptions instance
+func NewRemoveOptions() *RemoveOptions {
+	return &RemoveOptions{}
+}
+
+// Complete completes RemoveOptions after they've been created
+func (o *RemoveOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.pluginName = args[0]
+	cfg, err := preference.New()
+	if err != nil {
+		return err
+	}
+	o.manager = plugins.NewManager(cfg.GetPluginsDir())
+	return nil
+}
+
+// Validate validates the RemoveOptions based on completed values
+func (o *RemoveOptions) Validate() (err error) {
+	if o.manager.Lookup(o.pluginName) == "" {
+		return fmt.Errorf("plugin %s is not installed, the plugins found in the PATH can't be removed by odo", o.pluginName)
+	}
+	return nil
+}
+
+// Run contains the logic for the odo plugin remove command
+func (o *RemoveOptions) Run(cmd *cobra.Command) (err error) {
+	if err = o.manager.Remove(o.pluginName); err != nil {
+		return err
+	}
+	log.Successf("Plugin %s removed", o.pluginName)
+	return nil
+}
+
+// NewCmdRemove implements the odo plugin remove command
+func NewCmdRemove(name, fullName string) *cobra.Command {
+	o := NewRemoveOptions()
+	pluginRemoveCmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s <plugin name>", name),
+		Short:   "Remove an installed plugin",
+		Long:    removeLongDesc,
+		Example: fmt.Sprintf(removeExample, fullName),
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	return pluginRemoveCmd
+}
@@ -0,0 +1,132 @@
+package preference
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/preference"
+	odoutil "github.com/openshift/odo/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const diagnoseCommandName = "diagnose"
+
+var (
+	diagnoseLongDesc = ktemplates.LongDesc(`Show the network settings in effect for the outbound HTTP requests of odo
+
+	The proxy, the certificate authorities and the client certificate are the ones of the preference,
+	the proxy falls back to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+	The given URL is requested with these settings, to check that it can be reached, the command fails when it can't.`)
+
+	diagnoseExample = ktemplates.Examples(`# Show the network settings and check that the default devfile registry can be reached
+	%[1]s
+
+	# Check that a private registry can be reached
+	%[1]s --url https://registry.example.com
+	`)
+)
+
+// NetworkDiagnostic is the machine readable output of "odo preference diagnose"
+type NetworkDiagnostic struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              odoutil.HTTPTransportDiagnostic `json:"spec"`
+}
+
+// DiagnoseOptions encapsulates the options for the command
+type DiagnoseOptions struct {
+	url string
+}
+
+// NewDiagnoseOptions creates a new DiagnoseOptions instance
+func NewDiagnoseOptions() *DiagnoseOptions {
+	return &DiagnoseOptions{}
+}
+
+// Complete completes DiagnoseOptions after they've been created
+func (o *DiagnoseOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	return
+}
+
+// Validate validates the DiagnoseOptions based on completed values
+func (o *DiagnoseOptions) Validate() (err error) {
+	return
+}
+
+// Run contains the logic for the command
+func (o *DiagnoseOptions) Run(cmd *cobra.Command) (err error) {
+	diagnostic := odoutil.DiagnoseHTTPTransport(o.url)
+
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(NetworkDiagnostic{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "NetworkDiagnostic",
+				APIVersion: machineoutput.APIVersion,
+			},
+			Spec: diagnostic,
+		})
+		return diagnosticError(diagnostic)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 5, 2, 2, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "SETTING", "\t", "VALUE")
+	fmt.Fprintln(w, "Proxy", "\t", valueOrNone(diagnostic.Proxy), fmt.Sprintf("(from %s)", diagnostic.ProxySource))
+	fmt.Fprintln(w, "NoProxy", "\t", valueOrNone(diagnostic.NoProxy))
+	fmt.Fprintln(w, "CABundle", "\t", valueOrNone(diagnostic.CABundle))
+	if diagnostic.ClientCertificateExpiry != nil {
+		fmt.Fprintln(w, "ClientCertificate", "\t", diagnostic.ClientCertificate, fmt.Sprintf("(expires %s)", diagnostic.ClientCertificateExpiry.Format("2006-01-02")))
+	} else {
+		fmt.Fprintln(w, "ClientCertificate", "\t", "none")
+	}
+	w.Flush()
+
+	if diagnostic.URLProxy != "" {
+		log.Infof("%s is reached through the proxy %s", diagnostic.URL, diagnostic.URLProxy)
+	} else {
+		log.Infof("%s is reached without proxy", diagnostic.URL)
+	}
+	if err = diagnosticError(diagnostic); err != nil {
+		return err
+	}
+	log.Successf("%s responded %s", diagnostic.URL, diagnostic.Status)
+	return
+}
+
+// diagnosticError returns the error of the failed check of the diagnostic, so that the command fails
+func diagnosticError(diagnostic odoutil.HTTPTransportDiagnostic) error {
+	if diagnostic.Error != "" {
+		return fmt.Errorf("unable to reach %s: %s", diagnostic.URL, diagnostic.Error)
+	}
+	return nil
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// NewCmdDiagnose implements the preference diagnose odo command
+func NewCmdDiagnose(name, fullName string) *cobra.Command {
+	o := NewDiagnoseOptions()
+	preferenceDiagnoseCmd := &cobra.Command{
+		Use:         name,
+		Short:       "Show the network settings in effect",
+		Long:        diagnoseLongDesc,
+		Example:     fmt.Sprintf(fmt.Sprint("\n", diagnoseExample), fullName),
+		Annotations: map[string]string{"machineoutput": "json"},
+		Args:        cobra.ExactArgs(0),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	preferenceDiagnoseCmd.Flags().StringVar(&o.url, "url", preference.DefaultDevfileRegistryURL, "URL to check that it can be reached")
+	return preferenceDiagnoseCmd
+}
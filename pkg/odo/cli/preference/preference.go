@@ -15,6 +15,9 @@ const RecommendedCommandName = "preference"
 
 var preferenceLongDesc = ktemplates.LongDesc(`Modifies odo specific configuration settings within the global preference file.
 
+The .odo/preference.yaml file of the repository is layered over the global preference file, and the ODO_<PARAMETER>
+environment variables, such as ODO_PUSH_TIMEOUT, are layered over both.
+
 %[1]s`)
 
 // NewCmdPreference implements the utils config odo command
@@ -22,19 +25,21 @@ func NewCmdPreference(name, fullName string) *cobra.Command {
 	preferenceViewCmd := NewCmdView(viewCommandName, util.GetFullName(fullName, viewCommandName))
 	preferenceSetCmd := NewCmdSet(setCommandName, util.GetFullName(fullName, setCommandName))
 	preferenceUnsetCmd := NewCmdUnset(unsetCommandName, util.GetFullName(fullName, unsetCommandName))
+	preferenceDiagnoseCmd := NewCmdDiagnose(diagnoseCommandName, util.GetFullName(fullName, diagnoseCommandName))
 	preferenceCmd := &cobra.Command{
 		Use:   name,
 		Short: "Modifies preference settings",
 		Long:  fmt.Sprintf(preferenceLongDesc, preference.FormatSupportedParameters()),
-		Example: fmt.Sprintf("%s\n%s\n%s",
+		Example: fmt.Sprintf("%s\n%s\n%s\n%s",
 			preferenceViewCmd.Example,
 			preferenceSetCmd.Example,
 			preferenceUnsetCmd.Example,
+			preferenceDiagnoseCmd.Example,
 		),
 	}
 
 	preferenceCmd.AddCommand(preferenceViewCmd, preferenceSetCmd)
-	preferenceCmd.AddCommand(preferenceUnsetCmd)
+	preferenceCmd.AddCommand(preferenceUnsetCmd, preferenceDiagnoseCmd)
 	preferenceCmd.SetUsageTemplate(util.CmdUsageTemplate)
 	preferenceCmd.Annotations = map[string]string{"command": "main"}
 
@@ -74,6 +74,12 @@ func (o *SetOptions) Run(cmd *cobra.Command) (err error) {
 	}
 
 	log.Info("Global preference was successfully updated")
+	switch cfg.GetSource(o.paramName) {
+	case preference.SourceProject:
+		log.Warningf("%s is overridden by the project preference %s", o.paramName, cfg.GetProjectFile())
+	case preference.SourceEnv:
+		log.Warningf("%s is overridden by the %s environment variable", o.paramName, preference.EnvName(o.paramName))
+	}
 	return nil
 }
 
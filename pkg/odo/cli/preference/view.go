@@ -56,17 +56,32 @@ func (o *ViewOptions) Run(cmd *cobra.Command) (err error) {
 		return
 	}
 	w := tabwriter.NewWriter(os.Stdout, 5, 2, 2, ' ', tabwriter.TabIndent)
-	fmt.Fprintln(w, "PARAMETER", "\t", "CURRENT_VALUE")
-	fmt.Fprintln(w, "UpdateNotification", "\t", showBlankIfNil(cfg.OdoSettings.UpdateNotification))
-	fmt.Fprintln(w, "NamePrefix", "\t", showBlankIfNil(cfg.OdoSettings.NamePrefix))
-	fmt.Fprintln(w, "Timeout", "\t", showBlankIfNil(cfg.OdoSettings.Timeout))
-	fmt.Fprintln(w, "BuildTimeout", "\t", showBlankIfNil(cfg.OdoSettings.BuildTimeout))
-	fmt.Fprintln(w, "PushTimeout", "\t", showBlankIfNil(cfg.OdoSettings.PushTimeout))
-	fmt.Fprintln(w, "Experimental", "\t", showBlankIfNil(cfg.OdoSettings.Experimental))
-	fmt.Fprintln(w, "Ephemeral", "\t", showBlankIfNil(cfg.OdoSettings.Ephemeral))
-	fmt.Fprintln(w, "ConsentTelemetry", "\t", showBlankIfNil(cfg.OdoSettings.ConsentTelemetry))
+	fmt.Fprintln(w, "PARAMETER", "\t", "CURRENT_VALUE", "\t", "SOURCE")
+	fmt.Fprintln(w, "UpdateNotification", "\t", showBlankIfNil(cfg.OdoSettings.UpdateNotification), "\t", cfg.GetSource("UpdateNotification"))
+	fmt.Fprintln(w, "NamePrefix", "\t", showBlankIfNil(cfg.OdoSettings.NamePrefix), "\t", cfg.GetSource("NamePrefix"))
+	fmt.Fprintln(w, "Timeout", "\t", showBlankIfNil(cfg.OdoSettings.Timeout), "\t", cfg.GetSource("Timeout"))
+	fmt.Fprintln(w, "BuildTimeout", "\t", showBlankIfNil(cfg.OdoSettings.BuildTimeout), "\t", cfg.GetSource("BuildTimeout"))
+	fmt.Fprintln(w, "PushTimeout", "\t", showBlankIfNil(cfg.OdoSettings.PushTimeout), "\t", cfg.GetSource("PushTimeout"))
+	fmt.Fprintln(w, "RegistryCacheTime", "\t", showBlankIfNil(cfg.OdoSettings.RegistryCacheTime), "\t", cfg.GetSource("RegistryCacheTime"))
+	fmt.Fprintln(w, "RegistryCacheSize", "\t", showBlankIfNil(cfg.OdoSettings.RegistryCacheSize), "\t", cfg.GetSource("RegistryCacheSize"))
+	fmt.Fprintln(w, "Experimental", "\t", showBlankIfNil(cfg.OdoSettings.Experimental), "\t", cfg.GetSource("Experimental"))
+	fmt.Fprintln(w, "Ephemeral", "\t", showBlankIfNil(cfg.OdoSettings.Ephemeral), "\t", cfg.GetSource("Ephemeral"))
+	fmt.Fprintln(w, "ConsentTelemetry", "\t", showBlankIfNil(cfg.OdoSettings.ConsentTelemetry), "\t", cfg.GetSource("ConsentTelemetry"))
+	fmt.Fprintln(w, "TelemetrySink", "\t", showBlankIfNil(cfg.OdoSettings.TelemetrySink), "\t", cfg.GetSource("TelemetrySink"))
+	fmt.Fprintln(w, "HTTPProxy", "\t", showBlankIfNil(cfg.OdoSettings.HTTPProxy), "\t", cfg.GetSource("HTTPProxy"))
+	fmt.Fprintln(w, "NoProxy", "\t", showBlankIfNil(cfg.OdoSettings.NoProxy), "\t", cfg.GetSource("NoProxy"))
+	fmt.Fprintln(w, "CABundle", "\t", showBlankIfNil(cfg.OdoSettings.CABundle), "\t", cfg.GetSource("CABundle"))
+	fmt.Fprintln(w, "ClientCertificate", "\t", showBlankIfNil(cfg.OdoSettings.ClientCertificate), "\t", cfg.GetSource("ClientCertificate"))
+	fmt.Fprintln(w, "ClientKey", "\t", showBlankIfNil(cfg.OdoSettings.ClientKey), "\t", cfg.GetSource("ClientKey"))
+	fmt.Fprintln(w, "Verify", "\t", showBlankIfNil(cfg.OdoSettings.Verify), "\t", cfg.GetSource("Verify"))
+	fmt.Fprintln(w, "PluginIndex", "\t", showBlankIfNil(cfg.OdoSettings.PluginIndex), "\t", cfg.GetSource("PluginIndex"))
+	fmt.Fprintln(w, "PushTarget", "\t", showBlankIfNil(cfg.OdoSettings.PushTarget), "\t", cfg.GetSource("PushTarget"))
 
 	w.Flush()
+
+	if projectFile := cfg.GetProjectFile(); projectFile != "" {
+		log.Italicf("\nThe project preference %s is layered over the global preference", projectFile)
+	}
 	return
 }
 
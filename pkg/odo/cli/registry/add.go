@@ -8,10 +8,10 @@ import (
 	// Third-party packages
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"github.com/zalando/go-keyring"
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
 
 	// odo packages
+	"github.com/openshift/odo/pkg/catalog"
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/preference"
@@ -28,6 +28,18 @@ var (
 	%[1]s CheRegistry https://che-devfile-registry.openshift.io
 
 	%[1]s RegistryFromGitHub https://github.com/elsony/devfile-registry
+
+	# Add private devfile registry authenticated with basic auth, signed by a private certificate authority
+	cat password.txt | %[1]s PrivateRegistry https://registry.example.com --username developer --password-stdin --ca-file ca.pem
+
+	# Add devfile registry mirrored with "odo registry mirror"
+	%[1]s OfflineRegistry file:///path/to/mirror
+
+	# Add private devfile registry authenticated with the credentials of docker-credential-pass
+	%[1]s PrivateRegistry https://registry.example.com --credential-helper pass
+
+	# Add devfile registry signing its devfiles and starter projects with the key of cosign.pub
+	%[1]s SignedRegistry https://registry.example.com --public-key cosign.pub
 	`)
 )
 
@@ -36,9 +48,8 @@ type AddOptions struct {
 	operation    string
 	registryName string
 	registryURL  string
-	user         string
-	token        string
 	forceFlag    bool
+	authOptions
 }
 
 // NewAddOptions creates a new AddOptions instance
@@ -51,28 +62,37 @@ func (o *AddOptions) Complete(name string, cmd *cobra.Command, args []string) (e
 	o.operation = "add"
 	o.registryName = args[0]
 	o.registryURL = args[1]
-	o.user = "default"
 	return
 }
 
 // Validate validates the AddOptions based on completed values
 func (o *AddOptions) Validate() (err error) {
-	err = util.ValidateURL(o.registryURL)
+	err = validateRegistryURL(o.registryURL)
 	if err != nil {
 		return err
 	}
 	if util2.IsGitBasedRegistry(o.registryURL) {
 		util2.PrintGitRegistryDeprecationWarning()
 	}
-	return
+	return o.authOptions.validate()
+}
+
+// validateRegistryURL validates the URL of a registry, a file:// URL must point to a registry mirror
+func validateRegistryURL(registryURL string) error {
+	if !util2.IsMirrorRegistry(registryURL) {
+		return util.ValidateURL(registryURL)
+	}
+	dir, err := catalog.MirrorDir(registryURL)
+	if err != nil {
+		return err
+	}
+	_, err = catalog.ReadMirrorManifest(dir)
+	return err
 }
 
 // Run contains the logic for "odo registry add" command
 func (o *AddOptions) Run(cmd *cobra.Command) (err error) {
-	isSecure := false
-	if o.token != "" {
-		isSecure = true
-	}
+	isSecure := o.isSecure()
 
 	cfg, err := preference.New()
 	if err != nil {
@@ -83,11 +103,9 @@ func (o *AddOptions) Run(cmd *cobra.Command) (err error) {
 		return err
 	}
 
-	if o.token != "" {
-		err = keyring.Set(util.CredentialPrefix+o.registryName, o.user, o.token)
-		if err != nil {
-			return errors.Wrap(err, "unable to store registry credential to keyring")
-		}
+	err = o.store(cfg, o.registryName, nil)
+	if err != nil {
+		return err
 	}
 
 	log.Info("New registry successfully added")
@@ -108,7 +126,7 @@ func NewCmdAdd(name, fullName string) *cobra.Command {
 		},
 	}
 
-	registryAddCmd.Flags().StringVar(&o.token, "token", "", "Token to be used to access secure registry")
+	addAuthFlags(registryAddCmd, &o.authOptions)
 
 	return registryAddCmd
 }
@@ -0,0 +1,148 @@
+package registry
+
+import (
+	// Built-in packages
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// Third-party packages
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
+
+	// odo packages
+	"github.com/openshift/odo/pkg/catalog"
+	"github.com/openshift/odo/pkg/log"
+	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
+	"github.com/openshift/odo/pkg/odo/util/completion"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/util"
+)
+
+// authOptions encapsulates the authentication options shared by the "odo registry add" and "odo registry update" commands
+type authOptions struct {
+	token            string
+	username         string
+	passwordStdin    bool
+	password         string
+	caFile           string
+	credentialHelper string
+	// publicKey is the PEM file of the public key verifying the signatures of the artifacts of the registry
+	publicKey string
+
+	// stdin is where the password is read from with --password-stdin
+	stdin io.Reader
+}
+
+// addAuthFlags adds the authentication flags to the given command
+func addAuthFlags(cmd *cobra.Command, o *authOptions) {
+	cmd.Flags().StringVar(&o.token, "token", "", "Token to be used to access secure registry")
+	cmd.Flags().StringVar(&o.username, "username", "", "Username to be used to access secure registry with basic auth")
+	cmd.Flags().BoolVar(&o.passwordStdin, "password-stdin", false, "Read the password of --username from stdin")
+	cmd.Flags().StringVar(&o.caFile, "ca-file", "", "PEM file of the certificate authorities to trust when accessing the registry")
+	cmd.Flags().StringVar(&o.credentialHelper, "credential-helper", "", "Docker credential helper providing the credentials of the registry, for example \"pass\" for docker-credential-pass")
+	cmd.Flags().StringVar(&o.publicKey, "public-key", "", "PEM file of the public key verifying the signatures of the devfiles and starter projects of the registry")
+	completion.RegisterCommandFlagHandler(cmd, "ca-file", completion.FileCompletionHandler)
+	completion.RegisterCommandFlagHandler(cmd, "public-key", completion.FileCompletionHandler)
+}
+
+// validate validates the authentication options, and reads the password from stdin
+func (o *authOptions) validate() error {
+	if o.credentialHelper != "" && (o.token != "" || o.username != "") {
+		return errors.New("--credential-helper can't be used with --token or --username")
+	}
+	if o.token != "" && o.username != "" {
+		return errors.New("--token and --username can't be used together")
+	}
+	if o.passwordStdin != (o.username != "") {
+		return errors.New("--username and --password-stdin must be used together")
+	}
+	if o.caFile != "" {
+		caFile, err := filepath.Abs(o.caFile)
+		if err != nil {
+			return err
+		}
+		if _, err = util.HTTPTransport(caFile); err != nil {
+			return err
+		}
+		o.caFile = caFile
+	}
+
+	if o.publicKey != "" {
+		publicKey, err := filepath.Abs(o.publicKey)
+		if err != nil {
+			return err
+		}
+		if _, err = catalog.ReadPublicKey(publicKey); err != nil {
+			return err
+		}
+		o.publicKey = publicKey
+	}
+
+	if o.passwordStdin {
+		stdin := o.stdin
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		password, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return errors.Wrap(err, "unable to read the password from stdin")
+		}
+		o.password = strings.TrimRight(string(password), "\r\n")
+		if o.password == "" {
+			return errors.New("the password read from stdin is empty")
+		}
+	}
+	return nil
+}
+
+// isSecure returns true if the registry has a secret stored in the keyring
+func (o *authOptions) isSecure() bool {
+	return o.token != "" || o.username != ""
+}
+
+// store stores the secret of the registry in the keyring, and the rest of its authentication and its public key in the preference file
+// the keyring entries of the previous authentication of the registry are deleted
+func (o *authOptions) store(cfg *preference.PreferenceInfo, registryName string, previous *preference.Registry) error {
+	if previous != nil && previous.Secure {
+		if err := deleteRegistrySecret(*previous); err != nil {
+			// the registry can be used without its previous secret
+			log.Warningf("Unable to delete the previous credential of registry %s: %v", registryName, err)
+		}
+	}
+
+	if o.token != "" {
+		err := keyring.Set(util.CredentialPrefix+registryName, registryUtil.RegistryUser, o.token)
+		if err != nil {
+			return errors.Wrap(err, "unable to store registry credential to keyring")
+		}
+	}
+	if o.username != "" {
+		err := keyring.Set(util.CredentialPrefix+registryName, o.username, o.password)
+		if err != nil {
+			return errors.Wrap(err, "unable to store registry credential to keyring")
+		}
+	}
+
+	err := cfg.SetRegistryAuth(registryName, o.username, o.caFile, o.credentialHelper)
+	if err != nil {
+		return err
+	}
+	return cfg.SetRegistryPublicKey(registryName, o.publicKey)
+}
+
+// deleteRegistrySecret deletes the secret of the given secure registry from the keyring
+func deleteRegistrySecret(registry preference.Registry) error {
+	user := registryUtil.RegistryUser
+	if registry.Username != "" {
+		user = registry.Username
+	}
+	err := keyring.Delete(util.CredentialPrefix+registry.Name, user)
+	if err != nil {
+		return errors.Wrap(err, "unable to delete registry credential from keyring")
+	}
+	return nil
+}
@@ -7,14 +7,11 @@ import (
 	// Third-party packages
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"github.com/zalando/go-keyring"
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
 
 	// odo packages
-	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/preference"
-	"github.com/openshift/odo/pkg/util"
 )
 
 const deleteCommandName = "delete"
@@ -33,7 +30,6 @@ type DeleteOptions struct {
 	operation    string
 	registryName string
 	registryURL  string
-	user         string
 	forceFlag    bool
 }
 
@@ -47,7 +43,6 @@ func (o *DeleteOptions) Complete(name string, cmd *cobra.Command, args []string)
 	o.operation = "delete"
 	o.registryName = args[0]
 	o.registryURL = ""
-	o.user = "default"
 	return
 }
 
@@ -58,22 +53,18 @@ func (o *DeleteOptions) Validate() (err error) {
 
 // Run contains the logic for "odo registry delete" command
 func (o *DeleteOptions) Run(cmd *cobra.Command) (err error) {
-	isSecure := registryUtil.IsSecure(o.registryName)
-
 	cfg, err := preference.New()
 	if err != nil {
 		return errors.Wrap(err, "unable to delete registry")
 	}
+	registry := cfg.GetRegistry(o.registryName)
 	err = cfg.RegistryHandler(o.operation, o.registryName, o.registryURL, o.forceFlag, false)
 	if err != nil {
 		return err
 	}
 
-	if isSecure {
-		err = keyring.Delete(util.CredentialPrefix+o.registryName, o.user)
-		if err != nil {
-			return errors.Wrap(err, "unable to delete registry credential from keyring")
-		}
+	if registry != nil && registry.Secure && cfg.GetRegistry(o.registryName) == nil {
+		return deleteRegistrySecret(*registry)
 	}
 
 	return nil
@@ -0,0 +1,136 @@
+package registry
+
+import (
+	// Built-in packages
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	// Third-party packages
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+
+	// odo packages
+	"github.com/openshift/odo/pkg/catalog"
+	"github.com/openshift/odo/pkg/component"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/preference"
+)
+
+const mirrorCommandName = "mirror"
+
+// "odo registry mirror" command description and examples
+var (
+	mirrorLongDesc = ktemplates.LongDesc(`Mirror devfile registry to a local directory
+
+	The index of the registry, the devfiles and resources of all its stacks and their starter projects
+	are downloaded to the directory, along with a manifest holding the digests of the files.
+	The directory can then be copied to a machine without internet access, and added as a registry
+	with a file:// URL. The files are verified against the manifest when they are used.`)
+
+	mirrorExample = ktemplates.Examples(`# Mirror the default devfile registry to the mirror directory
+	%[1]s DefaultDevfileRegistry ./mirror
+
+	# Add the mirror as a registry
+	odo registry add OfflineRegistry file:///path/to/mirror
+	`)
+)
+
+// MirrorOptions encapsulates the options for the "odo registry mirror" command
+type MirrorOptions struct {
+	registryName string
+	dir          string
+
+	registry *preference.Registry
+}
+
+// NewMirrorOptions creates a new MirrorOptions instance
+func NewMirrorOptions() *MirrorOptions {
+	return &MirrorOptions{}
+}
+
+// Complete completes MirrorOptions after they've been created
+func (o *MirrorOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.registryName = args[0]
+	o.dir, err = filepath.Abs(args[1])
+	return err
+}
+
+// Validate validates the MirrorOptions based on completed values
+func (o *MirrorOptions) Validate() (err error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return err
+	}
+	o.registry = cfg.GetRegistry(o.registryName)
+	if o.registry == nil {
+		return errors.Errorf("registry %q doesn't exist", o.registryName)
+	}
+	if registryUtil.IsGitBasedRegistry(o.registry.URL) || registryUtil.IsMirrorRegistry(o.registry.URL) {
+		return errors.Errorf("registry %q is not an OCI-based registry, only OCI-based registries can be mirrored", o.registryName)
+	}
+
+	files, err := ioutil.ReadDir(o.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(files) > 0 {
+		return errors.Errorf("the directory %s is not empty", o.dir)
+	}
+	return nil
+}
+
+// Run contains the logic for "odo registry mirror" command
+func (o *MirrorOptions) Run(cmd *cobra.Command) (err error) {
+	credentials, err := registryUtil.GetRegistryCredentials(o.registryName)
+	if err != nil {
+		return err
+	}
+	downloadStarterProject := func(starterProject *devfilev1.StarterProject, destDir string) error {
+		return component.DownloadStarterProject(starterProject, credentials, destDir)
+	}
+
+	registry := catalog.Registry{
+		Name:   o.registry.Name,
+		URL:    o.registry.URL,
+		Secure: o.registry.Secure,
+	}
+	manifest, err := catalog.MirrorRegistry(registry, o.dir, downloadStarterProject)
+	if err != nil {
+		// don't leave a partial mirror which could be mistaken for a complete one
+		_ = os.RemoveAll(o.dir)
+		return errors.Wrapf(err, "unable to mirror registry %s", o.registryName)
+	}
+
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(manifest)
+		return nil
+	}
+	log.Successf("Mirrored %d stacks and %d starter projects of registry %s to %s", manifest.Spec.Stacks, manifest.Spec.StarterProjects, o.registryName, o.dir)
+	log.Italicf("Run 'odo registry add <registry name> file://%s' to use the mirror", filepath.ToSlash(o.dir))
+	return nil
+}
+
+// NewCmdMirror implements the "odo registry mirror" command
+func NewCmdMirror(name, fullName string) *cobra.Command {
+	o := NewMirrorOptions()
+	registryMirrorCmd := &cobra.Command{
+		Use:         fmt.Sprintf("%s <registry name> <directory>", name),
+		Short:       "Mirror devfile registry to a local directory",
+		Long:        mirrorLongDesc,
+		Example:     fmt.Sprintf(fmt.Sprint(mirrorExample), fullName),
+		Args:        cobra.ExactArgs(2),
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	return registryMirrorCmd
+}
@@ -25,20 +25,22 @@ func NewCmdRegistry(name, fullName string) *cobra.Command {
 	registryListCmd := NewCmdList(listCommandName, util.GetFullName(fullName, listCommandName))
 	registryUpdateCmd := NewCmdUpdate(updateCommandName, util.GetFullName(fullName, updateCommandName))
 	registryDeleteCmd := NewCmdDelete(deleteCommandName, util.GetFullName(fullName, deleteCommandName))
+	registryMirrorCmd := NewCmdMirror(mirrorCommandName, util.GetFullName(fullName, mirrorCommandName))
 
 	registryCmd := &cobra.Command{
 		Use:   name,
 		Short: registryDesc,
 		Long:  registryDesc,
-		Example: fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s",
+		Example: fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s\n\n%s",
 			registryAddCmd.Example,
 			registryListCmd.Example,
 			registryUpdateCmd.Example,
 			registryDeleteCmd.Example,
+			registryMirrorCmd.Example,
 		),
 	}
 
-	registryCmd.AddCommand(registryAddCmd, registryListCmd, registryUpdateCmd, registryDeleteCmd)
+	registryCmd.AddCommand(registryAddCmd, registryListCmd, registryUpdateCmd, registryDeleteCmd, registryMirrorCmd)
 	registryCmd.SetUsageTemplate(util.CmdUsageTemplate)
 	registryCmd.Annotations = map[string]string{"command": "main"}
 
@@ -7,14 +7,12 @@ import (
 	// Third-party packages
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"github.com/zalando/go-keyring"
 	ktemplates "k8s.io/kubectl/pkg/util/templates"
 
 	// odo packages
 	registryUtil "github.com/openshift/odo/pkg/odo/cli/registry/util"
 	"github.com/openshift/odo/pkg/odo/genericclioptions"
 	"github.com/openshift/odo/pkg/preference"
-	"github.com/openshift/odo/pkg/util"
 )
 
 const updateCommandName = "update"
@@ -25,6 +23,9 @@ var (
 
 	updateExample = ktemplates.Examples(`# Update devfile registry URL
 	%[1]s CheRegistry https://che-devfile-registry-update.openshift.io
+
+	# Update devfile registry URL and its basic auth credentials
+	cat password.txt | %[1]s PrivateRegistry https://registry.example.com --username developer --password-stdin
 	`)
 )
 
@@ -33,9 +34,8 @@ type UpdateOptions struct {
 	operation    string
 	registryName string
 	registryURL  string
-	user         string
-	token        string
 	forceFlag    bool
+	authOptions
 }
 
 // NewUpdateOptions creates a new UpdateOptions instance
@@ -48,55 +48,38 @@ func (o *UpdateOptions) Complete(name string, cmd *cobra.Command, args []string)
 	o.operation = "update"
 	o.registryName = args[0]
 	o.registryURL = args[1]
-	o.user = "default"
 	return
 }
 
 // Validate validates the UpdateOptions based on completed values
 func (o *UpdateOptions) Validate() (err error) {
-	err = util.ValidateURL(o.registryURL)
+	err = validateRegistryURL(o.registryURL)
 	if err != nil {
 		return err
 	}
 	if registryUtil.IsGitBasedRegistry(o.registryURL) {
 		registryUtil.PrintGitRegistryDeprecationWarning()
 	}
-	return
+	return o.authOptions.validate()
 }
 
 // Run contains the logic for "odo registry update" command
 func (o *UpdateOptions) Run(cmd *cobra.Command) (err error) {
-	secureBeforeUpdate := false
-	secureAfterUpdate := false
-	if registryUtil.IsSecure(o.registryName) {
-		secureBeforeUpdate = true
-	}
-	if o.token != "" {
-		secureAfterUpdate = true
-	}
-
 	cfg, err := preference.New()
 	if err != nil {
 		return errors.Wrap(err, "unable to update registry")
 	}
-	err = cfg.RegistryHandler(o.operation, o.registryName, o.registryURL, o.forceFlag, secureAfterUpdate)
+	previous := cfg.GetRegistry(o.registryName)
+	err = cfg.RegistryHandler(o.operation, o.registryName, o.registryURL, o.forceFlag, o.isSecure())
 	if err != nil {
 		return err
 	}
-
-	if secureAfterUpdate {
-		err = keyring.Set(util.CredentialPrefix+o.registryName, o.user, o.token)
-		if err != nil {
-			return errors.Wrap(err, "unable to store registry credential to keyring")
-		}
-	} else if secureBeforeUpdate && !secureAfterUpdate {
-		err = keyring.Delete(util.CredentialPrefix+o.registryName, o.user)
-		if err != nil {
-			return errors.Wrap(err, "unable to delete registry credential from keyring")
-		}
+	if updated := cfg.GetRegistry(o.registryName); updated == nil || updated.URL != o.registryURL {
+		// the update was aborted by the user
+		return nil
 	}
 
-	return nil
+	return o.store(cfg, o.registryName, previous)
 }
 
 // NewCmdUpdate implements the "odo registry update" command
@@ -113,7 +96,7 @@ func NewCmdUpdate(name, fullName string) *cobra.Command {
 		},
 	}
 
-	registryUpdateCmd.Flags().StringVar(&o.token, "token", "", "Token to be used to access secure registry")
+	addAuthFlags(registryUpdateCmd, &o.authOptions)
 	registryUpdateCmd.Flags().BoolVarP(&o.forceFlag, "force", "f", false, "Don't ask for confirmation, update the registry directly")
 
 	return registryUpdateCmd
@@ -0,0 +1,141 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/zalando/go-keyring"
+)
+
+// identityTokenUsername is the username returned by docker credential helpers for identity tokens
+const identityTokenUsername = "<token>"
+
+// RegistryCredentials are the credentials odo uses to access a registry
+type RegistryCredentials struct {
+	// Token is the token of a secure registry, sent as a bearer token
+	Token string
+	// Username and Password are the basic auth credentials of the registry
+	Username string
+	Password string
+	// CAFile is a PEM file of the additional certificate authorities to trust when accessing the registry
+	CAFile string
+	// Host is the host of the registry, the basic auth credentials are only sent to this host
+	Host string
+}
+
+// credentialHelperOutput is the output of the get command of a docker credential helper
+type credentialHelperOutput struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// runCredentialHelper runs the given command of a docker credential helper with the given input
+// it's a variable so that tests can stub the helper
+var runCredentialHelper = func(helper string, command string, input io.Reader) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	// #nosec G204 -- the helper is set by the user with odo registry add or update
+	cmd := exec.Command("docker-credential-"+helper, command)
+	cmd.Stdin = input
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if output := strings.TrimSpace(stdout.String() + stderr.String()); output != "" {
+			return nil, fmt.Errorf("%v: %s", err, output)
+		}
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// GetRegistryCredentials returns the credentials of the given registry
+// from the keyring, or from the docker credential helper of the registry
+func GetRegistryCredentials(registryName string) (RegistryCredentials, error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return RegistryCredentials{}, err
+	}
+	registry := cfg.GetRegistry(registryName)
+	if registry == nil {
+		return RegistryCredentials{}, nil
+	}
+	return getRegistryCredentials(*registry)
+}
+
+func getRegistryCredentials(registry preference.Registry) (RegistryCredentials, error) {
+	credentials := RegistryCredentials{
+		CAFile: registry.CAFile,
+	}
+	if registryURL, err := url.Parse(registry.URL); err == nil {
+		credentials.Host = registryURL.Host
+	}
+
+	if registry.CredentialHelper != "" {
+		output, err := runCredentialHelper(registry.CredentialHelper, "get", strings.NewReader(credentials.Host))
+		if err != nil {
+			return credentials, errors.Wrapf(err, "unable to get the credentials of registry %s from credential helper %s", registry.Name, registry.CredentialHelper)
+		}
+		var helperCredentials credentialHelperOutput
+		if err = json.Unmarshal(output, &helperCredentials); err != nil {
+			return credentials, errors.Wrapf(err, "unable to parse the credentials of registry %s from credential helper %s", registry.Name, registry.CredentialHelper)
+		}
+		if helperCredentials.Username == identityTokenUsername {
+			credentials.Token = helperCredentials.Secret
+		} else {
+			credentials.Username = helperCredentials.Username
+			credentials.Password = helperCredentials.Secret
+		}
+		return credentials, nil
+	}
+
+	if !registry.Secure {
+		return credentials, nil
+	}
+	if registry.Username != "" {
+		password, err := keyring.Get(util.CredentialPrefix+registry.Name, registry.Username)
+		if err != nil {
+			return credentials, errors.Wrap(err, "unable to get secure registry credential from keyring")
+		}
+		credentials.Username = registry.Username
+		credentials.Password = password
+		return credentials, nil
+	}
+	token, err := keyring.Get(util.CredentialPrefix+registry.Name, RegistryUser)
+	if err != nil {
+		return credentials, errors.Wrap(err, "unable to get secure registry credential from keyring")
+	}
+	credentials.Token = token
+	return credentials, nil
+}
+
+// RequestParams returns the parameters of a request to the given URL with the credentials
+// the basic auth credentials are only sent to the host of the registry, so that they don't leak to the hosts of starter projects
+func (c RegistryCredentials) RequestParams(requestURL string) util.HTTPRequestParams {
+	params := util.HTTPRequestParams{
+		URL:    requestURL,
+		Token:  c.Token,
+		CAFile: c.CAFile,
+	}
+	if c.Username != "" && c.IsRegistryURL(requestURL) {
+		params.Username = c.Username
+		params.Password = c.Password
+	}
+	return params
+}
+
+// IsRegistryURL returns true if the given URL is on the host of the registry
+func (c RegistryCredentials) IsRegistryURL(requestURL string) bool {
+	parsedURL, err := url.Parse(requestURL)
+	if err != nil {
+		return false
+	}
+	return c.Host != "" && parsedURL.Host == c.Host
+}
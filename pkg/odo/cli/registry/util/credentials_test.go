@@ -0,0 +1,132 @@
+package util
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/zalando/go-keyring"
+)
+
+func TestGetRegistryCredentials(t *testing.T) {
+	keyring.MockInit()
+	if err := keyring.Set(util.CredentialPrefix+"TokenRegistry", RegistryUser, "token"); err != nil {
+		t.Fatal(err)
+	}
+	if err := keyring.Set(util.CredentialPrefix+"BasicRegistry", "developer", "password"); err != nil {
+		t.Fatal(err)
+	}
+
+	helperOutputs := map[string]string{
+		"pass":   `{"ServerURL": "registry.example.com", "Username": "developer", "Secret": "helper-password"}`,
+		"ecr":    `{"ServerURL": "registry.example.com", "Username": "<token>", "Secret": "identity-token"}`,
+		"broken": `not json`,
+	}
+	originalRunCredentialHelper := runCredentialHelper
+	defer func() { runCredentialHelper = originalRunCredentialHelper }()
+	runCredentialHelper = func(helper string, command string, input io.Reader) ([]byte, error) {
+		host, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		if command != "get" || string(host) != "registry.example.com" {
+			return nil, errors.New("unexpected request")
+		}
+		output, ok := helperOutputs[helper]
+		if !ok {
+			return nil, errors.New("credentials not found in native keychain")
+		}
+		return []byte(output), nil
+	}
+
+	tests := []struct {
+		name     string
+		registry preference.Registry
+		want     RegistryCredentials
+		wantErr  bool
+	}{
+		{
+			name:     "Case 1: registry which isn't secure",
+			registry: preference.Registry{Name: "PublicRegistry", URL: "https://registry.example.com", CAFile: "ca.pem"},
+			want:     RegistryCredentials{CAFile: "ca.pem", Host: "registry.example.com"},
+		},
+		{
+			name:     "Case 2: registry with a token",
+			registry: preference.Registry{Name: "TokenRegistry", URL: "https://registry.example.com", Secure: true},
+			want:     RegistryCredentials{Token: "token", Host: "registry.example.com"},
+		},
+		{
+			name:     "Case 3: registry with basic auth credentials",
+			registry: preference.Registry{Name: "BasicRegistry", URL: "https://registry.example.com", Secure: true, Username: "developer"},
+			want:     RegistryCredentials{Username: "developer", Password: "password", Host: "registry.example.com"},
+		},
+		{
+			name:     "Case 4: registry with a credential helper returning basic auth credentials",
+			registry: preference.Registry{Name: "HelperRegistry", URL: "https://registry.example.com", CredentialHelper: "pass"},
+			want:     RegistryCredentials{Username: "developer", Password: "helper-password", Host: "registry.example.com"},
+		},
+		{
+			name:     "Case 5: registry with a credential helper returning an identity token",
+			registry: preference.Registry{Name: "HelperRegistry", URL: "https://registry.example.com", CredentialHelper: "ecr"},
+			want:     RegistryCredentials{Token: "identity-token", Host: "registry.example.com"},
+		},
+		{
+			name:     "Case 6: registry with a credential helper failing",
+			registry: preference.Registry{Name: "HelperRegistry", URL: "https://registry.example.com", CredentialHelper: "missing"},
+			wantErr:  true,
+		},
+		{
+			name:     "Case 7: registry with a credential helper returning invalid output",
+			registry: preference.Registry{Name: "HelperRegistry", URL: "https://registry.example.com", CredentialHelper: "broken"},
+			wantErr:  true,
+		},
+		{
+			name:     "Case 8: secure registry without credentials in the keyring",
+			registry: preference.Registry{Name: "MissingRegistry", URL: "https://registry.example.com", Secure: true},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getRegistryCredentials(tt.registry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getRegistryCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getRegistryCredentials() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryCredentialsRequestParams(t *testing.T) {
+	credentials := RegistryCredentials{Username: "developer", Password: "password", CAFile: "ca.pem", Host: "registry.example.com"}
+
+	tests := []struct {
+		name string
+		url  string
+		want util.HTTPRequestParams
+	}{
+		{
+			name: "Case 1: request to the registry",
+			url:  "https://registry.example.com/starters/nodejs.zip",
+			want: util.HTTPRequestParams{URL: "https://registry.example.com/starters/nodejs.zip", Username: "developer", Password: "password", CAFile: "ca.pem"},
+		},
+		{
+			name: "Case 2: request to another host doesn't send the credentials",
+			url:  "https://github.com/odo-devfiles/nodejs-ex/archive/master.zip",
+			want: util.HTTPRequestParams{URL: "https://github.com/odo-devfiles/nodejs-ex/archive/master.zip", CAFile: "ca.pem"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := credentials.RequestParams(tt.url); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RequestParams() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
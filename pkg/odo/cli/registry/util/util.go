@@ -40,6 +40,11 @@ func IsGitBasedRegistry(url string) bool {
 	return strings.Contains(url, "github.com") || strings.Contains(url, "raw.githubusercontent.com")
 }
 
+// IsMirrorRegistry returns true if the registry of the given URL is a registry mirror on the local filesystem
+func IsMirrorRegistry(url string) bool {
+	return strings.HasPrefix(url, "file://")
+}
+
 func PrintGitRegistryDeprecationWarning() {
 	log.Deprecate("Git based registries", "Please see https://github.com/openshift/odo/tree/main/docs/public/git-registry-deprecation.adoc")
 }
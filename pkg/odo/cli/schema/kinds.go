@@ -0,0 +1,126 @@
+package schema
+
+import (
+	"github.com/openshift/odo/pkg/application"
+	"github.com/openshift/odo/pkg/component"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/cli/catalog/describe"
+	"github.com/openshift/odo/pkg/odo/cli/catalog/list"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/project"
+	"github.com/openshift/odo/pkg/segment"
+	"github.com/openshift/odo/pkg/service"
+	"github.com/openshift/odo/pkg/storage"
+	"github.com/openshift/odo/pkg/url"
+)
+
+// Kinds are the kinds of machine readable output having a published schema, a change to the output
+// of one of them not adding a field requires a new APIVersion
+var Kinds = []machineoutput.SchemaKind{
+	{
+		Name:        "component",
+		Description: "A component",
+		Commands:    []string{"odo create"},
+		Object:      component.Component{},
+	},
+	{
+		Name:        "component-description",
+		Description: "The full description of a component, with its URLs and storage",
+		Commands:    []string{"odo describe"},
+		Object:      component.ComponentFullDescription{},
+	},
+	{
+		Name:        "component-list",
+		Description: "The components of an application, or of the contexts of a path",
+		Commands:    []string{"odo list"},
+		Object:      component.CombinedComponentList{},
+	},
+	{
+		Name:        "url-list",
+		Description: "The URLs of a component",
+		Commands:    []string{"odo url list"},
+		Object:      url.URLList{},
+	},
+	{
+		Name:        "storage-list",
+		Description: "The storage of a component",
+		Commands:    []string{"odo storage list"},
+		Object:      storage.StorageList{},
+	},
+	{
+		Name:        "service-list",
+		Description: "The service catalog services of an application",
+		Commands:    []string{"odo service list"},
+		Object:      service.ServiceList{},
+	},
+	{
+		Name:        "project-list",
+		Description: "The projects of the cluster",
+		Commands:    []string{"odo project list"},
+		Object:      project.ProjectList{},
+	},
+	{
+		Name:        "application",
+		Description: "An application and its components",
+		Commands:    []string{"odo app describe"},
+		Object:      application.App{},
+	},
+	{
+		Name:        "application-list",
+		Description: "The applications of a project",
+		Commands:    []string{"odo app list"},
+		Object:      application.AppList{},
+	},
+	{
+		Name:        "catalog-component-list",
+		Description: "The component types of the catalog",
+		Commands:    []string{"odo catalog list components"},
+		Object:      list.CombinedCatalogList{},
+	},
+	{
+		Name:        "catalog-component-description",
+		Description: "The devfiles of a component type of the catalog, one per registry",
+		Commands:    []string{"odo catalog describe component"},
+		Object:      []describe.DevfileComponentDescription{},
+	},
+	{
+		Name:        "registry-list",
+		Description: "The devfile registries",
+		Commands:    []string{"odo registry list"},
+		Object:      machineoutput.RegistryListOutput{},
+	},
+	{
+		Name:        "preference-list",
+		Description: "The preferences, their values and defaults",
+		Commands:    []string{"odo preference view"},
+		Object:      preference.PreferenceList{},
+	},
+	{
+		Name:        "telemetry-event-list",
+		Description: "The usage data recently recorded, as uploaded to the telemetry sink",
+		Commands:    []string{"odo telemetry show"},
+		Object:      segment.RecordedEventList{},
+	},
+	{
+		Name:        "event",
+		Description: "An event of a stream of events, output on its own line",
+		Commands:    []string{"odo push", "odo status"},
+		Object:      machineoutput.MachineEventWrapper{},
+	},
+	{
+		Name:        "error",
+		Description: "The error of a command, output to the standard error",
+		Commands:    []string{"all the commands supporting -o"},
+		Object:      machineoutput.GenericError{},
+	},
+}
+
+// GetKind returns the kind of machine readable output with the given name, false if it doesn't exist
+func GetKind(name string) (machineoutput.SchemaKind, bool) {
+	for _, kind := range Kinds {
+		if kind.Name == name {
+			return kind, true
+		}
+	}
+	return machineoutput.SchemaKind{}, false
+}
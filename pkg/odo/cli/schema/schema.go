@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/odo/util"
+
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+// RecommendedCommandName is the recommended schema command name
+const RecommendedCommandName = "schema"
+
+var (
+	schemaLongDesc = ktemplates.LongDesc(`Print the JSON Schema of a kind of machine readable output
+
+	Every machine readable output carries the apiVersion of its schema. Within an apiVersion, fields are
+	only added to the outputs; any other change comes with a new apiVersion.
+	Without a kind, the kinds having a schema are listed.`)
+
+	schemaExample = ktemplates.Examples(`# List the kinds of machine readable output having a schema
+	%[1]s
+
+	# Print the JSON Schema of the output of "odo url list -o json"
+	%[1]s url-list
+
+	# Print the JSON Schema of the events of "odo push -o json"
+	%[1]s event
+	`)
+)
+
+// SchemaOptions encapsulates the options for the odo schema command
+type SchemaOptions struct {
+	kind *machineoutput.SchemaKind
+}
+
+// NewSchemaOptions creates a new SchemaOptions instance
+func NewSchemaOptions() *SchemaOptions {
+	return &SchemaOptions{}
+}
+
+// Complete completes SchemaOptions after they've been created
+func (o *SchemaOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	if len(args) == 0 {
+		return nil
+	}
+	kind, ok := GetKind(args[0])
+	if !ok {
+		return fmt.Errorf("unknown kind %q, the kinds are %s", args[0], strings.Join(kindNames(), ", "))
+	}
+	o.kind = &kind
+	return nil
+}
+
+// Validate validates the SchemaOptions based on completed values
+func (o *SchemaOptions) Validate() (err error) {
+	return nil
+}
+
+// Run contains the logic for the odo schema command
+func (o *SchemaOptions) Run(cmd *cobra.Command) (err error) {
+	if o.kind == nil {
+		w := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
+		fmt.Fprintln(w, "KIND", "\t", "COMMANDS", "\t", "DESCRIPTION")
+		for _, kind := range Kinds {
+			fmt.Fprintln(w, kind.Name, "\t", strings.Join(kind.Commands, ", "), "\t", kind.Description)
+		}
+		return w.Flush()
+	}
+
+	data, err := json.MarshalIndent(machineoutput.GenerateSchema(*o.kind), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func kindNames() []string {
+	var names []string
+	for _, kind := range Kinds {
+		names = append(names, kind.Name)
+	}
+	return names
+}
+
+// NewCmdSchema implements the odo schema command
+func NewCmdSchema(name, fullName string) *cobra.Command {
+	o := NewSchemaOptions()
+	schemaCmd := &cobra.Command{
+		Use:       fmt.Sprintf("%s [kind]", name),
+		Short:     "Print the JSON Schema of a kind of machine readable output",
+		Long:      schemaLongDesc,
+		Example:   fmt.Sprintf(schemaExample, fullName),
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: kindNames(),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	schemaCmd.Annotations = map[string]string{"command": "utility"}
+	schemaCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	return schemaCmd
+}
@@ -0,0 +1,188 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift/odo/pkg/application"
+	"github.com/openshift/odo/pkg/catalog"
+	"github.com/openshift/odo/pkg/component"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/cli/catalog/describe"
+	"github.com/openshift/odo/pkg/odo/cli/catalog/list"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/project"
+	"github.com/openshift/odo/pkg/segment"
+	"github.com/openshift/odo/pkg/service"
+	"github.com/openshift/odo/pkg/storage"
+	"github.com/openshift/odo/pkg/url"
+
+	"github.com/xeipuuv/gojsonschema"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// outputs returns the outputs of the commands for each kind, built as the commands build them
+func outputs(t *testing.T) map[string][]interface{} {
+	nodejs := component.NewComponent("nodejs")
+	nodejs.Spec.Type = "nodejs"
+	nodejs.Spec.URL = []string{"http-3000"}
+	nodejs.Status.State = component.StateTypePushed
+
+	urls := url.URLList{Items: []url.URL{
+		url.ConvertEnvinfoURL(localConfigProvider.LocalURL{Name: "http-3000", Port: 3000, Host: "example.com", Kind: localConfigProvider.INGRESS, Secure: true}, "nodejs"),
+		url.ConvertLocalURL(localConfigProvider.LocalURL{Name: "route-8080", Port: 8080, Kind: localConfigProvider.ROUTE}),
+	}}
+	urls.Items[0].Status.State = url.StateTypePushed
+	urls.Items[0].Status.CertificateExpiry = &metav1.Time{}
+
+	storages := storage.GetMachineReadableFormatForList([]storage.Storage{storage.GetMachineReadableFormat("data", "1Gi", "/data")})
+
+	description := component.ComponentFullDescription{
+		TypeMeta:   nodejs.TypeMeta,
+		ObjectMeta: nodejs.ObjectMeta,
+		Spec: component.ComponentFullDescriptionSpec{
+			Type:    "nodejs",
+			URL:     urls,
+			Storage: storages,
+			Env:     []corev1.EnvVar{{Name: "PORT", Value: "3000"}},
+			Ports:   []string{"3000"},
+		},
+	}
+
+	app := application.App{
+		TypeMeta:   metav1.TypeMeta{Kind: "Application", APIVersion: machineoutput.APIVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "myproject"},
+		Spec:       application.AppSpec{Components: []string{"nodejs"}},
+	}
+
+	var events []interface{}
+	client := machineoutput.NewConsoleMachineEventLoggingClientWithFunction(func(event machineoutput.MachineEventWrapper) {
+		events = append(events, event)
+	})
+	client.DevFileCommandExecutionBegin("devrun", "runtime", "npm start", "run", machineoutput.TimestampNow())
+	client.DevFileCommandExecutionComplete("devrun", "runtime", "npm start", "run", machineoutput.TimestampNow(), errors.New("exit status 1"))
+	client.ReportError(errors.New("unable to push"), machineoutput.TimestampNow())
+	client.SupervisordStatus([]machineoutput.SupervisordStatusEntry{{Program: "devrun", Status: "RUNNING"}}, machineoutput.TimestampNow())
+	client.URLReachable("http-3000", "http://example.com", 3000, false, "ingress", true, &machineoutput.URLProbe{Healthy: true, StatusCode: 200}, machineoutput.TimestampNow())
+	client.KubernetesPodStatus([]machineoutput.KubernetesPodStatusEntry{{
+		Name:       "nodejs-5c7f7d8b9-x2x5k",
+		Phase:      "Running",
+		Containers: []corev1.ContainerStatus{{Name: "runtime", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}},
+	}}, machineoutput.TimestampNow())
+	client.FileSync([]string{"server.js"}, nil, machineoutput.FileSyncFailed, errors.New("exit status 1"), machineoutput.TimestampNow())
+
+	return map[string][]interface{}{
+		"component":             {nodejs},
+		"component-description": {description},
+		"component-list": {
+			component.GetMachineReadableFormatForCombinedCompList(nil, []component.Component{nodejs}, nil),
+			component.GetMachineReadableFormatForCombinedCompList(nil, nil, nil),
+		},
+		"url-list":     {urls, url.URLList{}},
+		"storage-list": {storages, storage.StorageList{}},
+		"service-list": {service.ServiceList{
+			TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: machineoutput.APIVersion},
+			Items: []service.Service{{
+				TypeMeta:   metav1.TypeMeta{Kind: "Service", APIVersion: machineoutput.APIVersion},
+				ObjectMeta: metav1.ObjectMeta{Name: "mysql-persistent"},
+				Spec:       service.ServiceSpec{Type: "mysql-persistent", Plan: "default"},
+				Status:     service.ServiceStatus{Status: "ProvisionedSuccessfully"},
+			}},
+		}},
+		"project-list": {project.ProjectList{Items: []project.Project{project.GetMachineReadableFormat("myproject", true)}}},
+		"application":  {app},
+		"application-list": {
+			application.GetMachineReadableFormatForList([]application.App{app}),
+			application.GetMachineReadableFormatForList([]application.App{}),
+		},
+		"catalog-component-list": {list.CombinedCatalogList{
+			TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: machineoutput.APIVersion},
+			DevfileItems: []catalog.DevfileComponentType{{
+				Name:     "nodejs",
+				Registry: catalog.Registry{Name: "DefaultDevfileRegistry", URL: "https://registry.devfile.io"},
+				Tags:     []string{"NodeJS"},
+				Versions: []catalog.StackVersion{{Version: "1.0.0", Default: true}},
+			}},
+		}},
+		"catalog-component-description": {[]describe.DevfileComponentDescription{{
+			RegistryName: "DefaultDevfileRegistry",
+			Version:      "1.0.0",
+			Versions:     []catalog.StackVersion{{Version: "1.0.0", Default: true}},
+		}}},
+		"registry-list": {
+			machineoutput.NewRegistryListOutput(&[]preference.Registry{{Name: "DefaultDevfileRegistry", URL: "https://registry.devfile.io", Secure: false}}),
+			machineoutput.NewRegistryListOutput(nil),
+		},
+		"preference-list": {preference.NewPreferenceList(preference.PreferenceInfo{})},
+		"telemetry-event-list": {
+			segment.NewRecordedEventList([]segment.RecordedEvent{{
+				Event: segment.Event{
+					MessageID:  "5c8d1034-4a8e-4fdb-a00a-b3455a6da300",
+					Type:       "track",
+					UserID:     "359eb85b-01bc-4605-9e4e-bdd3ce5e74a4",
+					Event:      "odo push",
+					Properties: map[string]interface{}{"success": true, "componentType": "nodejs"},
+					Timestamp:  time.Now(),
+				},
+				Sink:   preference.TelemetrySinkSegment,
+				Status: segment.EventQueued,
+			}}, 1),
+			segment.NewRecordedEventList(nil, 0),
+		},
+		"event": events,
+		"error": {machineoutput.GenericError{
+			TypeMeta:   metav1.TypeMeta{Kind: machineoutput.Kind, APIVersion: machineoutput.APIVersion},
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()},
+			Message:    "unable to get the component",
+		}},
+	}
+}
+
+func TestSchemasValidateOutputs(t *testing.T) {
+	outputs := outputs(t)
+
+	for _, kind := range Kinds {
+		t.Run(kind.Name, func(t *testing.T) {
+			data, err := json.Marshal(machineoutput.GenerateSchema(kind))
+			if err != nil {
+				t.Fatal(err)
+			}
+			schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(data))
+			if err != nil {
+				t.Fatalf("the schema is invalid: %v", err)
+			}
+
+			objects, ok := outputs[kind.Name]
+			if !ok || len(objects) == 0 {
+				t.Fatalf("no output of the kind is validated")
+			}
+			for _, obj := range objects {
+				var out bytes.Buffer
+				if err := machineoutput.PrintObject(&out, machineoutput.OutputFormatJSON, obj); err != nil {
+					t.Fatal(err)
+				}
+				result, err := schema.Validate(gojsonschema.NewBytesLoader(out.Bytes()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !result.Valid() {
+					t.Errorf("the output doesn't match the schema: %v\n%s", result.Errors(), out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGetKind(t *testing.T) {
+	if _, ok := GetKind("url-list"); !ok {
+		t.Errorf("expected the url-list kind")
+	}
+	if _, ok := GetKind("url"); ok {
+		t.Errorf("unexpected url kind")
+	}
+}
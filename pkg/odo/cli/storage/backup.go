@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/odo/pkg/devfile"
+	"github.com/openshift/odo/pkg/devfile/adapters"
+	"github.com/openshift/odo/pkg/devfile/adapters/common"
+	"github.com/openshift/odo/pkg/devfile/adapters/docker"
+	"github.com/openshift/odo/pkg/devfile/adapters/kubernetes"
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/cli/component"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/odo/util/completion"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/storage/backup"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const backupRecommendedCommandName = "backup"
+
+var (
+	storageBackupShortDesc = `Back up the content of a storage of a component`
+	storageBackupLongDesc  = ktemplates.LongDesc(`Back up the content of a storage of a component.
+
+	The content of the storage is streamed out of the running component as a tar,
+	and saved to a local gzip compressed archive along with a manifest describing the backup.
+	The archive can be restored with the "storage restore" command.`)
+	storageBackupExample = ktemplates.Examples(`
+	# Back up the content of storage mystorage to mystorage-<date>-<time>.tar.gz
+  %[1]s mystorage
+
+	# Back up the content of storage mystorage to the given archive
+  %[1]s mystorage --file /backups/mystorage.tar.gz
+	`)
+)
+
+type BackupOptions struct {
+	storageName      string
+	file             string
+	componentContext string
+
+	storage *localConfigProvider.LocalStorage
+	*genericclioptions.Context
+}
+
+// NewStorageBackupOptions creates a new BackupOptions instance
+func NewStorageBackupOptions() *BackupOptions {
+	return &BackupOptions{}
+}
+
+// Complete completes BackupOptions after they've been created
+func (o *BackupOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.Context, err = newStorageContext(cmd, o.componentContext)
+
+	if err != nil {
+		return err
+	}
+
+	o.storageName = args[0]
+	if o.file == "" {
+		o.file = fmt.Sprintf("%s-%s.tar.gz", o.storageName, time.Now().Format("20060102-150405"))
+	}
+	return
+}
+
+// Validate validates the BackupOptions based on completed values
+func (o *BackupOptions) Validate() (err error) {
+	if o.EnvSpecificInfo == nil {
+		return fmt.Errorf("storage backup is only supported for devfile components")
+	}
+
+	o.storage, err = o.LocalConfigProvider.GetStorage(o.storageName)
+	if err != nil {
+		return err
+	}
+	if o.storage == nil {
+		return fmt.Errorf("the storage %v does not exist in the component %v", o.storageName, o.LocalConfigProvider.GetName())
+	}
+
+	if _, err := os.Stat(o.file); err == nil {
+		return fmt.Errorf("the file %s already exists", o.file)
+	}
+	return
+}
+
+// Run contains the logic for the odo storage backup command
+func (o *BackupOptions) Run(cmd *cobra.Command) (err error) {
+	adapter, err := newComponentAdapter(o.Context)
+	if err != nil {
+		return err
+	}
+	mount, err := adapter.GetStorageMount(o.storageName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(o.file)
+	if err != nil {
+		return err
+	}
+
+	s := log.Spinnerf("Backing up storage %s mounted on %s to %s", o.storageName, mount.Path, o.file)
+	manifest := backup.NewManifest(o.storageName, o.LocalConfigProvider.GetName(), o.LocalConfigProvider.GetApplication(), o.storage.Size)
+	manifest, err = backup.Backup(adapter, mount, manifest, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	s.End(err == nil)
+	if err != nil {
+		_ = os.Remove(o.file)
+		return err
+	}
+
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(manifest)
+	} else {
+		log.Successf("Backed up %d files (%d bytes) of storage %s to %s", manifest.Spec.Files, manifest.Spec.Bytes, o.storageName, o.file)
+	}
+	return
+}
+
+// newStorageContext returns the context of the component of the given directory,
+// without connecting to the cluster when the PushTarget preference runs the devfile components on Docker
+func newStorageContext(cmd *cobra.Command, componentContext string) (*genericclioptions.Context, error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return nil, err
+	}
+	devfilePath := filepath.Join(componentContext, component.DevfilePath)
+	if cfg.GetPushTarget() != preference.PushTargetDocker || !util.CheckPathExists(devfilePath) {
+		return genericclioptions.New(genericclioptions.CreateParameters{
+			Cmd:              cmd,
+			DevfilePath:      component.DevfilePath,
+			ComponentContext: componentContext,
+		})
+	}
+
+	context := genericclioptions.NewOfflineDevfileContext(cmd)
+	context.ComponentContext = componentContext
+	devObj, err := devfile.ParseFromFile(devfilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the devfile %s, with error: %s", devfilePath, err)
+	}
+	context.EnvSpecificInfo.SetDevfileObj(devObj)
+	context.LocalConfigProvider = context.EnvSpecificInfo
+	return context, nil
+}
+
+// newComponentAdapter returns the adapter of the devfile component of the given context,
+// for the platform set by the PushTarget preference
+func newComponentAdapter(context *genericclioptions.Context) (common.ComponentAdapter, error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return nil, err
+	}
+	var platformContext interface{} = docker.DockerContext{}
+	if cfg.GetPushTarget() != preference.PushTargetDocker {
+		platformContext = kubernetes.KubernetesContext{
+			Namespace: context.KClient.Namespace,
+		}
+	}
+	return adapters.NewComponentAdapter(context.EnvSpecificInfo.GetName(), context.ComponentContext, context.Application, context.EnvSpecificInfo.GetDevfileObj(), platformContext)
+}
+
+// NewCmdStorageBackup implements the odo storage backup command.
+func NewCmdStorageBackup(name, fullName string) *cobra.Command {
+	o := NewStorageBackupOptions()
+	storageBackupCmd := &cobra.Command{
+		Use:         name + " <storage name>",
+		Short:       storageBackupShortDesc,
+		Long:        storageBackupLongDesc,
+		Example:     fmt.Sprintf(storageBackupExample, fullName),
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	storageBackupCmd.Flags().StringVar(&o.file, "file", "", "Archive to save the backup to, <storage name>-<date>-<time>.tar.gz in the current directory if not specified")
+	completion.RegisterCommandFlagHandler(storageBackupCmd, "file", completion.FileCompletionHandler)
+
+	genericclioptions.AddContextFlag(storageBackupCmd, &o.componentContext)
+	completion.RegisterCommandFlagHandler(storageBackupCmd, "context", completion.FileCompletionHandler)
+
+	return storageBackupCmd
+}
@@ -2,7 +2,10 @@ package storage
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/openshift/odo/pkg/envinfo"
 	"github.com/openshift/odo/pkg/localConfigProvider"
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/machineoutput"
@@ -19,10 +22,24 @@ const createRecommendedCommandName = "create"
 
 var (
 	storageCreateShortDesc = `Create storage and mount to a component`
-	storageCreateLongDesc  = ktemplates.LongDesc(`Create storage and mount to a component`)
-	storageCreateExample   = ktemplates.Examples(`
+	storageCreateLongDesc  = ktemplates.LongDesc(`Create storage and mount to a component.
+
+	With --from-file or --from-dir, the storage is created from local files of the component directory:
+	a ConfigMap, or a Secret with --secret, holding the file or the files of the directory is mounted on the path.
+	The ConfigMap or Secret is updated when the files change on "odo push" and "odo watch",
+	and the kubelet refreshes the mounted files in the running containers without restarting them.`)
+	storageCreateExample = ktemplates.Examples(`
 	# Create storage of size 1Gb to a component
   %[1]s mystorage --path=/opt/app-root/src/storage/ --size=1Gi
+
+	# Create storage of the storage class 'fast', shared by the pods of a component
+  %[1]s mystorage --path=/data --size=5Gi --storage-class=fast --access-mode=ReadWriteMany
+
+	# Mount the file config/application.properties of the component directory on /deployments/config
+  %[1]s app-config --from-file=config/application.properties --path=/deployments/config
+
+	# Mount the files of the directory tls of the component directory on /etc/tls, from a Secret
+  %[1]s tls --from-dir=tls --path=/etc/tls --secret
 	`)
 )
 
@@ -32,8 +49,13 @@ type CreateOptions struct {
 	storagePath      string
 	componentContext string
 
-	container string // container to which this storage belongs
-	storage   localConfigProvider.LocalStorage
+	container    string // container to which this storage belongs
+	storageClass string // storage class of the PVC of this storage
+	accessMode   string // access mode of the PVC of this storage
+	fromFile     string // local file mounted instead of a PVC
+	fromDir      string // local directory whose files are mounted instead of a PVC
+	secret       bool   // mount the local files from a Secret instead of a ConfigMap
+	storage      localConfigProvider.LocalStorage
 	*genericclioptions.Context
 }
 
@@ -61,10 +83,23 @@ func (o *CreateOptions) Complete(name string, cmd *cobra.Command, args []string)
 	}
 
 	o.storage = localConfigProvider.LocalStorage{
-		Name:      o.storageName,
-		Size:      o.storageSize,
-		Path:      o.storagePath,
-		Container: o.container,
+		Name:         o.storageName,
+		Size:         o.storageSize,
+		Path:         o.storagePath,
+		Container:    o.container,
+		StorageClass: o.storageClass,
+		AccessMode:   o.accessMode,
+	}
+
+	if source := o.fromFile + o.fromDir; source != "" {
+		// the source is stored relative to the component directory
+		o.storage.Source, err = relativeToContext(o.componentContext, source)
+		if err != nil {
+			return err
+		}
+		if o.secret {
+			o.storage.SourceKind = envinfo.SourceKindSecret
+		}
 	}
 
 	o.Context.LocalConfigProvider.CompleteStorage(&o.storage)
@@ -74,6 +109,27 @@ func (o *CreateOptions) Complete(name string, cmd *cobra.Command, args []string)
 
 // Validate validates the CreateOptions based on completed values
 func (o *CreateOptions) Validate() (err error) {
+	if o.fromFile != "" && o.fromDir != "" {
+		return fmt.Errorf("only one of --from-file and --from-dir can be specified")
+	}
+	if o.secret && o.storage.Source == "" {
+		return fmt.Errorf("--secret can only be used with --from-file or --from-dir")
+	}
+
+	if o.storage.Source != "" {
+		// the source is read relative to the component directory when the component is pushed
+		stat, err := os.Stat(filepath.Join(o.componentContext, filepath.FromSlash(o.storage.Source)))
+		if err != nil {
+			return err
+		}
+		if o.fromFile != "" && !stat.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", o.fromFile)
+		}
+		if o.fromDir != "" && !stat.IsDir() {
+			return fmt.Errorf("%s is not a directory", o.fromDir)
+		}
+	}
+
 	// validate the storage
 	return o.LocalConfigProvider.ValidateStorage(o.storage)
 }
@@ -87,6 +143,10 @@ func (o *CreateOptions) Run(cmd *cobra.Command) (err error) {
 
 	if log.IsJSON() {
 		storageResultMachineReadable := storage.GetMachineReadableFormat(o.storage.Name, o.storage.Size, o.storage.Path)
+		storageResultMachineReadable.Spec.StorageClass = o.storage.StorageClass
+		storageResultMachineReadable.Spec.AccessMode = o.storage.AccessMode
+		storageResultMachineReadable.Spec.Source = o.storage.Source
+		storageResultMachineReadable.Spec.SourceKind = o.storage.SourceKind
 		machineoutput.OutputSuccess(storageResultMachineReadable)
 	} else {
 		log.Successf("Added storage %v to %v", o.storageName, o.Context.LocalConfigProvider.GetName())
@@ -114,9 +174,30 @@ func NewCmdStorageCreate(name, fullName string) *cobra.Command {
 	storageCreateCmd.Flags().StringVar(&o.storageSize, "size", "", "Size of storage to add")
 	storageCreateCmd.Flags().StringVar(&o.storagePath, "path", "", "Path to mount the storage on")
 	storageCreateCmd.Flags().StringVar(&o.container, "container", "", "Name of container to attach the storage to in devfile")
+	storageCreateCmd.Flags().StringVar(&o.storageClass, "storage-class", "", "Storage class of the storage, the default storage class of the cluster if not specified")
+	storageCreateCmd.Flags().StringVar(&o.accessMode, "access-mode", "", "Access mode of the storage, one of ReadWriteOnce|ReadOnlyMany|ReadWriteMany, ReadWriteOnce if not specified")
+	storageCreateCmd.Flags().StringVar(&o.fromFile, "from-file", "", "File of the component directory to mount on the path, from a ConfigMap or a Secret")
+	storageCreateCmd.Flags().StringVar(&o.fromDir, "from-dir", "", "Directory of the component directory whose files are mounted on the path, from a ConfigMap or a Secret")
+	storageCreateCmd.Flags().BoolVar(&o.secret, "secret", false, "Mount the files of --from-file or --from-dir from a Secret instead of a ConfigMap")
+	completion.RegisterCommandFlagHandler(storageCreateCmd, "from-file", completion.FileCompletionHandler)
+	completion.RegisterCommandFlagHandler(storageCreateCmd, "from-dir", completion.FileCompletionHandler)
+	completion.RegisterLocalCommandFlagHandler(storageCreateCmd, "container", completion.ContainerCompletionHandler)
 
 	genericclioptions.AddContextFlag(storageCreateCmd, &o.componentContext)
 	completion.RegisterCommandFlagHandler(storageCreateCmd, "context", completion.FileCompletionHandler)
 
 	return storageCreateCmd
 }
+
+// relativeToContext returns the given path relative to the given component context
+func relativeToContext(componentContext, path string) (string, error) {
+	contextDir, err := filepath.Abs(componentContext)
+	if err != nil {
+		return "", err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(contextDir, absPath)
+}
@@ -112,7 +112,7 @@ func NewCmdStorageDelete(name, fullName string) *cobra.Command {
 	}
 
 	storageDeleteCmd.Flags().BoolVarP(&o.storageForceDeleteFlag, "force", "f", false, "Delete storage without prompting")
-	completion.RegisterCommandHandler(storageDeleteCmd, completion.StorageDeleteCompletionHandler)
+	completion.RegisterLocalCommandHandler(storageDeleteCmd, completion.StorageDeleteCompletionHandler)
 
 	genericclioptions.AddContextFlag(storageDeleteCmd, &o.componentContext)
 	completion.RegisterCommandFlagHandler(storageDeleteCmd, "context", completion.FileCompletionHandler)
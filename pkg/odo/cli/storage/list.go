@@ -106,7 +106,7 @@ func printStorage(storageList storage.StorageList, compName string) {
 			_, ok := storageMap[mStorage.Name]
 			if !ok {
 				storageMap[mStorage.Name] = true
-				fmt.Fprintln(tabWriterMounted, mStorage.Name, "\t", mStorage.Spec.Size, "\t", mStorage.Spec.Path, "\t", mStorage.Status)
+				fmt.Fprintln(tabWriterMounted, mStorage.Name, "\t", storageSize(mStorage), "\t", mStorage.Spec.Path, "\t", mStorage.Status)
 			}
 		}
 
@@ -131,7 +131,7 @@ func printStorageWithContainer(storageList storage.StorageList, compName string)
 		fmt.Fprintln(tabWriterMounted, "NAME", "\t", "SIZE", "\t", "PATH", "\t", "CONTAINER", "\t", "STATE")
 		// iterating over all mounted storage and put in the mount storage table
 		for _, mStorage := range storageList.Items {
-			fmt.Fprintln(tabWriterMounted, mStorage.Name, "\t", mStorage.Spec.Size, "\t", mStorage.Spec.Path, "\t", mStorage.Spec.ContainerName, "\t", mStorage.Status)
+			fmt.Fprintln(tabWriterMounted, mStorage.Name, "\t", storageSize(mStorage), "\t", mStorage.Spec.Path, "\t", mStorage.Spec.ContainerName, "\t", mStorage.Status)
 		}
 
 		// print all mounted storage of the given component
@@ -144,6 +144,14 @@ func printStorageWithContainer(storageList storage.StorageList, compName string)
 	fmt.Println("")
 }
 
+// storageSize returns the size to display for the given storage, or its source for the storage mounted from local files
+func storageSize(s storage.Storage) string {
+	if s.Spec.Source != "" {
+		return fmt.Sprintf("%s (%s)", s.Spec.Source, s.Spec.SourceKind)
+	}
+	return s.Spec.Size
+}
+
 // isContainerDisplay checks whether the container name should be included in the output
 func isContainerDisplay(storageList storage.StorageList, components []localConfigProvider.LocalContainer) bool {
 
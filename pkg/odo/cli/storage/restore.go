@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/cli/ui"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/odo/util/completion"
+	"github.com/openshift/odo/pkg/storage/backup"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const restoreRecommendedCommandName = "restore"
+
+var (
+	storageRestoreShortDesc = `Restore the content of a storage of a component from a backup`
+	storageRestoreLongDesc  = ktemplates.LongDesc(`Restore the content of a storage of a component from a backup.
+
+	The archive created by the "storage backup" command is verified against its manifest,
+	and its content is extracted into the storage of the running component.
+	Files of the storage which are also in the archive are overwritten.`)
+	storageRestoreExample = ktemplates.Examples(`
+	# Restore the backup to the storage it was taken from
+  %[1]s mystorage-20210601-120000.tar.gz
+
+	# Restore the backup to the storage mystorage
+  %[1]s /backups/db.tar.gz --storage mystorage
+	`)
+)
+
+type RestoreOptions struct {
+	file             string
+	storageName      string
+	forceFlag        bool
+	componentContext string
+
+	manifest backup.Manifest
+	*genericclioptions.Context
+}
+
+// NewStorageRestoreOptions creates a new RestoreOptions instance
+func NewStorageRestoreOptions() *RestoreOptions {
+	return &RestoreOptions{}
+}
+
+// Complete completes RestoreOptions after they've been created
+func (o *RestoreOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.Context, err = newStorageContext(cmd, o.componentContext)
+
+	if err != nil {
+		return err
+	}
+
+	o.file = args[0]
+	return
+}
+
+// Validate validates the RestoreOptions based on completed values
+func (o *RestoreOptions) Validate() (err error) {
+	if o.EnvSpecificInfo == nil {
+		return fmt.Errorf("storage restore is only supported for devfile components")
+	}
+
+	file, err := os.Open(o.file)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	o.manifest, err = backup.ReadManifest(file)
+	if err != nil {
+		return fmt.Errorf("invalid backup %s: %v", o.file, err)
+	}
+
+	if o.storageName == "" {
+		o.storageName = o.manifest.Name
+	}
+	gotStorage, err := o.LocalConfigProvider.GetStorage(o.storageName)
+	if err != nil {
+		return err
+	}
+	if gotStorage == nil {
+		return fmt.Errorf("the storage %v does not exist in the component %v", o.storageName, o.LocalConfigProvider.GetName())
+	}
+	return
+}
+
+// Run contains the logic for the odo storage restore command
+func (o *RestoreOptions) Run(cmd *cobra.Command) (err error) {
+	adapter, err := newComponentAdapter(o.Context)
+	if err != nil {
+		return err
+	}
+	mount, err := adapter.GetStorageMount(o.storageName)
+	if err != nil {
+		return err
+	}
+
+	restoreMsg := fmt.Sprintf("Are you sure you want to restore the backup of storage %v of component %v to the storage %v mounted on %v, overwriting its files", o.manifest.Name, o.manifest.Spec.Component, o.storageName, mount.Path)
+	if !log.IsJSON() && !o.forceFlag && !ui.Proceed(restoreMsg) {
+		return fmt.Errorf("aborting restore of storage: %v", o.storageName)
+	}
+
+	file, err := os.Open(o.file)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	s := log.Spinnerf("Restoring %s to storage %s mounted on %s", o.file, o.storageName, mount.Path)
+	err = backup.Restore(adapter, mount, file)
+	s.End(err == nil)
+	if err != nil {
+		return err
+	}
+
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(o.manifest)
+	} else {
+		log.Successf("Restored %d files (%d bytes) to storage %s", o.manifest.Spec.Files, o.manifest.Spec.Bytes, o.storageName)
+	}
+	return
+}
+
+// NewCmdStorageRestore implements the odo storage restore command.
+func NewCmdStorageRestore(name, fullName string) *cobra.Command {
+	o := NewStorageRestoreOptions()
+	storageRestoreCmd := &cobra.Command{
+		Use:         name + " <archive>",
+		Short:       storageRestoreShortDesc,
+		Long:        storageRestoreLongDesc,
+		Example:     fmt.Sprintf(storageRestoreExample, fullName),
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+
+	storageRestoreCmd.Flags().StringVar(&o.storageName, "storage", "", "Storage to restore the backup to, the storage the backup was taken from if not specified")
+	storageRestoreCmd.Flags().BoolVarP(&o.forceFlag, "force", "f", false, "Restore the backup without prompting")
+	completion.RegisterCommandHandler(storageRestoreCmd, completion.FileCompletionHandler)
+
+	genericclioptions.AddContextFlag(storageRestoreCmd, &o.componentContext)
+	completion.RegisterCommandFlagHandler(storageRestoreCmd, "context", completion.FileCompletionHandler)
+
+	return storageRestoreCmd
+}
@@ -24,20 +24,26 @@ func NewCmdStorage(name, fullName string) *cobra.Command {
 	storageCreateCmd := NewCmdStorageCreate(createRecommendedCommandName, odoutil.GetFullName(fullName, createRecommendedCommandName))
 	storageDeleteCmd := NewCmdStorageDelete(deleteRecommendedCommandName, odoutil.GetFullName(fullName, deleteRecommendedCommandName))
 	storageListCmd := NewCmdStorageList(listRecommendedCommandName, odoutil.GetFullName(fullName, listRecommendedCommandName))
+	storageBackupCmd := NewCmdStorageBackup(backupRecommendedCommandName, odoutil.GetFullName(fullName, backupRecommendedCommandName))
+	storageRestoreCmd := NewCmdStorageRestore(restoreRecommendedCommandName, odoutil.GetFullName(fullName, restoreRecommendedCommandName))
 
 	var storageCmd = &cobra.Command{
 		Use:   name,
 		Short: storageShortDesc,
 		Long:  storageLongDesc,
-		Example: fmt.Sprintf("%s\n\n%s\n\n%s",
+		Example: fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s\n\n%s",
 			storageCreateCmd.Example,
 			storageDeleteCmd.Example,
-			storageListCmd.Example),
+			storageListCmd.Example,
+			storageBackupCmd.Example,
+			storageRestoreCmd.Example),
 	}
 
 	storageCmd.AddCommand(storageCreateCmd)
 	storageCmd.AddCommand(storageDeleteCmd)
 	storageCmd.AddCommand(storageListCmd)
+	storageCmd.AddCommand(storageBackupCmd)
+	storageCmd.AddCommand(storageRestoreCmd)
 
 	// Add a defined annotation in order to appear in the help menu
 	storageCmd.Annotations = map[string]string{"command": "main"}
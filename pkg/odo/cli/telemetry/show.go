@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/segment"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const showCommandName = "show"
+
+var (
+	showLongDesc = ktemplates.LongDesc(`Show the usage data recently recorded by odo.
+
+	The events are shown as they are uploaded to the sink, after the user information and the file paths are removed.
+	Every event is recorded, with the sink it was uploaded to and the outcome of the upload, even when the sink is none.`)
+
+	showExample = ktemplates.Examples(`# Show the usage data recently recorded
+	%[1]s
+
+	# Show the last 5 events
+	%[1]s --limit 5
+	`)
+)
+
+// ShowOptions encapsulates the options for the odo telemetry show command
+type ShowOptions struct {
+	limitFlag int
+
+	events []segment.RecordedEvent
+	queued int
+}
+
+// NewShowOptions creates a new ShowOptions instance
+func NewShowOptions() *ShowOptions {
+	return &ShowOptions{}
+}
+
+// Complete completes ShowOptions after they've been created
+func (o *ShowOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return errors.Wrap(err, "unable to read the preference")
+	}
+	dataDir := cfg.GetTelemetryDir()
+
+	o.events, err = segment.ReadRecordedEvents(dataDir)
+	if err != nil {
+		return errors.Wrap(err, "unable to read the recorded usage data")
+	}
+	queued, err := segment.ReadQueuedEvents(dataDir)
+	if err != nil {
+		return errors.Wrap(err, "unable to read the queued usage data")
+	}
+	o.queued = len(queued)
+	return nil
+}
+
+// Validate validates the ShowOptions based on completed values
+func (o *ShowOptions) Validate() (err error) {
+	if o.limitFlag < 0 {
+		return fmt.Errorf("the limit must be positive, got %d", o.limitFlag)
+	}
+	if o.limitFlag > 0 && len(o.events) > o.limitFlag {
+		o.events = o.events[len(o.events)-o.limitFlag:]
+	}
+	return nil
+}
+
+// Run contains the logic for the odo telemetry show command
+func (o *ShowOptions) Run(cmd *cobra.Command) (err error) {
+	if log.IsJSON() {
+		machineoutput.OutputSuccess(segment.NewRecordedEventList(o.events, o.queued))
+		return nil
+	}
+
+	if len(o.events) == 0 {
+		log.Info("No usage data was recorded")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "TIME", "\t", "TYPE", "\t", "EVENT", "\t", "SINK", "\t", "STATUS", "\t", "DATA")
+	for _, event := range o.events {
+		data := event.Properties
+		if event.Type == "identify" {
+			data = event.Traits
+		}
+		fmt.Fprintln(w, event.Timestamp.Local().Format("2006-01-02 15:04:05"), "\t", event.Type, "\t", event.Event.Event, "\t", event.Sink, "\t", event.Status, "\t", formatData(data))
+	}
+	w.Flush()
+
+	if o.queued > 0 {
+		log.Infof("\n%d event(s) are queued and will be uploaded with the usage data of the next command", o.queued)
+	}
+	return nil
+}
+
+// formatData returns the data as key=value pairs sorted by key
+func formatData(data map[string]interface{}) string {
+	var pairs []string
+	for k, v := range data {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+// NewCmdShow implements the odo telemetry show command
+func NewCmdShow(name, fullName string) *cobra.Command {
+	o := NewShowOptions()
+	showCmd := &cobra.Command{
+		Use:         name,
+		Short:       "Show the usage data recently recorded",
+		Long:        showLongDesc,
+		Example:     fmt.Sprintf(showExample, fullName),
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{"machineoutput": "json"},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	showCmd.Flags().IntVar(&o.limitFlag, "limit", 0, "Show only the given number of the latest events")
+	return showCmd
+}
@@ -1,77 +1,34 @@
 package telemetry
 
 import (
-	"encoding/json"
+	"github.com/openshift/odo/pkg/odo/util"
 
-	"github.com/openshift/odo/pkg/odo/genericclioptions"
-
-	"github.com/openshift/odo/pkg/preference"
-	"github.com/openshift/odo/pkg/segment"
-	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	"k8s.io/klog"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
 )
 
+// RecommendedCommandName is the recommended telemetry command name
 const RecommendedCommandName = "telemetry"
 
-type TelemetryOptions struct {
-	telemetryData segment.TelemetryData
-}
+var telemetryLongDesc = ktemplates.LongDesc(`Inspect the usage data collected by odo.
 
-func NewTelemetryOptions() *TelemetryOptions {
-	return &TelemetryOptions{}
-}
-
-func (o *TelemetryOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
-	err = json.Unmarshal([]byte(args[0]), &o.telemetryData)
-	return err
-}
-
-func (o *TelemetryOptions) Validate() (err error) {
-	return err
-}
-
-func (o *TelemetryOptions) Run(cmd *cobra.Command) (err error) {
-	cfg, err := preference.New()
-	if err != nil {
-		return errors.Wrapf(err, "unable to upload telemetry data")
-	}
+The usage data is uploaded to the sink set by the TelemetrySink preference: segment, none, a file:// URL
+or a http(s):// URL. The data which can't be uploaded is queued and uploaded with the data of the next command.`)
 
-	if !segment.IsTelemetryEnabled(cfg) {
-		return nil
-	}
-
-	segmentClient, err := segment.NewClient(cfg)
-	if err != nil {
-		klog.V(4).Infof("Cannot create a segment client. Will not send any data: %q", err)
-	}
-	defer segmentClient.Close()
-
-	err = segmentClient.Upload(o.telemetryData)
-	if err != nil {
-		klog.V(4).Infof("Cannot send data to telemetry: %q", err)
+// NewCmdTelemetry implements the odo telemetry command
+func NewCmdTelemetry(name, fullName string) *cobra.Command {
+	showCmd := NewCmdShow(showCommandName, util.GetFullName(fullName, showCommandName))
+	uploadCmd := NewCmdUpload(uploadCommandName)
+	telemetryCmd := &cobra.Command{
+		Use:     name,
+		Short:   "Inspect the usage data collected by odo",
+		Long:    telemetryLongDesc,
+		Example: showCmd.Example,
 	}
 
-	return segmentClient.Close()
-}
+	telemetryCmd.AddCommand(showCmd, uploadCmd)
+	telemetryCmd.SetUsageTemplate(util.CmdUsageTemplate)
+	telemetryCmd.Annotations = map[string]string{"command": "utility"}
 
-func NewCmdTelemetry(name string) *cobra.Command {
-	o := NewTelemetryOptions()
-	telemetryCmd := &cobra.Command{
-		Use:                    name,
-		Short:                  "Collect and upload usage data.",
-		BashCompletionFunction: "",
-		Hidden:                 true,
-		Args:                   cobra.ExactArgs(1),
-		Annotations:            map[string]string{},
-		SilenceErrors:          true,
-		SilenceUsage:           true,
-		DisableFlagsInUseLine:  true,
-		DisableSuggestions:     true,
-		FParseErrWhitelist:     cobra.FParseErrWhitelist{},
-		Run: func(cmd *cobra.Command, args []string) {
-			genericclioptions.GenericRun(o, cmd, args)
-		},
-	}
 	return telemetryCmd
 }
@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"encoding/json"
+
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/segment"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/klog"
+)
+
+const uploadCommandName = "upload"
+
+// UploadOptions encapsulates the options for the odo telemetry upload command
+type UploadOptions struct {
+	telemetryData segment.TelemetryData
+}
+
+// NewUploadOptions creates a new UploadOptions instance
+func NewUploadOptions() *UploadOptions {
+	return &UploadOptions{}
+}
+
+func (o *UploadOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	err = json.Unmarshal([]byte(args[0]), &o.telemetryData)
+	return err
+}
+
+func (o *UploadOptions) Validate() (err error) {
+	return err
+}
+
+func (o *UploadOptions) Run(cmd *cobra.Command) (err error) {
+	cfg, err := preference.New()
+	if err != nil {
+		return errors.Wrapf(err, "unable to upload telemetry data")
+	}
+
+	if !segment.IsTelemetryEnabled(cfg) {
+		return nil
+	}
+
+	segmentClient, err := segment.NewClient(cfg)
+	if err != nil {
+		klog.V(4).Infof("Cannot create a segment client. Will not send any data: %q", err)
+	}
+	defer segmentClient.Close()
+
+	err = segmentClient.Upload(o.telemetryData)
+	if err != nil {
+		klog.V(4).Infof("Cannot send data to telemetry: %q", err)
+	}
+
+	return segmentClient.Close()
+}
+
+// NewCmdUpload implements the hidden odo telemetry upload command, run in the background by the other commands
+func NewCmdUpload(name string) *cobra.Command {
+	o := NewUploadOptions()
+	uploadCmd := &cobra.Command{
+		Use:                    name,
+		Short:                  "Collect and upload usage data.",
+		BashCompletionFunction: "",
+		Hidden:                 true,
+		Args:                   cobra.ExactArgs(1),
+		Annotations:            map[string]string{},
+		SilenceErrors:          true,
+		SilenceUsage:           true,
+		DisableFlagsInUseLine:  true,
+		DisableSuggestions:     true,
+		FParseErrWhitelist:     cobra.FParseErrWhitelist{},
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	return uploadCmd
+}
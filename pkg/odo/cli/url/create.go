@@ -45,6 +45,21 @@ var (
 	# Create a secure URL for the current component
 	%[1]s --port 8080 --secure
 
+	# Create a secure URL of ingress kind with a certificate issued by the cert-manager ClusterIssuer 'letsencrypt'
+	%[1]s --port 8080 --host example.com --ingress --secure --issuer letsencrypt
+
+	# Create a URL of httproute kind attached to the Gateway API gateway 'gateway' of the 'infra' namespace
+	%[1]s --port 8080 --host example.com --gateway infra/gateway
+
+	# Create a secure URL of httproute kind only matching requests with the header 'X-Version: v2', TLS is terminated by the gateway
+	%[1]s --port 8080 --host example.com --gateway infra/gateway --secure --header X-Version=v2
+
+	# Create a URL of nodeport kind exposing a TCP endpoint through a NodePort service
+	%[1]s postgres --port 5432 --protocol tcp --kind nodeport
+
+	# Create a URL of loadbalancer kind exposing a UDP endpoint through a LoadBalancer service
+	%[1]s --port 5683 --protocol udp --kind loadbalancer
+
 	# Create a URL with a specific path and protocol type
 	%[1]s --port 8080 --path /hello --protocol http
 
@@ -60,12 +75,17 @@ type CreateOptions struct {
 	urlPort     int
 	secureURL   bool
 	now         bool
-	host        string // host of the URL
-	tlsSecret   string // tlsSecret is the secret to te used by the URL
-	path        string // path of the URL
-	protocol    string // protocol of the URL
-	container   string // container to which the URL belongs
+	host        string   // host of the URL
+	tlsSecret   string   // tlsSecret is the secret to te used by the URL
+	issuer      string   // issuer is the cert-manager issuer providing the certificate of the URL
+	issuerKind  string   // issuerKind is the kind of the cert-manager issuer
+	path        string   // path of the URL
+	protocol    string   // protocol of the URL
+	container   string   // container to which the URL belongs
+	gateway     string   // gateway to which the httproute of the URL is attached
+	headers     []string // headers matched by the httproute of the URL
 	wantIngress bool
+	kind        string // kind of the URL
 	url         localConfigProvider.LocalURL
 }
 
@@ -88,9 +108,25 @@ func (o *CreateOptions) Complete(_ string, cmd *cobra.Command, args []string) (e
 		return err
 	}
 
-	var urlType localConfigProvider.URLKind
+	urlType := localConfigProvider.URLKind(o.kind)
+	if o.wantIngress && o.gateway != "" {
+		return fmt.Errorf("--ingress and --gateway cannot be used together")
+	}
 	if o.wantIngress {
+		if urlType != "" && urlType != localConfigProvider.INGRESS {
+			return fmt.Errorf("--ingress and --kind %s cannot be used together", o.kind)
+		}
 		urlType = localConfigProvider.INGRESS
+	} else if o.gateway != "" {
+		if urlType != "" && urlType != localConfigProvider.HTTPROUTE {
+			return fmt.Errorf("--gateway and --kind %s cannot be used together", o.kind)
+		}
+		urlType = localConfigProvider.HTTPROUTE
+	}
+	switch urlType {
+	case "", localConfigProvider.ROUTE, localConfigProvider.INGRESS, localConfigProvider.HTTPROUTE, localConfigProvider.NODEPORT, localConfigProvider.LOADBALANCER:
+	default:
+		return fmt.Errorf("unsupported URL kind %q, must be one of %s|%s|%s|%s|%s", o.kind, localConfigProvider.ROUTE, localConfigProvider.INGRESS, localConfigProvider.HTTPROUTE, localConfigProvider.NODEPORT, localConfigProvider.LOADBALANCER)
 	}
 
 	// get the name
@@ -110,6 +146,23 @@ func (o *CreateOptions) Complete(_ string, cmd *cobra.Command, args []string) (e
 		Protocol:  o.protocol,
 		Path:      o.path,
 	}
+	if o.gateway != "" {
+		o.url.Gateway = o.gateway
+	}
+	if len(o.headers) > 0 {
+		o.url.Headers = make(map[string]string)
+		for _, header := range o.headers {
+			kv := strings.SplitN(header, "=", 2)
+			if len(kv) != 2 || kv[0] == "" {
+				return fmt.Errorf("header %q must be of the form name=value", header)
+			}
+			o.url.Headers[kv[0]] = kv[1]
+		}
+	}
+	if o.issuer != "" {
+		o.url.Issuer = o.issuer
+		o.url.IssuerKind = localConfigProvider.IssuerKind(o.issuerKind)
+	}
 
 	// complete the URL
 	err = o.Context.LocalConfigProvider.CompleteURL(&o.url)
@@ -210,8 +263,13 @@ func NewCmdURLCreate(name, fullName string) *cobra.Command {
 	urlCreateCmd.Flags().IntVarP(&o.urlPort, "port", "", -1, "Port number for the url of the component, required in case of components which expose more than one service port")
 
 	urlCreateCmd.Flags().StringVar(&o.tlsSecret, "tls-secret", "", "TLS secret name for the url of the component if the user bring their own TLS secret")
+	urlCreateCmd.Flags().StringVar(&o.issuer, "issuer", "", "Name of the cert-manager issuer providing the certificate of a secure URL of ingress kind, instead of a self-signed certificate")
+	urlCreateCmd.Flags().StringVar(&o.issuerKind, "issuer-kind", string(localConfigProvider.CLUSTERISSUER), fmt.Sprintf("Kind of the cert-manager issuer, %s or %s", localConfigProvider.CLUSTERISSUER, localConfigProvider.ISSUER))
 	urlCreateCmd.Flags().StringVarP(&o.host, "host", "", "", "Cluster IP for this URL")
+	urlCreateCmd.Flags().StringVar(&o.gateway, "gateway", "", "Create a Gateway API HTTPRoute attached to the given gateway, as [namespace/]name, instead of a Route or Ingress")
+	urlCreateCmd.Flags().StringArrayVar(&o.headers, "header", []string{}, "Header, as name=value, the requests must match to be routed by a URL of httproute kind. Can be specified multiple times")
 	urlCreateCmd.Flags().BoolVar(&o.wantIngress, "ingress", false, "Create an Ingress instead of Route on OpenShift clusters")
+	urlCreateCmd.Flags().StringVar(&o.kind, "kind", "", fmt.Sprintf("Kind of the URL, one of %s|%s|%s|%s|%s. The %s and %s kinds expose TCP or UDP endpoints through a dedicated Service", localConfigProvider.ROUTE, localConfigProvider.INGRESS, localConfigProvider.HTTPROUTE, localConfigProvider.NODEPORT, localConfigProvider.LOADBALANCER, localConfigProvider.NODEPORT, localConfigProvider.LOADBALANCER))
 	urlCreateCmd.Flags().BoolVarP(&o.secureURL, "secure", "", false, "Create a secure HTTPS URL")
 	urlCreateCmd.Flags().StringVarP(&o.path, "path", "", "", "path for this URL")
 	urlCreateCmd.Flags().StringVarP(&o.protocol, "protocol", "", string(devfilev1.HTTPEndpointProtocol), "protocol for this URL")
@@ -221,6 +279,8 @@ func NewCmdURLCreate(name, fullName string) *cobra.Command {
 	genericclioptions.AddNowFlag(urlCreateCmd, &o.now)
 	o.AddContextFlag(urlCreateCmd)
 	completion.RegisterCommandFlagHandler(urlCreateCmd, "context", completion.FileCompletionHandler)
+	completion.RegisterLocalCommandFlagHandler(urlCreateCmd, "container", completion.ContainerCompletionHandler)
+	completion.RegisterLocalCommandFlagHandler(urlCreateCmd, "port", completion.EndpointPortCompletionHandler)
 
 	return urlCreateCmd
 }
@@ -25,12 +25,17 @@ var (
 	urlListLongDesc  = ktemplates.LongDesc(`Lists all the available URLs which can be used to access the components.`)
 	urlListExample   = ktemplates.Examples(` # List the available URLs
   %[1]s
+
+  # List the available URLs and probe the /health path of the pushed ones
+  %[1]s --probe --probe-path /health
 	`)
 )
 
 // ListOptions encapsulates the options for the odo url list command
 type ListOptions struct {
 	componentContext string
+	probe            bool
+	probeOptions     url.ProbeOptions
 	*genericclioptions.Context
 	client url.Client
 }
@@ -67,6 +72,9 @@ func (o *ListOptions) Complete(name string, cmd *cobra.Command, args []string) (
 
 // Validate validates the ListOptions based on completed values
 func (o *ListOptions) Validate() (err error) {
+	if o.probeOptions.Timeout <= 0 {
+		return fmt.Errorf("the probe timeout must be positive")
+	}
 	return odoutil.CheckOutputFlag(o.OutputFlag)
 }
 
@@ -77,6 +85,9 @@ func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
 	if err != nil {
 		return err
 	}
+	if o.probe {
+		urls = url.ProbeURLs(urls, o.probeOptions)
+	}
 	if log.IsJSON() {
 		machineoutput.OutputSuccess(urls)
 	} else {
@@ -84,24 +95,57 @@ func (o *ListOptions) Run(cmd *cobra.Command) (err error) {
 			return fmt.Errorf("no URLs found for component %v. Refer `odo url create -h` to add one", componentName)
 		}
 
+		// the certificate expiry is only displayed when known for at least one URL
+		showExpiry := false
+		for _, u := range urls.Items {
+			if u.Status.CertificateExpiry != nil {
+				showExpiry = true
+				break
+			}
+		}
+
 		log.Infof("Found the following URLs for component %v", componentName)
 		tabWriterURL := tabwriter.NewWriter(os.Stdout, 5, 2, 3, ' ', tabwriter.TabIndent)
-		fmt.Fprintln(tabWriterURL, "NAME", "\t", "STATE", "\t", "URL", "\t", "PORT", "\t", "SECURE", "\t", "KIND")
+		header := []interface{}{"NAME", "\t", "STATE", "\t", "URL", "\t", "PORT", "\t", "SECURE", "\t", "KIND"}
+		if showExpiry {
+			header = append(header, "\t", "CERT EXPIRY")
+		}
+		if o.probe {
+			header = append(header, "\t", "PROBE", "\t", "LATENCY", "\t", "TLS", "\t", "REDIRECTS")
+		}
+		fmt.Fprintln(tabWriterURL, header...)
 
 		// are there changes between local and cluster states?
 		outOfSync := false
 		for _, u := range urls.Items {
-			if u.Spec.Kind == localConfigProvider.ROUTE {
-				var urlStr string
+			var urlStr string
+			if localConfigProvider.IsServiceURLKind(u.Spec.Kind) {
+				if u.Status.State == url.StateTypeNotPushed {
+					urlStr = "<provided by cluster>"
+				} else {
+					urlStr = url.GetServiceURLString(u)
+				}
+			} else if u.Spec.Kind == localConfigProvider.ROUTE {
 				if u.Status.State == url.StateTypeNotPushed {
 					urlStr = "<provided by cluster>"
 				} else {
 					urlStr = url.GetURLString(u.Spec.Protocol, u.Spec.Host, "", o.Context.LocalConfigInfo.Exists())
 				}
-				fmt.Fprintln(tabWriterURL, u.Name, "\t", u.Status.State, "\t", urlStr, "\t", u.Spec.Port, "\t", u.Spec.Secure, "\t", u.Spec.Kind)
 			} else {
-				fmt.Fprintln(tabWriterURL, u.Name, "\t", u.Status.State, "\t", url.GetURLString(u.Spec.Protocol, "", u.Spec.Host, false), "\t", u.Spec.Port, "\t", u.Spec.Secure, "\t", u.Spec.Kind)
+				urlStr = url.GetURLString(u.Spec.Protocol, "", u.Spec.Host, false)
+			}
+			row := []interface{}{u.Name, "\t", u.Status.State, "\t", urlStr, "\t", u.Spec.Port, "\t", u.Spec.Secure, "\t", u.Spec.Kind}
+			if showExpiry {
+				expiry := "-"
+				if u.Status.CertificateExpiry != nil && u.Spec.Kind != localConfigProvider.ROUTE {
+					expiry = u.Status.CertificateExpiry.Format("2006-01-02")
+				}
+				row = append(row, "\t", expiry)
 			}
+			if o.probe {
+				row = append(row, getProbeColumns(u.Status.Probe)...)
+			}
+			fmt.Fprintln(tabWriterURL, row...)
 			if u.Status.State != url.StateTypePushed {
 				outOfSync = true
 			}
@@ -130,7 +174,33 @@ func NewCmdURLList(name, fullName string) *cobra.Command {
 		},
 	}
 	genericclioptions.AddContextFlag(urlListCmd, &o.componentContext)
+	urlListCmd.Flags().BoolVar(&o.probe, "probe", false, "Probe the pushed URLs over HTTP(S) and report their status code, latency, TLS validity and redirects")
+	urlListCmd.Flags().StringVar(&o.probeOptions.Path, "probe-path", "", "Path appended to the URLs when probing them")
+	urlListCmd.Flags().IntVar(&o.probeOptions.ExpectedStatus, "probe-status", 0, "Status code expected from a healthy URL, any 2xx or 3xx status by default")
+	urlListCmd.Flags().DurationVar(&o.probeOptions.Timeout, "probe-timeout", url.DefaultProbeTimeout, "Time to wait for the response of a probed URL")
 	completion.RegisterCommandFlagHandler(urlListCmd, "context", completion.FileCompletionHandler)
 
 	return urlListCmd
 }
+
+// getProbeColumns returns the probe, latency, TLS and redirects columns for the given probe result
+func getProbeColumns(probe *url.ProbeResult) []interface{} {
+	if probe == nil {
+		return []interface{}{"\t", "-", "\t", "-", "\t", "-", "\t", "-"}
+	}
+	status := "unreachable"
+	if probe.Reachable {
+		status = fmt.Sprintf("%d", probe.StatusCode)
+		if !probe.Healthy {
+			status += " (unhealthy)"
+		}
+	}
+	tlsStatus := "-"
+	if probe.TLSValid != nil {
+		tlsStatus = "valid"
+		if !*probe.TLSValid {
+			tlsStatus = "invalid"
+		}
+	}
+	return []interface{}{"\t", status, "\t", fmt.Sprintf("%dms", probe.LatencyMs), "\t", tlsStatus, "\t", len(probe.Redirects)}
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+
+	util2 "github.com/openshift/odo/pkg/util"
+	"github.com/spf13/cobra"
+	ktemplates "k8s.io/kubectl/pkg/util/templates"
+)
+
+const (
+	completionCommandName = "completion"
+
+	// The completion scripts run the odo binary with the COMP_LINE environment variable set to the command line,
+	// odo prints the completions of the last word of the line.
+
+	// Bash output
+	bashCompletionOutput = `complete -C %[1]s odo
+`
+
+	// Zsh output
+	zshCompletionOutput = `autoload -U +X bashcompinit && bashcompinit
+complete -o nospace -C %[1]s odo
+`
+
+	// Fish output
+	fishCompletionOutput = `function __complete_odo
+    set -lx COMP_LINE (string join ' ' (commandline -o))
+    test (commandline -ct) = ""
+    and set COMP_LINE "$COMP_LINE "
+    %[1]s
+end
+complete -f -c odo -a "(__complete_odo)"
+`
+
+	// PowerShell output
+	powershellCompletionOutput = `Register-ArgumentCompleter -Native -CommandName odo -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $line = $commandAst.ToString()
+    if ($cursorPosition -lt $line.Length) {
+        $line = $line.Substring(0, $cursorPosition)
+    } elseif ($cursorPosition -gt $line.Length) {
+        $line = $line + ' '
+    }
+    $env:COMP_LINE = $line
+    try {
+        & %[1]s | ForEach-Object {
+            [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+        }
+    } finally {
+        Remove-Item Env:\COMP_LINE
+    }
+}
+`
+)
+
+var (
+	completionExample = ktemplates.Examples(`  # Bash completion
+  source <(%[1]s bash)
+
+  # Zsh completion
+  source <(%[1]s zsh)
+
+  # Fish completion
+  %[1]s fish > ~/.config/fish/completions/odo.fish
+
+  # PowerShell completion
+  %[1]s powershell | Out-String | Invoke-Expression
+`)
+	completionLongDesc = ktemplates.LongDesc(`Output the shell completion script of odo for bash, zsh, fish or PowerShell.
+
+The components, applications, projects, URLs and storage are completed, as well as the commands, containers,
+endpoint ports and volumes of the devfile of the current directory or of the --context flag.`)
+	completionShells = map[string]completionShell{
+		"bash":       {script: bashCompletionOutput, quote: posixQuote},
+		"zsh":        {script: zshCompletionOutput, quote: posixQuote},
+		"fish":       {script: fishCompletionOutput, quote: posixQuote},
+		"powershell": {script: powershellCompletionOutput, quote: powershellQuote},
+	}
+)
+
+// completionShell is the completion script of a shell, and how the shell quotes the path of the odo binary
+type completionShell struct {
+	script string
+	quote  func(string) string
+}
+
+// CompletionOptions encapsulates the options for the command
+type CompletionOptions struct {
+	shellType string
+}
+
+// NewCompletionOptions creates a new CompletionOptions instance
+func NewCompletionOptions() *CompletionOptions {
+	return &CompletionOptions{}
+}
+
+// Complete completes CompletionOptions after they've been created
+func (o *CompletionOptions) Complete(name string, cmd *cobra.Command, args []string) (err error) {
+	o.shellType = args[0]
+	return
+}
+
+// Validate validates the CompletionOptions based on completed values
+func (o *CompletionOptions) Validate() (err error) {
+	if _, ok := completionShells[o.shellType]; !ok {
+		return fmt.Errorf("unknown shell type %s, supported shells: %v", o.shellType, getCompletionShells())
+	}
+	return
+}
+
+// Run contains the logic for the command
+func (o *CompletionOptions) Run(cmd *cobra.Command) (err error) {
+	binary, err := os.Executable()
+	if err != nil {
+		binary = "odo"
+	}
+	_, err = os.Stdout.Write([]byte(completionScript(o.shellType, binary)))
+	return
+}
+
+// completionScript returns the completion script of the shell, running the given odo binary
+func completionScript(shellType string, binary string) string {
+	shell := completionShells[shellType]
+	return fmt.Sprintf(shell.script, shell.quote(binary))
+}
+
+// posixQuote quotes the string for bash, zsh and fish
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// powershellQuote quotes the string for PowerShell
+func powershellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// NewCmdCompletion implements the utils completion odo command
+func NewCmdCompletion(name, fullName string) *cobra.Command {
+	o := NewCompletionOptions()
+	completionCmd := &cobra.Command{
+		Use:       fmt.Sprintf("%s [bash|zsh|fish|powershell]", name),
+		Short:     "Output the shell completion script of odo",
+		Long:      completionLongDesc,
+		Example:   fmt.Sprintf(completionExample, fullName),
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: getCompletionShells(),
+		Run: func(cmd *cobra.Command, args []string) {
+			genericclioptions.GenericRun(o, cmd, args)
+		},
+	}
+	return completionCmd
+}
+
+func getCompletionShells() []string {
+	shells := make(map[string]string, len(completionShells))
+	for name, shell := range completionShells {
+		shells[name] = shell.script
+	}
+	return util2.GetSortedKeys(shells)
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetCompletionShells(t *testing.T) {
+	expected := []string{"bash", "fish", "powershell", "zsh"}
+	if actual := getCompletionShells(); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %+v, got: %+v", expected, actual)
+	}
+}
+
+func TestCompletionScript(t *testing.T) {
+	tests := []struct {
+		shell    string
+		binary   string
+		contains []string
+	}{
+		{
+			shell:    "bash",
+			binary:   "/usr/local/bin/odo",
+			contains: []string{"complete -C '/usr/local/bin/odo' odo"},
+		},
+		{
+			shell:    "zsh",
+			binary:   "/usr/local/bin/odo",
+			contains: []string{"bashcompinit", "complete -o nospace -C '/usr/local/bin/odo' odo"},
+		},
+		{
+			shell:    "fish",
+			binary:   "/home/user's/odo",
+			contains: []string{"set -lx COMP_LINE", `'/home/user'\''s/odo'`, `complete -f -c odo -a "(__complete_odo)"`},
+		},
+		{
+			shell:    "powershell",
+			binary:   `C:\Users\user's\odo.exe`,
+			contains: []string{"Register-ArgumentCompleter -Native -CommandName odo", `& 'C:\Users\user''s\odo.exe'`, "$env:COMP_LINE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			script := completionScript(tt.shell, tt.binary)
+			for _, s := range tt.contains {
+				if !strings.Contains(script, s) {
+					t.Errorf("expected the script to contain %q, got:\n%s", s, script)
+				}
+			}
+		})
+	}
+}
+
+func TestCompletionValidate(t *testing.T) {
+	for shell, wantErr := range map[string]bool{"fish": false, "powershell": false, "tcsh": true} {
+		o := CompletionOptions{shellType: shell}
+		if err := o.Validate(); (err != nil) != wantErr {
+			t.Errorf("%s: expected error %v, got %v", shell, wantErr, err)
+		}
+	}
+}
@@ -13,19 +13,22 @@ const RecommendedCommandName = "utils"
 // NewCmdUtils implements the utils odo command
 func NewCmdUtils(name, fullName string) *cobra.Command {
 	terminalCmd := NewCmdTerminal(terminalCommandName, odoutil.GetFullName(fullName, terminalCommandName))
+	completionCmd := NewCmdCompletion(completionCommandName, odoutil.GetFullName(fullName, completionCommandName))
 	convertCmd := NewCmdConvert(convertCommandName, odoutil.GetFullName(fullName, convertCommandName))
 	utilsCmd := &cobra.Command{
 		Use:   name,
 		Short: "Utilities for terminal commands and modifying odo configurations",
 		Long:  "Utilities for terminal commands and modifying odo configurations",
-		Example: fmt.Sprintf("%s\n",
-			terminalCmd.Example),
+		Example: fmt.Sprintf("%s\n%s\n",
+			terminalCmd.Example,
+			completionCmd.Example),
 	}
 
 	utilsCmd.Annotations = map[string]string{"command": "utility"}
 	utilsCmd.SetUsageTemplate(odoutil.CmdUsageTemplate)
 
 	utilsCmd.AddCommand(terminalCmd)
+	utilsCmd.AddCommand(completionCmd)
 	utilsCmd.AddCommand(convertCmd)
 	return utilsCmd
 }
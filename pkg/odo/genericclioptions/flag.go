@@ -1,9 +1,12 @@
 package genericclioptions
 
 import (
+	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/odo/util"
 	pkgUtil "github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"k8s.io/klog"
 )
 
 const (
@@ -17,6 +20,10 @@ const (
 	OutputFlagName = "output"
 	// ContextFlagName is the name of the flag allowing a user to specify the location of the component settings
 	ContextFlagName = "context"
+	// EventStreamFlagName is the name of the flag allowing a user to stream the machine readable events of a command
+	EventStreamFlagName = "event-stream"
+	// EventReplayFlagName is the name of the flag allowing a user to specify how many events are replayed to a new subscriber of the event stream
+	EventReplayFlagName = "event-replay"
 )
 
 // FlagValueIfSet retrieves the value of the specified flag if it is set for the given command
@@ -51,6 +58,31 @@ func AddContextFlag(cmd *cobra.Command, setValueTo *string) {
 	}
 }
 
+// AddEventStreamFlags adds the `event-stream` and `event-replay` flags to given cobra command,
+// the event stream is started by GenericRun before the command is completed
+func AddEventStreamFlags(cmd *cobra.Command) {
+	cmd.Flags().String(EventStreamFlagName, "", "Stream the machine readable events to the subscribers of the Unix domain socket unix:<path>, or of the server-sent events endpoint http://127.0.0.1:<port>/events")
+	cmd.Flags().Int(EventReplayFlagName, machineoutput.DefaultEventReplay, "Number of the last events replayed to a new subscriber of the event stream")
+}
+
+// startEventStream streams the machine readable events of the command if the `event-stream` flag is set
+func startEventStream(cmd *cobra.Command) error {
+	address := FlagValueIfSet(cmd, EventStreamFlagName)
+	if address == "" {
+		return nil
+	}
+	replay, err := cmd.Flags().GetInt(EventReplayFlagName)
+	if err != nil {
+		return err
+	}
+	stream, err := machineoutput.StartEventStream(address, replay)
+	if err != nil {
+		return errors.Wrap(err, "unable to start the event stream")
+	}
+	klog.V(2).Infof("Streaming the machine readable events on %s", stream.Address())
+	return nil
+}
+
 // AddNowFlag adds `now` flag to given cobra command
 func AddNowFlag(cmd *cobra.Command, setValueTo *bool) {
 	helpMessage := "Push changes to the cluster immediately"
@@ -0,0 +1,104 @@
+package genericclioptions
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/odo/pkg/config"
+	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/version"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog"
+)
+
+// environment variables describing the resolved context to the plugins, empty when not resolved
+const (
+	// PluginEnvBinary is the path of the odo executable, for the plugins running odo commands
+	PluginEnvBinary = "ODO_BINARY"
+	// PluginEnvVersion is the version of odo
+	PluginEnvVersion = "ODO_VERSION"
+	// PluginEnvContext is the absolute path of the component context directory, the working directory of the plugin
+	PluginEnvContext = "ODO_CONTEXT"
+	// PluginEnvDevfile is the absolute path of the devfile of the component, for devfile components
+	PluginEnvDevfile = "ODO_DEVFILE"
+	// PluginEnvComponent is the name of the component of the context directory
+	PluginEnvComponent = "ODO_COMPONENT"
+	// PluginEnvApplication is the application of the component of the context directory
+	PluginEnvApplication = "ODO_APPLICATION"
+	// PluginEnvProject is the project of the component of the context directory, the current project of the kubeconfig otherwise
+	PluginEnvProject = "ODO_PROJECT"
+)
+
+// PluginEnvVars are the environment variables describing the resolved context to the plugins
+var PluginEnvVars = []string{
+	PluginEnvBinary,
+	PluginEnvVersion,
+	PluginEnvContext,
+	PluginEnvDevfile,
+	PluginEnvComponent,
+	PluginEnvApplication,
+	PluginEnvProject,
+}
+
+// NewPluginContext resolves the context of a plugin run from the given component context directory, without any cluster call:
+// the component of the directory, devfile or S2I, its application and its project
+func NewPluginContext(componentContext string) *Context {
+	internalCxt := internalCxt{
+		ComponentContext: componentContext,
+	}
+
+	if envInfo, err := envinfo.NewEnvSpecificInfo(componentContext); err == nil && envInfo.Exists() {
+		internalCxt.EnvSpecificInfo = envInfo
+		internalCxt.LocalConfigProvider = envInfo
+	} else if localConfig, err := config.NewLocalConfigInfo(componentContext); err == nil && localConfig.Exists() {
+		internalCxt.LocalConfigInfo = localConfig
+		internalCxt.LocalConfigProvider = localConfig
+	}
+
+	if internalCxt.LocalConfigProvider != nil {
+		internalCxt.cmp = internalCxt.LocalConfigProvider.GetName()
+		internalCxt.Application = internalCxt.LocalConfigProvider.GetApplication()
+		internalCxt.Project = internalCxt.LocalConfigProvider.GetNamespace()
+	}
+	if internalCxt.Project == "" {
+		// the current project of the kubeconfig, read without connecting to the cluster
+		kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(), &clientcmd.ConfigOverrides{})
+		namespace, _, err := kubeConfig.Namespace()
+		if err != nil {
+			klog.V(4).Infof("Unable to get the current project of the kubeconfig: %v", err)
+		}
+		internalCxt.Project = namespace
+	}
+
+	return &Context{
+		internalCxt: internalCxt,
+	}
+}
+
+// PluginEnv returns the environment variables describing the resolved context to a plugin, as KEY=value
+func (o *Context) PluginEnv() []string {
+	values := map[string]string{
+		PluginEnvVersion:     version.VERSION,
+		PluginEnvComponent:   o.cmp,
+		PluginEnvApplication: o.Application,
+		PluginEnvProject:     o.Project,
+	}
+	if binary, err := os.Executable(); err == nil {
+		values[PluginEnvBinary] = binary
+	}
+	if componentContext, err := filepath.Abs(o.ComponentContext); err == nil {
+		values[PluginEnvContext] = componentContext
+		if o.EnvSpecificInfo != nil {
+			devfilePath := filepath.Join(componentContext, "devfile.yaml")
+			if _, err := os.Stat(devfilePath); err == nil {
+				values[PluginEnvDevfile] = devfilePath
+			}
+		}
+	}
+
+	env := make([]string, 0, len(PluginEnvVars))
+	for _, name := range PluginEnvVars {
+		env = append(env, name+"="+values[name])
+	}
+	return env
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/openshift/odo/pkg/version"
 
+	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/odo/cli/ui"
 	"gopkg.in/AlecAivazis/survey.v1"
 
@@ -70,6 +71,8 @@ func GenericRun(o Runnable, cmd *cobra.Command, args []string) {
 
 	// LogErrorAndExit is used so that we get -o (jsonoutput) for cmds which have json output implemented
 	util.LogErrorAndExit(checkConflictingFlags(cmd, args), "")
+	// The event stream is started before the completion, which may create the components adapters reporting the events
+	util.LogErrorAndExit(startEventStream(cmd), "")
 	// Run completion, validation and run.
 	// Only upload data to segment for completion and validation if a non-nil error is returned.
 	err = o.Complete(cmd.Name(), cmd, args)
@@ -87,6 +90,7 @@ func GenericRun(o Runnable, cmd *cobra.Command, args []string) {
 	err = o.Run(cmd)
 	startTelemetry(cmd, err, startTime)
 	util.LogErrorAndExit(err, "")
+	machineoutput.StopEventStream()
 }
 
 // startTelemetry uploads the data to segment if user has consented to usage data collection and the command is not telemetry
@@ -111,7 +115,7 @@ func startTelemetry(cmd *cobra.Command, err error, startTime time.Time) {
 		if err1 != nil {
 			klog.V(4).Infof("Failed to marshall telemetry data. %q", err1.Error())
 		}
-		command := exec.Command(os.Args[0], "telemetry", string(data))
+		command := exec.Command(os.Args[0], "telemetry", "upload", string(data))
 		if err1 = command.Start(); err1 != nil {
 			klog.V(4).Infof("Failed to start the telemetry process. Error: %q", err1.Error())
 			return
@@ -185,13 +189,16 @@ func CheckMachineReadableOutputCommand(cmd *cobra.Command) {
 	machineOutput := cmd.Annotations["machineoutput"]
 
 	// Check the valid output
-	if hasFlagChanged && outputFlag.Value.String() != "json" {
-		log.Error("Please input a valid output format for -o, available format: json")
-		os.Exit(1)
+	if hasFlagChanged {
+		if err := machineoutput.ValidateOutputFormat(outputFlag.Value.String()); err != nil {
+			_ = flag.Set("o", "")
+			log.Errorf("Please input a valid output format for -o: %v", err)
+			os.Exit(1)
+		}
 	}
 
-	// Check that if -o json has been passed, that the command actually USES json.. if not, error out.
-	if hasFlagChanged && outputFlag.Value.String() == "json" && machineOutput == "" {
+	// Check that if -o has been passed, that the command actually USES machine readable output.. if not, error out.
+	if hasFlagChanged && machineOutput == "" {
 
 		// By default we "disable" logging, so activate it so that the below error can be shown.
 		_ = flag.Set("o", "")
@@ -201,6 +208,13 @@ func CheckMachineReadableOutputCommand(cmd *cobra.Command) {
 		os.Exit(1)
 	}
 
+	// The commands outputting a stream of events only output json
+	if hasFlagChanged && machineOutput == util.MachineOutputEvents && outputFlag.Value.String() != machineoutput.OutputFormatJSON {
+		_ = flag.Set("o", "")
+		log.Error("This command outputs a stream of events, available format for -o: json")
+		os.Exit(1)
+	}
+
 	// Before running anything, we will make sure that no verbose output is made
 	// This is a HACK to manually override `-v 4` to `-v 0` (in which we have no klog.V(0) in our code...
 	// in order to have NO verbose output when combining both `-o json` and `-v 4` so json output
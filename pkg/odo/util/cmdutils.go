@@ -54,21 +54,25 @@ func LogErrorAndExit(err error, context string, a ...interface{}) {
 			}
 		}
 
+		// Report the error to the subscribers of the event stream, if any, and deliver them the last events
+		machineoutput.StopEventStreamWithError(err)
+
 		// Always exit 1 anyways
 		os.Exit(1)
 
 	}
 }
 
+// MachineOutputEvents is the value of the "machineoutput" annotation of the commands outputting a stream of events,
+// only with -o json. The commands annotated with "json" output their result in any of the machine readable output formats.
+const MachineOutputEvents = "events"
+
 // CheckOutputFlag validates the -o flag
 func CheckOutputFlag(outputFlag string) error {
-	switch outputFlag {
-	case "", "json":
-		return nil
-	default:
-		return fmt.Errorf("Please input valid output format. available format: json")
+	if err := machineoutput.ValidateOutputFormat(outputFlag); err != nil {
+		return fmt.Errorf("Please input valid output format: %v", err)
 	}
-
+	return nil
 }
 
 // PrintComponentInfo prints Component Information like path, URL & storage
@@ -244,7 +248,7 @@ func ModifyAdditionalFlags(cmd *cobra.Command) string {
 
 	f.VisitAll(func(f *pflag.Flag) {
 		// Remove json flag if machineoutput has not been passed in
-		if f.Name == "o" && machineOutput == "json" {
+		if f.Name == "o" && machineOutput != "" {
 			f.Hidden = false
 		}
 	})
@@ -113,6 +113,16 @@ func newHandler(cmd *cobra.Command, predictor ContextualizedPredictor) completio
 	}
 }
 
+// newLocalHandler wraps a ContextualizedPredictor only using local files into a completionHandler, the predictor is
+// given a nil context so that the completion doesn't need a cluster
+func newLocalHandler(cmd *cobra.Command, predictor ContextualizedPredictor) completionHandler {
+	return completionHandler{
+		cmd:       cmd,
+		ctxLoader: func(*cobra.Command) *genericclioptions.Context { return nil },
+		predictor: predictor,
+	}
+}
+
 // RegisterCommandHandler registers the provided ContextualizedPredictor as a completion handler for the specified command
 func RegisterCommandHandler(command *cobra.Command, predictor ContextualizedPredictor) {
 	completionHandlers[getCommandCompletionHandlerKey(command)] = newHandler(command, predictor)
@@ -124,6 +134,18 @@ func RegisterCommandFlagHandler(command *cobra.Command, flag string, predictor C
 	completionHandlers[getCommandFlagCompletionHandlerKey(command, flag)] = newHandler(command, predictor)
 }
 
+// RegisterLocalCommandHandler registers the provided ContextualizedPredictor, only using local files, as a completion
+// handler for the specified command
+func RegisterLocalCommandHandler(command *cobra.Command, predictor ContextualizedPredictor) {
+	completionHandlers[getCommandCompletionHandlerKey(command)] = newLocalHandler(command, predictor)
+}
+
+// RegisterLocalCommandFlagHandler registers the provided ContextualizedPredictor, only using local files, as a completion
+// handler for the specified flag of the specified command
+func RegisterLocalCommandFlagHandler(command *cobra.Command, flag string, predictor ContextualizedPredictor) {
+	completionHandlers[getCommandFlagCompletionHandlerKey(command, flag)] = newLocalHandler(command, predictor)
+}
+
 // GetCommandHandler retrieves the command handler associated with the specified command or nil otherwise
 func GetCommandHandler(command *cobra.Command) (predictor complete.Predictor, ok bool) {
 	predictor, ok = completionHandlers[getCommandCompletionHandlerKey(command)]
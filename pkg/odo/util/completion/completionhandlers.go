@@ -204,8 +204,12 @@ var URLCompletionHandler = func(cmd *cobra.Command, args parsedArgs, context *ge
 	return
 }
 
-// StorageDeleteCompletionHandler provides storage name completion for storage delete
+// StorageDeleteCompletionHandler provides storage name completion for storage delete, the volumes of the devfile for
+// devfile components
 var StorageDeleteCompletionHandler = func(cmd *cobra.Command, args parsedArgs, context *genericclioptions.Context) (completions []string) {
+	if _, ok := parseLocalDevfile(args); ok {
+		return VolumeCompletionHandler(cmd, args, context)
+	}
 	completions = make([]string, 0)
 
 	localConfig, err := config.New()
@@ -0,0 +1,135 @@
+package completion
+
+import (
+	"path/filepath"
+	"strconv"
+
+	devfilev1 "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/openshift/odo/pkg/component"
+	"github.com/openshift/odo/pkg/odo/genericclioptions"
+	"github.com/posener/complete"
+	"github.com/spf13/cobra"
+)
+
+// BuildCommandCompletionHandler provides completion for the IDs of the build commands of the local devfile
+var BuildCommandCompletionHandler = devfileCommandCompletionHandler(devfilev1.BuildCommandGroupKind)
+
+// RunCommandCompletionHandler provides completion for the IDs of the run commands of the local devfile
+var RunCommandCompletionHandler = devfileCommandCompletionHandler(devfilev1.RunCommandGroupKind)
+
+// DebugCommandCompletionHandler provides completion for the IDs of the debug commands of the local devfile
+var DebugCommandCompletionHandler = devfileCommandCompletionHandler(devfilev1.DebugCommandGroupKind)
+
+// TestCommandCompletionHandler provides completion for the IDs of the test commands of the local devfile
+var TestCommandCompletionHandler = devfileCommandCompletionHandler(devfilev1.TestCommandGroupKind)
+
+// devfileCommandCompletionHandler returns a handler providing completion for the IDs of the commands of the given
+// group of the local devfile
+func devfileCommandCompletionHandler(group devfilev1.CommandGroupKind) ContextualizedPredictor {
+	return func(cmd *cobra.Command, args parsedArgs, context *genericclioptions.Context) (completions []string) {
+		completions = make([]string, 0)
+		devObj, ok := parseLocalDevfile(args)
+		if !ok {
+			return completions
+		}
+
+		commands, err := devObj.Data.GetCommands(parsercommon.DevfileOptions{
+			CommandOptions: parsercommon.CommandOptions{CommandGroupKind: group},
+		})
+		if err != nil {
+			complete.Log("Error retrieving the commands of the devfile")
+			return completions
+		}
+		for _, command := range commands {
+			completions = append(completions, command.Id)
+		}
+		return completions
+	}
+}
+
+// ContainerCompletionHandler provides completion for the names of the container components of the local devfile
+var ContainerCompletionHandler = func(cmd *cobra.Command, args parsedArgs, context *genericclioptions.Context) (completions []string) {
+	completions = make([]string, 0)
+	devObj, ok := parseLocalDevfile(args)
+	if !ok {
+		return completions
+	}
+
+	containers, err := devObj.Data.GetDevfileContainerComponents(parsercommon.DevfileOptions{})
+	if err != nil {
+		complete.Log("Error retrieving the containers of the devfile")
+		return completions
+	}
+	for _, container := range containers {
+		completions = append(completions, container.Name)
+	}
+	return completions
+}
+
+// EndpointPortCompletionHandler provides completion for the target ports of the endpoints of the local devfile,
+// the endpoints of the container of the --container flag if it is set
+var EndpointPortCompletionHandler = func(cmd *cobra.Command, args parsedArgs, context *genericclioptions.Context) (completions []string) {
+	completions = make([]string, 0)
+	devObj, ok := parseLocalDevfile(args)
+	if !ok {
+		return completions
+	}
+
+	containers, err := devObj.Data.GetDevfileContainerComponents(parsercommon.DevfileOptions{})
+	if err != nil {
+		complete.Log("Error retrieving the containers of the devfile")
+		return completions
+	}
+	selectedContainer := args.flagValues["container"]
+	found := make(map[int]bool)
+	for _, container := range containers {
+		if selectedContainer != "" && container.Name != selectedContainer {
+			continue
+		}
+		for _, endpoint := range container.Container.Endpoints {
+			if !found[endpoint.TargetPort] {
+				found[endpoint.TargetPort] = true
+				completions = append(completions, strconv.Itoa(endpoint.TargetPort))
+			}
+		}
+	}
+	return completions
+}
+
+// VolumeCompletionHandler provides completion for the names of the volume components of the local devfile
+var VolumeCompletionHandler = func(cmd *cobra.Command, args parsedArgs, context *genericclioptions.Context) (completions []string) {
+	completions = make([]string, 0)
+	devObj, ok := parseLocalDevfile(args)
+	if !ok {
+		return completions
+	}
+
+	volumes, err := devObj.Data.GetDevfileVolumeComponents(parsercommon.DevfileOptions{})
+	if err != nil {
+		complete.Log("Error retrieving the volumes of the devfile")
+		return completions
+	}
+	for _, volume := range volumes {
+		// we found the volume name in the list which means
+		// that the volume name has been already selected by the user so no need to suggest more
+		if args.commands[volume.Name] {
+			return nil
+		}
+		completions = append(completions, volume.Name)
+	}
+	return completions
+}
+
+// parseLocalDevfile parses the devfile of the directory of the --context flag, or of the current directory. The devfile
+// is not validated, and the warnings are not shown: the completion must not output anything else than the completions.
+func parseLocalDevfile(args parsedArgs) (parser.DevfileObj, bool) {
+	devfilePath := filepath.Join(args.flagValues["context"], component.DevfilePath)
+	devObj, err := parser.ParseDevfile(parser.ParserArgs{Path: devfilePath})
+	if err != nil {
+		complete.Log("Error parsing the devfile " + devfilePath)
+		return parser.DevfileObj{}, false
+	}
+	return devObj, true
+}
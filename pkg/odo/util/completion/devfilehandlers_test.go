@@ -0,0 +1,154 @@
+package completion
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/odo/pkg/component"
+)
+
+const completionDevfile = `schemaVersion: 2.0.0
+metadata:
+  name: nodejs
+components:
+  - name: runtime
+    container:
+      image: registry.access.redhat.com/ubi8/nodejs-12:1-36
+      endpoints:
+        - name: http-3000
+          targetPort: 3000
+        - name: debug
+          targetPort: 5858
+  - name: tools
+    container:
+      image: quay.io/eclipse/che-nodejs10-ubi:nightly
+      endpoints:
+        - name: http-8080
+          targetPort: 8080
+        - name: other-3000
+          targetPort: 3000
+  - name: cache
+    volume:
+      size: 1Gi
+commands:
+  - id: install
+    exec:
+      component: runtime
+      commandLine: npm install
+      group:
+        kind: build
+        isDefault: true
+  - id: run
+    exec:
+      component: runtime
+      commandLine: npm start
+      group:
+        kind: run
+        isDefault: true
+  - id: run-tools
+    exec:
+      component: tools
+      commandLine: npm start
+      group:
+        kind: run
+  - id: debug
+    exec:
+      component: runtime
+      commandLine: npm run debug
+      group:
+        kind: debug
+        isDefault: true
+  - id: test
+    exec:
+      component: runtime
+      commandLine: npm test
+      group:
+        kind: test
+        isDefault: true
+`
+
+func TestDevfileCompletionHandlers(t *testing.T) {
+	contextDir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(contextDir, component.DevfilePath), []byte(completionDevfile), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		handler ContextualizedPredictor
+		args    parsedArgs
+		want    []string
+	}{
+		{
+			name:    "build commands",
+			handler: BuildCommandCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": contextDir}},
+			want:    []string{"install"},
+		},
+		{
+			name:    "run commands",
+			handler: RunCommandCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": contextDir}},
+			want:    []string{"run", "run-tools"},
+		},
+		{
+			name:    "debug commands",
+			handler: DebugCommandCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": contextDir}},
+			want:    []string{"debug"},
+		},
+		{
+			name:    "test commands",
+			handler: TestCommandCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": contextDir}},
+			want:    []string{"test"},
+		},
+		{
+			name:    "containers",
+			handler: ContainerCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": contextDir}},
+			want:    []string{"runtime", "tools"},
+		},
+		{
+			name:    "ports of all the containers",
+			handler: EndpointPortCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": contextDir}},
+			want:    []string{"3000", "5858", "8080"},
+		},
+		{
+			name:    "ports of the selected container",
+			handler: EndpointPortCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": contextDir, "container": "tools"}},
+			want:    []string{"8080", "3000"},
+		},
+		{
+			name:    "volumes",
+			handler: VolumeCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": contextDir}},
+			want:    []string{"cache"},
+		},
+		{
+			name:    "volume already selected",
+			handler: VolumeCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": contextDir}, commands: map[string]bool{"cache": true}},
+			want:    nil,
+		},
+		{
+			name:    "no devfile",
+			handler: RunCommandCompletionHandler,
+			args:    parsedArgs{flagValues: map[string]string{"context": t.TempDir()}},
+			want:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.handler(nil, tt.args, nil)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,422 @@
+package plugins
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/ghodss/yaml"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+)
+
+const (
+	// Prefix prefixes the names of the executables of the plugins, odo-<name>
+	Prefix = "odo"
+	// ManifestFile is the file of an installed plugin holding its manifest
+	ManifestFile = "plugin.yaml"
+
+	// SourceInstalled is the source of the plugins installed with "odo plugin install"
+	SourceInstalled = "installed"
+	// SourcePath is the source of the odo-<name> executables found in the PATH
+	SourcePath = "path"
+
+	// sha256DigestPrefix prefixes the SHA256 digests of the executables of the index
+	sha256DigestPrefix = "sha256:"
+)
+
+// validName matches the names of the plugins, which are part of the names of their executables
+var validName = regexp.MustCompile(`^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$`)
+
+// Manifest describes a plugin
+type Manifest struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+	// MinOdoVersion is the oldest version of odo the plugin works with
+	MinOdoVersion string `json:"minOdoVersion,omitempty"`
+}
+
+// IndexEntry is a version of a plugin offered by a plugin index, a JSON array of entries
+type IndexEntry struct {
+	Manifest `json:",inline"`
+	// Platforms are the executables of the version of the plugin
+	Platforms []Platform `json:"platforms"`
+}
+
+// Platform is the executable of a plugin for an operating system and an architecture
+type Platform struct {
+	// OS and Arch are the GOOS and GOARCH of the executable
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	// URL is the http(s):// or file:// URL of the executable
+	URL string `json:"url"`
+	// Digest is the digest of the executable, "sha256:<hex>"
+	Digest string `json:"digest"`
+}
+
+// Plugin is a plugin found by odo
+type Plugin struct {
+	Manifest `json:",inline"`
+	// Path is the path of the executable of the plugin
+	Path string `json:"path"`
+	// Source is SourceInstalled or SourcePath
+	Source string `json:"source"`
+}
+
+// Validate checks the name and the versions of the manifest
+func (m Manifest) Validate() error {
+	if !validName.MatchString(m.Name) {
+		return errors.Errorf("invalid plugin name %q, it must consist of lower case alphanumeric characters, '-' or '_'", m.Name)
+	}
+	if _, err := semver.ParseTolerant(m.Version); err != nil {
+		return errors.Wrapf(err, "invalid version %q of plugin %s", m.Version, m.Name)
+	}
+	if m.MinOdoVersion != "" {
+		if _, err := semver.ParseTolerant(m.MinOdoVersion); err != nil {
+			return errors.Wrapf(err, "invalid minimum odo version %q of plugin %s", m.MinOdoVersion, m.Name)
+		}
+	}
+	return nil
+}
+
+// CheckOdoVersion returns an error if the plugin requires a version of odo newer than the given one
+func (m Manifest) CheckOdoVersion(odoVersion string) error {
+	if m.MinOdoVersion == "" {
+		return nil
+	}
+	current, err := semver.ParseTolerant(odoVersion)
+	if err != nil {
+		return errors.Wrapf(err, "invalid odo version %q", odoVersion)
+	}
+	minimum, err := semver.ParseTolerant(m.MinOdoVersion)
+	if err != nil {
+		return errors.Wrapf(err, "invalid minimum odo version %q of plugin %s", m.MinOdoVersion, m.Name)
+	}
+	if current.LT(minimum) {
+		return errors.Errorf("plugin %s %s requires odo %s or newer, odo is %s", m.Name, m.Version, m.MinOdoVersion, odoVersion)
+	}
+	return nil
+}
+
+// ExecutableName returns the name of the executable of the given plugin
+func ExecutableName(name string) string {
+	executable := fmt.Sprintf("%s-%s", Prefix, name)
+	if runtime.GOOS == "windows" {
+		executable += ".exe"
+	}
+	return executable
+}
+
+// Manager manages the plugins installed in a directory, one directory per plugin holding its manifest and its executable
+type Manager struct {
+	dir string
+}
+
+// NewManager returns the manager of the plugins installed in the given directory
+func NewManager(dir string) *Manager {
+	return &Manager{dir: dir}
+}
+
+// Lookup returns the path of the executable of the given installed plugin, empty if the plugin is not installed
+func (m *Manager) Lookup(name string) string {
+	if !validName.MatchString(name) {
+		return ""
+	}
+	path := filepath.Join(m.dir, name, ExecutableName(name))
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return ""
+	}
+	return path
+}
+
+// CheckOdoVersion returns an error if the installed plugin of the given executable requires a version of odo newer than the given one,
+// the plugins of the PATH have no manifest and are not checked
+func (m *Manager) CheckOdoVersion(executable string, odoVersion string) error {
+	pluginDir := filepath.Dir(executable)
+	if filepath.Clean(filepath.Dir(pluginDir)) != filepath.Clean(m.dir) {
+		return nil
+	}
+	manifest, err := readManifest(filepath.Join(pluginDir, ManifestFile))
+	if err != nil {
+		return errors.Wrapf(err, "unable to read the manifest of the plugin of %s", executable)
+	}
+	return manifest.CheckOdoVersion(odoVersion)
+}
+
+// Installed returns the installed plugins, sorted by name
+func (m *Manager) Installed() ([]Plugin, error) {
+	dirs, err := ioutil.ReadDir(m.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var plugins []Plugin
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		manifest, err := readManifest(filepath.Join(m.dir, dir.Name(), ManifestFile))
+		if err != nil {
+			klog.V(4).Infof("Ignoring the plugin of %s: %v", dir.Name(), err)
+			continue
+		}
+		plugins = append(plugins, Plugin{
+			Manifest: manifest,
+			Path:     filepath.Join(m.dir, dir.Name(), ExecutableName(manifest.Name)),
+			Source:   SourceInstalled,
+		})
+	}
+	return plugins, nil
+}
+
+// List returns the installed plugins and the plugins of the PATH, sorted by name,
+// an installed plugin hides the plugin of the same name of the PATH
+func (m *Manager) List() ([]Plugin, error) {
+	plugins, err := m.Installed()
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool)
+	for _, plugin := range plugins {
+		found[plugin.Name] = true
+	}
+	for _, plugin := range pathPlugins() {
+		if !found[plugin.Name] {
+			found[plugin.Name] = true
+			plugins = append(plugins, plugin)
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins, nil
+}
+
+// pathPlugins returns the odo-<name> executables of the PATH, the first one of each name
+func pathPlugins() []Plugin {
+	var plugins []Plugin
+	found := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			name := strings.TrimSuffix(file.Name(), ".exe")
+			if file.IsDir() || !strings.HasPrefix(name, Prefix+"-") {
+				continue
+			}
+			name = strings.TrimPrefix(name, Prefix+"-")
+			if found[name] {
+				continue
+			}
+			path, err := exec.LookPath(filepath.Join(dir, file.Name()))
+			if err != nil {
+				continue
+			}
+			found[name] = true
+			plugins = append(plugins, Plugin{
+				Manifest: Manifest{Name: name},
+				Path:     path,
+				Source:   SourcePath,
+			})
+		}
+	}
+	return plugins
+}
+
+// Install installs the executable of the given version of a plugin for the current platform, verifying its digest,
+// and replaces the installed version of the plugin if any
+func (m *Manager) Install(entry IndexEntry) (Plugin, error) {
+	if err := entry.Validate(); err != nil {
+		return Plugin{}, err
+	}
+	platform, err := entry.Platform(runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return Plugin{}, err
+	}
+	executable, err := readURL(platform.URL)
+	if err != nil {
+		return Plugin{}, errors.Wrapf(err, "unable to download the executable of plugin %s %s", entry.Name, entry.Version)
+	}
+	sum := sha256.Sum256(executable)
+	if got := sha256DigestPrefix + hex.EncodeToString(sum[:]); !strings.EqualFold(got, platform.Digest) {
+		return Plugin{}, errors.Errorf("the executable of plugin %s %s downloaded from %s is corrupted, its digest is %s but the index expects %s", entry.Name, entry.Version, platform.URL, got, platform.Digest)
+	}
+	return m.install(entry.Manifest, executable)
+}
+
+// ReadDirManifest returns the validated manifest of the plugin of the given directory
+func ReadDirManifest(dir string) (Manifest, error) {
+	manifest, err := readManifest(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err = manifest.Validate(); err != nil {
+		return Manifest{}, err
+	}
+	return manifest, nil
+}
+
+// InstallDir installs the plugin of the given directory, holding its manifest and its executable
+func (m *Manager) InstallDir(dir string) (Plugin, error) {
+	manifest, err := ReadDirManifest(dir)
+	if err != nil {
+		return Plugin{}, err
+	}
+	executable, err := ioutil.ReadFile(filepath.Join(dir, ExecutableName(manifest.Name)))
+	if err != nil {
+		return Plugin{}, errors.Wrapf(err, "unable to read the executable of plugin %s", manifest.Name)
+	}
+	return m.install(manifest, executable)
+}
+
+func (m *Manager) install(manifest Manifest, executable []byte) (Plugin, error) {
+	// the plugin is written aside, and replaces the installed version once complete
+	tmpDir, err := ioutil.TempDir(m.dirOrCreate(), "."+manifest.Name)
+	if err != nil {
+		return Plugin{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return Plugin{}, err
+	}
+	if err = ioutil.WriteFile(filepath.Join(tmpDir, ManifestFile), data, 0640); err != nil {
+		return Plugin{}, err
+	}
+	// #nosec G306 -- the plugin has to be executable
+	if err = ioutil.WriteFile(filepath.Join(tmpDir, ExecutableName(manifest.Name)), executable, 0750); err != nil {
+		return Plugin{}, err
+	}
+
+	pluginDir := filepath.Join(m.dir, manifest.Name)
+	if err = os.RemoveAll(pluginDir); err != nil {
+		return Plugin{}, errors.Wrapf(err, "unable to remove the installed version of plugin %s", manifest.Name)
+	}
+	if err = os.Rename(tmpDir, pluginDir); err != nil {
+		return Plugin{}, err
+	}
+	return Plugin{
+		Manifest: manifest,
+		Path:     filepath.Join(pluginDir, ExecutableName(manifest.Name)),
+		Source:   SourceInstalled,
+	}, nil
+}
+
+// dirOrCreate creates the directory of the installed plugins if needed, and returns it
+func (m *Manager) dirOrCreate() string {
+	if err := os.MkdirAll(m.dir, 0750); err != nil {
+		klog.V(4).Infof("Unable to create the plugins directory %s: %v", m.dir, err)
+	}
+	return m.dir
+}
+
+// Remove removes the given installed plugin
+func (m *Manager) Remove(name string) error {
+	if m.Lookup(name) == "" {
+		return errors.Errorf("plugin %s is not installed", name)
+	}
+	return os.RemoveAll(filepath.Join(m.dir, name))
+}
+
+// Platform returns the executable of the version of the plugin for the given operating system and architecture
+func (e IndexEntry) Platform(goos string, goarch string) (Platform, error) {
+	for _, platform := range e.Platforms {
+		if platform.OS == goos && platform.Arch == goarch {
+			return platform, nil
+		}
+	}
+	return Platform{}, errors.Errorf("plugin %s %s is not available for %s/%s", e.Name, e.Version, goos, goarch)
+}
+
+// GetIndex downloads the plugin index of the given http(s):// or file:// URL
+func GetIndex(indexURL string) ([]IndexEntry, error) {
+	data, err := readURL(indexURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to download the plugin index %s", indexURL)
+	}
+	var index []IndexEntry
+	if err = json.Unmarshal(data, &index); err != nil {
+		return nil, errors.Wrapf(err, "unable to unmarshal the plugin index %s", indexURL)
+	}
+	return index, nil
+}
+
+// FindInIndex returns the given version of the given plugin of the index, its latest version if the version is empty
+func FindInIndex(index []IndexEntry, name string, version string) (IndexEntry, error) {
+	var found *IndexEntry
+	var latest semver.Version
+	for i, entry := range index {
+		if entry.Name != name {
+			continue
+		}
+		entryVersion, err := semver.ParseTolerant(entry.Version)
+		if err != nil {
+			klog.V(4).Infof("Ignoring version %q of plugin %s of the index: %v", entry.Version, name, err)
+			continue
+		}
+		if version != "" {
+			if wanted, err := semver.ParseTolerant(version); err == nil && wanted.EQ(entryVersion) {
+				return entry, nil
+			}
+			continue
+		}
+		if found == nil || entryVersion.GT(latest) {
+			found = &index[i]
+			latest = entryVersion
+		}
+	}
+	if found == nil {
+		if version != "" {
+			return IndexEntry{}, errors.Errorf("version %s of plugin %s is not in the plugin index", version, name)
+		}
+		return IndexEntry{}, errors.Errorf("plugin %s is not in the plugin index", name)
+	}
+	return *found, nil
+}
+
+func readManifest(file string) (Manifest, error) {
+	var manifest Manifest
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return manifest, err
+	}
+	if err = yaml.Unmarshal(data, &manifest); err != nil {
+		return manifest, errors.Wrapf(err, "invalid plugin manifest %s", file)
+	}
+	return manifest, nil
+}
+
+// readURL reads the content of the given http(s):// or file:// URL
+func readURL(rawURL string) ([]byte, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsedURL.Scheme == "file" {
+		path := parsedURL.Path
+		if runtime.GOOS == "windows" {
+			// file:///C:/plugins has the path /C:/plugins
+			path = strings.TrimPrefix(path, "/")
+		}
+		return ioutil.ReadFile(filepath.FromSlash(path))
+	}
+	return util.DownloadFileInMemory(util.HTTPRequestParams{URL: rawURL})
+}
@@ -0,0 +1,247 @@
+package plugins
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestManifestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest Manifest
+		wantErr  bool
+	}{
+		{
+			name:     "Case 1: valid manifest",
+			manifest: Manifest{Name: "hello", Version: "1.0.0", MinOdoVersion: "v2.1.0"},
+		},
+		{
+			name:     "Case 2: invalid name",
+			manifest: Manifest{Name: "Hello World", Version: "1.0.0"},
+			wantErr:  true,
+		},
+		{
+			name:     "Case 3: invalid version",
+			manifest: Manifest{Name: "hello", Version: "latest"},
+			wantErr:  true,
+		},
+		{
+			name:     "Case 4: invalid minimum odo version",
+			manifest: Manifest{Name: "hello", Version: "1.0.0", MinOdoVersion: "two"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.manifest.Validate(); tt.wantErr != (err != nil) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManifestCheckOdoVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		minOdoVersion string
+		odoVersion    string
+		wantErr       bool
+	}{
+		{
+			name:       "Case 1: no minimum odo version",
+			odoVersion: "v2.2.3",
+		},
+		{
+			name:          "Case 2: newer odo",
+			minOdoVersion: "2.1.0",
+			odoVersion:    "v2.2.3",
+		},
+		{
+			name:          "Case 3: same odo",
+			minOdoVersion: "2.2.3",
+			odoVersion:    "v2.2.3",
+		},
+		{
+			name:          "Case 4: older odo",
+			minOdoVersion: "2.3.0",
+			odoVersion:    "v2.2.3",
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := Manifest{Name: "hello", Version: "1.0.0", MinOdoVersion: tt.minOdoVersion}
+			if err := manifest.CheckOdoVersion(tt.odoVersion); tt.wantErr != (err != nil) {
+				t.Errorf("CheckOdoVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindInIndex(t *testing.T) {
+	index := []IndexEntry{
+		{Manifest: Manifest{Name: "hello", Version: "1.0.0"}},
+		{Manifest: Manifest{Name: "hello", Version: "1.10.0"}},
+		{Manifest: Manifest{Name: "hello", Version: "1.2.0"}},
+		{Manifest: Manifest{Name: "bye", Version: "2.0.0"}},
+	}
+	tests := []struct {
+		name        string
+		plugin      string
+		version     string
+		wantVersion string
+		wantErr     bool
+	}{
+		{
+			name:        "Case 1: latest version",
+			plugin:      "hello",
+			wantVersion: "1.10.0",
+		},
+		{
+			name:        "Case 2: given version",
+			plugin:      "hello",
+			version:     "v1.2.0",
+			wantVersion: "1.2.0",
+		},
+		{
+			name:    "Case 3: missing version",
+			plugin:  "hello",
+			version: "3.0.0",
+			wantErr: true,
+		},
+		{
+			name:    "Case 4: missing plugin",
+			plugin:  "greet",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := FindInIndex(index, tt.plugin, tt.version)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("FindInIndex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && entry.Version != tt.wantVersion {
+				t.Errorf("FindInIndex() version = %s, want %s", entry.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestManagerInstall(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "plugins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	executable := filepath.Join(tmpDir, "odo-hello")
+	content := []byte("#!/bin/sh\necho hello\n")
+	if err = ioutil.WriteFile(executable, content, 0750); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	digest := sha256DigestPrefix + hex.EncodeToString(sum[:])
+	entry := func(digest string) IndexEntry {
+		return IndexEntry{
+			Manifest: Manifest{Name: "hello", Version: "1.0.0", Description: "Greets", MinOdoVersion: "2.0.0"},
+			Platforms: []Platform{{
+				OS:     runtime.GOOS,
+				Arch:   runtime.GOARCH,
+				URL:    "file://" + filepath.ToSlash(executable),
+				Digest: digest,
+			}},
+		}
+	}
+
+	manager := NewManager(filepath.Join(tmpDir, "installed"))
+	if _, err = manager.Install(entry(sha256DigestPrefix + "00")); err == nil {
+		t.Fatalf("Install() expected an error for a corrupted executable")
+	}
+	if manager.Lookup("hello") != "" {
+		t.Fatalf("Install() installed a corrupted executable")
+	}
+
+	plugin, err := manager.Install(entry(digest))
+	if err != nil {
+		t.Fatalf("Install() unexpected error: %v", err)
+	}
+	if plugin.Source != SourceInstalled || manager.Lookup("hello") != plugin.Path {
+		t.Errorf("Install() plugin = %+v, want the installed plugin found by Lookup()", plugin)
+	}
+	installed, err := manager.Installed()
+	if err != nil {
+		t.Fatalf("Installed() unexpected error: %v", err)
+	}
+	if len(installed) != 1 || installed[0].Manifest != plugin.Manifest {
+		t.Errorf("Installed() = %+v, want the manifest of the installed plugin", installed)
+	}
+	if err = manager.CheckOdoVersion(plugin.Path, "1.2.0"); err == nil {
+		t.Errorf("CheckOdoVersion() expected an error for an odo older than the minimum version of the plugin")
+	}
+	if err = manager.CheckOdoVersion(plugin.Path, "v2.0.0"); err != nil {
+		t.Errorf("CheckOdoVersion() unexpected error: %v", err)
+	}
+	if err = manager.CheckOdoVersion(executable, "1.2.0"); err != nil {
+		t.Errorf("CheckOdoVersion() unexpected error for a plugin of the PATH: %v", err)
+	}
+
+	if err = manager.Remove("hello"); err != nil {
+		t.Fatalf("Remove() unexpected error: %v", err)
+	}
+	if manager.Lookup("hello") != "" {
+		t.Errorf("Remove() didn't remove the plugin")
+	}
+	if err = manager.Remove("hello"); err == nil {
+		t.Errorf("Remove() expected an error for a plugin not installed")
+	}
+}
+
+func TestReadDirManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  bool
+	}{
+		{
+			name:     "Case 1: valid manifest",
+			manifest: "name: hello\nversion: 1.0.0\nminOdoVersion: 9.0.0\n",
+		},
+		{
+			name:     "Case 2: invalid manifest",
+			manifest: "name: Hello World\nversion: 1.0.0\n",
+			wantErr:  true,
+		},
+		{
+			name:    "Case 3: missing manifest",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "plugin")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			if tt.manifest != "" {
+				if err = ioutil.WriteFile(filepath.Join(dir, ManifestFile), []byte(tt.manifest), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			manifest, err := ReadDirManifest(dir)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ReadDirManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (manifest.Name != "hello" || manifest.MinOdoVersion != "9.0.0") {
+				t.Errorf("ReadDirManifest() = %+v, want the manifest of the directory", manifest)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package preference
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog"
+
+	"github.com/openshift/odo/pkg/log"
+	"github.com/openshift/odo/pkg/util"
+)
+
+const (
+	// projectPreferenceDir is the directory of a repository holding the project preference file
+	projectPreferenceDir = ".odo"
+
+	// envPrefix is the prefix of the environment variables overriding the preferences, ODO_PUSH_TIMEOUT for PushTimeout
+	envPrefix = "ODO_"
+
+	// SourceDefault is the source of a preference not set, its default value is used
+	SourceDefault = "default"
+
+	// SourceGlobal is the source of a preference set in the global preference file
+	SourceGlobal = "global"
+
+	// SourceProject is the source of a preference set in the .odo/preference.yaml file of the repository
+	SourceProject = "project"
+
+	// SourceEnv is the source of a preference set by its environment variable
+	SourceEnv = "env"
+)
+
+// projectParameters are the parameters a project preference can set, the other ones are personal or would let a
+// repository change the servers odo trusts and the commands it runs
+var projectParameters = []string{
+	NamePrefixSetting,
+	TimeoutSetting,
+	BuildTimeoutSetting,
+	PushTimeoutSetting,
+	ExperimentalSetting,
+	RegistryCacheTimeSetting,
+	RegistryCacheSizeSetting,
+	EphemeralSetting,
+}
+
+// warned holds the warnings already shown, the preference is read several times by a command
+var warned sync.Map
+
+// layers are the preferences layered over the global preference, by increasing precedence
+type layers struct {
+	// global is the preference of the global file, the one odo preference set and unset modify
+	global Preference
+
+	// projectFile is the project preference file, empty if there is none
+	projectFile string
+	// project holds the settings of the project preference a project preference can set
+	project OdoSettings
+
+	// env holds the values of the environment variables overriding the preferences, by parameter
+	env map[string]string
+}
+
+// EnvName returns the name of the environment variable overriding the given parameter, ODO_PUSH_TIMEOUT for PushTimeout
+func EnvName(parameter string) string {
+	for _, p := range GetSupportedParameters() {
+		if strings.EqualFold(p, parameter) {
+			parameter = p
+		}
+	}
+	var name strings.Builder
+	runes := []rune(parameter)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			name.WriteRune('_')
+		}
+		name.WriteRune(unicode.ToUpper(r))
+	}
+	return envPrefix + name.String()
+}
+
+// FindProjectPreferenceFile returns the .odo/preference.yaml file of the given directory or of its closest parent
+// having one, up to the root of the repository. It returns an empty path if there is none.
+func FindProjectPreferenceFile(dir string, globalFile string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	globalFile, err = filepath.Abs(globalFile)
+	if err != nil {
+		return "", err
+	}
+	for {
+		file := filepath.Join(dir, projectPreferenceDir, configFileName)
+		// the global preference file is in ~/.odo, it is not the preference of a project in the home directory
+		if file != globalFile {
+			if _, err = os.Stat(file); err == nil {
+				return file, nil
+			}
+		}
+		if _, err = os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// loadLayers layers the project preference and the environment variables over the global preference
+func (c *PreferenceInfo) loadLayers() error {
+	l := layers{
+		global: c.Preference,
+		env:    map[string]string{},
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	l.projectFile, err = FindProjectPreferenceFile(dir, c.Filename)
+	if err != nil {
+		return err
+	}
+	if l.projectFile != "" {
+		klog.V(4).Infof("The path for project preference file is %+v", l.projectFile)
+		var project Preference
+		if err = util.GetFromFile(&project, l.projectFile); err != nil {
+			return errors.Wrapf(err, "unable to read the project preference file %s", l.projectFile)
+		}
+		l.project = projectSettings(project.OdoSettings, l.projectFile)
+	}
+
+	for _, parameter := range GetSupportedParameters() {
+		if value := os.Getenv(EnvName(parameter)); value != "" {
+			l.env[parameter] = value
+		}
+	}
+
+	if l.projectFile == "" && len(l.env) == 0 {
+		return nil
+	}
+	c.layers = &l
+	c.applyLayers()
+	return nil
+}
+
+// projectSettings returns the settings a project preference can set, the other ones are ignored with a warning
+func projectSettings(settings OdoSettings, projectFile string) OdoSettings {
+	result := OdoSettings{RegistryList: projectRegistries(settings.RegistryList, projectFile)}
+	from := reflect.ValueOf(settings)
+	to := reflect.ValueOf(&result).Elem()
+	for _, parameter := range GetSupportedParameters() {
+		if !util.IsSet(settings, parameter) {
+			continue
+		}
+		if !isProjectParameter(parameter) {
+			warnOnce("%s can't be set by the project preference %s, it is ignored", parameter, projectFile)
+			continue
+		}
+		to.FieldByName(parameter).Set(from.FieldByName(parameter))
+	}
+	if len(settings.Hooks) > 0 {
+		warnOnce("Hooks can't be set by the project preference %s, use the env.yaml of the component", projectFile)
+	}
+	return result
+}
+
+// projectRegistries returns the name and the URL of the registries of the project preference. The security settings
+// and the credentials of a registry are personal, a repository must not make odo trust a server or send it credentials.
+func projectRegistries(registries *[]Registry, projectFile string) *[]Registry {
+	if registries == nil {
+		return nil
+	}
+	result := make([]Registry, len(*registries))
+	for i, registry := range *registries {
+		if registry.Secure || registry.Username != "" || registry.CredentialHelper != "" || registry.CAFile != "" || registry.PublicKey != "" {
+			warnOnce("Only the name and the URL of registry %s can be set by the project preference %s, its other settings are ignored", registry.Name, projectFile)
+		}
+		result[i] = Registry{Name: registry.Name, URL: registry.URL}
+	}
+	return &result
+}
+
+func isProjectParameter(parameter string) bool {
+	for _, p := range projectParameters {
+		if strings.EqualFold(p, parameter) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyLayers sets the preference to the global preference overridden by the project preference, overridden by the
+// environment variables
+func (c *PreferenceInfo) applyLayers() {
+	l := c.layers
+	c.Preference = l.global
+
+	to := reflect.ValueOf(&c.OdoSettings).Elem()
+	from := reflect.ValueOf(l.project)
+	for _, parameter := range projectParameters {
+		if util.IsSet(l.project, parameter) {
+			to.FieldByName(parameter).Set(from.FieldByName(parameter))
+		}
+	}
+	if l.project.RegistryList != nil {
+		// the project only adds registries after the global ones, the credentials of a global registry are looked up
+		// by its name and a project must not redirect them to another server
+		var registries []Registry
+		if l.global.OdoSettings.RegistryList != nil {
+			registries = append(registries, *l.global.OdoSettings.RegistryList...)
+		}
+		for _, registry := range *l.project.RegistryList {
+			if containsRegistry(registries, registry.Name) {
+				warnOnce("Registry %s of the project preference %s is ignored, a global registry has the same name", registry.Name, l.projectFile)
+				continue
+			}
+			registries = append(registries, registry)
+		}
+		c.OdoSettings.RegistryList = &registries
+	}
+
+	for parameter, value := range l.env {
+		if err := c.setValue(parameter, value); err != nil {
+			warnOnce("%s is ignored: %v", EnvName(parameter), err)
+			delete(l.env, parameter)
+		}
+	}
+}
+
+func containsRegistry(registries []Registry, name string) bool {
+	for _, registry := range registries {
+		if registry.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// updateGlobal applies the change to the global preference, and layers the project preference and the environment
+// variables again over the result
+func (c *PreferenceInfo) updateGlobal(change func(global *PreferenceInfo) error) error {
+	global := &PreferenceInfo{
+		Filename:   c.Filename,
+		Preference: c.layers.global,
+	}
+	if err := change(global); err != nil {
+		return err
+	}
+	c.layers.global = global.Preference
+	c.applyLayers()
+	return nil
+}
+
+// GetSource returns where the value of the given parameter comes from: default, global, project or env
+func (c *PreferenceInfo) GetSource(parameter string) string {
+	if c.layers == nil {
+		if util.IsSet(c.OdoSettings, parameter) {
+			return SourceGlobal
+		}
+		return SourceDefault
+	}
+	for p := range c.layers.env {
+		if strings.EqualFold(p, parameter) {
+			return SourceEnv
+		}
+	}
+	switch {
+	case util.IsSet(c.layers.project, parameter):
+		return SourceProject
+	case util.IsSet(c.layers.global.OdoSettings, parameter):
+		return SourceGlobal
+	}
+	return SourceDefault
+}
+
+// GetProjectFile returns the project preference file layered over the global preference, empty if there is none
+func (c *PreferenceInfo) GetProjectFile() string {
+	if c.layers == nil {
+		return ""
+	}
+	return c.layers.projectFile
+}
+
+// warnOnce shows the warning once per command
+func warnOnce(format string, a ...interface{}) {
+	warning := fmt.Sprintf(format, a...)
+	if _, loaded := warned.LoadOrStore(warning, true); !loaded {
+		log.Warning(warning)
+	}
+}
@@ -0,0 +1,229 @@
+package preference
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openshift/odo/pkg/util"
+)
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		parameter string
+		want      string
+	}{
+		{parameter: PushTimeoutSetting, want: "ODO_PUSH_TIMEOUT"},
+		{parameter: "pushtimeout", want: "ODO_PUSH_TIMEOUT"},
+		{parameter: HTTPProxySetting, want: "ODO_HTTP_PROXY"},
+		{parameter: CABundleSetting, want: "ODO_CA_BUNDLE"},
+		{parameter: ExperimentalSetting, want: "ODO_EXPERIMENTAL"},
+		{parameter: RegistryCacheTimeSetting, want: "ODO_REGISTRY_CACHE_TIME"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.parameter, func(t *testing.T) {
+			if got := EnvName(tt.parameter); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFindProjectPreferenceFile(t *testing.T) {
+	home := t.TempDir()
+	globalFile := filepath.Join(home, ".odo", configFileName)
+	repo := filepath.Join(home, "repo")
+	component := filepath.Join(repo, "services", "frontend")
+	projectFile := filepath.Join(repo, ".odo", configFileName)
+	for _, file := range []string{globalFile, projectFile} {
+		writeFile(t, file, "kind: Preference\n")
+	}
+	if err := os.MkdirAll(component, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		git  bool
+		want string
+	}{
+		{
+			name: "Case 1: project preference found from a subdirectory",
+			dir:  component,
+			want: projectFile,
+		},
+		{
+			name: "Case 2: the global preference is not a project preference",
+			dir:  home,
+			want: "",
+		},
+		{
+			name: "Case 3: the search stops at the root of the repository",
+			dir:  component,
+			git:  true,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.git {
+				if err := os.MkdirAll(filepath.Join(component, ".git"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				defer os.RemoveAll(filepath.Join(component, ".git"))
+			}
+			got, err := FindProjectPreferenceFile(tt.dir, globalFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLayeredPreference(t *testing.T) {
+	dir := t.TempDir()
+	globalFile := filepath.Join(dir, "global", configFileName)
+	writeFile(t, globalFile, `kind: Preference
+apiversion: odo.dev/v1alpha1
+OdoSettings:
+  Timeout: 5
+  PushTimeout: 100
+  BuildTimeout: 100
+  ConsentTelemetry: false
+  RegistryList:
+  - Name: DefaultDevfileRegistry
+    URL: https://registry.devfile.io
+  - Name: Team
+    URL: https://old.example.com
+`)
+	writeFile(t, filepath.Join(dir, "repo", ".odo", configFileName), `kind: Preference
+apiversion: odo.dev/v1alpha1
+OdoSettings:
+  PushTimeout: 200
+  Ephemeral: false
+  ConsentTelemetry: true
+  RegistryList:
+  - Name: Team
+    URL: https://registry.example.com
+  - Name: Shared
+    URL: https://shared.example.com
+    Secure: true
+    Username: developer
+    CredentialHelper: pass
+    CAFile: /tmp/ca.pem
+    PublicKey: /tmp/key.pem
+`)
+	chdir(t, filepath.Join(dir, "repo"))
+	setEnv(t, GlobalConfigEnvName, globalFile)
+	setEnv(t, "ODO_BUILD_TIMEOUT", "300")
+	setEnv(t, "ODO_NAME_PREFIX", "")
+
+	cfg, err := NewPreferenceInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := []struct {
+		parameter string
+		value     interface{}
+		source    string
+	}{
+		{TimeoutSetting, cfg.GetTimeout(), SourceGlobal},
+		{PushTimeoutSetting, cfg.GetPushTimeout(), SourceProject},
+		{BuildTimeoutSetting, cfg.GetBuildTimeout(), SourceEnv},
+		{EphemeralSetting, cfg.GetEphemeralSourceVolume(), SourceProject},
+		{ConsentTelemetrySetting, cfg.GetConsentTelemetry(), SourceGlobal},
+		{NamePrefixSetting, cfg.GetNamePrefix(), SourceDefault},
+	}
+	want := []interface{}{5, 200, 300, false, false, ""}
+	for i, v := range values {
+		if v.value != want[i] {
+			t.Errorf("expected %s %v, got %v", v.parameter, want[i], v.value)
+		}
+		if source := cfg.GetSource(v.parameter); source != v.source {
+			t.Errorf("expected %s from %s, got %s", v.parameter, v.source, source)
+		}
+	}
+	if registry := cfg.GetRegistry("Team"); registry == nil || registry.URL != "https://old.example.com" {
+		t.Errorf("expected the registry of the project not to override the global one, got %+v", registry)
+	}
+	if registry := cfg.GetRegistry(DefaultDevfileRegistryName); registry == nil {
+		t.Errorf("expected the global registries to be kept")
+	}
+	registries := *cfg.OdoSettings.RegistryList
+	if len(registries) != 3 || registries[2] != (Registry{Name: "Shared", URL: "https://shared.example.com"}) {
+		t.Errorf("expected the project to only add the name and the URL of a new registry, got %+v", registries)
+	}
+
+	// odo preference set only changes the global preference
+	if err = cfg.SetConfiguration("timeout", "10"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GetTimeout() != 10 {
+		t.Errorf("expected the new timeout, got %d", cfg.GetTimeout())
+	}
+	var global Preference
+	if err = util.GetFromFile(&global, globalFile); err != nil {
+		t.Fatal(err)
+	}
+	if *global.OdoSettings.Timeout != 10 || *global.OdoSettings.PushTimeout != 100 || global.OdoSettings.Ephemeral != nil {
+		t.Errorf("expected only the global values to be written, got %+v", global.OdoSettings)
+	}
+	if global.OdoSettings.BuildTimeout == nil || *global.OdoSettings.BuildTimeout != 100 {
+		t.Errorf("expected the environment variable not to be written")
+	}
+	if (*global.OdoSettings.RegistryList)[1].URL != "https://old.example.com" {
+		t.Errorf("expected the project registries not to be written")
+	}
+}
+
+func TestInvalidEnvIsIgnored(t *testing.T) {
+	chdir(t, t.TempDir())
+	setEnv(t, GlobalConfigEnvName, filepath.Join(t.TempDir(), configFileName))
+	setEnv(t, "ODO_PUSH_TIMEOUT", "soon")
+
+	cfg, err := NewPreferenceInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GetPushTimeout() != DefaultPushTimeout || cfg.GetSource(PushTimeoutSetting) != SourceDefault {
+		t.Errorf("expected the invalid value to be ignored, got %d from %s", cfg.GetPushTimeout(), cfg.GetSource(PushTimeoutSetting))
+	}
+}
+
+func writeFile(t *testing.T, file string, content string) {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func setEnv(t *testing.T, name string, value string) {
+	old, ok := os.LookupEnv(name)
+	os.Setenv(name, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(name, old)
+		} else {
+			os.Unsetenv(name)
+		}
+	})
+}
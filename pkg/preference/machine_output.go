@@ -22,6 +22,7 @@ type PreferenceItem struct {
 	Default     interface{} // default value of the preference if the user hasn't set the value
 	Type        string      // the type of the preference, possible values int, string, bool
 	Description string      // The description of the preference
+	Source      string      // Where the value comes from: default, global, project or env
 }
 
 func NewPreferenceList(prefInfo PreferenceInfo) PreferenceList {
@@ -35,6 +36,14 @@ func NewPreferenceList(prefInfo PreferenceInfo) PreferenceList {
 }
 
 func toPreferenceItems(prefInfo PreferenceInfo) []PreferenceItem {
+	items := preferenceItems(prefInfo)
+	for i := range items {
+		items[i].Source = prefInfo.GetSource(items[i].Name)
+	}
+	return items
+}
+
+func preferenceItems(prefInfo PreferenceInfo) []PreferenceItem {
 	odoSettings := prefInfo.OdoSettings
 	return []PreferenceItem{
 		{
@@ -72,6 +81,27 @@ func toPreferenceItems(prefInfo PreferenceInfo) []PreferenceItem {
 			Type:        getType(prefInfo.GetPushTimeout()),
 			Description: PushTimeoutSettingDescription,
 		},
+		{
+			Name:        RegistryCacheTimeSetting,
+			Value:       odoSettings.RegistryCacheTime,
+			Default:     DefaultRegistryCacheTime,
+			Type:        getType(prefInfo.GetRegistryCacheTime()),
+			Description: RegistryCacheTimeDescription,
+		},
+		{
+			Name:        RegistryCacheSizeSetting,
+			Value:       odoSettings.RegistryCacheSize,
+			Default:     DefaultRegistryCacheSize,
+			Type:        getType(prefInfo.GetRegistryCacheSize()),
+			Description: RegistryCacheSizeDescription,
+		},
+		{
+			Name:        EphemeralSetting,
+			Value:       odoSettings.Ephemeral,
+			Default:     DefaultEphemeralSettings,
+			Type:        getType(prefInfo.GetEphemeralSourceVolume()),
+			Description: EphemeralDescription,
+		},
 		{
 			Name:        ExperimentalSetting,
 			Value:       odoSettings.Experimental,
@@ -86,6 +116,69 @@ func toPreferenceItems(prefInfo PreferenceInfo) []PreferenceItem {
 			Type:        getType(prefInfo.GetConsentTelemetry()),
 			Description: ConsentTelemetryDescription,
 		},
+		{
+			Name:        HTTPProxySetting,
+			Value:       odoSettings.HTTPProxy,
+			Default:     "",
+			Type:        "string",
+			Description: HTTPProxyDescription,
+		},
+		{
+			Name:        NoProxySetting,
+			Value:       odoSettings.NoProxy,
+			Default:     "",
+			Type:        "string",
+			Description: NoProxyDescription,
+		},
+		{
+			Name:        CABundleSetting,
+			Value:       odoSettings.CABundle,
+			Default:     "",
+			Type:        "string",
+			Description: CABundleDescription,
+		},
+		{
+			Name:        ClientCertificateSetting,
+			Value:       odoSettings.ClientCertificate,
+			Default:     "",
+			Type:        "string",
+			Description: ClientCertificateDescription,
+		},
+		{
+			Name:        ClientKeySetting,
+			Value:       odoSettings.ClientKey,
+			Default:     "",
+			Type:        "string",
+			Description: ClientKeyDescription,
+		},
+		{
+			Name:        VerifySetting,
+			Value:       odoSettings.Verify,
+			Default:     DefaultVerify,
+			Type:        getType(prefInfo.GetVerify()),
+			Description: VerifySettingDescription,
+		},
+		{
+			Name:        TelemetrySinkSetting,
+			Value:       odoSettings.TelemetrySink,
+			Default:     DefaultTelemetrySink,
+			Type:        getType(prefInfo.GetTelemetrySink()),
+			Description: TelemetrySinkDescription,
+		},
+		{
+			Name:        PluginIndexSetting,
+			Value:       odoSettings.PluginIndex,
+			Default:     "",
+			Type:        "string",
+			Description: PluginIndexSettingDescription,
+		},
+		{
+			Name:        PushTargetSetting,
+			Value:       odoSettings.PushTarget,
+			Default:     DefaultPushTarget,
+			Type:        getType(prefInfo.GetPushTarget()),
+			Description: PushTargetDescription,
+		},
 	}
 }
 
@@ -2,6 +2,7 @@ package preference
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog"
 
+	"github.com/openshift/odo/pkg/hooks"
 	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/odo/cli/ui"
 	"github.com/openshift/odo/pkg/util"
@@ -62,6 +64,78 @@ const (
 	// RegistryCacheTimeSetting is human-readable description for the registrycachetime setting
 	RegistryCacheTimeSetting = "RegistryCacheTime"
 
+	// RegistryCacheSizeSetting is the name of the setting controlling the size of the cache of each registry
+	RegistryCacheSizeSetting = "RegistryCacheSize"
+
+	// HTTPProxySetting is the name of the setting controlling the proxy of the outbound HTTP requests
+	HTTPProxySetting = "HTTPProxy"
+
+	// HTTPProxyDescription is human-readable description for the HTTPProxy setting
+	HTTPProxyDescription = "URL of the proxy of the outbound HTTP requests of odo (Default: the proxy of the HTTPS_PROXY and HTTP_PROXY environment variables)"
+
+	// NoProxySetting is the name of the setting controlling the hosts reached without HTTPProxy
+	NoProxySetting = "NoProxy"
+
+	// NoProxyDescription is human-readable description for the NoProxy setting
+	NoProxyDescription = "Comma separated list of the hosts, domains and CIDRs odo reaches without HTTPProxy"
+
+	// CABundleSetting is the name of the setting controlling the additional certificate authorities of the outbound HTTP requests
+	CABundleSetting = "CABundle"
+
+	// CABundleDescription is human-readable description for the CABundle setting
+	CABundleDescription = "PEM file of the certificate authorities odo trusts in addition to the system ones"
+
+	// ClientCertificateSetting is the name of the setting controlling the client certificate of the outbound HTTP requests
+	ClientCertificateSetting = "ClientCertificate"
+
+	// ClientCertificateDescription is human-readable description for the ClientCertificate setting
+	ClientCertificateDescription = "PEM file of the certificate odo presents to the servers requesting one, set with ClientKey"
+
+	// ClientKeySetting is the name of the setting controlling the key of the client certificate
+	ClientKeySetting = "ClientKey"
+
+	// ClientKeyDescription is human-readable description for the ClientKey setting
+	ClientKeyDescription = "PEM file of the private key of ClientCertificate"
+
+	// VerifySetting is the name of the setting controlling the verification of the artifacts downloaded from the registries
+	VerifySetting = "Verify"
+
+	// VerifyOff doesn't verify the artifacts downloaded from the registries
+	VerifyOff = "off"
+
+	// VerifyWarn warns about the artifacts downloaded from the registries which can't be verified
+	VerifyWarn = "warn"
+
+	// VerifyEnforce refuses the artifacts downloaded from the registries which can't be verified
+	VerifyEnforce = "enforce"
+
+	// DefaultVerify is the default value of the Verify setting
+	DefaultVerify = VerifyOff
+
+	// PluginIndexSetting is the name of the setting controlling the index of the plugins installed with "odo plugin install"
+	PluginIndexSetting = "PluginIndex"
+
+	// PluginIndexSettingDescription is human-readable description for the PluginIndex setting
+	PluginIndexSettingDescription = "http(s):// or file:// URL of the plugin index the plugins are installed from with \"odo plugin install\""
+
+	// PushTargetSetting is the name of the setting controlling the platform the devfile components are run on
+	PushTargetSetting = "PushTarget"
+
+	// PushTargetKube runs the devfile components on the Kubernetes or OpenShift cluster
+	PushTargetKube = "kube"
+
+	// PushTargetDocker runs the devfile components as local Docker containers
+	PushTargetDocker = "docker"
+
+	// DefaultPushTarget is the default value of the PushTarget setting
+	DefaultPushTarget = PushTargetKube
+
+	// pluginsDirName is the directory next to the preference file holding the installed plugins
+	pluginsDirName = "plugins"
+
+	// daemonTokenFileName is the file next to the preference file holding the token of the running "odo daemon"
+	daemonTokenFileName = "daemon-token"
+
 	// DefaultDevfileRegistryName is the name of default devfile registry
 	DefaultDevfileRegistryName = "DefaultDevfileRegistry"
 
@@ -74,6 +148,9 @@ const (
 	// DefaultRegistryCacheTime is time (in minutes) for how long odo will cache information from Devfile registry
 	DefaultRegistryCacheTime = 15
 
+	// DefaultRegistryCacheSize is the size (in MiB) of the responses odo will cache for each Devfile registry
+	DefaultRegistryCacheSize = 20
+
 	// EphemeralSetting specifies if ephemeral volumes needs to be used as source volume.
 	EphemeralSetting = "Ephemeral"
 
@@ -85,6 +162,21 @@ const (
 
 	// DefaultConsentTelemetry is a default value for ConsentTelemetry preference
 	DefaultConsentTelemetrySetting = false
+
+	// TelemetrySinkSetting is the name of the setting controlling where the usage data is uploaded
+	TelemetrySinkSetting = "TelemetrySink"
+
+	// TelemetrySinkSegment uploads the usage data to Segment
+	TelemetrySinkSegment = "segment"
+
+	// TelemetrySinkNone only records the usage data locally, see "odo telemetry show"
+	TelemetrySinkNone = "none"
+
+	// DefaultTelemetrySink is the default value of the TelemetrySink setting
+	DefaultTelemetrySink = TelemetrySinkSegment
+
+	// telemetryDirName is the directory next to the preference file holding the recorded and queued usage data
+	telemetryDirName = "telemetry"
 )
 
 // TimeoutSettingDescription is human-readable description for the timeout setting
@@ -99,12 +191,24 @@ var BuildTimeoutSettingDescription = fmt.Sprintf("BuildTimeout (in seconds) for
 // RegistryCacheTimeDescription adds a description for RegistryCacheTime
 var RegistryCacheTimeDescription = fmt.Sprintf("For how long (in minutes) odo will cache information from Devfile registry (Default: %d)", DefaultRegistryCacheTime)
 
+// RegistryCacheSizeDescription adds a description for RegistryCacheSize
+var RegistryCacheSizeDescription = fmt.Sprintf("How much (in MiB) odo will cache from each Devfile registry, 0 for no limit (Default: %d)", DefaultRegistryCacheSize)
+
+// VerifySettingDescription adds a description for Verify
+var VerifySettingDescription = fmt.Sprintf("Verification of the devfiles and starter projects downloaded from the registries against the digests and signatures of the registries: %s, %s or %s (Default: %s)", VerifyOff, VerifyWarn, VerifyEnforce, DefaultVerify)
+
+// PushTargetDescription adds a description for PushTarget
+var PushTargetDescription = fmt.Sprintf("Platform the devfile components are run on: %s or %s (Default: %s)", PushTargetKube, PushTargetDocker, DefaultPushTarget)
+
 // EphemeralDescription adds a description for EphemeralSourceVolume
 var EphemeralDescription = fmt.Sprintf("If true odo will create a emptyDir volume to store source code (Default: %t)", DefaultEphemeralSettings)
 
 //TelemetryConsentDescription adds a description for TelemetryConsentSetting
 var ConsentTelemetryDescription = fmt.Sprintf("If true odo will collect telemetry for the user's odo usage (Default: %t)\n\t\t    For more information: https://developers.redhat.com/article/tool-data-collection", DefaultConsentTelemetrySetting)
 
+// TelemetrySinkDescription adds a description for TelemetrySink
+var TelemetrySinkDescription = fmt.Sprintf("Where the usage data is uploaded when ConsentTelemetry is true: %s, %s, a file:// URL of a file to append the data to, or a http(s):// URL of an endpoint to POST the data to (Default: %s)", TelemetrySinkSegment, TelemetrySinkNone, DefaultTelemetrySink)
+
 // This value can be provided to set a seperate directory for users 'homedir' resolution
 // note for mocking purpose ONLY
 var customHomeDir = os.Getenv("CUSTOM_HOMEDIR")
@@ -119,8 +223,18 @@ var (
 		PushTimeoutSetting:        PushTimeoutSettingDescription,
 		ExperimentalSetting:       ExperimentalDescription,
 		RegistryCacheTimeSetting:  RegistryCacheTimeDescription,
+		RegistryCacheSizeSetting:  RegistryCacheSizeDescription,
 		EphemeralSetting:          EphemeralDescription,
 		ConsentTelemetrySetting:   ConsentTelemetryDescription,
+		TelemetrySinkSetting:      TelemetrySinkDescription,
+		HTTPProxySetting:          HTTPProxyDescription,
+		NoProxySetting:            NoProxyDescription,
+		CABundleSetting:           CABundleDescription,
+		ClientCertificateSetting:  ClientCertificateDescription,
+		ClientKeySetting:          ClientKeyDescription,
+		VerifySetting:             VerifySettingDescription,
+		PluginIndexSetting:        PluginIndexSettingDescription,
+		PushTargetSetting:         PushTargetDescription,
 	}
 
 	// set-like map to quickly check if a parameter is supported
@@ -132,6 +246,10 @@ var (
 type PreferenceInfo struct {
 	Filename   string `yaml:"FileName,omitempty"`
 	Preference `yaml:",omitempty"`
+
+	// layers are the project preference and the environment variables layered over the global preference,
+	// nil if there are none
+	layers *layers
 }
 
 // OdoSettings holds all odo specific configurations
@@ -160,11 +278,40 @@ type OdoSettings struct {
 	// RegistryCacheTime how long odo should cache information from registry
 	RegistryCacheTime *int `yaml:"RegistryCacheTime,omitempty"`
 
+	// RegistryCacheSize how much odo should cache from each registry
+	RegistryCacheSize *int `yaml:"RegistryCacheSize,omitempty"`
+
 	// Ephemeral if true creates odo emptyDir to store odo source code
 	Ephemeral *bool `yaml:"Ephemeral,omitempty"`
 
 	// ConsentTelemetry if true collects telemetry for odo
 	ConsentTelemetry *bool `yaml:"ConsentTelemetry,omitempty"`
+
+	// TelemetrySink is where the telemetry is uploaded: segment, none, a file:// or a http(s):// URL
+	TelemetrySink *string `yaml:"TelemetrySink,omitempty"`
+
+	// HTTPProxy is the proxy of the outbound HTTP requests, and NoProxy the hosts reached without it
+	HTTPProxy *string `yaml:"HTTPProxy,omitempty"`
+	NoProxy   *string `yaml:"NoProxy,omitempty"`
+
+	// CABundle is the PEM file of the additional certificate authorities of the outbound HTTP requests
+	CABundle *string `yaml:"CABundle,omitempty"`
+
+	// ClientCertificate and ClientKey are the PEM files of the client certificate of the outbound HTTP requests
+	ClientCertificate *string `yaml:"ClientCertificate,omitempty"`
+	ClientKey         *string `yaml:"ClientKey,omitempty"`
+
+	// Verify is the verification policy of the artifacts downloaded from the registries
+	Verify *string `yaml:"Verify,omitempty"`
+
+	// PluginIndex is the URL of the index the plugins are installed from
+	PluginIndex *string `yaml:"PluginIndex,omitempty"`
+
+	// PushTarget is the platform the devfile components are run on: kube or docker
+	PushTarget *string `yaml:"PushTarget,omitempty"`
+
+	// Hooks are the local commands run at the events of the push, watch and delete of all the components
+	Hooks []hooks.Hook `yaml:"Hooks,omitempty"`
 }
 
 // Registry includes the registry metadata
@@ -172,6 +319,14 @@ type Registry struct {
 	Name   string `yaml:"Name,omitempty"`
 	URL    string `yaml:"URL,omitempty"`
 	Secure bool
+	// Username is the user of the basic auth credentials of the registry, the password is stored in the keyring
+	Username string `yaml:"Username,omitempty"`
+	// CAFile is a PEM file of the additional certificate authorities to trust when accessing the registry
+	CAFile string `yaml:"CAFile,omitempty"`
+	// CredentialHelper is the docker credential helper providing the credentials of the registry, "pass" for docker-credential-pass
+	CredentialHelper string `yaml:"CredentialHelper,omitempty"`
+	// PublicKey is a PEM file of the public key verifying the signatures of the artifacts of the registry
+	PublicKey string `yaml:"PublicKey,omitempty"`
 }
 
 // Preference stores all the preferences related to odo
@@ -239,6 +394,9 @@ func NewPreferenceInfo() (*PreferenceInfo, error) {
 	// If the preference file doesn't exist then we return with default preference
 	if _, err = os.Stat(preferenceFile); os.IsNotExist(err) {
 		c.OdoSettings.RegistryList = &defaultRegistryList
+		if err = c.loadLayers(); err != nil {
+			return nil, err
+		}
 		return &c, nil
 	}
 
@@ -263,11 +421,20 @@ func NewPreferenceInfo() (*PreferenceInfo, error) {
 		}
 	}
 
+	if err = c.loadLayers(); err != nil {
+		return nil, err
+	}
 	return &c, nil
 }
 
 // RegistryHandler handles registry add, update and delete operations
 func (c *PreferenceInfo) RegistryHandler(operation string, registryName string, registryURL string, forceFlag bool, isSecure bool) error {
+	if c.layers != nil {
+		return c.updateGlobal(func(global *PreferenceInfo) error {
+			return global.RegistryHandler(operation, registryName, registryURL, forceFlag, isSecure)
+		})
+	}
+
 	var registryList []Registry
 	var err error
 	registryExist := false
@@ -309,6 +476,80 @@ func (c *PreferenceInfo) RegistryHandler(operation string, registryName string,
 	return nil
 }
 
+// SetRegistryAuth sets how odo authenticates to the given registry
+// the secrets of the registry are not stored in the preference file, but in the keyring or in the credential helper
+func (c *PreferenceInfo) SetRegistryAuth(registryName string, username string, caFile string, credentialHelper string) error {
+	if c.layers != nil {
+		return c.updateGlobal(func(global *PreferenceInfo) error {
+			return global.SetRegistryAuth(registryName, username, caFile, credentialHelper)
+		})
+	}
+
+	if c.OdoSettings.RegistryList == nil {
+		return errors.Errorf("registry %q doesn't exist", registryName)
+	}
+
+	registryList := *c.OdoSettings.RegistryList
+	found := false
+	for index := range registryList {
+		if registryList[index].Name == registryName {
+			registryList[index].Username = username
+			registryList[index].CAFile = caFile
+			registryList[index].CredentialHelper = credentialHelper
+			found = true
+		}
+	}
+	if !found {
+		return errors.Errorf("registry %q doesn't exist", registryName)
+	}
+
+	err := util.WriteToFile(&c.Preference, c.Filename)
+	if err != nil {
+		return errors.Errorf("unable to write the authentication of registry %q to preference file", registryName)
+	}
+	return nil
+}
+
+// SetRegistryPublicKey sets the PEM file of the public key verifying the signatures of the artifacts of the given registry,
+// the signatures are not verified if the file is empty
+func (c *PreferenceInfo) SetRegistryPublicKey(registryName string, publicKey string) error {
+	if c.layers != nil {
+		return c.updateGlobal(func(global *PreferenceInfo) error {
+			return global.SetRegistryPublicKey(registryName, publicKey)
+		})
+	}
+
+	registry := c.GetRegistry(registryName)
+	if registry == nil {
+		return errors.Errorf("registry %q doesn't exist", registryName)
+	}
+	for index := range *c.OdoSettings.RegistryList {
+		if (*c.OdoSettings.RegistryList)[index].Name == registryName {
+			(*c.OdoSettings.RegistryList)[index].PublicKey = publicKey
+		}
+	}
+
+	err := util.WriteToFile(&c.Preference, c.Filename)
+	if err != nil {
+		return errors.Errorf("unable to write the public key of registry %q to preference file", registryName)
+	}
+	return nil
+}
+
+// GetRegistry returns the registry of the given name, nil if it doesn't exist
+func (c *PreferenceInfo) GetRegistry(registryName string) *Registry {
+	if c.OdoSettings.RegistryList == nil {
+		return nil
+	}
+	for _, registry := range *c.OdoSettings.RegistryList {
+		if registry.Name == registryName {
+			registry := registry
+			return &registry
+		}
+	}
+	return nil
+}
+
 func handleWithoutRegistryExist(registryList []Registry, operation string, registryName string, registryURL string, isSecure bool) ([]Registry, error) {
 	switch operation {
 
@@ -367,8 +608,27 @@ func handleWithRegistryExist(index int, registryList []Registry, operation strin
 
 // SetConfiguration modifies Odo configurations in the config file
 // as of now being used for nameprefix, timeout, updatenotification
-// TODO: Use reflect to set parameters
 func (c *PreferenceInfo) SetConfiguration(parameter string, value string) error {
+	if c.layers != nil {
+		return c.updateGlobal(func(global *PreferenceInfo) error {
+			return global.SetConfiguration(parameter, value)
+		})
+	}
+
+	if err := c.setValue(parameter, value); err != nil {
+		return err
+	}
+
+	err := util.WriteToFile(&c.Preference, c.Filename)
+	if err != nil {
+		return errors.Errorf("unable to set %q, something is wrong with odo, kindly raise an issue at https://github.com/openshift/odo/issues/new?template=Bug.md", parameter)
+	}
+	return nil
+}
+
+// setValue sets the parameter to the value, after validating the value
+// TODO: Use reflect to set parameters
+func (c *PreferenceInfo) setValue(parameter string, value string) error {
 	if p, ok := asSupportedParameter(parameter); ok {
 		// processing values according to the parameter names
 		switch p {
@@ -413,6 +673,16 @@ func (c *PreferenceInfo) SetConfiguration(parameter string, value string) error
 			}
 			c.OdoSettings.RegistryCacheTime = &typedval
 
+		case "registrycachesize":
+			typedval, err := strconv.Atoi(value)
+			if err != nil {
+				return errors.Errorf("unable to set %q to %q, value must be an integer", parameter, value)
+			}
+			if typedval < 0 {
+				return errors.Errorf("cannot set cache size to less than 0")
+			}
+			c.OdoSettings.RegistryCacheSize = &typedval
+
 		case "updatenotification":
 			val, err := strconv.ParseBool(strings.ToLower(value))
 			if err != nil {
@@ -444,21 +714,76 @@ func (c *PreferenceInfo) SetConfiguration(parameter string, value string) error
 				return errors.Errorf("unable to set %q to %q, value must be a boolean", parameter, value)
 			}
 			c.OdoSettings.ConsentTelemetry = &val
+
+		case "telemetrysink":
+			if err := ValidateTelemetrySink(value); err != nil {
+				return errors.Wrapf(err, "unable to set %q to %q", parameter, value)
+			}
+			c.OdoSettings.TelemetrySink = &value
+
+		case "httpproxy":
+			proxyURL, err := url.Parse(value)
+			if err != nil || proxyURL.Host == "" {
+				return errors.Errorf("unable to set %q to %q, value must be a URL such as http://proxy.example.com:3128", parameter, value)
+			}
+			c.OdoSettings.HTTPProxy = &value
+
+		case "noproxy":
+			c.OdoSettings.NoProxy = &value
+
+		case "cabundle", "clientcertificate", "clientkey":
+			file, err := filepath.Abs(value)
+			if err != nil {
+				return err
+			}
+			if _, err = os.Stat(file); err != nil {
+				return errors.Wrapf(err, "unable to set %q to %q", parameter, value)
+			}
+			switch p {
+			case "cabundle":
+				c.OdoSettings.CABundle = &file
+			case "clientcertificate":
+				c.OdoSettings.ClientCertificate = &file
+			default:
+				c.OdoSettings.ClientKey = &file
+			}
+
+		case "verify":
+			if err := ValidateVerifyPolicy(value); err != nil {
+				return errors.Wrapf(err, "unable to set %q to %q", parameter, value)
+			}
+			value = strings.ToLower(value)
+			c.OdoSettings.Verify = &value
+
+		case "pluginindex":
+			indexURL, err := url.Parse(value)
+			if err != nil || (indexURL.Scheme != "http" && indexURL.Scheme != "https" && indexURL.Scheme != "file") {
+				return errors.Errorf("unable to set %q to %q, value must be a http(s):// or file:// URL", parameter, value)
+			}
+			c.OdoSettings.PluginIndex = &value
+
+		case "pushtarget":
+			value = strings.ToLower(value)
+			if value != PushTargetKube && value != PushTargetDocker {
+				return errors.Errorf("unable to set %q to %q, value must be %s or %s", parameter, value, PushTargetKube, PushTargetDocker)
+			}
+			c.OdoSettings.PushTarget = &value
 		}
 	} else {
 		return errors.Errorf("unknown parameter : %q is not a parameter in odo preference, run help to see list of available parameters", parameter)
 	}
-
-	err := util.WriteToFile(&c.Preference, c.Filename)
-	if err != nil {
-		return errors.Errorf("unable to set %q, something is wrong with odo, kindly raise an issue at https://github.com/openshift/odo/issues/new?template=Bug.md", parameter)
-	}
 	return nil
 }
 
 // DeleteConfiguration delete Odo configurations in the global config file
 // as of now being used for nameprefix, timeout, updatenotification
 func (c *PreferenceInfo) DeleteConfiguration(parameter string) error {
+	if c.layers != nil {
+		return c.updateGlobal(func(global *PreferenceInfo) error {
+			return global.DeleteConfiguration(parameter)
+		})
+	}
+
 	if p, ok := asSupportedParameter(parameter); ok {
 		// processing values according to the parameter names
 
@@ -476,8 +801,11 @@ func (c *PreferenceInfo) DeleteConfiguration(parameter string) error {
 	return nil
 }
 
-// IsSet checks if the value is set in the preference
+// IsSet checks if the value is set in the global preference
 func (c *PreferenceInfo) IsSet(parameter string) bool {
+	if c.layers != nil {
+		return util.IsSet(c.layers.global.OdoSettings, parameter)
+	}
 	return util.IsSet(c.OdoSettings, parameter)
 }
 
@@ -505,6 +833,98 @@ func (c *PreferenceInfo) GetRegistryCacheTime() int {
 	return util.GetIntOrDefault(c.OdoSettings.RegistryCacheTime, DefaultRegistryCacheTime)
 }
 
+// GetRegistryCacheSize gets the value set by RegistryCacheSize
+func (c *PreferenceInfo) GetRegistryCacheSize() int {
+	return util.GetIntOrDefault(c.OdoSettings.RegistryCacheSize, DefaultRegistryCacheSize)
+}
+
+// GetHTTPTransportSettings returns the settings of the transport shared by the outbound HTTP requests
+func (c *PreferenceInfo) GetHTTPTransportSettings() util.HTTPTransportSettings {
+	return util.HTTPTransportSettings{
+		Proxy:             util.GetStringOrEmpty(c.OdoSettings.HTTPProxy),
+		NoProxy:           util.GetStringOrEmpty(c.OdoSettings.NoProxy),
+		CABundle:          util.GetStringOrEmpty(c.OdoSettings.CABundle),
+		ClientCertificate: util.GetStringOrEmpty(c.OdoSettings.ClientCertificate),
+		ClientKey:         util.GetStringOrEmpty(c.OdoSettings.ClientKey),
+	}
+}
+
+// GetVerify returns the verification policy of the artifacts downloaded from the registries
+func (c *PreferenceInfo) GetVerify() string {
+	if c.OdoSettings.Verify == nil {
+		return DefaultVerify
+	}
+	return *c.OdoSettings.Verify
+}
+
+// GetPluginIndex returns the URL of the index the plugins are installed from, empty if not set
+func (c *PreferenceInfo) GetPluginIndex() string {
+	return util.GetStringOrEmpty(c.OdoSettings.PluginIndex)
+}
+
+// GetPushTarget returns the platform the devfile components are run on: kube or docker
+func (c *PreferenceInfo) GetPushTarget() string {
+	if c.OdoSettings.PushTarget == nil {
+		return DefaultPushTarget
+	}
+	return *c.OdoSettings.PushTarget
+}
+
+// GetHooks returns the local lifecycle hooks of all the components
+func (c *PreferenceInfo) GetHooks() []hooks.Hook {
+	return c.OdoSettings.Hooks
+}
+
+// GetPluginsDir returns the directory of the installed plugins, next to the preference file
+func (c *PreferenceInfo) GetPluginsDir() string {
+	return filepath.Join(filepath.Dir(c.Filename), pluginsDirName)
+}
+
+// GetDaemonTokenFile returns the file holding the token of the running "odo daemon", next to the preference file
+func (c *PreferenceInfo) GetDaemonTokenFile() string {
+	return filepath.Join(filepath.Dir(c.Filename), daemonTokenFileName)
+}
+
+// GetTelemetrySink returns where the usage data is uploaded: segment, none, a file:// or a http(s):// URL
+func (c *PreferenceInfo) GetTelemetrySink() string {
+	if c.OdoSettings.TelemetrySink == nil {
+		return DefaultTelemetrySink
+	}
+	return *c.OdoSettings.TelemetrySink
+}
+
+// GetTelemetryDir returns the directory of the recorded and queued usage data, next to the preference file
+func (c *PreferenceInfo) GetTelemetryDir() string {
+	return filepath.Join(filepath.Dir(c.Filename), telemetryDirName)
+}
+
+// ValidateTelemetrySink returns an error if the given sink is not segment, none, a file:// URL or a http(s):// URL
+func ValidateTelemetrySink(sink string) error {
+	switch sink {
+	case TelemetrySinkSegment, TelemetrySinkNone:
+		return nil
+	}
+	sinkURL, err := url.Parse(sink)
+	if err == nil {
+		switch {
+		case (sinkURL.Scheme == "http" || sinkURL.Scheme == "https") && sinkURL.Host != "":
+			return nil
+		case sinkURL.Scheme == "file" && sinkURL.Path != "":
+			return nil
+		}
+	}
+	return errors.Errorf("the telemetry sink must be %s, %s, a file:// URL or a http(s):// URL", TelemetrySinkSegment, TelemetrySinkNone)
+}
+
+// ValidateVerifyPolicy returns an error if the given verification policy is not off, warn or enforce
+func ValidateVerifyPolicy(policy string) error {
+	switch strings.ToLower(policy) {
+	case VerifyOff, VerifyWarn, VerifyEnforce:
+		return nil
+	}
+	return errors.Errorf("the verification policy must be %s, %s or %s", VerifyOff, VerifyWarn, VerifyEnforce)
+}
+
 // GetUpdateNotification returns the value of UpdateNotification from preferences
 // and if absent then returns default
 func (c *PreferenceInfo) GetUpdateNotification() bool {
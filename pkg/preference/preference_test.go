@@ -476,6 +476,111 @@ func TestSetConfiguration(t *testing.T) {
 			wantErr: false,
 			want:    false,
 		},
+		{
+			name:           "Case 30: set RegistryCacheSize to 50",
+			parameter:      "RegistryCacheSize",
+			value:          "50",
+			existingConfig: Preference{},
+			wantErr:        false,
+		},
+		{
+			name:           "Case 31: set RegistryCacheSize to negative value",
+			parameter:      "RegistryCacheSize",
+			value:          "-1",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
+		{
+			name:           "Case 32: set HTTPProxy to a proxy URL",
+			parameter:      "HTTPProxy",
+			value:          "http://proxy.example.com:3128",
+			existingConfig: Preference{},
+			wantErr:        false,
+		},
+		{
+			name:           "Case 33: set HTTPProxy to a value which is not a URL",
+			parameter:      "HTTPProxy",
+			value:          "proxy.example.com",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
+		{
+			name:           "Case 34: set CABundle to a file which doesn't exist",
+			parameter:      "CABundle",
+			value:          "/nonexistent/ca.pem",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
+		{
+			name:           "Case 35: set Verify to enforce",
+			parameter:      "Verify",
+			value:          "Enforce",
+			existingConfig: Preference{},
+			wantErr:        false,
+		},
+		{
+			name:           "Case 36: set Verify to an unknown policy",
+			parameter:      "Verify",
+			value:          "strict",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
+		{
+			name:           "Case 37: set PluginIndex to a https URL",
+			parameter:      "PluginIndex",
+			value:          "https://example.com/plugins/index.json",
+			existingConfig: Preference{},
+			wantErr:        false,
+		},
+		{
+			name:           "Case 38: set PluginIndex to a path",
+			parameter:      "PluginIndex",
+			value:          "plugins/index.json",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
+		{
+			name:           "Case 39: set TelemetrySink to none",
+			parameter:      "TelemetrySink",
+			value:          "none",
+			existingConfig: Preference{},
+			wantErr:        false,
+		},
+		{
+			name:           "Case 40: set TelemetrySink to a https URL",
+			parameter:      "TelemetrySink",
+			value:          "https://telemetry.example.com/odo",
+			existingConfig: Preference{},
+			wantErr:        false,
+		},
+		{
+			name:           "Case 41: set TelemetrySink to a file URL",
+			parameter:      "TelemetrySink",
+			value:          "file:///var/log/odo/telemetry.jsonl",
+			existingConfig: Preference{},
+			wantErr:        false,
+		},
+		{
+			name:           "Case 42: set TelemetrySink to an unknown sink",
+			parameter:      "TelemetrySink",
+			value:          "syslog",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
+		{
+			name:           "Case 43: set PushTarget to docker",
+			parameter:      "PushTarget",
+			value:          "Docker",
+			existingConfig: Preference{},
+			wantErr:        false,
+		},
+		{
+			name:           "Case 44: set PushTarget to an unknown platform",
+			parameter:      "PushTarget",
+			value:          "podman",
+			existingConfig: Preference{},
+			wantErr:        true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -953,3 +1058,75 @@ func TestGetConsentTelemetry(t *testing.T) {
 		})
 	}
 }
+
+func TestSetRegistryAuth(t *testing.T) {
+	tempConfigFile, err := ioutil.TempFile("", "odoconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tempConfigFile.Close()
+	os.Setenv(GlobalConfigEnvName, tempConfigFile.Name())
+
+	tests := []struct {
+		name             string
+		registryName     string
+		username         string
+		caFile           string
+		credentialHelper string
+		want             *Registry
+		wantErr          bool
+	}{
+		{
+			name:         "Case 1: basic auth and certificate authority of an existing registry",
+			registryName: DefaultDevfileRegistryName,
+			username:     "developer",
+			caFile:       "ca.pem",
+			want: &Registry{
+				Name:     DefaultDevfileRegistryName,
+				URL:      DefaultDevfileRegistryURL,
+				Username: "developer",
+				CAFile:   "ca.pem",
+			},
+		},
+		{
+			name:             "Case 2: credential helper of an existing registry",
+			registryName:     DefaultDevfileRegistryName,
+			credentialHelper: "pass",
+			want: &Registry{
+				Name:             DefaultDevfileRegistryName,
+				URL:              DefaultDevfileRegistryURL,
+				CredentialHelper: "pass",
+			},
+		},
+		{
+			name:         "Case 3: registry which doesn't exist",
+			registryName: "MissingRegistry",
+			username:     "developer",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewPreferenceInfo()
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = cfg.SetRegistryAuth(tt.registryName, tt.username, tt.caFile, tt.credentialHelper)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetRegistryAuth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			written, err := NewPreferenceInfo()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := written.GetRegistry(tt.registryName); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetRegistry() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
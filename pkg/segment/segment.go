@@ -11,10 +11,12 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 
 	scontext "github.com/openshift/odo/pkg/segment/context"
 
 	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/util"
 	"github.com/pborman/uuid"
 	"github.com/pkg/errors"
 	"golang.org/x/term"
@@ -54,6 +56,16 @@ type Client struct {
 	Preference *preference.PreferenceInfo
 	// TelemetryFilePath points to the file containing anonymousID used for tracking odo commands executed by the user
 	TelemetryFilePath string
+	// Sink is where the data is uploaded: segment, none, a file:// or a http(s):// URL
+	Sink string
+	// DataDir holds the recently recorded events and the events queued for a later upload
+	DataDir string
+
+	// events are uploaded when the client is closed
+	events []Event
+	// failed holds the IDs of the messages Segment didn't accept
+	failed map[string]bool
+	mu     sync.Mutex
 }
 
 // NewClient returns a Client created with the default args
@@ -61,16 +73,31 @@ func NewClient(preference *preference.PreferenceInfo) (*Client, error) {
 	homeDir, _ := os.UserHomeDir()
 	return newCustomClient(preference,
 		filepath.Join(homeDir, ".redhat", "anonymousId"),
+		preference.GetTelemetryDir(),
 		analytics.DefaultEndpoint,
 	)
 }
 
 // newCustomClient returns a Client created with custom args
-func newCustomClient(preference *preference.PreferenceInfo, telemetryFilePath string, segmentEndpoint string) (*Client, error) {
+func newCustomClient(preference *preference.PreferenceInfo, telemetryFilePath string, dataDir string, segmentEndpoint string) (*Client, error) {
+	// the data is sent through the proxy and with the certificate authorities of the preference
+	transport, err := util.HTTPTransport("")
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		Preference:        preference,
+		TelemetryFilePath: telemetryFilePath,
+		Sink:              preference.GetTelemetrySink(),
+		DataDir:           dataDir,
+		failed:            map[string]bool{},
+	}
 	// DefaultContext has IP set to 0.0.0.0 so that it does not track user's IP, which it does in case no IP is set
-	client, err := analytics.NewWithConfig(writeKey, analytics.Config{
-		Endpoint: segmentEndpoint,
-		Verbose:  true,
+	c.SegmentClient, err = analytics.NewWithConfig(writeKey, analytics.Config{
+		Endpoint:  segmentEndpoint,
+		Transport: transport,
+		Verbose:   true,
+		Callback:  deliveryCallback{client: c},
 		DefaultContext: &analytics.Context{
 			IP: net.IPv4(0, 0, 0, 0),
 		},
@@ -78,19 +105,62 @@ func newCustomClient(preference *preference.PreferenceInfo, telemetryFilePath st
 	if err != nil {
 		return nil, err
 	}
-	return &Client{
-		SegmentClient:     client,
-		Preference:        preference,
-		TelemetryFilePath: telemetryFilePath,
-	}, nil
+	return c, nil
 }
 
-// Close client connection and send the data
+// Close uploads the data to the sink, with the data queued by the previous uploads. The data which can't be
+// uploaded is queued for the next upload, and the data is recorded locally for "odo telemetry show".
 func (c *Client) Close() error {
-	return c.SegmentClient.Close()
+	if len(c.events) == 0 {
+		return c.SegmentClient.Close()
+	}
+	events := c.events
+	c.events = nil
+
+	unlock, err := lockDataDir(c.DataDir)
+	if err != nil {
+		_ = c.SegmentClient.Close()
+		return errors.Wrap(err, "unable to upload telemetry data")
+	}
+	defer unlock()
+
+	queued, err := ReadQueuedEvents(c.DataDir)
+	if err != nil {
+		klog.V(4).Infof("Dropping the unreadable queued telemetry data: %v", err)
+	}
+	failed, err := c.deliver(append(queued, events...))
+	if err != nil {
+		klog.V(4).Infof("Unable to upload telemetry data to %s, queueing it: %v", c.Sink, err)
+	}
+	if c.Sink != preference.TelemetrySinkSegment {
+		// the Segment client was not used, but its goroutine must be stopped
+		_ = c.SegmentClient.Close()
+	}
+
+	failedIDs := map[string]bool{}
+	for _, event := range failed {
+		failedIDs[event.MessageID] = true
+	}
+	recorded := make([]RecordedEvent, len(events))
+	for i, event := range events {
+		status := EventSent
+		if c.Sink == preference.TelemetrySinkNone {
+			status = EventRecorded
+		} else if failedIDs[event.MessageID] {
+			status = EventQueued
+		}
+		recorded[i] = RecordedEvent{Event: event, Sink: c.Sink, Status: status}
+	}
+	if err1 := recordEvents(c.DataDir, recorded); err1 != nil {
+		klog.V(4).Infof("Unable to record telemetry data: %v", err1)
+	}
+	if err1 := writeQueue(c.DataDir, failed); err1 != nil {
+		klog.V(4).Infof("Unable to queue telemetry data: %v", err1)
+	}
+	return err
 }
 
-// Upload prepares the data to be sent to segment and send it once the client connection closes
+// Upload prepares the data to be sent to the sink and send it once the client connection closes
 func (c *Client) Upload(data TelemetryData) error {
 	// if the user has not consented for telemetry, return
 	if !IsTelemetryEnabled(c.Preference) {
@@ -121,11 +191,8 @@ func (c *Client) Upload(data TelemetryData) error {
 	}
 
 	// queue the data that has telemetry information
-	return c.SegmentClient.Enqueue(analytics.Track{
-		UserId:     userId,
-		Event:      data.Event,
-		Properties: properties,
-	})
+	c.events = append(c.events, newTrackEvent(userId, data.Event, properties))
+	return nil
 }
 
 // addConfigTraits adds information about the system
@@ -158,13 +225,7 @@ func getUserIdentity(client *Client, telemetryFilePath string) (string, error) {
 			return "", err
 		}
 		// Since a new ID was created, send the Identify message data that helps identify the user on segment
-		if err1 := client.SegmentClient.Enqueue(analytics.Identify{
-			UserId: strings.TrimSpace(string(id)),
-			Traits: addConfigTraits(),
-		}); err1 != nil {
-			// TODO: maybe change this to klog Info instead of returning
-			return "", err1
-		}
+		client.events = append(client.events, newIdentifyEvent(strings.TrimSpace(string(id)), addConfigTraits()))
 
 	}
 	return strings.TrimSpace(string(id)), nil
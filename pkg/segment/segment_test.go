@@ -73,7 +73,7 @@ func TestClientUploadWithoutConsent(t *testing.T) {
 			},
 		},
 	}
-	c, err := newCustomClient(cfg, createConfigDir(t), server.URL)
+	c, err := newCustomClient(cfg, createConfigDir(t), t.TempDir(), server.URL)
 	if err != nil {
 		t.Error(err)
 	}
@@ -136,7 +136,7 @@ func TestClientUploadWithConsent(t *testing.T) {
 	for _, tt := range tests {
 		t.Log("Running test: ", tt.testName)
 		t.Run(tt.testName, func(t *testing.T) {
-			c, err := newCustomClient(cfg, createConfigDir(t), server.URL)
+			c, err := newCustomClient(cfg, createConfigDir(t), t.TempDir(), server.URL)
 			if err != nil {
 				t.Error(err)
 			}
@@ -279,7 +279,7 @@ func TestClientUploadWithContext(t *testing.T) {
 			scontext.SetClusterType(ctx, fakeClient)
 			uploadData = fakeTelemetryData("odo project set", nil, ctx)
 		}
-		c, err := newCustomClient(cfg, createConfigDir(t), server.URL)
+		c, err := newCustomClient(cfg, createConfigDir(t), t.TempDir(), server.URL)
 		if err != nil {
 			t.Error(err)
 		}
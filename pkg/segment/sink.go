@@ -0,0 +1,345 @@
+package segment
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/preference"
+	"github.com/openshift/odo/pkg/util"
+	"github.com/pborman/uuid"
+	"github.com/pkg/errors"
+	"gopkg.in/segmentio/analytics-go.v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+const (
+	// eventsFileName is the file of the data directory holding the recently recorded events
+	eventsFileName = "events.json"
+	// queueFileName is the file of the data directory holding the events to upload later
+	queueFileName = "queue.json"
+	// lockFileName is the file of the data directory existing while an upload reads and rewrites the events and the queue
+	lockFileName = "upload.lock"
+
+	// maxRecordedEvents is the number of recorded events kept, the oldest ones are dropped
+	maxRecordedEvents = 100
+	// maxQueuedEvents is the number of events kept for a later upload, the oldest ones are dropped
+	maxQueuedEvents = 100
+
+	// lockTimeout is how long an upload waits for the uploads of the other odo processes
+	lockTimeout = time.Minute
+	// lockRetryInterval is the time between two attempts to take the lock
+	lockRetryInterval = 50 * time.Millisecond
+	// staleLockAge is the age of a lock left by an upload which didn't release it, the lock is then broken
+	staleLockAge = 5 * time.Minute
+)
+
+const (
+	// EventSent is the status of an event uploaded to the sink
+	EventSent = "sent"
+	// EventQueued is the status of an event which couldn't be uploaded, it is retried at the next upload
+	EventQueued = "queued"
+	// EventRecorded is the status of an event only recorded locally, with the none sink
+	EventRecorded = "recorded"
+)
+
+// Event is an event as uploaded to the sink, after the sanitization of the data
+type Event struct {
+	MessageID  string                 `json:"messageId"`
+	Type       string                 `json:"type"`
+	UserID     string                 `json:"userId"`
+	Event      string                 `json:"event,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Traits     map[string]interface{} `json:"traits,omitempty"`
+	Timestamp  time.Time              `json:"timestamp"`
+}
+
+// RecordedEvent is an event recorded locally, with the sink it was uploaded to and the outcome of the upload
+type RecordedEvent struct {
+	Event  `json:",inline"`
+	Sink   string `json:"sink"`
+	Status string `json:"status"`
+}
+
+// RecordedEventList is the machine readable output of the recorded events
+type RecordedEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []RecordedEvent `json:"items"`
+	// Queued is the number of events waiting for a later upload
+	Queued int `json:"queued"`
+}
+
+// NewRecordedEventList returns the machine readable output of the recorded events
+func NewRecordedEventList(events []RecordedEvent, queued int) RecordedEventList {
+	if events == nil {
+		events = []RecordedEvent{}
+	}
+	return RecordedEventList{
+		TypeMeta: metav1.TypeMeta{Kind: "List", APIVersion: machineoutput.APIVersion},
+		Items:    events,
+		Queued:   queued,
+	}
+}
+
+func newTrackEvent(userID string, event string, properties map[string]interface{}) Event {
+	return Event{
+		MessageID:  uuid.NewRandom().String(),
+		Type:       "track",
+		UserID:     userID,
+		Event:      event,
+		Properties: properties,
+		Timestamp:  time.Now().UTC(),
+	}
+}
+
+func newIdentifyEvent(userID string, traits map[string]interface{}) Event {
+	return Event{
+		MessageID: uuid.NewRandom().String(),
+		Type:      "identify",
+		UserID:    userID,
+		Traits:    traits,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// segmentMessage returns the Segment message of the event
+func (e Event) segmentMessage() analytics.Message {
+	if e.Type == "identify" {
+		return analytics.Identify{
+			MessageId: e.MessageID,
+			UserId:    e.UserID,
+			Traits:    e.Traits,
+			Timestamp: e.Timestamp,
+		}
+	}
+	return analytics.Track{
+		MessageId:  e.MessageID,
+		UserId:     e.UserID,
+		Event:      e.Event,
+		Properties: e.Properties,
+		Timestamp:  e.Timestamp,
+	}
+}
+
+// deliveryCallback collects the IDs of the messages Segment didn't accept
+type deliveryCallback struct {
+	client *Client
+}
+
+// Success is called for the messages delivered to Segment
+func (d deliveryCallback) Success(analytics.Message) {}
+
+// Failure is called for the messages which couldn't be delivered to Segment
+func (d deliveryCallback) Failure(message analytics.Message, err error) {
+	klog.V(4).Infof("Unable to upload telemetry data: %v", err)
+	d.client.mu.Lock()
+	defer d.client.mu.Unlock()
+	switch m := message.(type) {
+	case analytics.Track:
+		d.client.failed[m.MessageId] = true
+	case analytics.Identify:
+		d.client.failed[m.MessageId] = true
+	}
+}
+
+// deliver uploads the events to the sink, and returns the events which couldn't be uploaded
+func (c *Client) deliver(events []Event) ([]Event, error) {
+	switch sink := c.Sink; sink {
+	case preference.TelemetrySinkNone:
+		return nil, nil
+
+	case preference.TelemetrySinkSegment:
+		for _, event := range events {
+			if err := c.SegmentClient.Enqueue(event.segmentMessage()); err != nil {
+				c.failed[event.MessageID] = true
+			}
+		}
+		// the messages are delivered when the client is closed
+		err := c.SegmentClient.Close()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		var failed []Event
+		for _, event := range events {
+			if c.failed[event.MessageID] {
+				failed = append(failed, event)
+			}
+		}
+		return failed, err
+
+	default:
+		sinkURL, err := url.Parse(sink)
+		if err != nil {
+			return events, err
+		}
+		if sinkURL.Scheme == "file" {
+			err = appendEventsToFile(sinkURL.Path, events)
+		} else {
+			err = postEvents(sink, events)
+		}
+		if err != nil {
+			return events, err
+		}
+		return nil, nil
+	}
+}
+
+// appendEventsToFile appends the events to the file as JSON lines
+func appendEventsToFile(path string, events []Event) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	encoder := json.NewEncoder(file)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postEvents sends the events to the HTTP endpoint as {"batch": [...]}
+func postEvents(endpoint string, events []Event) error {
+	body, err := json.Marshal(map[string][]Event{"batch": events})
+	if err != nil {
+		return err
+	}
+	client, err := util.HTTPClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the telemetry endpoint %s answered %s", endpoint, resp.Status)
+	}
+	return nil
+}
+
+// lockDataDir takes the exclusive lock of the events and the queue of the data directory, held by an upload from the read
+// of the queue to the write of the queue and of the recorded events, so that the concurrent uploads of the background
+// "odo telemetry upload" processes neither upload the same queued events nor overwrite the events of each other.
+// It returns the function releasing the lock.
+func lockDataDir(dataDir string) (func(), error) {
+	if err := os.MkdirAll(dataDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dataDir, lockFileName)
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			file.Close()
+			return func() {
+				if err := os.Remove(path); err != nil {
+					klog.V(4).Infof("Unable to release the telemetry lock %s: %v", path, err)
+				}
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		// the lock of a process killed during its upload is never released
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			klog.V(4).Infof("Breaking the stale telemetry lock %s", path)
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("timed out waiting for the telemetry lock %s", path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// ReadRecordedEvents returns the events recorded in the data directory, the oldest first
+func ReadRecordedEvents(dataDir string) ([]RecordedEvent, error) {
+	var events []RecordedEvent
+	err := readJSONFile(filepath.Join(dataDir, eventsFileName), &events)
+	return events, err
+}
+
+// ReadQueuedEvents returns the events of the data directory waiting for a later upload, the oldest first
+func ReadQueuedEvents(dataDir string) ([]Event, error) {
+	var events []Event
+	err := readJSONFile(filepath.Join(dataDir, queueFileName), &events)
+	return events, err
+}
+
+// recordEvents adds the events to the recorded events of the data directory
+func recordEvents(dataDir string, events []RecordedEvent) error {
+	recorded, err := ReadRecordedEvents(dataDir)
+	if err != nil {
+		klog.V(4).Infof("Dropping the unreadable recorded telemetry data: %v", err)
+	}
+	recorded = append(recorded, events...)
+	if len(recorded) > maxRecordedEvents {
+		recorded = recorded[len(recorded)-maxRecordedEvents:]
+	}
+	return writeJSONFile(filepath.Join(dataDir, eventsFileName), recorded)
+}
+
+// writeQueue replaces the events of the data directory waiting for a later upload
+func writeQueue(dataDir string, events []Event) error {
+	if len(events) > maxQueuedEvents {
+		events = events[len(events)-maxQueuedEvents:]
+	}
+	if len(events) == 0 {
+		err := os.Remove(filepath.Join(dataDir, queueFileName))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return writeJSONFile(filepath.Join(dataDir, queueFileName), events)
+}
+
+func readJSONFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(json.Unmarshal(data, v), "unable to parse %s", path)
+}
+
+// writeJSONFile replaces the file atomically, the concurrent telemetry processes never read a partial file
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,286 @@
+package segment
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift/odo/pkg/preference"
+)
+
+func sinkPreference(sink string) *preference.PreferenceInfo {
+	trueValue := true
+	return &preference.PreferenceInfo{
+		Preference: preference.Preference{
+			OdoSettings: preference.OdoSettings{
+				ConsentTelemetry: &trueValue,
+				TelemetrySink:    &sink,
+			},
+		},
+	}
+}
+
+func uploadCommand(t *testing.T, c *Client, cmd string) {
+	if err := c.Upload(fakeTelemetryData(cmd, nil, context.Background())); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientUploadToNoneSink(t *testing.T) {
+	dataDir := t.TempDir()
+	c, err := newCustomClient(sinkPreference(preference.TelemetrySinkNone), createConfigDir(t), dataDir, "http://127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadCommand(t, c, "odo push")
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	recorded, err := ReadRecordedEvents(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) != 2 || recorded[0].Type != "identify" || recorded[1].Event.Event != "odo push" {
+		t.Fatalf("expected the identify and track events to be recorded, got %+v", recorded)
+	}
+	for _, event := range recorded {
+		if event.Status != EventRecorded || event.Sink != preference.TelemetrySinkNone {
+			t.Errorf("unexpected sink %q or status %q", event.Sink, event.Status)
+		}
+	}
+	if queued, _ := ReadQueuedEvents(dataDir); len(queued) != 0 {
+		t.Errorf("expected no queued event, got %d", len(queued))
+	}
+}
+
+func TestClientUploadToFileSink(t *testing.T) {
+	dataDir := t.TempDir()
+	path := filepath.Join(t.TempDir(), "events", "telemetry.jsonl")
+	c, err := newCustomClient(sinkPreference("file://"+path), createConfigDir(t), dataDir, "http://127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadCommand(t, c, "odo url create")
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 2 || events[1].Event != "odo url create" {
+		t.Fatalf("expected the events written as JSON lines, got %+v", events)
+	}
+}
+
+func TestConcurrentUploadsToFileSink(t *testing.T) {
+	const uploads = 8
+	dataDir := t.TempDir()
+	path := filepath.Join(t.TempDir(), "telemetry.jsonl")
+	queued := make([]Event, 10)
+	for i := range queued {
+		queued[i] = newTrackEvent("user", "odo push", nil)
+	}
+	if err := writeQueue(dataDir, queued); err != nil {
+		t.Fatal(err)
+	}
+
+	clients := make([]*Client, uploads)
+	for i := range clients {
+		c, err := newCustomClient(sinkPreference("file://"+path), createConfigDir(t), dataDir, "http://127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		uploadCommand(t, c, "odo watch")
+		clients[i] = c
+	}
+	var wg sync.WaitGroup
+	for _, c := range clients {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			if err := c.Close(); err != nil {
+				t.Error(err)
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := map[string]int{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		sent[event.MessageID]++
+	}
+	for _, event := range queued {
+		if sent[event.MessageID] != 1 {
+			t.Errorf("expected the queued event %s to be uploaded once, got %d", event.MessageID, sent[event.MessageID])
+		}
+	}
+	if len(sent) != len(queued)+2*uploads {
+		t.Errorf("expected %d uploaded events, got %d", len(queued)+2*uploads, len(sent))
+	}
+	if queued, _ := ReadQueuedEvents(dataDir); len(queued) != 0 {
+		t.Errorf("expected the queue to be emptied, got %d events", len(queued))
+	}
+	if recorded, _ := ReadRecordedEvents(dataDir); len(recorded) != 2*uploads {
+		t.Errorf("expected the events of every upload to be recorded, got %d", len(recorded))
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, lockFileName)); !os.IsNotExist(err) {
+		t.Errorf("expected the lock to be released")
+	}
+}
+
+func TestClientUploadToHTTPSinkWithQueue(t *testing.T) {
+	dataDir := t.TempDir()
+	available := false
+	var received []Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		var body struct {
+			Batch []Event `json:"batch"`
+		}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Error(err)
+		}
+		received = append(received, body.Batch...)
+	}))
+	defer server.Close()
+
+	// the endpoint is unavailable, the events are queued
+	c, err := newCustomClient(sinkPreference(server.URL), createConfigDir(t), dataDir, "http://127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadCommand(t, c, "odo push")
+	if err = c.Close(); err == nil {
+		t.Errorf("expected an error from the unavailable endpoint")
+	}
+	queued, err := ReadQueuedEvents(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 2 {
+		t.Fatalf("expected 2 queued events, got %d", len(queued))
+	}
+	recorded, _ := ReadRecordedEvents(dataDir)
+	if len(recorded) != 2 || recorded[1].Status != EventQueued {
+		t.Fatalf("expected the events to be recorded as queued, got %+v", recorded)
+	}
+
+	// the next upload retries the queued events
+	available = true
+	c, err = newCustomClient(sinkPreference(server.URL), createConfigDir(t), dataDir, "http://127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadCommand(t, c, "odo watch")
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 4 || received[1].Event != "odo push" || received[3].Event != "odo watch" {
+		t.Errorf("expected the queued events to be uploaded before the new ones, got %+v", received)
+	}
+	if queued, _ = ReadQueuedEvents(dataDir); len(queued) != 0 {
+		t.Errorf("expected the queue to be emptied, got %d events", len(queued))
+	}
+	recorded, _ = ReadRecordedEvents(dataDir)
+	if len(recorded) != 4 || recorded[3].Status != EventSent {
+		t.Errorf("expected the new events to be recorded as sent, got %+v", recorded)
+	}
+}
+
+func TestRecordEventsKeepsTheLatest(t *testing.T) {
+	dataDir := t.TempDir()
+	events := make([]RecordedEvent, maxRecordedEvents+10)
+	for i := range events {
+		events[i] = RecordedEvent{Event: newTrackEvent("user", "odo push", nil), Sink: preference.TelemetrySinkNone, Status: EventRecorded}
+	}
+	if err := recordEvents(dataDir, events); err != nil {
+		t.Fatal(err)
+	}
+	recorded, err := ReadRecordedEvents(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) != maxRecordedEvents || recorded[0].MessageID != events[10].MessageID {
+		t.Errorf("expected the %d latest events to be kept, got %d", maxRecordedEvents, len(recorded))
+	}
+}
+
+func TestLockDataDir(t *testing.T) {
+	dataDir := t.TempDir()
+	unlock, err := lockDataDir(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	released := make(chan struct{})
+	locked := make(chan struct{})
+	go func() {
+		unlock2, err := lockDataDir(dataDir)
+		if err != nil {
+			t.Error(err)
+			close(locked)
+			return
+		}
+		select {
+		case <-released:
+		default:
+			t.Error("expected the lock to be taken after its release")
+		}
+		unlock2()
+		close(locked)
+	}()
+	time.Sleep(5 * lockRetryInterval)
+	close(released)
+	unlock()
+	<-locked
+
+	// the lock left by a killed upload is broken
+	path := filepath.Join(dataDir, lockFileName)
+	if err = ioutil.WriteFile(path, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * staleLockAge)
+	if err = os.Chtimes(path, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = lockDataDir(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+}
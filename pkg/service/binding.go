@@ -0,0 +1,231 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	devfile "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/library/pkg/devfile/parser"
+	"github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	parsercommon "github.com/devfile/library/pkg/devfile/parser/data/v2/common"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	servicebinding "github.com/redhat-developer/service-binding-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog"
+
+	"github.com/openshift/odo/pkg/kclient"
+)
+
+const (
+	// defaultBindingRoot is the directory used by the Service Binding Operator to mount the binding files
+	// when neither SERVICE_BINDING_ROOT nor the mountPath of the ServiceBinding are set
+	defaultBindingRoot = "/bindings"
+
+	// maskedBindingValue replaces the values of the binding secret unless they are explicitly requested
+	maskedBindingValue = "********"
+)
+
+// InjectedBinding describes a single entry of a binding secret, as seen from the containers of the component
+type InjectedBinding struct {
+	Name      string `json:"name"`
+	Source    string `json:"source"`
+	AsFile    bool   `json:"asFile"`
+	MountPath string `json:"mountPath,omitempty"`
+	Value     string `json:"value"`
+}
+
+// BindingPreview describes the data injected into a component by a ServiceBinding
+type BindingPreview struct {
+	Name        string `json:"name"`
+	Service     string `json:"service"`
+	BindAsFiles bool   `json:"bindAsFiles"`
+	MountPath   string `json:"mountPath,omitempty"`
+	SecretName  string `json:"secretName,omitempty"`
+	// Resolved is false when the binding secret doesn't exist yet on the cluster
+	Resolved bool              `json:"resolved"`
+	Bindings []InjectedBinding `json:"bindings,omitempty"`
+}
+
+// ListDevfileServiceBindings returns the ServiceBindings defined in a Devfile
+func ListDevfileServiceBindings(devfileObj parser.DevfileObj) ([]servicebinding.ServiceBinding, error) {
+	if devfileObj.Data == nil {
+		return nil, nil
+	}
+	components, err := devfileObj.Data.GetComponents(common.DevfileOptions{
+		ComponentOptions: parsercommon.ComponentOptions{ComponentType: devfile.KubernetesComponentType},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var sbrs []servicebinding.ServiceBinding
+	for _, c := range components {
+		var u unstructured.Unstructured
+		err = yaml.Unmarshal([]byte(c.Kubernetes.Inlined), &u)
+		if err != nil {
+			return nil, err
+		}
+		if !isLinkResource(u.GetKind()) {
+			continue
+		}
+		var sbr servicebinding.ServiceBinding
+		js, err := u.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(js, &sbr)
+		if err != nil {
+			return nil, err
+		}
+		sbrs = append(sbrs, sbr)
+	}
+	return sbrs, nil
+}
+
+// NewBindingPreview returns the unresolved preview of the given ServiceBinding,
+// containing only the information that can be computed without accessing the cluster
+func NewBindingPreview(sbr servicebinding.ServiceBinding) BindingPreview {
+	preview := BindingPreview{
+		Name:        sbr.Name,
+		BindAsFiles: sbr.Spec.BindAsFiles,
+		MountPath:   bindingMountPath(sbr),
+	}
+	if len(sbr.Spec.Services) == 1 {
+		preview.Service = bindingServiceName(sbr.Spec.Services[0])
+	}
+	return preview
+}
+
+// NewSecretBindingPreview returns the preview of the given ServiceBinding resolved with the given secret,
+// for the links made without the Service Binding Operator. Values are masked unless showValues is true
+func NewSecretBindingPreview(sbr servicebinding.ServiceBinding, secret *corev1.Secret, showValues bool) BindingPreview {
+	preview := NewBindingPreview(sbr)
+	preview.resolve(secret, showValues)
+	return preview
+}
+
+// GetBindingPreview resolves the secret created by the Service Binding Operator for the given ServiceBinding
+// and returns the entries it injects into the component. Values are masked unless showValues is true.
+// The preview is returned unresolved if the ServiceBinding or its secret don't exist on the cluster yet
+func GetBindingPreview(client *kclient.Client, sbr servicebinding.ServiceBinding, showValues bool) (BindingPreview, error) {
+	preview := NewBindingPreview(sbr)
+	if client == nil {
+		return preview, nil
+	}
+
+	u, err := client.GetDynamicResource(kclient.ServiceBindingGroup, kclient.ServiceBindingVersion, kclient.ServiceBindingResource, sbr.Name)
+	if err != nil {
+		if kerrors.IsNotFound(errors.Cause(err)) {
+			klog.V(4).Infof("ServiceBinding %s not found on the cluster", sbr.Name)
+			return preview, nil
+		}
+		return preview, err
+	}
+
+	var clusterSbr servicebinding.ServiceBinding
+	js, err := u.MarshalJSON()
+	if err != nil {
+		return preview, err
+	}
+	err = json.Unmarshal(js, &clusterSbr)
+	if err != nil {
+		return preview, err
+	}
+	if clusterSbr.Status.Secret == "" {
+		return preview, nil
+	}
+
+	secret, err := client.GetSecret(clusterSbr.Status.Secret, client.Namespace)
+	if err != nil {
+		if kerrors.IsNotFound(errors.Cause(err)) {
+			klog.V(4).Infof("secret %s of ServiceBinding %s not found on the cluster", clusterSbr.Status.Secret, sbr.Name)
+			return preview, nil
+		}
+		return preview, err
+	}
+	preview.resolve(secret, showValues)
+	return preview, nil
+}
+
+// resolve fills the preview with the entries of the binding secret
+func (bp *BindingPreview) resolve(secret *corev1.Secret, showValues bool) {
+	bp.SecretName = secret.Name
+	bp.Resolved = true
+	bp.Bindings = nil
+
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		binding := InjectedBinding{
+			Name:   key,
+			Source: "Secret/" + secret.Name,
+			AsFile: bp.BindAsFiles,
+			Value:  maskedBindingValue,
+		}
+		if bp.BindAsFiles {
+			binding.MountPath = path.Join(bp.MountPath, key)
+		}
+		if showValues {
+			binding.Value = string(secret.Data[key])
+		}
+		bp.Bindings = append(bp.Bindings, binding)
+	}
+}
+
+// FormatBindingPreviews returns a human readable description of the given binding previews
+func FormatBindingPreviews(previews []BindingPreview) string {
+	var output string
+	for _, preview := range previews {
+		if preview.BindAsFiles {
+			output += fmt.Sprintf(" · %s (as files in %s)\n", preview.Service, preview.MountPath)
+		} else {
+			output += fmt.Sprintf(" · %s (as environment variables)\n", preview.Service)
+		}
+
+		if !preview.Resolved {
+			output += "    binding data not available yet, it will be resolved by the Service Binding Operator after `odo push`\n"
+			continue
+		}
+		if len(preview.Bindings) == 0 {
+			output += fmt.Sprintf("    no data found in Secret/%s\n", preview.SecretName)
+			continue
+		}
+		for _, binding := range preview.Bindings {
+			if binding.AsFile {
+				output += fmt.Sprintf("    · %s=%s (file %s, from %s)\n", binding.Name, binding.Value, binding.MountPath, binding.Source)
+			} else {
+				output += fmt.Sprintf("    · %s=%s (from %s)\n", binding.Name, binding.Value, binding.Source)
+			}
+		}
+	}
+	return strings.TrimSuffix(output, "\n")
+}
+
+// bindingMountPath returns the directory in which the Service Binding Operator mounts the binding files,
+// or an empty string if the bindings are injected as environment variables
+func bindingMountPath(sbr servicebinding.ServiceBinding) string {
+	if !sbr.Spec.BindAsFiles {
+		return ""
+	}
+	if sbr.Spec.MountPath != "" {
+		return sbr.Spec.MountPath
+	}
+	return path.Join(defaultBindingRoot, sbr.Name)
+}
+
+// bindingServiceName returns the name of the bound service, as displayed by odo
+func bindingServiceName(service servicebinding.Service) string {
+	if service.Kind == "Service" {
+		return service.Name
+	}
+	return service.Kind + "/" + service.Name
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/odo/pkg/kclient"
+	servicebinding "github.com/redhat-developer/service-binding-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+func TestBindingPreviewResolve(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodejs-etcdcluster-example-secret"},
+		Data: map[string][]byte{
+			"password": []byte("secret"),
+			"host":     []byte("example.svc"),
+		},
+	}
+	service := servicebinding.Service{
+		NamespacedRef: servicebinding.NamespacedRef{
+			Ref: servicebinding.Ref{Kind: "EtcdCluster", Name: "example"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		sbr        servicebinding.ServiceBinding
+		showValues bool
+		want       BindingPreview
+	}{
+		{
+			name: "Case 1: bindings injected as environment variables are masked",
+			sbr: servicebinding.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "nodejs-etcdcluster-example"},
+				Spec:       servicebinding.ServiceBindingSpec{Services: []servicebinding.Service{service}},
+			},
+			want: BindingPreview{
+				Name:       "nodejs-etcdcluster-example",
+				Service:    "EtcdCluster/example",
+				SecretName: "nodejs-etcdcluster-example-secret",
+				Resolved:   true,
+				Bindings: []InjectedBinding{
+					{Name: "host", Source: "Secret/nodejs-etcdcluster-example-secret", Value: maskedBindingValue},
+					{Name: "password", Source: "Secret/nodejs-etcdcluster-example-secret", Value: maskedBindingValue},
+				},
+			},
+		},
+		{
+			name: "Case 2: bindings injected as files under the default binding root",
+			sbr: servicebinding.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+				Spec:       servicebinding.ServiceBindingSpec{Services: []servicebinding.Service{service}, BindAsFiles: true},
+			},
+			showValues: true,
+			want: BindingPreview{
+				Name:        "etcd",
+				Service:     "EtcdCluster/example",
+				BindAsFiles: true,
+				MountPath:   "/bindings/etcd",
+				SecretName:  "nodejs-etcdcluster-example-secret",
+				Resolved:    true,
+				Bindings: []InjectedBinding{
+					{Name: "host", Source: "Secret/nodejs-etcdcluster-example-secret", AsFile: true, MountPath: "/bindings/etcd/host", Value: "example.svc"},
+					{Name: "password", Source: "Secret/nodejs-etcdcluster-example-secret", AsFile: true, MountPath: "/bindings/etcd/password", Value: "secret"},
+				},
+			},
+		},
+		{
+			name: "Case 3: bindings injected as files under a custom mount path",
+			sbr: servicebinding.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+				Spec: servicebinding.ServiceBindingSpec{
+					Services:    []servicebinding.Service{{NamespacedRef: servicebinding.NamespacedRef{Ref: servicebinding.Ref{Kind: "Service", Name: "backend"}}}},
+					BindAsFiles: true,
+					MountPath:   "/etc/backend",
+				},
+			},
+			want: BindingPreview{
+				Name:        "etcd",
+				Service:     "backend",
+				BindAsFiles: true,
+				MountPath:   "/etc/backend",
+				SecretName:  "nodejs-etcdcluster-example-secret",
+				Resolved:    true,
+				Bindings: []InjectedBinding{
+					{Name: "host", Source: "Secret/nodejs-etcdcluster-example-secret", AsFile: true, MountPath: "/etc/backend/host", Value: maskedBindingValue},
+					{Name: "password", Source: "Secret/nodejs-etcdcluster-example-secret", AsFile: true, MountPath: "/etc/backend/password", Value: maskedBindingValue},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewBindingPreview(tt.sbr)
+			got.resolve(secret, tt.showValues)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeServiceBindings is a dynamic client returning the given ServiceBindings, only Get is implemented
+type fakeServiceBindings struct {
+	dynamic.Interface
+	dynamic.NamespaceableResourceInterface
+	bindings map[string]servicebinding.ServiceBinding
+}
+
+func (f fakeServiceBindings) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return f
+}
+
+func (f fakeServiceBindings) Namespace(namespace string) dynamic.ResourceInterface {
+	return f
+}
+
+func (f fakeServiceBindings) Get(ctx context.Context, name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	sbr, ok := f.bindings[name]
+	if !ok {
+		return nil, kerrors.NewNotFound(schema.GroupResource{Group: kclient.ServiceBindingGroup, Resource: kclient.ServiceBindingResource}, name)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&sbr)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+func TestGetBindingPreview(t *testing.T) {
+	client, fakeClientSet := kclient.FakeNew()
+	client.Namespace = "project"
+	client.DynamicClient = fakeServiceBindings{bindings: map[string]servicebinding.ServiceBinding{
+		"nodejs-etcd": {
+			ObjectMeta: metav1.ObjectMeta{Name: "nodejs-etcd"},
+			Status:     servicebinding.ServiceBindingStatus{Secret: "nodejs-etcd-secret"},
+		},
+		"nodejs-pending": {
+			ObjectMeta: metav1.ObjectMeta{Name: "nodejs-pending"},
+		},
+		"nodejs-deleted-secret": {
+			ObjectMeta: metav1.ObjectMeta{Name: "nodejs-deleted-secret"},
+			Status:     servicebinding.ServiceBindingStatus{Secret: "deleted-secret"},
+		},
+	}}
+	_, err := fakeClientSet.Kubernetes.CoreV1().Secrets("project").Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodejs-etcd-secret", Namespace: "project"},
+		Data:       map[string][]byte{"password": []byte("100%secret")},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := servicebinding.Service{
+		NamespacedRef: servicebinding.NamespacedRef{
+			Ref: servicebinding.Ref{Kind: "EtcdCluster", Name: "example"},
+		},
+	}
+	unresolved := func(name string) BindingPreview {
+		return BindingPreview{Name: name, Service: "EtcdCluster/example"}
+	}
+
+	tests := []struct {
+		name       string
+		sbrName    string
+		showValues bool
+		want       BindingPreview
+	}{
+		{
+			name:       "Case 1: the binding secret is resolved",
+			sbrName:    "nodejs-etcd",
+			showValues: true,
+			want: BindingPreview{
+				Name:       "nodejs-etcd",
+				Service:    "EtcdCluster/example",
+				SecretName: "nodejs-etcd-secret",
+				Resolved:   true,
+				Bindings: []InjectedBinding{
+					{Name: "password", Source: "Secret/nodejs-etcd-secret", Value: "100%secret"},
+				},
+			},
+		},
+		{
+			name:    "Case 2: the ServiceBinding doesn't exist on the cluster yet",
+			sbrName: "nodejs-new",
+			want:    unresolved("nodejs-new"),
+		},
+		{
+			name:    "Case 3: the binding secret isn't created yet by the Service Binding Operator",
+			sbrName: "nodejs-pending",
+			want:    unresolved("nodejs-pending"),
+		},
+		{
+			name:    "Case 4: the binding secret doesn't exist on the cluster",
+			sbrName: "nodejs-deleted-secret",
+			want:    unresolved("nodejs-deleted-secret"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sbr := servicebinding.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.sbrName},
+				Spec:       servicebinding.ServiceBindingSpec{Services: []servicebinding.Service{service}},
+			}
+			got, err := GetBindingPreview(client, sbr, tt.showValues)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -709,44 +709,17 @@ func IsDefined(name string, devfileObj parser.DevfileObj) (bool, error) {
 
 // ListDevfileLinks returns the names of the links defined in a Devfile
 func ListDevfileLinks(devfileObj parser.DevfileObj) ([]string, error) {
-	if devfileObj.Data == nil {
-		return nil, nil
-	}
-	components, err := devfileObj.Data.GetComponents(common.DevfileOptions{
-		ComponentOptions: parsercommon.ComponentOptions{ComponentType: devfile.KubernetesComponentType},
-	})
+	sbrs, err := ListDevfileServiceBindings(devfileObj)
 	if err != nil {
 		return nil, err
 	}
 	var services []string
-	for _, c := range components {
-		var u unstructured.Unstructured
-		err = yaml.Unmarshal([]byte(c.Kubernetes.Inlined), &u)
-		if err != nil {
-			return nil, err
-		}
-		if !isLinkResource(u.GetKind()) {
-			continue
-		}
-		var sbr servicebinding.ServiceBinding
-		js, err := u.MarshalJSON()
-		if err != nil {
-			return nil, err
-		}
-		err = json.Unmarshal(js, &sbr)
-		if err != nil {
-			return nil, err
-		}
+	for _, sbr := range sbrs {
 		sbrServices := sbr.Spec.Services
 		if len(sbrServices) != 1 {
 			return nil, errors.New("ServiceBinding should have only one service")
 		}
-		service := sbrServices[0]
-		if service.Kind == "Service" {
-			services = append(services, service.Name)
-		} else {
-			services = append(services, service.Kind+"/"+service.Name)
-		}
+		services = append(services, bindingServiceName(sbrServices[0]))
 	}
 	return services, nil
 }
@@ -0,0 +1,295 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/openshift/odo/pkg/devfile/adapters/common"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+const (
+	// ManifestFile is the name of the entry of a backup archive holding its manifest
+	ManifestFile = "manifest.json"
+	// ManifestKind is the kind of the manifest of a backup archive
+	ManifestKind = "StorageBackup"
+	// dataDir is the directory of a backup archive holding the content of the storage
+	dataDir = "data/"
+
+	apiVersion = "odo.dev/v1alpha1"
+)
+
+// Executor executes commands in the containers of a component
+type Executor interface {
+	ExecCMDInContainer(info common.ComponentInfo, cmd []string, stdout io.Writer, stderr io.Writer, stdin io.Reader, tty bool) error
+}
+
+// Manifest describes the content of a backup archive, its name is the name of the storage
+type Manifest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ManifestSpec `json:"spec"`
+}
+
+// ManifestSpec holds the origin of a backup archive, and a summary of its content
+type ManifestSpec struct {
+	// Component is the name of the component the storage was backed up from
+	Component string `json:"component"`
+	// Application is the name of the application of the component
+	Application string `json:"application,omitempty"`
+	// Path is the path the storage was mounted on
+	Path string `json:"path"`
+	// Size is the size of the storage
+	Size string `json:"size,omitempty"`
+	// Files is the number of regular files in the archive
+	Files int `json:"files"`
+	// Bytes is the total size of the regular files in the archive
+	Bytes int64 `json:"bytes"`
+	// SHA256 is the digest of the names and contents of the entries of the archive
+	SHA256 string `json:"sha256"`
+}
+
+// NewManifest returns the manifest of a backup of the given storage
+func NewManifest(storageName, componentName, applicationName, size string) Manifest {
+	return Manifest{
+		TypeMeta:   metav1.TypeMeta{Kind: ManifestKind, APIVersion: apiVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: storageName},
+		Spec: ManifestSpec{
+			Component:   componentName,
+			Application: applicationName,
+			Size:        size,
+		},
+	}
+}
+
+// Backup streams the content of the mounted storage out of its container as a tar,
+// and writes it to w as a gzip compressed archive followed by the given manifest completed with a summary of the content
+func Backup(executor Executor, mount common.StorageMount, manifest Manifest, w io.Writer) (Manifest, error) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	var stderr bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		cmd := []string{"tar", "cf", "-", "-C", mount.Path, "."}
+		klog.V(4).Infof("Executing %v in container %s", cmd, mount.ContainerName)
+		err := executor.ExecCMDInContainer(mount.ComponentInfo, cmd, pw, &stderr, nil, false)
+		if err == nil && stderr.Len() > 0 {
+			klog.V(4).Infof("tar reported: %s", stderr.String())
+		}
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	digest := sha256.New()
+
+	tarReader := tar.NewReader(pr)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// wait for the command to end before reading its error output
+			_ = pr.CloseWithError(err)
+			<-done
+			return manifest, errors.Wrapf(withOutput(err, stderr), "unable to read the content of storage %s", manifest.Name)
+		}
+
+		name := path.Clean(header.Name)
+		if name == "." {
+			continue
+		}
+		header.Name = dataDir + name
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = dataDir + path.Clean(header.Linkname)
+		}
+
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return manifest, errors.Wrapf(err, "unable to write %s to the archive", name)
+		}
+		err = copyEntry(digest, tarWriter, tarReader, header.Name)
+		if err != nil {
+			return manifest, errors.Wrapf(err, "unable to write %s to the archive", name)
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			manifest.Spec.Files++
+			manifest.Spec.Bytes += header.Size
+		}
+	}
+
+	// tar can fail after writing the end of the archive, e.g. when it can't read some files:
+	// wait for the command to end so that an incomplete backup is not reported as a success
+	_, _ = io.Copy(ioutil.Discard, pr)
+	if err := <-done; err != nil {
+		return manifest, errors.Wrapf(withOutput(err, stderr), "unable to read the content of storage %s", manifest.Name)
+	}
+
+	manifest.Spec.Path = mount.Path
+	manifest.Spec.SHA256 = hex.EncodeToString(digest.Sum(nil))
+	manifest.CreationTimestamp = metav1.Now()
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, errors.Wrap(err, "unable to marshal the manifest")
+	}
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name:     ManifestFile,
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return manifest, errors.Wrap(err, "unable to write the manifest to the archive")
+	}
+	if _, err = tarWriter.Write(data); err != nil {
+		return manifest, errors.Wrap(err, "unable to write the manifest to the archive")
+	}
+
+	if err = tarWriter.Close(); err != nil {
+		return manifest, errors.Wrap(err, "unable to write the archive")
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return manifest, errors.Wrap(err, "unable to write the archive")
+	}
+	return manifest, nil
+}
+
+// ReadManifest reads the manifest of the given backup archive
+// and verifies the content of the archive matches the digest of the manifest
+func ReadManifest(r io.Reader) (Manifest, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return Manifest{}, errors.Wrap(err, "unable to read the archive")
+	}
+	defer gzipReader.Close()
+
+	var manifest *Manifest
+	digest := sha256.New()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Manifest{}, errors.Wrap(err, "unable to read the archive")
+		}
+
+		switch {
+		case header.Name == ManifestFile:
+			manifest = &Manifest{}
+			if err = json.NewDecoder(tarReader).Decode(manifest); err != nil {
+				return Manifest{}, errors.Wrap(err, "unable to read the manifest of the archive")
+			}
+		case strings.HasPrefix(header.Name, dataDir):
+			if err = copyEntry(digest, ioutil.Discard, tarReader, header.Name); err != nil {
+				return Manifest{}, errors.Wrapf(err, "unable to read %s from the archive", header.Name)
+			}
+		default:
+			klog.V(4).Infof("Ignoring unknown entry %s of the archive", header.Name)
+		}
+	}
+
+	if manifest == nil {
+		return Manifest{}, fmt.Errorf("the archive has no %s, it is not a storage backup", ManifestFile)
+	}
+	if manifest.Kind != ManifestKind {
+		return Manifest{}, fmt.Errorf("the manifest of the archive is of kind %q, expected %q", manifest.Kind, ManifestKind)
+	}
+	if sum := hex.EncodeToString(digest.Sum(nil)); sum != manifest.Spec.SHA256 {
+		return Manifest{}, fmt.Errorf("the archive is corrupted, the digest of its content is %s but the manifest expects %s", sum, manifest.Spec.SHA256)
+	}
+	return *manifest, nil
+}
+
+// Restore extracts the content of the given backup archive into the mounted storage
+// the content of the archive should be verified with ReadManifest first
+func Restore(executor Executor, mount common.StorageMount, r io.Reader) error {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		_ = pw.CloseWithError(extractData(r, pw))
+	}()
+
+	var stdout, stderr bytes.Buffer
+	cmd := []string{"tar", "xf", "-", "-C", mount.Path}
+	klog.V(4).Infof("Executing %v in container %s", cmd, mount.ContainerName)
+	err := executor.ExecCMDInContainer(mount.ComponentInfo, cmd, &stdout, &stderr, pr, false)
+	if err != nil {
+		return errors.Wrapf(withOutput(err, stderr), "unable to extract the archive in %s", mount.Path)
+	}
+	return nil
+}
+
+// extractData writes the content of the storage held by the given backup archive to w as a tar
+func extractData(r io.Reader, w io.Writer) error {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "unable to read the archive")
+	}
+	defer gzipReader.Close()
+
+	tarWriter := tar.NewWriter(w)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "unable to read the archive")
+		}
+		if !strings.HasPrefix(header.Name, dataDir) {
+			continue
+		}
+
+		header.Name = strings.TrimPrefix(header.Name, dataDir)
+		if header.Typeflag == tar.TypeLink {
+			header.Linkname = strings.TrimPrefix(header.Linkname, dataDir)
+		}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err = io.Copy(tarWriter, tarReader); err != nil {
+			return err
+		}
+	}
+	return tarWriter.Close()
+}
+
+// copyEntry copies the content of an entry of an archive, adding its name and content to the given digest
+func copyEntry(digest hash.Hash, w io.Writer, r io.Reader, name string) error {
+	_, _ = digest.Write([]byte(name))
+	_, _ = digest.Write([]byte{0})
+	_, err := io.Copy(io.MultiWriter(w, digest), r)
+	return err
+}
+
+// withOutput appends the error output of a command to the error it returned
+func withOutput(err error, stderr bytes.Buffer) error {
+	if output := strings.TrimSpace(stderr.String()); output != "" {
+		return fmt.Errorf("%v: %s", err, output)
+	}
+	return err
+}
@@ -0,0 +1,221 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/openshift/odo/pkg/devfile/adapters/common"
+)
+
+// fakeExecutor emulates tar in a container whose storage holds the given files,
+// tar writes the archive of the files before failing with err when it is set
+type fakeExecutor struct {
+	files    map[string]string
+	restored map[string]string
+	err      error
+}
+
+func (f *fakeExecutor) ExecCMDInContainer(info common.ComponentInfo, cmd []string, stdout io.Writer, stderr io.Writer, stdin io.Reader, tty bool) error {
+	if f.err != nil {
+		if err := writeTar(stdout, f.files, "./"); err != nil {
+			return err
+		}
+		_, _ = stderr.Write([]byte("tar: permission denied"))
+		return f.err
+	}
+	switch cmd[1] {
+	case "cf":
+		return writeTar(stdout, f.files, "./")
+	case "xf":
+		f.restored = make(map[string]string)
+		tarReader := tar.NewReader(stdin)
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			content, err := ioutil.ReadAll(tarReader)
+			if err != nil {
+				return err
+			}
+			f.restored[header.Name] = string(content)
+		}
+	}
+	return errors.New("unexpected command")
+}
+
+// writeTar writes the given files to w as a tar, prefixing their names with the given prefix
+func writeTar(w io.Writer, files map[string]string, prefix string) error {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tarWriter := tar.NewWriter(w)
+	if prefix == "./" {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: "./", Typeflag: tar.TypeDir, Mode: 0755}); err != nil {
+			return err
+		}
+	}
+	for _, name := range names {
+		err := tarWriter.WriteHeader(&tar.Header{Name: prefix + name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(files[name]))})
+		if err != nil {
+			return err
+		}
+		if _, err = tarWriter.Write([]byte(files[name])); err != nil {
+			return err
+		}
+	}
+	return tarWriter.Close()
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	files := map[string]string{
+		"db/data.sql":    "CREATE TABLE example;",
+		"db/schema.json": "{}",
+		"README":         "backup me",
+	}
+	mount := common.StorageMount{
+		ComponentInfo: common.ComponentInfo{PodName: "nodejs-pod", ContainerName: "runtime"},
+		Path:          "/data",
+	}
+
+	executor := &fakeExecutor{files: files}
+	var archive bytes.Buffer
+	manifest, err := Backup(executor, mount, NewManifest("db", "nodejs", "app", "1Gi"), &archive)
+	if err != nil {
+		t.Fatalf("Backup() unexpected error: %v", err)
+	}
+	if manifest.Spec.Files != 3 || manifest.Spec.Bytes != 32 || manifest.Spec.Path != "/data" || manifest.Spec.SHA256 == "" {
+		t.Errorf("Backup() unexpected manifest: %+v", manifest.Spec)
+	}
+
+	readManifest, err := ReadManifest(bytes.NewReader(archive.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadManifest() unexpected error: %v", err)
+	}
+	if readManifest.Name != "db" || readManifest.Spec.SHA256 != manifest.Spec.SHA256 || readManifest.Spec.Component != "nodejs" {
+		t.Errorf("ReadManifest() = %+v, want %+v", readManifest, manifest)
+	}
+
+	err = Restore(executor, mount, bytes.NewReader(archive.Bytes()))
+	if err != nil {
+		t.Fatalf("Restore() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(executor.restored, files) {
+		t.Errorf("Restore() restored %v, want %v", executor.restored, files)
+	}
+}
+
+func TestBackupExecError(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name: "Case 1: tar fails without reading any file",
+		},
+		{
+			name:  "Case 2: tar fails after writing the archive of the files it could read",
+			files: map[string]string{"README": "backup me"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &fakeExecutor{files: tt.files, err: errors.New("command terminated with exit code 2")}
+			_, err := Backup(executor, common.StorageMount{Path: "/data"}, NewManifest("db", "nodejs", "app", "1Gi"), ioutil.Discard)
+			if err == nil {
+				t.Fatalf("Backup() expected an error")
+			}
+			if want := "unable to read the content of storage db: command terminated with exit code 2: tar: permission denied"; err.Error() != want {
+				t.Errorf("Backup() error = %q, want %q", err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	files := map[string]string{
+		"data.sql": "CREATE TABLE example;",
+	}
+	var validArchive bytes.Buffer
+	manifest, err := Backup(&fakeExecutor{files: files}, common.StorageMount{Path: "/data"}, NewManifest("db", "nodejs", "app", "1Gi"), &validArchive)
+	if err != nil {
+		t.Fatalf("Backup() unexpected error: %v", err)
+	}
+
+	// createArchive creates a backup archive holding the given files and manifest
+	createArchive := func(files map[string]string, manifest interface{}) []byte {
+		var buf bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buf)
+		entries := make(map[string]string)
+		for name, content := range files {
+			entries[dataDir+name] = content
+		}
+		if manifest != nil {
+			data, err := json.Marshal(manifest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			entries[ManifestFile] = string(data)
+		}
+		if err := writeTar(gzipWriter, entries, ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := gzipWriter.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name    string
+		archive []byte
+		wantErr bool
+	}{
+		{
+			name:    "case 1: valid archive",
+			archive: validArchive.Bytes(),
+		},
+		{
+			name:    "case 2: archive with a modified file",
+			archive: createArchive(map[string]string{"data.sql": "DROP TABLE example;"}, manifest),
+			wantErr: true,
+		},
+		{
+			name:    "case 3: archive without manifest",
+			archive: createArchive(files, nil),
+			wantErr: true,
+		},
+		{
+			name:    "case 4: archive with a manifest of another kind",
+			archive: createArchive(files, map[string]string{"kind": "Component"}),
+			wantErr: true,
+		},
+		{
+			name:    "case 5: not an archive",
+			archive: []byte("example"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadManifest(bytes.NewReader(tt.archive))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
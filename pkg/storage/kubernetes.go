@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/devfile/library/pkg/devfile/generator"
+	"github.com/openshift/odo/pkg/envinfo"
 	"github.com/openshift/odo/pkg/kclient"
+	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/occlient"
 	storagelabels "github.com/openshift/odo/pkg/storage/labels"
 	"github.com/pkg/errors"
@@ -16,6 +19,9 @@ import (
 	"k8s.io/klog"
 )
 
+// pvcResizeTimeout is the time to wait for the volume of an expanded PVC to be resized
+const pvcResizeTimeout = 30 * time.Second
+
 // kubernetesClient contains information required for devfile based Storage operations
 type kubernetesClient struct {
 	generic
@@ -52,6 +58,13 @@ func (k kubernetesClient) Create(storage Storage) error {
 		Quantity:   quantity,
 	}
 	pvc := generator.GetPVC(pvcParams)
+	if storage.Spec.StorageClass != "" {
+		storageClass := storage.Spec.StorageClass
+		pvc.Spec.StorageClassName = &storageClass
+	}
+	if storage.Spec.AccessMode != "" {
+		pvc.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.PersistentVolumeAccessMode(storage.Spec.AccessMode)}
+	}
 
 	// Create PVC
 	klog.V(2).Infof("Creating a PVC with name %v and labels %v", pvcName, labels)
@@ -62,6 +75,64 @@ func (k kubernetesClient) Create(storage Storage) error {
 	return nil
 }
 
+// Expand sets the size of the pvc belonging to the given Storage to the size of the Storage
+// it returns true if the pod of the component must be restarted for the file system of the volume to be resized
+func (k kubernetesClient) Expand(storage Storage) (bool, error) {
+	pvcName, err := getPVCNameFromStorageName(&k.client, storage.Name)
+	if err != nil {
+		return false, err
+	}
+
+	quantity, err := resource.ParseQuantity(storage.Spec.Size)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to parse size: %v", storage.Spec.Size)
+	}
+
+	pvc, err := k.client.GetKubeClient().GetPVCFromName(pvcName)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to get PVC %v", pvcName)
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return false, ExpansionNotAllowedError{Storage: storage.Name}
+	}
+
+	storageClass, err := k.client.GetKubeClient().GetStorageClass(*pvc.Spec.StorageClassName)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to get the storage class of storage %s", storage.Name)
+	}
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		return false, ExpansionNotAllowedError{Storage: storage.Name, StorageClass: storageClass.Name}
+	}
+
+	klog.V(2).Infof("Expanding PVC %v to %v", pvcName, storage.Spec.Size)
+	pvc, err = k.client.GetKubeClient().ExpandPVC(pvcName, quantity)
+	if err != nil {
+		return false, err
+	}
+	if kclient.IsPVCFileSystemResizePending(pvc) {
+		return true, nil
+	}
+
+	restartRequired, err := k.client.GetKubeClient().WaitForPVCResize(pvcName, quantity, pvcResizeTimeout)
+	if err != nil {
+		log.Warningf("The volume of storage %s is still being resized: %v", storage.Name, err)
+		return false, nil
+	}
+	return restartRequired, nil
+}
+
+// isSameStorage returns true if the given local Storage matches the given cluster Storage
+// the storage class and the access mode of the cluster Storage are the defaults when not set locally
+func isSameStorage(local, cluster Storage) bool {
+	if local.Spec.StorageClass == "" {
+		local.Spec.StorageClass = cluster.Spec.StorageClass
+	}
+	if local.Spec.AccessMode == "" && cluster.Spec.AccessMode == string(corev1.ReadWriteOnce) {
+		local.Spec.AccessMode = cluster.Spec.AccessMode
+	}
+	return reflect.DeepEqual(local, cluster)
+}
+
 // Delete deletes the pvc belonging to the given Storage
 func (k kubernetesClient) Delete(name string) error {
 	pvcName, err := getPVCNameFromStorageName(&k.client, name)
@@ -141,7 +212,14 @@ func (k kubernetesClient) ListFromCluster() (StorageList, error) {
 
 				found = true
 				size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
-				storage = append(storage, GetMachineFormatWithContainer(pvc.Labels[storagelabels.DevfileStorageLabel], size.String(), volumeMount.Spec.Path, volumeMount.Spec.ContainerName))
+				clusterStorage := GetMachineFormatWithContainer(pvc.Labels[storagelabels.DevfileStorageLabel], size.String(), volumeMount.Spec.Path, volumeMount.Spec.ContainerName)
+				if pvc.Spec.StorageClassName != nil {
+					clusterStorage.Spec.StorageClass = *pvc.Spec.StorageClassName
+				}
+				if len(pvc.Spec.AccessModes) > 0 {
+					clusterStorage.Spec.AccessMode = string(pvc.Spec.AccessModes[0])
+				}
+				storage = append(storage, clusterStorage)
 			}
 		}
 		if !found {
@@ -149,6 +227,12 @@ func (k kubernetesClient) ListFromCluster() (StorageList, error) {
 		}
 	}
 
+	sourceStorage, err := k.listSourceStorageFromCluster(volumeMounts, validVolumeMounts)
+	if err != nil {
+		return StorageList{}, err
+	}
+	storage = append(storage, sourceStorage...)
+
 	// to track volumes created by Service Binding Operator
 	for _, volume := range pod.Spec.Volumes {
 		if volume.Secret != nil {
@@ -165,6 +249,48 @@ func (k kubernetesClient) ListFromCluster() (StorageList, error) {
 	return StorageList{Items: storage}, nil
 }
 
+// listSourceStorageFromCluster lists the Storage mounted from the ConfigMaps and Secrets created from local files
+// the volume mounts used by these Storage are added to validVolumeMounts
+func (k kubernetesClient) listSourceStorageFromCluster(volumeMounts []Storage, validVolumeMounts map[string]bool) ([]Storage, error) {
+	selector := fmt.Sprintf("%v=%s,%s", "component", k.localConfig.GetName(), storagelabels.DevfileStorageLabel)
+
+	configMaps, err := k.client.GetKubeClient().ListConfigMaps(selector)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := k.client.GetKubeClient().ListSecrets(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceKinds := make(map[string]string)
+	var sources []metav1.ObjectMeta
+	for _, configMap := range configMaps {
+		sourceKinds[configMap.Name] = envinfo.SourceKindConfigMap
+		sources = append(sources, configMap.ObjectMeta)
+	}
+	for _, secret := range secrets {
+		sourceKinds[secret.Name] = envinfo.SourceKindSecret
+		sources = append(sources, secret.ObjectMeta)
+	}
+
+	var storage []Storage
+	for _, source := range sources {
+		for _, volumeMount := range volumeMounts {
+			if volumeMount.Name != source.Name+"-vol" {
+				continue
+			}
+			validVolumeMounts[volumeMount.Name] = true
+
+			clusterStorage := GetMachineFormatWithContainer(source.Labels[storagelabels.DevfileStorageLabel], "", volumeMount.Spec.Path, volumeMount.Spec.ContainerName)
+			clusterStorage.Spec.Source = source.Annotations[storagelabels.SourceAnnotation]
+			clusterStorage.Spec.SourceKind = sourceKinds[source.Name]
+			storage = append(storage, clusterStorage)
+		}
+	}
+	return storage, nil
+}
+
 // List lists pvc based Storage and local Storage with respective states
 func (k kubernetesClient) List() (StorageList, error) {
 	localConfigStorage, err := k.localConfig.ListStorage()
@@ -187,7 +313,7 @@ func (k kubernetesClient) List() (StorageList, error) {
 	for _, localStore := range localStorage.Items {
 		found := false
 		for _, clusterStore := range clusterStorage.Items {
-			if reflect.DeepEqual(localStore, clusterStore) {
+			if isSameStorage(localStore, clusterStore) {
 				found = true
 			}
 		}
@@ -203,7 +329,7 @@ func (k kubernetesClient) List() (StorageList, error) {
 	for _, clusterStore := range clusterStorage.Items {
 		found := false
 		for _, localStore := range localStorage.Items {
-			if reflect.DeepEqual(localStore, clusterStore) {
+			if isSameStorage(localStore, clusterStore) {
 				found = true
 			}
 		}
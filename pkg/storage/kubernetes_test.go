@@ -12,6 +12,7 @@ import (
 	storageLabels "github.com/openshift/odo/pkg/storage/labels"
 	"github.com/openshift/odo/pkg/testingutil"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -690,6 +691,23 @@ func Test_kubernetesClient_Create(t *testing.T) {
 				storage: GetMachineFormatWithContainer("odo-projects-vol", "5Gi", "/data", "runtime"),
 			},
 		},
+		{
+			name: "case 4: valid storage with a storage class and an access mode",
+			fields: fields{
+				generic: generic{
+					appName:       "app",
+					componentName: "nodejs",
+				},
+			},
+			args: args{
+				storage: func() Storage {
+					storage := GetMachineFormatWithContainer("storage-0", "5Gi", "/data", "runtime")
+					storage.Spec.StorageClass = "fast"
+					storage.Spec.AccessMode = "ReadWriteMany"
+					return storage
+				}(),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -740,6 +758,21 @@ func Test_kubernetesClient_Create(t *testing.T) {
 				t.Errorf("size of PVC is not matching to expected size, expected: %v, got %v", quantity, createdPVC.Spec.Resources.Requests["storage"])
 			}
 
+			wantStorageClass := ""
+			if createdPVC.Spec.StorageClassName != nil {
+				wantStorageClass = *createdPVC.Spec.StorageClassName
+			}
+			if wantStorageClass != tt.args.storage.Spec.StorageClass {
+				t.Errorf("storage class of PVC is not matching to expected storage class, expected: %v, got %v", tt.args.storage.Spec.StorageClass, wantStorageClass)
+			}
+			wantAccessMode := tt.args.storage.Spec.AccessMode
+			if wantAccessMode == "" {
+				wantAccessMode = string(corev1.ReadWriteOnce)
+			}
+			if len(createdPVC.Spec.AccessModes) != 1 || string(createdPVC.Spec.AccessModes[0]) != wantAccessMode {
+				t.Errorf("access modes of PVC are not matching to expected access mode, expected: %v, got %v", wantAccessMode, createdPVC.Spec.AccessModes)
+			}
+
 			wantedPVCName, err := generatePVCName(tt.args.storage.Name, tt.fields.generic.componentName, tt.fields.generic.appName)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
@@ -751,6 +784,117 @@ func Test_kubernetesClient_Create(t *testing.T) {
 	}
 }
 
+func Test_kubernetesClient_Expand(t *testing.T) {
+	pvcName := "pvc-0"
+	allowExpansion := true
+	denyExpansion := false
+
+	tests := []struct {
+		name                string
+		storageClass        string
+		allowExpansion      *bool
+		resizePending       bool
+		wantRestartRequired bool
+		wantErr             bool
+	}{
+		{
+			name:                "case 1: file system resized when the pod restarts",
+			storageClass:        "fast",
+			allowExpansion:      &allowExpansion,
+			resizePending:       true,
+			wantRestartRequired: true,
+		},
+		{
+			name:    "case 2: pvc without a storage class",
+			wantErr: true,
+		},
+		{
+			name:           "case 3: storage class not allowing volume expansion",
+			storageClass:   "standard",
+			allowExpansion: &denyExpansion,
+			wantErr:        true,
+		},
+		{
+			name:         "case 4: storage class not specifying volume expansion",
+			storageClass: "standard",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fkclient, fkclientset := kclient.FakeNew()
+
+			fakeocclient, _ := occlient.FakeNew()
+			fakeocclient.SetKubeClient(fkclient)
+
+			pvc := testingutil.FakePVC(pvcName, "5Gi", getStorageLabels("storage-0", "nodejs", "app"))
+			if tt.storageClass != "" {
+				pvc.Spec.StorageClassName = &tt.storageClass
+			}
+			if tt.resizePending {
+				pvc.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+					{
+						Type:   corev1.PersistentVolumeClaimFileSystemResizePending,
+						Status: corev1.ConditionTrue,
+					},
+				}
+			}
+
+			fkclientset.Kubernetes.PrependReactor("list", "persistentvolumeclaims", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.PersistentVolumeClaimList{Items: []corev1.PersistentVolumeClaim{*pvc}}, nil
+			})
+			fkclientset.Kubernetes.PrependReactor("get", "persistentvolumeclaims", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, pvc.DeepCopy(), nil
+			})
+			fkclientset.Kubernetes.PrependReactor("get", "storageclasses", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &storagev1.StorageClass{
+					ObjectMeta:           metav1.ObjectMeta{Name: action.(ktesting.GetAction).GetName()},
+					AllowVolumeExpansion: tt.allowExpansion,
+				}, nil
+			})
+			fkclientset.Kubernetes.PrependReactor("update", "persistentvolumeclaims", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, action.(ktesting.UpdateAction).GetObject(), nil
+			})
+
+			k := kubernetesClient{
+				generic: generic{
+					appName:       "app",
+					componentName: "nodejs",
+				},
+				client: *fakeocclient,
+			}
+			restartRequired, err := k.Expand(GetMachineFormatWithContainer("storage-0", "10Gi", "/data", "runtime"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if _, ok := err.(ExpansionNotAllowedError); !ok {
+					t.Errorf("Expand() error = %v, want an ExpansionNotAllowedError", err)
+				}
+				return
+			}
+			if restartRequired != tt.wantRestartRequired {
+				t.Errorf("Expand() restartRequired = %v, want %v", restartRequired, tt.wantRestartRequired)
+			}
+
+			var updatedPVC *corev1.PersistentVolumeClaim
+			for _, action := range fkclientset.Kubernetes.Actions() {
+				if action.Matches("update", "persistentvolumeclaims") {
+					updatedPVC = action.(ktesting.UpdateAction).GetObject().(*corev1.PersistentVolumeClaim)
+				}
+			}
+			if updatedPVC == nil {
+				t.Fatalf("expected the PVC to be updated")
+			}
+			size := updatedPVC.Spec.Resources.Requests[corev1.ResourceStorage]
+			if size.String() != "10Gi" {
+				t.Errorf("expected the PVC to be expanded to 10Gi, got %v", size.String())
+			}
+		})
+	}
+}
+
 func Test_kubernetesClient_Delete(t *testing.T) {
 	pvcName := "pvc-0"
 	returnedPVCs := corev1.PersistentVolumeClaimList{
@@ -15,6 +15,10 @@ const DevfileStorageLabel = "storage-name"
 // SourceStorageLabel
 const SourcePVCLabel = "odo-source-pvc"
 
+// SourceAnnotation is the annotation key holding the local file or directory
+// from which the ConfigMap or Secret of a devfile storage is created
+const SourceAnnotation = "odo.dev/storage-source"
+
 // GetLabels gets the labels to be applied to the given storage besides the
 // component labels and application labels.
 func GetLabels(storageName string, componentName string, applicationName string, additional bool) map[string]string {
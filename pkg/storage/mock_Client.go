@@ -46,6 +46,21 @@ func (mr *MockClientMockRecorder) Create(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockClient)(nil).Create), arg0)
 }
 
+// Expand mocks base method
+func (m *MockClient) Expand(arg0 Storage) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expand", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expand indicates an expected call of Expand
+func (mr *MockClientMockRecorder) Expand(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expand", reflect.TypeOf((*MockClient)(nil).Expand), arg0)
+}
+
 // Delete mocks base method
 func (m *MockClient) Delete(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -25,6 +25,12 @@ func (s s2iClient) Create(storage Storage) error {
 	return nil
 }
 
+// this method is currently not being used by s2i components
+// it is here to satisfy the interface
+func (s s2iClient) Expand(storage Storage) (bool, error) {
+	return false, nil
+}
+
 // this method is currently not being used by s2i components
 // it is here to satisfy the interface
 func (s s2iClient) Delete(name string) error {
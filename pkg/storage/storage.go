@@ -16,6 +16,7 @@ import (
 	"github.com/openshift/odo/pkg/util"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog"
 )
@@ -538,6 +539,10 @@ func ConvertListLocalToMachine(storageListConfig []localConfigProvider.LocalStor
 	for _, storeLocal := range storageListConfig {
 		s := GetMachineReadableFormat(storeLocal.Name, storeLocal.Size, storeLocal.Path)
 		s.Spec.ContainerName = storeLocal.Container
+		s.Spec.StorageClass = storeLocal.StorageClass
+		s.Spec.AccessMode = storeLocal.AccessMode
+		s.Spec.Source = storeLocal.Source
+		s.Spec.SourceKind = storeLocal.SourceKind
 		storageListLocal = append(storageListLocal, s)
 	}
 
@@ -620,8 +625,23 @@ type ClientOptions struct {
 	LocalConfigProvider localConfigProvider.LocalConfigProvider
 }
 
+// ExpansionNotAllowedError is returned by Expand when the storage class of the storage doesn't allow volume expansion
+type ExpansionNotAllowedError struct {
+	Storage string
+	// StorageClass is empty when the PVC of the storage has no storage class
+	StorageClass string
+}
+
+func (e ExpansionNotAllowedError) Error() string {
+	if e.StorageClass == "" {
+		return fmt.Sprintf("the PVC of storage %s has no storage class to expand it", e.Storage)
+	}
+	return fmt.Sprintf("storage class %s of storage %s does not allow volume expansion", e.StorageClass, e.Storage)
+}
+
 type Client interface {
 	Create(Storage) error
+	Expand(Storage) (bool, error)
 	Delete(string) error
 	ListFromCluster() (StorageList, error)
 	List() (StorageList, error)
@@ -667,6 +687,10 @@ func Push(client Client, configProvider localConfigProvider.LocalConfigProvider)
 	}
 	storageClusterNames := make(map[string]Storage)
 	for _, storage := range storageClusterList.Items {
+		// the storage mounted from local files are not PVCs, they are pushed with the component
+		if storage.Spec.Source != "" {
+			continue
+		}
 		storageClusterNames[storage.Name] = storage
 	}
 
@@ -678,9 +702,14 @@ func Push(client Client, configProvider localConfigProvider.LocalConfigProvider)
 		return err
 	}
 	for _, storage := range ConvertListLocalToMachine(localStorage).Items {
+		if storage.Spec.Source != "" {
+			continue
+		}
 		storageConfigNames[storage.Name] = storage
 	}
 
+	var storageToExpand []Storage
+
 	// find storage to delete
 	for storageName, storage := range storageClusterNames {
 		val, ok := storageConfigNames[storageName]
@@ -693,8 +722,12 @@ func Push(client Client, configProvider localConfigProvider.LocalConfigProvider)
 			log.Successf("Deleted storage %v from %v", storage.Name, configProvider.GetName())
 			continue
 		} else if storage.Name == val.Name {
-			if val.Spec.Size != storage.Spec.Size {
-				return errors.Errorf("config mismatch for storage with the same name %s", storage.Name)
+			expand, err := isExpanded(val, storage)
+			if err != nil {
+				return err
+			}
+			if expand {
+				storageToExpand = append(storageToExpand, val)
 			}
 		}
 	}
@@ -711,5 +744,49 @@ func Push(client Client, configProvider localConfigProvider.LocalConfigProvider)
 		}
 	}
 
+	// expand the storage whose size has grown
+	for _, storage := range storageToExpand {
+		restartRequired, err := client.Expand(storage)
+		if _, ok := err.(ExpansionNotAllowedError); ok {
+			log.Warningf("The size of storage %v of %v cannot be changed to %v: %v", storage.Name, configProvider.GetName(), storage.Spec.Size, err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		log.Successf("Expanded storage %v of %v to %v", storage.Name, configProvider.GetName(), storage.Spec.Size)
+		if restartRequired {
+			log.Warningf("The file system of storage %v is resized when the pod of %v restarts, please restart it to use the new size", storage.Name, configProvider.GetName())
+		}
+	}
+
 	return err
 }
+
+// isExpanded compares the local storage to the storage of the same name on the cluster
+// it returns true if the size of the local storage has grown
+// and an error if the local storage cannot be applied to the storage on the cluster
+func isExpanded(local, cluster Storage) (bool, error) {
+	if local.Spec.StorageClass != "" && cluster.Spec.StorageClass != "" && local.Spec.StorageClass != cluster.Spec.StorageClass {
+		return false, errors.Errorf("config mismatch for storage %s, the storage class cannot be changed from %s to %s", local.Name, cluster.Spec.StorageClass, local.Spec.StorageClass)
+	}
+	if local.Spec.AccessMode != "" && cluster.Spec.AccessMode != "" && local.Spec.AccessMode != cluster.Spec.AccessMode {
+		return false, errors.Errorf("config mismatch for storage %s, the access mode cannot be changed from %s to %s", local.Name, cluster.Spec.AccessMode, local.Spec.AccessMode)
+	}
+
+	localSize, err := resource.ParseQuantity(local.Spec.Size)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to parse size: %v", local.Spec.Size)
+	}
+	clusterSize, err := resource.ParseQuantity(cluster.Spec.Size)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to parse size: %v", cluster.Spec.Size)
+	}
+	switch localSize.Cmp(clusterSize) {
+	case -1:
+		return false, errors.Errorf("config mismatch for storage %s, the size cannot be reduced from %s to %s", local.Name, cluster.Spec.Size, local.Spec.Size)
+	case 1:
+		return true, nil
+	}
+	return false, nil
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -1067,6 +1068,8 @@ func TestPush(t *testing.T) {
 		returnedFromCluster StorageList
 		createdItems        []localConfigProvider.LocalStorage
 		deletedItems        []string
+		expandedItems       []localConfigProvider.LocalStorage
+		expandErr           error
 		wantErr             bool
 	}{
 		{
@@ -1209,6 +1212,179 @@ func TestPush(t *testing.T) {
 			},
 			deletedItems: []string{"storage-0"},
 		},
+		{
+			name: "case 10: storage expanded",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "8Gi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage1,
+				},
+			},
+			expandedItems: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "8Gi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+		},
+		{
+			name: "case 11: same size in different units",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "5120Mi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage1,
+				},
+			},
+		},
+		{
+			name: "case 12: storage class mismatch",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:         "storage-1",
+					Size:         "5Gi",
+					Path:         "/path",
+					Container:    "runtime-1",
+					StorageClass: "fast",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					func() Storage {
+						storage := clusterStorage1
+						storage.Spec.StorageClass = "standard"
+						return storage
+					}(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 13: access mode mismatch",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:       "storage-1",
+					Size:       "5Gi",
+					Path:       "/path",
+					Container:  "runtime-1",
+					AccessMode: "ReadWriteMany",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					func() Storage {
+						storage := clusterStorage1
+						storage.Spec.AccessMode = "ReadWriteOnce"
+						return storage
+					}(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case 14: storage mounted from a local file already on the cluster",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				localStorage0,
+				{
+					Name:       "config",
+					Path:       "/etc/config",
+					Container:  "runtime-0",
+					Source:     "config/app.properties",
+					SourceKind: "ConfigMap",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage0,
+					func() Storage {
+						storage := GetMachineFormatWithContainer("config", "", "/etc/config", "runtime-0")
+						storage.Spec.Source = "config/app.properties"
+						storage.Spec.SourceKind = "ConfigMap"
+						return storage
+					}(),
+				},
+			},
+		},
+		{
+			name:              "case 15: storage mounted from a local file removed from the config",
+			returnedFromLocal: []localConfigProvider.LocalStorage{},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					func() Storage {
+						storage := GetMachineFormatWithContainer("config", "", "/etc/config", "runtime-0")
+						storage.Spec.Source = "config/app.properties"
+						storage.Spec.SourceKind = "ConfigMap"
+						return storage
+					}(),
+				},
+			},
+		},
+		{
+			name: "case 16: storage class not allowing the expansion",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "8Gi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage1,
+				},
+			},
+			expandedItems: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "8Gi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+			expandErr: ExpansionNotAllowedError{Storage: "storage-1", StorageClass: "standard"},
+		},
+		{
+			name: "case 17: storage expansion failed",
+			returnedFromLocal: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "8Gi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+			returnedFromCluster: StorageList{
+				Items: []Storage{
+					clusterStorage1,
+				},
+			},
+			expandedItems: []localConfigProvider.LocalStorage{
+				{
+					Name:      "storage-1",
+					Size:      "8Gi",
+					Path:      "/path",
+					Container: "runtime-1",
+				},
+			},
+			expandErr: errors.New("unable to update the PVC"),
+			wantErr:   true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1233,6 +1409,11 @@ func TestPush(t *testing.T) {
 				fakeStorageClient.EXPECT().Delete(tt.deletedItems[i]).Return(nil).Times(1)
 			}
 
+			expand := ConvertListLocalToMachine(tt.expandedItems)
+			for i := range expand.Items {
+				fakeStorageClient.EXPECT().Expand(expand.Items[i]).Return(false, tt.expandErr).Times(1)
+			}
+
 			if err := Push(fakeStorageClient, fakeLocalConfig); (err != nil) != tt.wantErr {
 				t.Errorf("Push() error = %v, wantErr %v", err, tt.wantErr)
 			}
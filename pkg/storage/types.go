@@ -31,6 +31,16 @@ type StorageSpec struct {
 	Path string `json:"path,omitempty"`
 
 	ContainerName string `json:"containerName,omitempty"`
+
+	// StorageClass is the storage class of the PVC, the default storage class of the cluster if empty
+	StorageClass string `json:"storageClass,omitempty"`
+	// AccessMode is the access mode of the PVC, ReadWriteOnce if empty
+	AccessMode string `json:"accessMode,omitempty"`
+
+	// Source is the local file or directory whose content is mounted from a ConfigMap or a Secret instead of a PVC
+	Source string `json:"source,omitempty"`
+	// SourceKind is the kind of the resource holding the content of Source, ConfigMap or Secret
+	SourceKind string `json:"sourceKind,omitempty"`
 }
 
 // StorageList is a list of storages
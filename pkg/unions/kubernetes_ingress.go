@@ -55,6 +55,16 @@ func (ki *KubernetesIngress) GetName() string {
 	return ""
 }
 
+//GetAnnotations returns the annotations of underlying networking v1 or extensions v1 ingress
+func (ki *KubernetesIngress) GetAnnotations() map[string]string {
+	if ki.NetworkingV1Ingress != nil {
+		return ki.NetworkingV1Ingress.GetAnnotations()
+	} else if ki.ExtensionV1Beta1Ingress != nil {
+		return ki.ExtensionV1Beta1Ingress.GetAnnotations()
+	}
+	return nil
+}
+
 //GetProtocol returns `https` if tls is configured on either networking v1 or extensions v1 ingress, else `http`
 func (ki *KubernetesIngress) GetProtocol() string {
 	if (ki.NetworkingV1Ingress != nil && len(ki.NetworkingV1Ingress.Spec.TLS) > 0) || (ki.ExtensionV1Beta1Ingress != nil && len(ki.ExtensionV1Beta1Ingress.Spec.TLS) > 0) {
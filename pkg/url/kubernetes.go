@@ -4,16 +4,20 @@ import (
 	"fmt"
 	"github.com/openshift/odo/pkg/unions"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/devfile/library/pkg/devfile/generator"
 	routev1 "github.com/openshift/api/route/v1"
 	componentlabels "github.com/openshift/odo/pkg/component/labels"
 	"github.com/openshift/odo/pkg/kclient"
 	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/occlient"
 	urlLabels "github.com/openshift/odo/pkg/url/labels"
 	"github.com/openshift/odo/pkg/util"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	iextensionsv1 "k8s.io/api/extensions/v1beta1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +26,13 @@ import (
 	"k8s.io/klog"
 )
 
+const (
+	// certificateReadyTimeout is the time to wait for cert-manager to issue the certificate of a URL
+	certificateReadyTimeout = 5 * time.Minute
+	// serviceExternalAddressTimeout is the time to wait for the load balancer of a URL to be assigned an external address
+	serviceExternalAddressTimeout = 2 * time.Minute
+)
+
 // kubernetesClient contains information required for devfile based URL based operations
 type kubernetesClient struct {
 	generic
@@ -29,7 +40,7 @@ type kubernetesClient struct {
 	client           occlient.Client
 }
 
-// ListFromCluster lists both route and ingress based URLs from the cluster
+// ListFromCluster lists the route, ingress and httproute based URLs from the cluster
 func (k kubernetesClient) ListFromCluster() (URLList, error) {
 	labelSelector := componentlabels.GetSelector(k.componentName, k.appName)
 	klog.V(4).Infof("Listing ingresses with label selector: %v", labelSelector)
@@ -46,8 +57,33 @@ func (k kubernetesClient) ListFromCluster() (URLList, error) {
 		}
 	}
 
+	var httpRoutes []kclient.HTTPRoute
+	if httpRouteSupported, e := k.client.GetKubeClient().IsHTTPRouteSupported(); e != nil {
+		klog.V(4).Infof("unable to check if httproutes are supported: %v", e)
+	} else if httpRouteSupported {
+		httpRoutes, err = k.client.GetKubeClient().ListHTTPRoutes(labelSelector)
+		if err != nil {
+			return URLList{}, errors.Wrap(err, "unable to list httproutes")
+		}
+	}
+
+	// the services dedicated to URLs of NodePort and LoadBalancer kind
+	services, err := k.client.GetKubeClient().ListServices(labelSelector + "," + urlLabels.URLLabel)
+	if err != nil {
+		return URLList{}, errors.Wrap(err, "unable to list services")
+	}
+
 	var clusterURLs []URL
 	clusterURLs = append(clusterURLs, NewURLsFromKubernetesIngressList(ingresses)...)
+	for _, s := range services {
+		if s.Spec.Type != corev1.ServiceTypeNodePort && s.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		clusterURLs = append(clusterURLs, NewURLFromService(s))
+	}
+	for _, r := range httpRoutes {
+		clusterURLs = append(clusterURLs, NewURLFromHTTPRoute(r))
+	}
 	for _, r := range routes {
 		// ignore the routes created by ingresses
 		if r.OwnerReferences != nil && r.OwnerReferences[0].Kind == "Ingress" {
@@ -60,7 +96,7 @@ func (k kubernetesClient) ListFromCluster() (URLList, error) {
 	return getMachineReadableFormatForList(clusterURLs), nil
 }
 
-// List lists both route/ingress based URLs and local URLs with respective states
+// List lists the route/ingress/httproute based URLs and local URLs with respective states
 func (k kubernetesClient) List() (URLList, error) {
 	// get the URLs present on the cluster
 	clusterURLMap := make(map[string]URL)
@@ -73,7 +109,19 @@ func (k kubernetesClient) List() (URLList, error) {
 		}
 	}
 
+	var nodeAddress *string
 	for _, url := range clusterURLs.Items {
+		if url.Spec.Kind == localConfigProvider.INGRESS && url.Spec.TLSSecret != "" {
+			url.Status.CertificateExpiry = k.getCertificateExpiry(url.Spec.TLSSecret)
+		}
+		if url.Spec.Kind == localConfigProvider.NODEPORT {
+			// NodePort services are reachable through the address of any node
+			if nodeAddress == nil {
+				address := k.getNodeAddress()
+				nodeAddress = &address
+			}
+			url.Spec.Host = *nodeAddress
+		}
 		clusterURLMap[url.Name] = url
 	}
 
@@ -151,14 +199,26 @@ func (k kubernetesClient) Delete(name string, kind localConfigProvider.URLKind)
 			return err
 		}
 		return k.client.DeleteRoute(route.Name)
+	case localConfigProvider.HTTPROUTE:
+		route, err := k.client.GetKubeClient().GetOneHTTPRouteFromSelector(selector)
+		if err != nil {
+			return err
+		}
+		return k.client.GetKubeClient().DeleteHTTPRoute(route.Name)
+	case localConfigProvider.NODEPORT, localConfigProvider.LOADBALANCER:
+		service, err := k.client.GetKubeClient().GetOneServiceFromSelector(selector)
+		if err != nil {
+			return err
+		}
+		return k.client.GetKubeClient().DeleteService(service.Name)
 	default:
 		return fmt.Errorf("url type is not supported")
 	}
 }
 
-// Create creates a route or ingress based on the given URL
+// Create creates a route, ingress, httproute or service based on the given URL
 func (k kubernetesClient) Create(url URL) (string, error) {
-	if url.Spec.Kind != localConfigProvider.INGRESS && url.Spec.Kind != localConfigProvider.ROUTE {
+	if url.Spec.Kind != localConfigProvider.INGRESS && url.Spec.Kind != localConfigProvider.ROUTE && url.Spec.Kind != localConfigProvider.HTTPROUTE && !localConfigProvider.IsServiceURLKind(url.Spec.Kind) {
 		return "", fmt.Errorf("urlKind %s is not supported for URL creation", url.Spec.Kind)
 	}
 
@@ -168,9 +228,14 @@ func (k kubernetesClient) Create(url URL) (string, error) {
 
 	labels := urlLabels.GetLabels(url.Name, k.componentName, k.appName, true)
 
-	if url.Spec.Kind == localConfigProvider.INGRESS {
+	switch url.Spec.Kind {
+	case localConfigProvider.INGRESS:
 		return k.createIngress(url, labels)
-	} else {
+	case localConfigProvider.HTTPROUTE:
+		return k.createHTTPRoute(url, labels)
+	case localConfigProvider.NODEPORT, localConfigProvider.LOADBALANCER:
+		return k.createService(url, labels)
+	default:
 		if !k.isRouteSupported {
 			return "", errors.Errorf("routes are not available on non OpenShift clusters")
 		}
@@ -186,7 +251,7 @@ func (k kubernetesClient) createIngress(url URL, labels map[string]string) (stri
 		return "", errors.Errorf("the host cannot be empty")
 	}
 
-	service, err := k.client.GetKubeClient().GetOneService(k.componentName, k.appName)
+	service, err := k.client.GetKubeClient().GetOneServiceFromSelector(urlLabels.GetComponentServiceSelector(k.componentName, k.appName))
 	if err != nil {
 		return "", err
 	}
@@ -200,8 +265,24 @@ func (k kubernetesClient) createIngress(url URL, labels map[string]string) (stri
 	}
 	ownerReference := generator.GetOwnerReference(deployment)
 
+	var annotations map[string]string
 	if url.Spec.Secure {
-		if len(url.Spec.TLSSecret) != 0 {
+		if len(url.Spec.Issuer) != 0 {
+			// the certificate is requested to cert-manager, which stores it in the secret referenced by the ingress
+			supported, err := k.client.GetKubeClient().IsCertManagerSupported()
+			if err != nil {
+				return "", errors.Wrap(err, "unable to check if cert-manager is installed")
+			}
+			if !supported {
+				return "", fmt.Errorf("cert-manager is not installed on the cluster, unable to use issuer %q for URL %s", url.Spec.Issuer, url.Name)
+			}
+			annotation := kclient.IssuerAnnotation
+			if url.Spec.IssuerKind == localConfigProvider.CLUSTERISSUER {
+				annotation = kclient.ClusterIssuerAnnotation
+			}
+			annotations = map[string]string{annotation: url.Spec.Issuer}
+			url.Spec.TLSSecret = getCertificateSecretName(url.Name, k.componentName)
+		} else if len(url.Spec.TLSSecret) != 0 {
 			// get the user given secret
 			_, err := k.client.GetKubeClient().GetSecret(url.Spec.TLSSecret, k.client.Namespace)
 			if err != nil {
@@ -247,7 +328,7 @@ func (k kubernetesClient) createIngress(url URL, labels map[string]string) (stri
 	if err != nil {
 		return "", err
 	}
-	objectMeta := generator.GetObjectMeta(k.componentName, k.client.Namespace, labels, nil)
+	objectMeta := generator.GetObjectMeta(k.componentName, k.client.Namespace, labels, annotations)
 	// to avoid error due to duplicate ingress name defined in different devfile components
 	objectMeta.Name = ingressName
 	objectMeta.OwnerReferences = append(objectMeta.OwnerReferences, ownerReference)
@@ -268,6 +349,17 @@ func (k kubernetesClient) createIngress(url URL, labels map[string]string) (stri
 	if err != nil {
 		return "", fmt.Errorf("unable to create ingress %w", err)
 	}
+
+	if len(url.Spec.Issuer) != 0 {
+		// the certificate is created by the cert-manager ingress-shim with the name of the secret
+		s := log.Spinnerf("Waiting for the certificate of URL %s to be issued by %s", url.Name, url.Spec.Issuer)
+		err = k.client.GetKubeClient().WaitForCertificateReady(url.Spec.TLSSecret, certificateReadyTimeout)
+		if err != nil {
+			s.End(false)
+			return "", errors.Wrapf(err, "the certificate of URL %s was not issued", url.Name)
+		}
+		s.End(true)
+	}
 	return i.GetURLString(), nil
 }
 
@@ -288,7 +380,7 @@ func (k kubernetesClient) createRoute(url URL, labels map[string]string) (string
 	}
 	ownerReference := generator.GetOwnerReference(deployment)
 
-	service, err := k.client.GetKubeClient().GetOneService(k.componentName, k.appName)
+	service, err := k.client.GetKubeClient().GetOneServiceFromSelector(urlLabels.GetComponentServiceSelector(k.componentName, k.appName))
 	if err != nil {
 		return "", err
 	}
@@ -304,6 +396,210 @@ func (k kubernetesClient) createRoute(url URL, labels map[string]string) (string
 	return GetURLString(GetProtocol(*route, iextensionsv1.Ingress{}), route.Spec.Host, "", true), nil
 }
 
+// createHTTPRoute creates a Gateway API httproute for the given URL with the given labels
+func (k kubernetesClient) createHTTPRoute(url URL, labels map[string]string) (string, error) {
+	if url.Spec.Host == "" {
+		return "", errors.Errorf("the host cannot be empty")
+	}
+	if url.Spec.Gateway == "" {
+		return "", errors.Errorf("the gateway cannot be empty")
+	}
+
+	supported, err := k.client.GetKubeClient().IsHTTPRouteSupported()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to check if httproutes are supported")
+	}
+	if !supported {
+		return "", errors.Errorf("httproutes are not available, the Gateway API is not installed on the cluster")
+	}
+
+	service, err := k.client.GetKubeClient().GetOneServiceFromSelector(urlLabels.GetComponentServiceSelector(k.componentName, k.appName))
+	if err != nil {
+		return "", err
+	}
+
+	deployment, err := k.client.GetKubeClient().GetOneDeployment(k.componentName, k.appName)
+	if err != nil {
+		return "", err
+	}
+	ownerReference := generator.GetOwnerReference(deployment)
+
+	hostname := fmt.Sprintf("%v.%v", url.Name, url.Spec.Host)
+
+	parentRef := kclient.ParentReference{Name: url.Spec.Gateway}
+	if i := strings.Index(url.Spec.Gateway, "/"); i != -1 {
+		parentRef.Namespace, parentRef.Name = url.Spec.Gateway[:i], url.Spec.Gateway[i+1:]
+	}
+	if url.Spec.Secure {
+		// TLS is terminated by the gateway, attach the route to a listener serving HTTPS for the hostname
+		gateway, err := k.client.GetKubeClient().GetGateway(parentRef.Namespace, parentRef.Name)
+		if err != nil {
+			return "", err
+		}
+		listener, found := gateway.GetHTTPSListener(hostname)
+		if !found {
+			return "", errors.Errorf("gateway %s has no HTTPS listener for host %s, TLS of URLs of HTTPRoute kind must be configured on the gateway", url.Spec.Gateway, hostname)
+		}
+		parentRef.SectionName = listener
+	}
+
+	routeName, err := getResourceName(url.Name, k.componentName, k.appName)
+	if err != nil {
+		return "", err
+	}
+
+	path := url.Spec.Path
+	if path == "" {
+		path = "/"
+	}
+	match := kclient.HTTPRouteMatch{
+		Path: &kclient.HTTPPathMatch{Type: kclient.PathPrefixMatch, Value: path},
+	}
+	// sort the headers to get a consistent resource
+	var headerNames []string
+	for name := range url.Spec.Headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	for _, name := range headerNames {
+		match.Headers = append(match.Headers, kclient.HTTPHeaderMatch{Type: kclient.HeaderExactMatch, Name: name, Value: url.Spec.Headers[name]})
+	}
+
+	objectMeta := generator.GetObjectMeta(routeName, k.client.Namespace, labels, nil)
+	objectMeta.OwnerReferences = append(objectMeta.OwnerReferences, ownerReference)
+
+	route := kclient.HTTPRoute{
+		ObjectMeta: objectMeta,
+		Spec: kclient.HTTPRouteSpec{
+			ParentRefs: []kclient.ParentReference{parentRef},
+			Hostnames:  []string{hostname},
+			Rules: []kclient.HTTPRouteRule{{
+				Matches:     []kclient.HTTPRouteMatch{match},
+				BackendRefs: []kclient.HTTPBackendRef{{Name: service.Name, Port: int32(url.Spec.Port)}},
+			}},
+		},
+	}
+	_, err = k.client.GetKubeClient().CreateHTTPRoute(route)
+	if err != nil {
+		if kerrors.IsAlreadyExists(errors.Cause(err)) {
+			return "", fmt.Errorf("url named %q already exists in the same app named %q", url.Name, k.appName)
+		}
+		return "", err
+	}
+
+	protocol := "http"
+	if url.Spec.Secure {
+		protocol = "https"
+	}
+	return GetURLString(protocol, "", hostname, false), nil
+}
+
+// createService creates a NodePort or LoadBalancer service dedicated to the given URL with the given labels,
+// or updates it if it already exists
+func (k kubernetesClient) createService(url URL, labels map[string]string) (string, error) {
+	componentService, err := k.client.GetKubeClient().GetOneServiceFromSelector(urlLabels.GetComponentServiceSelector(k.componentName, k.appName))
+	if err != nil {
+		return "", err
+	}
+
+	deployment, err := k.client.GetKubeClient().GetOneDeployment(k.componentName, k.appName)
+	if err != nil {
+		return "", err
+	}
+	ownerReference := generator.GetOwnerReference(deployment)
+
+	serviceName, err := getResourceName(url.Name, k.componentName, k.appName)
+	if err != nil {
+		return "", err
+	}
+
+	serviceType := corev1.ServiceTypeNodePort
+	if url.Spec.Kind == localConfigProvider.LOADBALANCER {
+		serviceType = corev1.ServiceTypeLoadBalancer
+	}
+	protocol := corev1.ProtocolTCP
+	if strings.EqualFold(url.Spec.Protocol, string(corev1.ProtocolUDP)) {
+		protocol = corev1.ProtocolUDP
+	}
+
+	objectMeta := generator.GetObjectMeta(serviceName, k.client.Namespace, labels, nil)
+	objectMeta.OwnerReferences = append(objectMeta.OwnerReferences, ownerReference)
+	serviceSpec := corev1.ServiceSpec{
+		Type: serviceType,
+		// select the same pods as the service of the component
+		Selector: componentService.Spec.Selector,
+		Ports: []corev1.ServicePort{{
+			Name:       url.Name,
+			Port:       int32(url.Spec.Port),
+			TargetPort: intstr.FromInt(url.Spec.Port),
+			Protocol:   protocol,
+		}},
+	}
+
+	service, err := k.client.GetKubeClient().CreateService(corev1.Service{ObjectMeta: objectMeta, Spec: serviceSpec})
+	if kerrors.IsAlreadyExists(errors.Cause(err)) {
+		// update the existing service, keeping the fields allocated by the cluster
+		existing, err := k.client.GetKubeClient().GetService(serviceName)
+		if err != nil {
+			return "", err
+		}
+		existing.Labels = objectMeta.Labels
+		existing.Spec.Type = serviceSpec.Type
+		existing.Spec.Selector = serviceSpec.Selector
+		existing.Spec.Ports = serviceSpec.Ports
+		service, err = k.client.GetKubeClient().UpdateService(*existing)
+		if err != nil {
+			return "", err
+		}
+	} else if err != nil {
+		return "", err
+	}
+
+	u := NewURLFromService(*service)
+	if url.Spec.Kind == localConfigProvider.NODEPORT {
+		u.Spec.Host = k.getNodeAddress()
+	} else if u.Spec.Host == "" {
+		s := log.Spinnerf("Waiting for the external address of URL %s", url.Name)
+		service, err = k.client.GetKubeClient().WaitForServiceLoadBalancerIngress(serviceName, serviceExternalAddressTimeout)
+		if err != nil {
+			s.End(false)
+			log.Warningf("The load balancer of URL %s has no external address yet, refer `odo url list` to get it once assigned", url.Name)
+			klog.V(4).Infof("unable to get the external address of service %s: %v", serviceName, err)
+		} else {
+			s.End(true)
+			u = NewURLFromService(*service)
+		}
+	}
+	return GetServiceURLString(u), nil
+}
+
+// getNodeAddress returns the address of a node of the cluster, or a placeholder if it cannot be determined
+func (k kubernetesClient) getNodeAddress() string {
+	address, err := k.client.GetKubeClient().GetNodeAddress()
+	if err != nil {
+		klog.V(4).Infof("unable to get the address of a node: %v", err)
+		return "<node address>"
+	}
+	return address
+}
+
+// getCertificateExpiry returns the expiry date of the certificate stored in the given TLS secret,
+// or nil if it cannot be determined
+func (k kubernetesClient) getCertificateExpiry(secretName string) *metav1.Time {
+	secret, err := k.client.GetKubeClient().GetSecret(secretName, k.client.Namespace)
+	if err != nil {
+		klog.V(4).Infof("unable to get the TLS secret %s: %v", secretName, err)
+		return nil
+	}
+	expiry, err := kclient.GetCertificateExpiry(secret)
+	if err != nil {
+		klog.V(4).Infof("unable to get the certificate expiry: %v", err)
+		return nil
+	}
+	expiryTime := metav1.NewTime(expiry)
+	return &expiryTime
+}
+
 // getResourceName gets the route/ingress resource name
 func getResourceName(urlName, componentName, appName string) (string, error) {
 	resourceName, err := util.NamespaceKubernetesObject(urlName, componentName)
@@ -544,6 +544,20 @@ func Test_kubernetesClient_createIngress(t *testing.T) {
 			want:               "https://example.com",
 			wantErr:            false,
 		},
+		{
+			name:   "Case 7: Fail to create a secure ingress with an issuer when cert-manager is not installed",
+			fields: fields{generic: generic{componentName: "nodejs", appName: "app"}},
+			args: args{
+				url: func() URL {
+					url := getFakeURL("example", "com", 8080, "/", "http", localConfigProvider.INGRESS, StateTypeNotPushed)
+					url.Spec.Secure = true
+					url.Spec.Issuer = "letsencrypt"
+					url.Spec.IssuerKind = localConfigProvider.CLUSTERISSUER
+					return url
+				}(),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -931,6 +945,30 @@ func Test_kubernetesClient_Create(t *testing.T) {
 			want:    "http://example.com",
 			wantErr: false,
 		},
+		{
+			name:   "Case 6: httproute is not supported on the cluster",
+			fields: fields{generic: generic{componentName: "nodejs", appName: "app"}, isRouteSupported: true},
+			args: args{
+				url: func() URL {
+					url := getFakeURL("example", "com", 8080, "/", "http", localConfigProvider.HTTPROUTE, StateTypeNotPushed)
+					url.Spec.Gateway = "gateway"
+					return url
+				}(),
+			},
+			wantErr: true,
+		},
+		{
+			name:   "Case 7: create a nodeport service",
+			fields: fields{generic: generic{componentName: "nodejs", appName: "app"}},
+			args: args{
+				url: func() URL {
+					url := getFakeURL("example", "", 5432, "", "tcp", localConfigProvider.NODEPORT, StateTypeNotPushed)
+					return url
+				}(),
+			},
+			want:    "tcp://10.0.0.1:30432",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -956,6 +994,18 @@ func Test_kubernetesClient_Create(t *testing.T) {
 				return true, route, nil
 			})
 
+			fakeKClientSet.Kubernetes.PrependReactor("create", "services", func(action ktesting.Action) (bool, runtime.Object, error) {
+				service := action.(ktesting.CreateAction).GetObject().(*corev1.Service)
+				service.Spec.Ports[0].NodePort = 30432
+				return true, service, nil
+			})
+
+			fakeKClientSet.Kubernetes.PrependReactor("list", "nodes", func(action ktesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.NodeList{Items: []corev1.Node{
+					{Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}}}},
+				}}, nil
+			})
+
 			k := kubernetesClient{
 				generic:          tt.fields.generic,
 				isRouteSupported: tt.fields.isRouteSupported,
@@ -991,6 +1041,22 @@ func Test_kubernetesClient_Create(t *testing.T) {
 				if !reflect.DeepEqual(createdRoute.Labels, requiredRoute.Labels) {
 					t.Errorf("route labels not matching, %v", pretty.Compare(requiredRoute.Labels, createdRoute.Labels))
 				}
+			} else if tt.args.url.Spec.Kind == localConfigProvider.NODEPORT {
+				var createdService *corev1.Service
+				for _, action := range fakeKClientSet.Kubernetes.Actions() {
+					if action.Matches("create", "services") {
+						createdService = action.(ktesting.CreateAction).GetObject().(*corev1.Service)
+					}
+				}
+				if createdService == nil {
+					t.Fatalf("expected a service to be created, got the actions %v", fakeKClientSet.Kubernetes.Actions())
+				}
+				if createdService.Spec.Type != corev1.ServiceTypeNodePort {
+					t.Errorf("expected a service of type %s, got %s", corev1.ServiceTypeNodePort, createdService.Spec.Type)
+				}
+				if createdService.Labels[urlLabels.URLLabel] != tt.args.url.Name {
+					t.Errorf("expected the url label %s, got %v", tt.args.url.Name, createdService.Labels)
+				}
 			}
 		})
 	}
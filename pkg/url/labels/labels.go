@@ -15,3 +15,9 @@ func GetLabels(urlName string, componentName string, applicationName string, add
 	labels[URLLabel] = urlName
 	return labels
 }
+
+// GetComponentServiceSelector returns the selector of the service of the component,
+// ignoring the services dedicated to the URLs of the component
+func GetComponentServiceSelector(componentName string, applicationName string) string {
+	return componentlabels.GetSelector(componentName, applicationName) + ",!" + URLLabel
+}
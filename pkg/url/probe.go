@@ -0,0 +1,182 @@
+package url
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/odo/pkg/localConfigProvider"
+	"github.com/openshift/odo/pkg/machineoutput"
+	"github.com/openshift/odo/pkg/util"
+	"k8s.io/klog"
+)
+
+const (
+	// DefaultProbeTimeout is the default time to wait for the response of a probed URL
+	DefaultProbeTimeout = 5 * time.Second
+	// maxProbeRedirects is the maximum number of redirects followed while probing a URL
+	maxProbeRedirects = 10
+)
+
+// ProbeOptions configures how URLs are probed
+type ProbeOptions struct {
+	// Path is appended to the path of the URL
+	Path string
+	// ExpectedStatus is the status code expected from a healthy URL, any 2xx or 3xx status when 0
+	ExpectedStatus int
+	// Timeout is the time to wait for the response
+	Timeout time.Duration
+}
+
+// ProbeResult is the result of the probe of a URL
+type ProbeResult struct {
+	// URL is the probed URL
+	URL string `json:"url"`
+	// Reachable is true if any response was received
+	Reachable bool `json:"reachable"`
+	// Healthy is true if the final response has the expected status code
+	Healthy bool `json:"healthy"`
+	// StatusCode is the status code of the final response
+	StatusCode int `json:"statusCode,omitempty"`
+	// LatencyMs is the time in milliseconds to receive the final response
+	LatencyMs int64 `json:"latencyMs"`
+	// TLSValid indicates, for HTTPS URLs, if the certificate served is trusted and valid for the host
+	TLSValid *bool `json:"tlsValid,omitempty"`
+	// TLSError is the reason the certificate is not valid
+	TLSError string `json:"tlsError,omitempty"`
+	// Redirects are the locations the URL was redirected to, in order
+	Redirects []string `json:"redirects,omitempty"`
+	// Error is the reason the URL is not reachable
+	Error string `json:"error,omitempty"`
+}
+
+// ProbeURL sends a GET request to the given URL and reports the response received
+func ProbeURL(target string, options ProbeOptions) ProbeResult {
+	if options.Path != "" {
+		target = strings.TrimSuffix(target, "/") + "/" + strings.TrimPrefix(options.Path, "/")
+	}
+	if options.Timeout == 0 {
+		options.Timeout = DefaultProbeTimeout
+	}
+	result := ProbeResult{URL: target}
+
+	// probe through the proxy and with the client certificate of the preference
+	transport, err := util.HTTPTransport("")
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	// the certificate is verified separately, so that URLs with an untrusted certificate are still probed
+	/* #nosec */
+	transport.TLSClientConfig.InsecureSkipVerify = true
+	client := &http.Client{
+		Timeout:   options.Timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxProbeRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxProbeRedirects)
+			}
+			result.Redirects = append(result.Redirects, req.URL.String())
+			return nil
+		},
+	}
+
+	start := time.Now()
+	resp, err := client.Get(target)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		klog.V(4).Infof("Get request failed for %s: %v", target, err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.Reachable = true
+	result.StatusCode = resp.StatusCode
+	if options.ExpectedStatus == 0 {
+		result.Healthy = resp.StatusCode >= 200 && resp.StatusCode < 400
+	} else {
+		result.Healthy = resp.StatusCode == options.ExpectedStatus
+	}
+
+	if resp.TLS != nil {
+		valid := true
+		if err := verifyPeerCertificates(resp.TLS, resp.Request.URL.Hostname()); err != nil {
+			valid = false
+			result.TLSError = err.Error()
+		}
+		result.TLSValid = &valid
+	}
+	klog.V(4).Infof("Get request succeeded for %s, with response code %d", target, resp.StatusCode)
+	return result
+}
+
+// ProbeURLs probes the pushed HTTP(S) URLs of the given list concurrently and sets their probe status
+func ProbeURLs(urls URLList, options ProbeOptions) URLList {
+	var wg sync.WaitGroup
+	for i := range urls.Items {
+		if urls.Items[i].Status.State != StateTypePushed || localConfigProvider.IsServiceURLKind(urls.Items[i].Spec.Kind) {
+			continue
+		}
+		wg.Add(1)
+		go func(u *URL) {
+			defer wg.Done()
+			result := ProbeURL(GetURLStringForProbe(*u), options)
+			u.Status.Probe = &result
+		}(&urls.Items[i])
+	}
+	wg.Wait()
+	return urls
+}
+
+// GetURLStringForProbe returns the string representation of a pushed URL to probe
+func GetURLStringForProbe(u URL) string {
+	protocol := u.Spec.Protocol
+	if protocol == "" {
+		protocol = "http"
+		if u.Spec.Secure {
+			protocol = "https"
+		}
+	}
+	if u.Spec.Kind == localConfigProvider.ROUTE {
+		return GetURLString(protocol, u.Spec.Host, "", false) + u.Spec.Path
+	}
+	return GetURLString(protocol, "", u.Spec.Host, false) + u.Spec.Path
+}
+
+// ToMachineOutput converts the probe result to the probe of a URLReachable event
+func (r ProbeResult) ToMachineOutput() *machineoutput.URLProbe {
+	return &machineoutput.URLProbe{
+		Healthy:    r.Healthy,
+		StatusCode: r.StatusCode,
+		LatencyMs:  r.LatencyMs,
+		TLSValid:   r.TLSValid,
+		TLSError:   r.TLSError,
+		Redirects:  r.Redirects,
+		Error:      r.Error,
+	}
+}
+
+// verifyPeerCertificates verifies the certificate chain presented by the server against the system roots and the given host
+func verifyPeerCertificates(state *tls.ConnectionState, host string) error {
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("no certificate presented by the server")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Intermediates: intermediates,
+	})
+	return err
+}
@@ -0,0 +1,105 @@
+package url
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestProbeURL(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/health", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	tlsServer := httptest.NewTLSServer(mux)
+	defer tlsServer.Close()
+
+	tests := []struct {
+		name          string
+		url           string
+		options       ProbeOptions
+		wantReachable bool
+		wantHealthy   bool
+		wantStatus    int
+		wantRedirects int
+		wantTLSValid  *bool
+	}{
+		{
+			name:          "Case 1: healthy URL",
+			url:           server.URL,
+			options:       ProbeOptions{Path: "/health"},
+			wantReachable: true,
+			wantHealthy:   true,
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "Case 2: redirected URL",
+			url:           server.URL + "/",
+			options:       ProbeOptions{Path: "old"},
+			wantReachable: true,
+			wantHealthy:   true,
+			wantStatus:    http.StatusOK,
+			wantRedirects: 1,
+		},
+		{
+			name:          "Case 3: reachable URL with an unexpected status",
+			url:           server.URL,
+			options:       ProbeOptions{Path: "/broken"},
+			wantReachable: true,
+			wantHealthy:   false,
+			wantStatus:    http.StatusInternalServerError,
+		},
+		{
+			name:          "Case 4: reachable URL with the expected status",
+			url:           server.URL,
+			options:       ProbeOptions{Path: "/broken", ExpectedStatus: http.StatusInternalServerError},
+			wantReachable: true,
+			wantHealthy:   true,
+			wantStatus:    http.StatusInternalServerError,
+		},
+		{
+			name:          "Case 5: secure URL with an untrusted certificate",
+			url:           tlsServer.URL,
+			options:       ProbeOptions{Path: "/health"},
+			wantReachable: true,
+			wantHealthy:   true,
+			wantStatus:    http.StatusOK,
+			wantTLSValid:  new(bool),
+		},
+		{
+			name:          "Case 6: unreachable URL",
+			url:           "http://127.0.0.1:1",
+			options:       ProbeOptions{Timeout: time.Second},
+			wantReachable: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProbeURL(tt.url, tt.options)
+			if got.Reachable != tt.wantReachable {
+				t.Fatalf("expected reachable to be %v, got %v (%s)", tt.wantReachable, got.Reachable, got.Error)
+			}
+			if got.Healthy != tt.wantHealthy {
+				t.Errorf("expected healthy to be %v, got %v", tt.wantHealthy, got.Healthy)
+			}
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("expected status code %d, got %d", tt.wantStatus, got.StatusCode)
+			}
+			if len(got.Redirects) != tt.wantRedirects {
+				t.Errorf("expected %d redirects, got %v", tt.wantRedirects, got.Redirects)
+			}
+			if (got.TLSValid == nil) != (tt.wantTLSValid == nil) || (got.TLSValid != nil && *got.TLSValid != *tt.wantTLSValid) {
+				t.Errorf("expected TLS validity %v, got %v", tt.wantTLSValid, got.TLSValid)
+			}
+		})
+	}
+}
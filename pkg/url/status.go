@@ -1,9 +1,7 @@
 package url
 
 import (
-	"crypto/tls"
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/openshift/odo/pkg/localConfigProvider"
@@ -12,18 +10,17 @@ import (
 	"github.com/openshift/odo/pkg/kclient"
 	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/occlient"
-	"github.com/pkg/errors"
 
 	"k8s.io/klog"
 )
 
 const (
-	// URLFailureWaitTime is how long to wait on error from URL connection
+	// URLFailureWaitTime is how long to wait on error from URL connection, and the default time between two probes of a URL
 	URLFailureWaitTime = time.Duration(5) * time.Second
 )
 
-// StartURLHttpRequestStatusWatchForK8S begins testing URLs for responses, outputting the result to console
-func StartURLHttpRequestStatusWatchForK8S(occlient *occlient.Client, client *kclient.Client, localConfigProvider *localConfigProvider.LocalConfigProvider, loggingClient machineoutput.MachineEventLoggingClient) {
+// StartURLHttpRequestStatusWatchForK8S begins probing URLs every interval, outputting the result to console
+func StartURLHttpRequestStatusWatchForK8S(occlient *occlient.Client, client *kclient.Client, localConfigProvider *localConfigProvider.LocalConfigProvider, loggingClient machineoutput.MachineEventLoggingClient, probeOptions ProbeOptions, interval time.Duration) {
 
 	// This is a non-blocking function so that other status watchers may start as needed
 	go func() {
@@ -44,22 +41,16 @@ func StartURLHttpRequestStatusWatchForK8S(occlient *occlient.Client, client *kcl
 			}
 		}
 
-		if len(urlList) == 0 {
-			return
-		}
-
-		singleEntry := [][]statusURL{urlList}
-
-		startURLTester(singleEntry, loggingClient)
+		startURLTester(urlList, interval, probeOptions, loggingClient)
 
 	}()
 }
 
-// startURLTester kicks off a new goroutine for each set of URLs to test
-func startURLTester(urlsToTest [][]statusURL, loggingClient machineoutput.MachineEventLoggingClient) {
+// startURLTester kicks off a new goroutine for each URL to test
+func startURLTester(urlsToTest []statusURL, interval time.Duration, probeOptions ProbeOptions, loggingClient machineoutput.MachineEventLoggingClient) {
 
 	for _, urlToTest := range urlsToTest {
-		startURLTestGoRoutine(urlToTest, URLFailureWaitTime, loggingClient)
+		startURLTestGoRoutine(urlToTest, interval, probeOptions, loggingClient)
 	}
 }
 
@@ -99,10 +90,18 @@ func getURLsForKubernetes(oclient *occlient.Client, client *kclient.Client, loca
 			continue
 		}
 
+		// Ignore the URLs exposed through a service, they are not necessarily served over HTTP
+		if localConfigProvider.IsServiceURLKind(u.Spec.Kind) {
+			continue
+		}
+
 		var properURL, protocol string
 
 		if u.Spec.Kind != localConfigProvider.ROUTE {
-			protocol = GetProtocol(routev1.Route{}, ConvertExtensionV1IngressURLToIngress(u, componentName))
+			protocol = u.Spec.Protocol
+			if protocol == "" {
+				protocol = GetProtocol(routev1.Route{}, ConvertExtensionV1IngressURLToIngress(u, componentName))
+			}
 			properURL = GetURLString(protocol, "", u.Spec.Host, false)
 		} else {
 			protocol = u.Spec.Protocol
@@ -132,83 +131,35 @@ type statusURL struct {
 	kind   string
 }
 
-// startURLTestGoRoutine tests one or more urls ('urls' param); if at least one of them is successful, a success is reported.
-// If a success was previously reported, additional successes will not be reported (until at least one failure occurs)
-// Likewise if a failure was previously reported.
-func startURLTestGoRoutine(urls []statusURL, delayBetweenRequests time.Duration, loggingClient machineoutput.MachineEventLoggingClient) {
+// startURLTestGoRoutine probes the url periodically; a result is reported the first time and when it changes from the previously reported one
+func startURLTestGoRoutine(u statusURL, delayBetweenRequests time.Duration, probeOptions ProbeOptions, loggingClient machineoutput.MachineEventLoggingClient) {
 
 	go func() {
 
-		var previousResult *bool = nil
+		var previousResult *ProbeResult = nil
 
 		for {
-
-			successfulMatch := (*statusURL)(nil)
-			atLeastOneSuccess := false
-
-			for _, currURL := range urls {
-				anyResponseReceived, err := testURL(currURL.url)
-
-				if err != nil {
-					klog.V(4).Infof("Error on connecting to URL '%s' %v", currURL.url, err)
-				}
-
-				if anyResponseReceived {
-					match := currURL
-					successfulMatch = &match
-					atLeastOneSuccess = true
-					break
-				}
-			}
+			result := ProbeURL(u.url, probeOptions)
 
 			// If this is the first time we have seen a result for this URL, OR the result has changed from last time
-			if previousResult == nil || *previousResult != atLeastOneSuccess {
-
-				if successfulMatch != nil {
-					// At least one of the URLs was reachable, so report success for it
-					loggingClient.URLReachable((*successfulMatch).name, (*successfulMatch).url, (*successfulMatch).port, (*successfulMatch).secure, (*successfulMatch).kind, true, machineoutput.TimestampNow())
-				} else {
-					// Otherwise report failure for all URLs
-					for _, currURL := range urls {
-						loggingClient.URLReachable(currURL.name, currURL.url, currURL.port, currURL.secure, currURL.kind, atLeastOneSuccess, machineoutput.TimestampNow())
-					}
-				}
+			if previousResult == nil || probeResultChanged(*previousResult, result) {
+				loggingClient.URLReachable(u.name, u.url, u.port, u.secure, u.kind, result.Reachable, result.ToMachineOutput(), machineoutput.TimestampNow())
 			}
 
-			previousResult = &atLeastOneSuccess
+			previousResult = &result
 
 			time.Sleep(delayBetweenRequests)
 		}
 	}()
 }
 
-// testURL tests a single URL, returning true if ANY response was received, or false otherwise (plus an error if applicable)
-func testURL(url string) (bool, error) {
-
-	// Suppress 'G402 (CWE-295): TLS InsecureSkipVerify set true': since we are not using the contents of the HTTP response,
-	// the fact that it can be MITM-ed is irrelevant.
-	/* #nosec */
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+// probeResultChanged returns true if the state of the URL differs between the two probe results, ignoring the latency
+func probeResultChanged(previous, current ProbeResult) bool {
+	if previous.Reachable != current.Reachable || previous.Healthy != current.Healthy || previous.StatusCode != current.StatusCode {
+		return true
 	}
-
-	client := &http.Client{Transport: tr}
-
-	resp, err := client.Get(url)
-	if err != nil || resp == nil {
-		errMsg := "Get request failed for " + url + " , with no response code."
-		if err != nil {
-			return false, errors.Wrapf(err, errMsg)
-		}
-		return false, errors.New(errMsg)
+	if (previous.TLSValid == nil) != (current.TLSValid == nil) {
+		return true
 	}
-
-	defer resp.Body.Close()
-
-	// Any response code (including 5XX, 4XX) is considered a success; we only use this to determine if the process
-	// is responding to requests.
-	klog.V(4).Infof("Get request succeeded for '%s', with response code %d", url, resp.StatusCode)
-
-	return true, nil
-
+	return previous.TLSValid != nil && *previous.TLSValid != *current.TLSValid
 }
@@ -1,9 +1,13 @@
 package url
 
 import (
+	"strings"
+
+	"github.com/openshift/odo/pkg/kclient"
 	"github.com/openshift/odo/pkg/localConfigProvider"
 	"github.com/openshift/odo/pkg/unions"
 	urlLabels "github.com/openshift/odo/pkg/url/labels"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"reflect"
@@ -19,14 +23,18 @@ type URL struct {
 
 // URLSpec is
 type URLSpec struct {
-	Host         string                      `json:"host,omitempty"`
-	Protocol     string                      `json:"protocol,omitempty"`
-	Port         int                         `json:"port,omitempty"`
-	Secure       bool                        `json:"secure"`
-	Kind         localConfigProvider.URLKind `json:"kind,omitempty"`
-	TLSSecret    string                      `json:"tlssecret,omitempty"`
-	ExternalPort int                         `json:"externalport,omitempty"`
-	Path         string                      `json:"path,omitempty"`
+	Host         string                         `json:"host,omitempty"`
+	Protocol     string                         `json:"protocol,omitempty"`
+	Port         int                            `json:"port,omitempty"`
+	Secure       bool                           `json:"secure"`
+	Kind         localConfigProvider.URLKind    `json:"kind,omitempty"`
+	TLSSecret    string                         `json:"tlssecret,omitempty"`
+	ExternalPort int                            `json:"externalport,omitempty"`
+	Path         string                         `json:"path,omitempty"`
+	Issuer       string                         `json:"issuer,omitempty"`
+	IssuerKind   localConfigProvider.IssuerKind `json:"issuerKind,omitempty"`
+	Gateway      string                         `json:"gateway,omitempty"`
+	Headers      map[string]string              `json:"headers,omitempty"`
 }
 
 // URLList is a list of applications
@@ -40,6 +48,10 @@ type URLList struct {
 type URLStatus struct {
 	// "Pushed" or "Not Pushed" or "Locally Delted"
 	State StateType `json:"state"`
+	// CertificateExpiry is the expiry date of the certificate of a pushed secure Ingress URL
+	CertificateExpiry *metav1.Time `json:"certificateExpiry,omitempty"`
+	// Probe is the result of the HTTP(S) probe of a pushed URL
+	Probe *ProbeResult `json:"probe,omitempty"`
 }
 
 type StateType string
@@ -106,9 +118,102 @@ func NewURLFromKubernetesIngress(ki *unions.KubernetesIngress, skipIfGenerated b
 			u.Spec.Protocol = "http"
 		}
 	}
+	u.Spec.Issuer, u.Spec.IssuerKind = getIngressIssuer(ki.GetAnnotations())
 	return u
 }
 
+// getIngressIssuer returns the name and kind of the cert-manager issuer requested by the annotations of an Ingress
+func getIngressIssuer(annotations map[string]string) (string, localConfigProvider.IssuerKind) {
+	if issuer, ok := annotations[kclient.ClusterIssuerAnnotation]; ok {
+		return issuer, localConfigProvider.CLUSTERISSUER
+	}
+	if issuer, ok := annotations[kclient.IssuerAnnotation]; ok {
+		return issuer, localConfigProvider.ISSUER
+	}
+	return "", ""
+}
+
+// NewURLFromHTTPRoute returns the URL definition of the given Gateway API HTTPRoute
+func NewURLFromHTTPRoute(route kclient.HTTPRoute) URL {
+	u := URL{
+		TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: apiVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: route.Labels[urlLabels.URLLabel]},
+		Spec: URLSpec{
+			Protocol: "http",
+			Kind:     localConfigProvider.HTTPROUTE,
+		},
+	}
+	if len(route.Spec.Hostnames) > 0 {
+		u.Spec.Host = route.Spec.Hostnames[0]
+	}
+	if len(route.Spec.ParentRefs) > 0 {
+		parent := route.Spec.ParentRefs[0]
+		u.Spec.Gateway = parent.Name
+		if parent.Namespace != "" {
+			u.Spec.Gateway = parent.Namespace + "/" + parent.Name
+		}
+		// secure URLs are attached to an HTTPS listener of the gateway terminating TLS
+		if parent.SectionName != "" {
+			u.Spec.Secure = true
+			u.Spec.Protocol = "https"
+		}
+	}
+	if len(route.Spec.Rules) > 0 {
+		rule := route.Spec.Rules[0]
+		if len(rule.BackendRefs) > 0 {
+			u.Spec.Port = int(rule.BackendRefs[0].Port)
+		}
+		if len(rule.Matches) > 0 {
+			if rule.Matches[0].Path != nil {
+				u.Spec.Path = rule.Matches[0].Path.Value
+			}
+			for _, header := range rule.Matches[0].Headers {
+				if u.Spec.Headers == nil {
+					u.Spec.Headers = make(map[string]string)
+				}
+				u.Spec.Headers[header.Name] = header.Value
+			}
+		}
+	}
+	return u
+}
+
+// NewURLFromService returns the URL definition of the given Service dedicated to a URL of NodePort or LoadBalancer kind
+func NewURLFromService(service corev1.Service) URL {
+	u := URL{
+		TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: apiVersion},
+		ObjectMeta: metav1.ObjectMeta{Name: service.Labels[urlLabels.URLLabel]},
+		Spec: URLSpec{
+			Kind: localConfigProvider.NODEPORT,
+		},
+	}
+	if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		u.Spec.Kind = localConfigProvider.LOADBALANCER
+	}
+	if len(service.Spec.Ports) > 0 {
+		port := service.Spec.Ports[0]
+		u.Spec.Port = int(port.Port)
+		u.Spec.Protocol = strings.ToLower(string(port.Protocol))
+		if u.Spec.Kind == localConfigProvider.NODEPORT {
+			u.Spec.ExternalPort = int(port.NodePort)
+		} else {
+			u.Spec.ExternalPort = int(port.Port)
+		}
+	}
+	if u.Spec.Kind == localConfigProvider.LOADBALANCER && len(service.Status.LoadBalancer.Ingress) > 0 {
+		u.Spec.Host = getLoadBalancerAddress(service.Status.LoadBalancer.Ingress[0])
+	}
+	return u
+}
+
+// getLoadBalancerAddress returns the IP, or the hostname if no IP is set, of a load balancer ingress point
+func getLoadBalancerAddress(ingress corev1.LoadBalancerIngress) string {
+	if ingress.IP != "" {
+		return ingress.IP
+	}
+	return ingress.Hostname
+}
+
 // Get returns URL definition for given URL name
 func (urls URLList) Get(urlName string) URL {
 	for _, url := range urls.Items {
@@ -133,22 +133,30 @@ func ConvertEnvinfoURL(envinfoURL localConfigProvider.LocalURL, serviceName stri
 			Name: envinfoURL.Name,
 		},
 		Spec: URLSpec{
-			Host:      envinfoURL.Host,
-			Protocol:  envinfoURL.Protocol,
-			Port:      envinfoURL.Port,
-			Secure:    envinfoURL.Secure,
-			Kind:      kind,
-			TLSSecret: envinfoURL.TLSSecret,
-			Path:      envinfoURL.Path,
+			Host:       envinfoURL.Host,
+			Protocol:   envinfoURL.Protocol,
+			Port:       envinfoURL.Port,
+			Secure:     envinfoURL.Secure,
+			Kind:       kind,
+			TLSSecret:  envinfoURL.TLSSecret,
+			Path:       envinfoURL.Path,
+			Issuer:     envinfoURL.Issuer,
+			IssuerKind: envinfoURL.IssuerKind,
+			Gateway:    envinfoURL.Gateway,
+			Headers:    envinfoURL.Headers,
 		},
 	}
 	if kind == localConfigProvider.INGRESS {
 		url.Spec.Host = hostString
 		if envinfoURL.Secure && len(envinfoURL.TLSSecret) > 0 {
 			url.Spec.TLSSecret = envinfoURL.TLSSecret
+		} else if envinfoURL.Secure && len(envinfoURL.Issuer) > 0 {
+			url.Spec.TLSSecret = getCertificateSecretName(envinfoURL.Name, serviceName)
 		} else if envinfoURL.Secure {
 			url.Spec.TLSSecret = fmt.Sprintf("%s-tlssecret", serviceName)
 		}
+	} else if kind == localConfigProvider.HTTPROUTE {
+		url.Spec.Host = hostString
 	}
 	return url
 }
@@ -164,13 +172,17 @@ func ConvertLocalURL(localURL localConfigProvider.LocalURL) URL {
 			Name: localURL.Name,
 		},
 		Spec: URLSpec{
-			Host:      localURL.Host,
-			Protocol:  localURL.Protocol,
-			Port:      localURL.Port,
-			Secure:    localURL.Secure,
-			Kind:      localURL.Kind,
-			TLSSecret: localURL.TLSSecret,
-			Path:      localURL.Path,
+			Host:       localURL.Host,
+			Protocol:   localURL.Protocol,
+			Port:       localURL.Port,
+			Secure:     localURL.Secure,
+			Kind:       localURL.Kind,
+			TLSSecret:  localURL.TLSSecret,
+			Path:       localURL.Path,
+			Issuer:     localURL.Issuer,
+			IssuerKind: localURL.IssuerKind,
+			Gateway:    localURL.Gateway,
+			Headers:    localURL.Headers,
 		},
 	}
 }
@@ -190,6 +202,15 @@ func GetURLString(protocol, URL, ingressDomain string, isS2I bool) string {
 	return protocol + "://" + URL
 }
 
+// GetServiceURLString returns the string representation of a URL of NodePort or LoadBalancer kind
+func GetServiceURLString(u URL) string {
+	host := u.Spec.Host
+	if host == "" {
+		host = "<pending>"
+	}
+	return fmt.Sprintf("%s://%s:%d", u.Spec.Protocol, host, u.Spec.ExternalPort)
+}
+
 // Exists checks if the url exists in the component or not
 // urlName is the name of the url for checking
 // componentName is the name of the component to which the url's existence is checked
@@ -255,6 +276,11 @@ func getDefaultTLSSecretName(componentName, appName string) string {
 	return componentName + "-" + appName + "-tlssecret"
 }
 
+// getCertificateSecretName returns the name of the secret in which cert-manager stores the certificate of a URL
+func getCertificateSecretName(urlName, componentName string) string {
+	return componentName + "-" + urlName + "-tls"
+}
+
 // ConvertExtensionV1IngressURLToIngress converts IngressURL to Ingress
 func ConvertExtensionV1IngressURLToIngress(ingressURL URL, serviceName string) iextensionsv1.Ingress {
 	port := intstr.IntOrString{
@@ -356,10 +382,20 @@ func Push(parameters PushParameters) error {
 				// in case of a secure ingress type URL with no user given tls secret
 				// the default secret name is used during creation
 				// thus setting it to the local URLs to avoid config mismatch
-				if val.Spec.Secure && val.Spec.TLSSecret == "" {
+				if val.Spec.Secure && val.Spec.TLSSecret == "" && val.Spec.Issuer != "" {
+					val.Spec.TLSSecret = getCertificateSecretName(urlName, parameters.LocalConfig.GetName())
+				} else if val.Spec.Secure && val.Spec.TLSSecret == "" {
 					val.Spec.TLSSecret = getDefaultTLSSecretName(parameters.LocalConfig.GetName(), parameters.LocalConfig.GetApplication())
 				}
 				val.Spec.Host = fmt.Sprintf("%v.%v", urlName, val.Spec.Host)
+			} else if val.Spec.Kind == localConfigProvider.HTTPROUTE {
+				// the hostname of an httproute is also the combination of name and host of the url
+				val.Spec.Host = fmt.Sprintf("%v.%v", urlName, val.Spec.Host)
+			} else if localConfigProvider.IsServiceURLKind(val.Spec.Kind) {
+				// the address and external port of a service are provided by the cluster
+				// removing them for the urls from the cluster to avoid config mismatch
+				urlSpec.Spec.Host = ""
+				urlSpec.Spec.ExternalPort = 0
 			} else if val.Spec.Kind == localConfigProvider.ROUTE {
 				// we don't allow the host input for route based URLs
 				// removing it for the urls from the cluster to avoid config mismatch
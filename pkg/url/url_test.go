@@ -18,6 +18,7 @@ import (
 	"github.com/openshift/odo/pkg/testingutil"
 	"github.com/openshift/odo/pkg/url/labels"
 	"github.com/openshift/odo/pkg/version"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -896,7 +897,24 @@ func TestConvertEnvinfoURL(t *testing.T) {
 			},
 		},
 		{
-			name: "Case 4: Insecure route URL",
+			name: "Case 4: secure Ingress URL with a cert-manager issuer",
+			envInfoURL: localConfigProvider.LocalURL{
+				Name:       urlName,
+				Host:       host,
+				Port:       8080,
+				Secure:     true,
+				Issuer:     "letsencrypt",
+				IssuerKind: localConfigProvider.CLUSTERISSUER,
+				Kind:       localConfigProvider.INGRESS,
+			},
+			wantURL: URL{
+				TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: "odo.dev/v1alpha1"},
+				ObjectMeta: metav1.ObjectMeta{Name: urlName},
+				Spec:       URLSpec{Host: fmt.Sprintf("%s.%s", urlName, host), Port: 8080, Secure: true, TLSSecret: fmt.Sprintf("%s-%s-tls", serviceName, urlName), Issuer: "letsencrypt", IssuerKind: localConfigProvider.CLUSTERISSUER, Kind: localConfigProvider.INGRESS},
+			},
+		},
+		{
+			name: "Case 5: insecure route URL",
 			envInfoURL: localConfigProvider.LocalURL{
 				Name: urlName,
 				Port: 8080,
@@ -909,7 +927,7 @@ func TestConvertEnvinfoURL(t *testing.T) {
 			},
 		},
 		{
-			name: "Case 4: Secure route URL",
+			name: "Case 6: secure route URL",
 			envInfoURL: localConfigProvider.LocalURL{
 				Name:   urlName,
 				Port:   8080,
@@ -922,6 +940,22 @@ func TestConvertEnvinfoURL(t *testing.T) {
 				Spec:       URLSpec{Port: 8080, Secure: true, Kind: localConfigProvider.ROUTE},
 			},
 		},
+		{
+			name: "Case 7: HTTPRoute URL attached to a gateway",
+			envInfoURL: localConfigProvider.LocalURL{
+				Name:    urlName,
+				Host:    host,
+				Port:    8080,
+				Gateway: "infra/gateway",
+				Headers: map[string]string{"X-Version": "v2"},
+				Kind:    localConfigProvider.HTTPROUTE,
+			},
+			wantURL: URL{
+				TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: "odo.dev/v1alpha1"},
+				ObjectMeta: metav1.ObjectMeta{Name: urlName},
+				Spec:       URLSpec{Host: fmt.Sprintf("%s.%s", urlName, host), Port: 8080, Gateway: "infra/gateway", Headers: map[string]string{"X-Version": "v2"}, Kind: localConfigProvider.HTTPROUTE},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -986,3 +1020,111 @@ func TestGetURLString(t *testing.T) {
 		})
 	}
 }
+
+func TestNewURLFromHTTPRoute(t *testing.T) {
+	tests := []struct {
+		name  string
+		route kclient.HTTPRoute
+		want  URL
+	}{
+		{
+			name: "Case 1: insecure HTTPRoute with header matching",
+			route: kclient.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "example-nodejs-app", Labels: map[string]string{labels.URLLabel: "example"}},
+				Spec: kclient.HTTPRouteSpec{
+					ParentRefs: []kclient.ParentReference{{Name: "gateway"}},
+					Hostnames:  []string{"example.com"},
+					Rules: []kclient.HTTPRouteRule{{
+						Matches: []kclient.HTTPRouteMatch{{
+							Path:    &kclient.HTTPPathMatch{Type: kclient.PathPrefixMatch, Value: "/"},
+							Headers: []kclient.HTTPHeaderMatch{{Type: kclient.HeaderExactMatch, Name: "X-Version", Value: "v2"}},
+						}},
+						BackendRefs: []kclient.HTTPBackendRef{{Name: "nodejs-app", Port: 8080}},
+					}},
+				},
+			},
+			want: URL{
+				TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: "odo.dev/v1alpha1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec:       URLSpec{Host: "example.com", Protocol: "http", Port: 8080, Path: "/", Gateway: "gateway", Headers: map[string]string{"X-Version": "v2"}, Kind: localConfigProvider.HTTPROUTE},
+			},
+		},
+		{
+			name: "Case 2: secure HTTPRoute attached to the HTTPS listener of a gateway in another namespace",
+			route: kclient.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "example-nodejs-app", Labels: map[string]string{labels.URLLabel: "example"}},
+				Spec: kclient.HTTPRouteSpec{
+					ParentRefs: []kclient.ParentReference{{Name: "gateway", Namespace: "infra", SectionName: "https"}},
+					Hostnames:  []string{"example.com"},
+					Rules: []kclient.HTTPRouteRule{{
+						Matches:     []kclient.HTTPRouteMatch{{Path: &kclient.HTTPPathMatch{Type: kclient.PathPrefixMatch, Value: "/api"}}},
+						BackendRefs: []kclient.HTTPBackendRef{{Name: "nodejs-app", Port: 8080}},
+					}},
+				},
+			},
+			want: URL{
+				TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: "odo.dev/v1alpha1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec:       URLSpec{Host: "example.com", Protocol: "https", Port: 8080, Secure: true, Path: "/api", Gateway: "infra/gateway", Kind: localConfigProvider.HTTPROUTE},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewURLFromHTTPRoute(tt.route)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewURLFromService(t *testing.T) {
+	tests := []struct {
+		name    string
+		service corev1.Service
+		want    URL
+	}{
+		{
+			name: "Case 1: NodePort service",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "postgres-nodejs-app", Labels: map[string]string{labels.URLLabel: "postgres"}},
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeNodePort,
+					Ports: []corev1.ServicePort{{Port: 5432, NodePort: 30432, Protocol: corev1.ProtocolTCP}},
+				},
+			},
+			want: URL{
+				TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: "odo.dev/v1alpha1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "postgres"},
+				Spec:       URLSpec{Protocol: "tcp", Port: 5432, ExternalPort: 30432, Kind: localConfigProvider.NODEPORT},
+			},
+		},
+		{
+			name: "Case 2: LoadBalancer service with an external hostname",
+			service: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "coap-nodejs-app", Labels: map[string]string{labels.URLLabel: "coap"}},
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeLoadBalancer,
+					Ports: []corev1.ServicePort{{Port: 5683, NodePort: 31683, Protocol: corev1.ProtocolUDP}},
+				},
+				Status: corev1.ServiceStatus{
+					LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}}},
+				},
+			},
+			want: URL{
+				TypeMeta:   metav1.TypeMeta{Kind: "url", APIVersion: "odo.dev/v1alpha1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "coap"},
+				Spec:       URLSpec{Host: "lb.example.com", Protocol: "udp", Port: 5683, ExternalPort: 5683, Kind: localConfigProvider.LOADBALANCER},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewURLFromService(tt.service)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -1,15 +1,71 @@
 package util
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/devfile/library/pkg/testingutil/filesystem"
+	"github.com/pkg/errors"
 	"k8s.io/klog"
 )
 
+const (
+	// httpCacheMetadataSuffix is the suffix of the files holding the metadata of the cached responses
+	httpCacheMetadataSuffix = ".json"
+	// httpCacheBodySuffix is the suffix of the files holding the bodies of the cached responses
+	httpCacheBodySuffix = ".body"
+)
+
+// HTTPCacheEntry is the metadata of a response of the HTTP cache
+type HTTPCacheEntry struct {
+	URL string `json:"url"`
+	// Group is the group whose size is limited with the response, the registry of the response
+	Group string `json:"group,omitempty"`
+	// ETag and LastModified are the validators of the response, used to revalidate it when it is stale
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	// Stored is when the response was last stored or revalidated
+	Stored time.Time `json:"stored"`
+	Size   int64     `json:"size"`
+}
+
+// isFresh returns true if the response can be used without revalidation
+func (e HTTPCacheEntry) isFresh(cacheTime time.Duration) bool {
+	return time.Since(e.Stored) < cacheTime
+}
+
+// httpCache stores HTTP responses and their metadata in a directory, by the hash of their URL
+type httpCache struct {
+	dir string
+}
+
+func defaultHTTPCache() httpCache {
+	return httpCache{dir: httpCacheDir}
+}
+
+// ListHTTPCache returns the responses of the HTTP cache, sorted by group and URL
+func ListHTTPCache() ([]HTTPCacheEntry, error) {
+	return defaultHTTPCache().list()
+}
+
+// CleanHTTPCache deletes the responses of the given group from the HTTP cache, of all the groups if the group is empty,
+// and returns the number of deleted responses
+func CleanHTTPCache(group string) (int, error) {
+	return defaultHTTPCache().clean(group)
+}
+
+// DeleteHTTPCacheEntry deletes the response of the given URL from the HTTP cache
+func DeleteHTTPCacheEntry(url string) error {
+	return defaultHTTPCache().delete(url)
+}
+
 // CleanDefaultHTTPCacheDir cleans the default directory used for HTTP caching
 func CleanDefaultHTTPCacheDir() error {
 	return cleanDefaultHTTPCacheDir(filesystem.DefaultFs{})
@@ -31,21 +87,162 @@ func cleanDefaultHTTPCacheDir(fs filesystem.Filesystem) error {
 	return nil
 }
 
-// cleanHttpCache checks cacheDir and deletes all files that were modified more than cacheTime back
-func cleanHttpCache(cacheDir string, cacheTime time.Duration) error {
-	cacheFiles, err := ioutil.ReadDir(cacheDir)
+func httpCacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached response of the given URL, nil if there is none
+func (c httpCache) get(url string) (*HTTPCacheEntry, []byte, error) {
+	key := httpCacheKey(url)
+	metadata, err := ioutil.ReadFile(filepath.Join(c.dir, key+httpCacheMetadataSuffix))
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var entry HTTPCacheEntry
+	if err = json.Unmarshal(metadata, &entry); err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid cache entry of %s", url)
+	}
+	body, err := ioutil.ReadFile(filepath.Join(c.dir, key+httpCacheBodySuffix))
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &entry, body, nil
+}
+
+// put stores the response of the URL of the entry, and deletes the oldest responses of its group
+// when the responses of the group are larger than maxGroupSize bytes, 0 for no limit
+func (c httpCache) put(entry HTTPCacheEntry, body []byte, maxGroupSize int64) error {
+	if err := os.MkdirAll(c.dir, 0750); err != nil {
+		return err
+	}
+	entry.Stored = time.Now()
+	entry.Size = int64(len(body))
+	metadata, err := json.Marshal(entry)
 	if err != nil {
 		return err
 	}
 
-	for _, f := range cacheFiles {
-		if f.ModTime().Add(cacheTime).Before(time.Now()) {
-			klog.V(4).Infof("Removing cache file %s, because it is older than %s", f.Name(), cacheTime.String())
-			err := os.Remove(filepath.Join(cacheDir, f.Name()))
-			if err != nil {
-				return err
+	key := httpCacheKey(entry.URL)
+	// the body is written first, a metadata file always has its body
+	if err = ioutil.WriteFile(filepath.Join(c.dir, key+httpCacheBodySuffix), body, 0600); err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(filepath.Join(c.dir, key+httpCacheMetadataSuffix), metadata, 0600); err != nil {
+		return err
+	}
+	if maxGroupSize > 0 {
+		return c.prune(entry.Group, maxGroupSize)
+	}
+	return nil
+}
+
+func (c httpCache) delete(url string) error {
+	key := httpCacheKey(url)
+	for _, suffix := range []string{httpCacheMetadataSuffix, httpCacheBodySuffix} {
+		if err := os.Remove(filepath.Join(c.dir, key+suffix)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c httpCache) list() ([]HTTPCacheEntry, error) {
+	files, err := ioutil.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []HTTPCacheEntry
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), httpCacheMetadataSuffix) {
+			continue
+		}
+		metadata, err := ioutil.ReadFile(filepath.Join(c.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var entry HTTPCacheEntry
+		if err = json.Unmarshal(metadata, &entry); err != nil {
+			klog.V(4).Infof("Ignoring invalid cache entry %s: %v", f.Name(), err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Group != entries[j].Group {
+			return entries[i].Group < entries[j].Group
+		}
+		return entries[i].URL < entries[j].URL
+	})
+	return entries, nil
+}
+
+func (c httpCache) clean(group string) (int, error) {
+	entries, err := c.list()
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, entry := range entries {
+		if group != "" && entry.Group != group {
+			continue
+		}
+		if err = c.delete(entry.URL); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	if group == "" {
+		// also delete the files which are not responses, such as the ones of previous versions of the cache
+		files, err := ioutil.ReadDir(c.dir)
+		if err != nil && !os.IsNotExist(err) {
+			return deleted, err
+		}
+		for _, f := range files {
+			if err = os.RemoveAll(filepath.Join(c.dir, f.Name())); err != nil {
+				return deleted, err
 			}
 		}
 	}
+	return deleted, nil
+}
+
+// prune deletes the least recently stored responses of the group until the responses of the group are at most maxSize bytes
+func (c httpCache) prune(group string, maxSize int64) error {
+	entries, err := c.list()
+	if err != nil {
+		return err
+	}
+	var groupEntries []HTTPCacheEntry
+	var size int64
+	for _, entry := range entries {
+		if entry.Group == group {
+			groupEntries = append(groupEntries, entry)
+			size += entry.Size
+		}
+	}
+	sort.Slice(groupEntries, func(i, j int) bool {
+		return groupEntries[i].Stored.Before(groupEntries[j].Stored)
+	})
+	for _, entry := range groupEntries {
+		if size <= maxSize {
+			break
+		}
+		klog.V(4).Infof("Removing the cached response of %s, the cache of %q is larger than %d bytes", entry.URL, group, maxSize)
+		if err = c.delete(entry.URL); err != nil {
+			return err
+		}
+		size -= entry.Size
+	}
 	return nil
 }
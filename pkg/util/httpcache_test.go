@@ -1,9 +1,15 @@
 package util
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/devfile/library/pkg/testingutil/filesystem"
 )
@@ -40,3 +46,115 @@ func TestCleanDefaultHTTPCacheDir(t *testing.T) {
 	}
 
 }
+
+func TestHTTPGetRequestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defaultCacheDir := httpCacheDir
+	httpCacheDir = dir
+	defer func() { httpCacheDir = defaultCacheDir }()
+
+	requests, conditionalRequests := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			conditionalRequests++
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", `"v1"`)
+		_, _ = rw.Write([]byte("index"))
+	}))
+	request := HTTPRequestParams{URL: server.URL + "/index", CacheGroup: "MyRegistry"}
+
+	// Case 1: the response is downloaded and cached
+	got, err := HTTPGetRequest(request, 1)
+	if err != nil || string(got) != "index" || requests != 1 {
+		t.Fatalf("HTTPGetRequest() = %q, %v after %d requests, want the response of the server", got, err, requests)
+	}
+	// Case 2: the fresh cached response is used without contacting the server
+	got, err = HTTPGetRequest(request, 1)
+	if err != nil || string(got) != "index" || requests != 1 {
+		t.Fatalf("HTTPGetRequest() = %q, %v after %d requests, want the cached response", got, err, requests)
+	}
+
+	cache := defaultHTTPCache()
+	makeStale := func() {
+		entry, _, err := cache.get(request.URL)
+		if err != nil || entry == nil {
+			t.Fatalf("the response wasn't cached: %v", err)
+		}
+		entry.Stored = time.Now().Add(-time.Hour)
+		metadata, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ioutil.WriteFile(filepath.Join(dir, httpCacheKey(request.URL)+httpCacheMetadataSuffix), metadata, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Case 3: the stale cached response is revalidated
+	makeStale()
+	got, err = HTTPGetRequest(request, 1)
+	if err != nil || string(got) != "index" || conditionalRequests != 1 {
+		t.Fatalf("HTTPGetRequest() = %q, %v after %d conditional requests, want the revalidated response", got, err, conditionalRequests)
+	}
+	entry, _, err := cache.get(request.URL)
+	if err != nil || time.Since(entry.Stored) > time.Minute {
+		t.Errorf("the revalidated response wasn't refreshed: %+v, %v", entry, err)
+	}
+
+	// Case 4: the stale cached response is used when the server can't be reached
+	makeStale()
+	server.Close()
+	got, err = HTTPGetRequest(request, 1)
+	if err != nil || string(got) != "index" {
+		t.Errorf("HTTPGetRequest() = %q, %v, want the stale cached response when the server can't be reached", got, err)
+	}
+}
+
+func TestHTTPCachePrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "httpcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache := httpCache{dir: dir}
+
+	for _, url := range []string{"https://registry.example.com/1", "https://registry.example.com/2", "https://registry.example.com/3"} {
+		if err = cache.put(HTTPCacheEntry{URL: url, Group: "MyRegistry"}, []byte("0123456789"), 25); err != nil {
+			t.Fatal(err)
+		}
+		// the responses are pruned by the time they were stored
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err = cache.put(HTTPCacheEntry{URL: "https://other.example.com/1", Group: "OtherRegistry"}, []byte("0123456789"), 25); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := cache.list()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var urls []string
+	for _, entry := range entries {
+		urls = append(urls, entry.URL)
+	}
+	want := []string{"https://registry.example.com/2", "https://registry.example.com/3", "https://other.example.com/1"}
+	if !reflect.DeepEqual(urls, want) {
+		t.Errorf("list() = %v, want %v", urls, want)
+	}
+
+	deleted, err := cache.clean("MyRegistry")
+	if err != nil || deleted != 2 {
+		t.Errorf("clean() = %d, %v, want 2 deleted responses", deleted, err)
+	}
+	deleted, err = cache.clean("")
+	if err != nil || deleted != 1 {
+		t.Errorf("clean() = %d, %v, want 1 deleted response", deleted, err)
+	}
+}
@@ -0,0 +1,82 @@
+package util
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ListenLocal listens on the given local address, unix:<path> for a Unix domain socket or http://<loopback host>:<port>,
+// and returns the listener and the address the clients connect to, unix:<path> or http://<host>:<port>
+func ListenLocal(address string) (net.Listener, string, error) {
+	parsedURL, err := url.Parse(address)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "invalid address %q", address)
+	}
+	switch parsedURL.Scheme {
+	case "unix":
+		path := parsedURL.Path
+		if path == "" {
+			path = parsedURL.Opaque
+		}
+		if path == "" {
+			return nil, "", errors.Errorf("invalid address %q, the socket path is missing", address)
+		}
+		// a socket left by a previous run prevents listening
+		if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			_ = os.Remove(path)
+		}
+		listener, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, "", err
+		}
+		return listener, "unix:" + path, nil
+
+	case "http":
+		if !IsLoopbackHost(parsedURL.Host) {
+			return nil, "", errors.Errorf("invalid address %q, only a loopback address can be listened on", address)
+		}
+		listener, err := net.Listen("tcp", parsedURL.Host)
+		if err != nil {
+			return nil, "", err
+		}
+		return listener, fmt.Sprintf("http://%s", listener.Addr().String()), nil
+
+	default:
+		return nil, "", errors.Errorf("invalid address %q, it must be unix:<path> or http://127.0.0.1:<port>", address)
+	}
+}
+
+// IsLoopbackHost returns true if the given host, with an optional port, is localhost or a loopback address
+func IsLoopbackHost(host string) bool {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// CheckLocalRequest returns an error if the request to a local server may come from a web page: its Origin header, when set,
+// must be a loopback host, and so must its Host header when checkHost is true, refusing the requests of DNS rebinding
+// attacks. The Host header of the requests of a Unix domain socket is not meaningful and is not checked.
+func CheckLocalRequest(r *http.Request, checkHost bool) error {
+	if checkHost && !IsLoopbackHost(r.Host) {
+		return errors.Errorf("the host %q is not a loopback host", r.Host)
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		originURL, err := url.Parse(origin)
+		if err != nil || !IsLoopbackHost(originURL.Host) {
+			return errors.Errorf("the origin %q is not a loopback host", origin)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package util
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestListenLocal(t *testing.T) {
+	socketDir, err := ioutil.TempDir("", "listen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(socketDir)
+	socketPath := filepath.Join(socketDir, "odo.sock")
+
+	tests := []struct {
+		name        string
+		address     string
+		wantAddress string
+		wantErr     bool
+	}{
+		{
+			name:        "Case 1: loopback address",
+			address:     "http://127.0.0.1:0",
+			wantAddress: "http://127.0.0.1:",
+		},
+		{
+			name:        "Case 2: Unix domain socket",
+			address:     "unix:" + socketPath,
+			wantAddress: "unix:" + socketPath,
+		},
+		{
+			name:    "Case 3: not a loopback address",
+			address: "http://0.0.0.0:0",
+			wantErr: true,
+		},
+		{
+			name:    "Case 4: unsupported scheme",
+			address: "tcp://127.0.0.1:0",
+			wantErr: true,
+		},
+		{
+			name:    "Case 5: missing socket path",
+			address: "unix:",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener, address, err := ListenLocal(tt.address)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ListenLocal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer listener.Close()
+			if !strings.HasPrefix(address, tt.wantAddress) {
+				t.Errorf("ListenLocal() address = %q, want %q", address, tt.wantAddress)
+			}
+		})
+	}
+}
+
+func TestCheckLocalRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      string
+		origin    string
+		checkHost bool
+		wantErr   bool
+	}{
+		{
+			name:      "Case 1: request of the local machine",
+			host:      "127.0.0.1:20000",
+			checkHost: true,
+		},
+		{
+			name:      "Case 2: request of a local web page",
+			host:      "localhost:20000",
+			origin:    "http://localhost:3000",
+			checkHost: true,
+		},
+		{
+			name:      "Case 3: request of a remote web page",
+			host:      "127.0.0.1:20000",
+			origin:    "https://example.com",
+			checkHost: true,
+			wantErr:   true,
+		},
+		{
+			name:      "Case 4: host of a DNS rebinding",
+			host:      "attacker.example.com:20000",
+			checkHost: true,
+			wantErr:   true,
+		},
+		{
+			name: "Case 5: host of a Unix domain socket request",
+			host: "odo",
+		},
+		{
+			name:      "Case 6: IPv6 loopback host",
+			host:      "[::1]:20000",
+			checkHost: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "http://"+tt.host+"/", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if err = CheckLocalRequest(req, tt.checkHost); tt.wantErr != (err != nil) {
+				t.Errorf("CheckLocalRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// HTTPTransportSettings configures the transport shared by all the outbound HTTP requests of odo
+type HTTPTransportSettings struct {
+	// Proxy is the URL of the proxy of the requests, the proxy of the environment is used if empty
+	Proxy string
+	// NoProxy is the comma separated list of the hosts, domains and CIDRs reached without Proxy
+	NoProxy string
+	// CABundle is a PEM file of the certificate authorities to trust in addition to the system ones
+	CABundle string
+	// ClientCertificate and ClientKey are the PEM files of the certificate presented to the servers requesting one
+	ClientCertificate string
+	ClientKey         string
+}
+
+var (
+	httpTransportMutex    sync.Mutex
+	httpTransportSettings HTTPTransportSettings
+	// sharedHTTPTransport is created from httpTransportSettings on first use
+	sharedHTTPTransport *http.Transport
+)
+
+// SetHTTPTransportSettings configures the transport shared by all the outbound HTTP requests of odo
+func SetHTTPTransportSettings(settings HTTPTransportSettings) {
+	httpTransportMutex.Lock()
+	defer httpTransportMutex.Unlock()
+	httpTransportSettings = settings
+	sharedHTTPTransport = nil
+}
+
+// HTTPTransport returns the transport shared by all the outbound HTTP requests of odo,
+// also trusting the certificate authorities of the given PEM file if not empty
+func HTTPTransport(caFile string) (*http.Transport, error) {
+	httpTransportMutex.Lock()
+	defer httpTransportMutex.Unlock()
+
+	if sharedHTTPTransport == nil {
+		transport, err := newHTTPTransport(httpTransportSettings)
+		if err != nil {
+			return nil, err
+		}
+		sharedHTTPTransport = transport
+	}
+	if caFile == "" {
+		return sharedHTTPTransport, nil
+	}
+
+	certPool, err := newCertPool(httpTransportSettings.CABundle, caFile)
+	if err != nil {
+		return nil, err
+	}
+	transport := sharedHTTPTransport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.RootCAs = certPool
+	return transport, nil
+}
+
+// HTTPClient returns a client of the transport shared by all the outbound HTTP requests of odo
+func HTTPClient() (*http.Client, error) {
+	transport, err := HTTPTransport("")
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   HTTPRequestTimeout,
+	}, nil
+}
+
+func newHTTPTransport(settings HTTPTransportSettings) (*http.Transport, error) {
+	proxy, err := settings.proxyFunc()
+	if err != nil {
+		return nil, err
+	}
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		ResponseHeaderTimeout: ResponseHeaderTimeout,
+		IdleConnTimeout:       90 * time.Second,
+	}
+
+	if settings.CABundle == "" && settings.ClientCertificate == "" && settings.ClientKey == "" {
+		return transport, nil
+	}
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	if settings.CABundle != "" {
+		transport.TLSClientConfig.RootCAs, err = newCertPool(settings.CABundle)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if settings.ClientCertificate != "" || settings.ClientKey != "" {
+		if settings.ClientCertificate == "" || settings.ClientKey == "" {
+			return nil, errors.New("the client certificate and the client key must be set together")
+		}
+		certificate, err := tls.LoadX509KeyPair(settings.ClientCertificate, settings.ClientKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to load the client certificate %s", settings.ClientCertificate)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return transport, nil
+}
+
+// newCertPool returns the system certificate authorities and the ones of the given PEM files
+func newCertPool(caFiles ...string) (*x509.CertPool, error) {
+	certPool, err := x509.SystemCertPool()
+	if err != nil || certPool == nil {
+		certPool = x509.NewCertPool()
+	}
+	for _, caFile := range caFiles {
+		if caFile == "" {
+			continue
+		}
+		caCerts, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to read the certificate authorities of %s", caFile)
+		}
+		if !certPool.AppendCertsFromPEM(caCerts) {
+			return nil, errors.Errorf("no certificate authority found in %s", caFile)
+		}
+	}
+	return certPool, nil
+}
+
+// proxyFunc returns the function selecting the proxy of a request
+func (s HTTPTransportSettings) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if s.Proxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	proxyURL, err := url.Parse(s.Proxy)
+	if err != nil || proxyURL.Host == "" {
+		return nil, errors.Errorf("invalid proxy URL %q", s.Proxy)
+	}
+	return func(req *http.Request) (*url.URL, error) {
+		if s.bypassesProxy(req.URL.Hostname()) {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}, nil
+}
+
+// bypassesProxy returns true if the host matches an entry of NoProxy: "*", a host, a domain or a CIDR
+func (s HTTPTransportSettings) bypassesProxy(host string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+	for _, entry := range strings.Split(s.NoProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			return true
+		case ip != nil && strings.Contains(entry, "/"):
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+				return true
+			}
+		case host == strings.TrimPrefix(entry, "."):
+			return true
+		case strings.HasSuffix(host, "."+strings.TrimPrefix(entry, ".")):
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPTransportDiagnostic describes the settings in effect of the transport shared by all the outbound HTTP requests of odo
+type HTTPTransportDiagnostic struct {
+	// ProxySource is where the proxy comes from: "preference", "environment" or "none"
+	ProxySource string `json:"proxySource"`
+	Proxy       string `json:"proxy,omitempty"`
+	NoProxy     string `json:"noProxy,omitempty"`
+	// URL is the URL the request was checked against, URLProxy the proxy used to reach it
+	URL      string `json:"url"`
+	URLProxy string `json:"urlProxy,omitempty"`
+	CABundle string `json:"caBundle,omitempty"`
+	// ClientCertificate is the subject of the client certificate, ClientCertificateExpiry its expiration
+	ClientCertificate       string     `json:"clientCertificate,omitempty"`
+	ClientCertificateExpiry *time.Time `json:"clientCertificateExpiry,omitempty"`
+	// Status is the status of the response of the URL, Error why the URL can't be reached
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// DiagnoseHTTPTransport describes the settings in effect of the shared transport, and checks whether the URL can be reached with it
+func DiagnoseHTTPTransport(targetURL string) HTTPTransportDiagnostic {
+	httpTransportMutex.Lock()
+	settings := httpTransportSettings
+	httpTransportMutex.Unlock()
+
+	diagnostic := HTTPTransportDiagnostic{
+		URL:      targetURL,
+		CABundle: settings.CABundle,
+	}
+	switch {
+	case settings.Proxy != "":
+		diagnostic.ProxySource = "preference"
+		diagnostic.Proxy = settings.Proxy
+		diagnostic.NoProxy = settings.NoProxy
+	case proxyFromEnvironment("HTTPS_PROXY") != "" || proxyFromEnvironment("HTTP_PROXY") != "":
+		diagnostic.ProxySource = "environment"
+		diagnostic.Proxy = proxyFromEnvironment("HTTPS_PROXY")
+		if diagnostic.Proxy == "" {
+			diagnostic.Proxy = proxyFromEnvironment("HTTP_PROXY")
+		}
+		diagnostic.NoProxy = proxyFromEnvironment("NO_PROXY")
+	default:
+		diagnostic.ProxySource = "none"
+	}
+
+	if settings.ClientCertificate != "" && settings.ClientKey != "" {
+		if certificate, err := tls.LoadX509KeyPair(settings.ClientCertificate, settings.ClientKey); err == nil && len(certificate.Certificate) > 0 {
+			if leaf, err := x509.ParseCertificate(certificate.Certificate[0]); err == nil {
+				diagnostic.ClientCertificate = leaf.Subject.String()
+				diagnostic.ClientCertificateExpiry = &leaf.NotAfter
+			}
+		}
+	}
+
+	client, err := HTTPClient()
+	if err != nil {
+		diagnostic.Error = err.Error()
+		return diagnostic
+	}
+	req, err := http.NewRequest(http.MethodHead, targetURL, nil)
+	if err != nil {
+		diagnostic.Error = err.Error()
+		return diagnostic
+	}
+	if proxyURL, err := client.Transport.(*http.Transport).Proxy(req); err == nil && proxyURL != nil {
+		diagnostic.URLProxy = proxyURL.String()
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		diagnostic.Error = err.Error()
+		return diagnostic
+	}
+	resp.Body.Close()
+	diagnostic.Status = resp.Status
+	return diagnostic
+}
+
+// proxyFromEnvironment returns the value of the upper or lower case proxy environment variable
+func proxyFromEnvironment(name string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return os.Getenv(strings.ToLower(name))
+}
@@ -0,0 +1,188 @@
+package util
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBypassesProxy(t *testing.T) {
+	tests := []struct {
+		name    string
+		noProxy string
+		host    string
+		want    bool
+	}{
+		{
+			name: "Case 1: empty list",
+			host: "registry.devfile.io",
+			want: false,
+		},
+		{
+			name:    "Case 2: wildcard",
+			noProxy: "*",
+			host:    "registry.devfile.io",
+			want:    true,
+		},
+		{
+			name:    "Case 3: same host",
+			noProxy: "localhost, registry.devfile.io",
+			host:    "Registry.Devfile.io",
+			want:    true,
+		},
+		{
+			name:    "Case 4: subdomain of a domain",
+			noProxy: ".devfile.io",
+			host:    "registry.devfile.io",
+			want:    true,
+		},
+		{
+			name:    "Case 5: domain without leading dot",
+			noProxy: "devfile.io",
+			host:    "registry.devfile.io",
+			want:    true,
+		},
+		{
+			name:    "Case 6: host only sharing the suffix",
+			noProxy: "devfile.io",
+			host:    "notdevfile.io",
+			want:    false,
+		},
+		{
+			name:    "Case 7: IP in a CIDR",
+			noProxy: "10.0.0.0/8",
+			host:    "10.1.2.3",
+			want:    true,
+		},
+		{
+			name:    "Case 8: IP outside of a CIDR",
+			noProxy: "10.0.0.0/8",
+			host:    "192.168.1.1",
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := HTTPTransportSettings{Proxy: "http://proxy.example.com:3128", NoProxy: tt.noProxy}
+			if got := settings.bypassesProxy(tt.host); got != tt.want {
+				t.Errorf("bypassesProxy(%q) = %v, want %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHTTPTransport(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "odo-transport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	invalidCA := filepath.Join(tempDir, "invalid.pem")
+	if err = ioutil.WriteFile(invalidCA, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		settings  HTTPTransportSettings
+		url       string
+		wantProxy string
+		wantErr   bool
+	}{
+		{
+			name:      "Case 1: proxy of the preference",
+			settings:  HTTPTransportSettings{Proxy: "http://proxy.example.com:3128", NoProxy: "localhost"},
+			url:       "https://registry.devfile.io/index",
+			wantProxy: "http://proxy.example.com:3128",
+		},
+		{
+			name:     "Case 2: host of the no proxy list",
+			settings: HTTPTransportSettings{Proxy: "http://proxy.example.com:3128", NoProxy: "localhost,.devfile.io"},
+			url:      "https://registry.devfile.io/index",
+		},
+		{
+			name:     "Case 3: invalid proxy URL",
+			settings: HTTPTransportSettings{Proxy: "proxy.example.com"},
+			wantErr:  true,
+		},
+		{
+			name:     "Case 4: client certificate without client key",
+			settings: HTTPTransportSettings{ClientCertificate: filepath.Join(tempDir, "cert.pem")},
+			wantErr:  true,
+		},
+		{
+			name:     "Case 5: CA bundle without certificate",
+			settings: HTTPTransportSettings{CABundle: invalidCA},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := newHTTPTransport(tt.settings)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("newHTTPTransport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proxy, err := transport.Proxy(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ""
+			if proxy != nil {
+				got = proxy.String()
+			}
+			if got != tt.wantProxy {
+				t.Errorf("proxy of %s = %q, want %q", tt.url, got, tt.wantProxy)
+			}
+		})
+	}
+}
+
+func TestHTTPTransportCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tempDir, err := ioutil.TempDir("", "odo-transport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tempDir)
+	caBundle := filepath.Join(tempDir, "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err = ioutil.WriteFile(caBundle, certificate, 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer SetHTTPTransportSettings(HTTPTransportSettings{})
+
+	SetHTTPTransportSettings(HTTPTransportSettings{NoProxy: "*"})
+	client, err := HTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = client.Get(server.URL); err == nil {
+		t.Errorf("expected an error without the CA bundle of the server")
+	}
+
+	SetHTTPTransportSettings(HTTPTransportSettings{Proxy: "http://proxy.example.com:3128", NoProxy: "127.0.0.1", CABundle: caBundle})
+	client, err = HTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error with the CA bundle of the server: %v", err)
+	}
+	resp.Body.Close()
+}
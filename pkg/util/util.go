@@ -32,8 +32,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/go-git/go-git/v5"
 	"github.com/gobwas/glob"
-	"github.com/gregjones/httpcache"
-	"github.com/gregjones/httpcache/diskcache"
+	"github.com/openshift/odo/pkg/log"
 	"github.com/openshift/odo/pkg/testingutil/filesystem"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -73,6 +72,14 @@ type ResourceRequirementInfo struct {
 type HTTPRequestParams struct {
 	URL   string
 	Token string
+	// Username and Password are the basic auth credentials of the request, used when there is no Token
+	Username string
+	Password string
+	// CAFile is a PEM file of the additional certificate authorities to trust for the request
+	CAFile string
+	// CacheGroup is the group of the cached response, the responses of a group are limited to CacheSize bytes, 0 for no limit
+	CacheGroup string
+	CacheSize  int64
 }
 
 // DownloadParams holds parameters of forming file download request
@@ -765,6 +772,7 @@ func GetRemoteFilesMarkedForDeletion(delSrcRelPaths []string, remoteFolder strin
 
 // HTTPGetRequest gets resource contents given URL and token (if applicable)
 // cacheFor determines how long the response should be cached (in minutes), 0 for no caching
+// a cached response older than cacheFor is revalidated with the server, and is used when the server can't be reached
 func HTTPGetRequest(request HTTPRequestParams, cacheFor int) ([]byte, error) {
 	// Build http request
 	req, err := http.NewRequest("GET", request.URL, nil)
@@ -774,50 +782,67 @@ func HTTPGetRequest(request HTTPRequestParams, cacheFor int) ([]byte, error) {
 	if request.Token != "" {
 		bearer := "Bearer " + request.Token
 		req.Header.Add("Authorization", bearer)
+	} else if request.Username != "" {
+		req.SetBasicAuth(request.Username, request.Password)
 	}
 
+	transport, err := HTTPTransport(request.CAFile)
+	if err != nil {
+		return nil, err
+	}
 	httpClient := &http.Client{
-		Transport: &http.Transport{
-			ResponseHeaderTimeout: ResponseHeaderTimeout,
-		},
-		Timeout: HTTPRequestTimeout,
+		Transport: transport,
+		Timeout:   HTTPRequestTimeout,
 	}
 
 	klog.V(4).Infof("HTTPGetRequest: %s", req.URL.String())
 
+	cache := defaultHTTPCache()
+	var cached *HTTPCacheEntry
+	var cachedBody []byte
 	if cacheFor > 0 {
-		// if there is an error during cache setup we show warning and continue without using cache
-		cacheError := false
-		httpCacheTime := time.Duration(cacheFor) * time.Minute
-
-		// make sure that cache directory exists
-		err = os.MkdirAll(httpCacheDir, 0750)
+		// if there is an error while reading the cache we continue without using it
+		cached, cachedBody, err = cache.get(request.URL)
 		if err != nil {
-			cacheError = true
-			klog.WarningDepth(4, "Unable to setup cache: ", err)
+			klog.V(4).Infof("Unable to read the cached response of %s: %v", request.URL, err)
+			cached = nil
 		}
-		err = cleanHttpCache(httpCacheDir, httpCacheTime)
-		if err != nil {
-			cacheError = true
-			klog.WarningDepth(4, "Unable to clean up cache directory: ", err)
-		}
-
-		if !cacheError {
-			httpClient.Transport = httpcache.NewTransport(diskcache.New(httpCacheDir))
-			klog.V(4).Infof("Response will be cached in %s for %s", httpCacheDir, httpCacheTime)
-		} else {
-			klog.V(4).Info("Response won't be cached.")
+		if cached != nil {
+			if cached.isFresh(time.Duration(cacheFor) * time.Minute) {
+				klog.V(4).Infof("Cached response used.")
+				return cachedBody, nil
+			}
+			if cached.ETag != "" {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.LastModified)
+			}
 		}
 	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
+		if cached != nil {
+			log.Warningf("Unable to reach %s, using the response cached %s ago: %v", request.URL, time.Since(cached.Stored).Round(time.Second), err)
+			return cachedBody, nil
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.Header.Get(httpcache.XFromCache) != "" {
-		klog.V(4).Infof("Cached response used.")
+	if cached != nil {
+		switch {
+		case resp.StatusCode == http.StatusNotModified:
+			klog.V(4).Infof("Cached response revalidated.")
+			if err = cache.put(*cached, cachedBody, request.CacheSize); err != nil {
+				klog.V(4).Infof("Unable to cache the response of %s: %v", request.URL, err)
+			}
+			return cachedBody, nil
+		case resp.StatusCode >= http.StatusInternalServerError:
+			log.Warningf("Unable to get %s: %s, using the response cached %s ago", request.URL, http.StatusText(resp.StatusCode), time.Since(cached.Stored).Round(time.Second))
+			return cachedBody, nil
+		}
 	}
 
 	// We have a non 1xx / 2xx status, return an error
@@ -831,6 +856,18 @@ func HTTPGetRequest(request HTTPRequestParams, cacheFor int) ([]byte, error) {
 		return nil, err
 	}
 
+	if cacheFor > 0 {
+		entry := HTTPCacheEntry{
+			URL:          request.URL,
+			Group:        request.CacheGroup,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		}
+		if err = cache.put(entry, bytes, request.CacheSize); err != nil {
+			klog.V(4).Infof("Unable to cache the response of %s: %v", request.URL, err)
+		}
+	}
+
 	return bytes, err
 }
 
@@ -891,7 +928,14 @@ func ConvertGitSSHRemoteToHTTPS(remote string) string {
 // GetAndExtractZip downloads a zip file from a URL with a http prefix or
 // takes an absolute path prefixed with file:// and extracts it to a destination.
 // pathToUnzip specifies the path within the zip folder to extract
-func GetAndExtractZip(zipURL string, destination string, pathToUnzip string, starterToken string) error {
+// the credentials of the request are used to download the zip file from a URL with a http prefix
+func GetAndExtractZip(request HTTPRequestParams, destination string, pathToUnzip string) error {
+	return GetAndExtractVerifiedZip(request, destination, pathToUnzip, nil)
+}
+
+// GetAndExtractVerifiedZip is GetAndExtractZip calling verify, if not nil, with the zip file before extracting it
+func GetAndExtractVerifiedZip(request HTTPRequestParams, destination string, pathToUnzip string, verify func(zipFile string) error) error {
+	zipURL := request.URL
 	if zipURL == "" {
 		return errors.Errorf("Empty zip url: %s", zipURL)
 	}
@@ -909,10 +953,7 @@ func GetAndExtractZip(zipURL string, destination string, pathToUnzip string, sta
 		pathToZip = path.Join(os.TempDir(), "_"+time+".zip")
 
 		params := DownloadParams{
-			Request: HTTPRequestParams{
-				URL:   zipURL,
-				Token: starterToken,
-			},
+			Request:  request,
 			Filepath: pathToZip,
 		}
 		err := DownloadFile(params)
@@ -929,6 +970,12 @@ func GetAndExtractZip(zipURL string, destination string, pathToUnzip string, sta
 		return errors.Errorf("Invalid Zip URL: %s . Should either be prefixed with file://, http:// or https://", zipURL)
 	}
 
+	if verify != nil {
+		if err := verify(pathToZip); err != nil {
+			return err
+		}
+	}
+
 	filenames, err := Unzip(pathToZip, destination, pathToUnzip)
 	if err != nil {
 		return err
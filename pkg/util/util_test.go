@@ -1,6 +1,7 @@
 package util
 
 import (
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -1250,6 +1251,68 @@ func TestHTTPGetRequest(t *testing.T) {
 	}
 }
 
+func TestHTTPGetRequestWithBasicAuth(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if username, password, ok := req.BasicAuth(); !ok || username != "developer" || password != "password" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err := rw.Write([]byte("OK"))
+		if err != nil {
+			t.Error(err)
+		}
+	}))
+	defer server.Close()
+
+	caFile, err := ioutil.TempFile("", "ca*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(caFile.Name())
+	err = pem.Encode(caFile, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	caFile.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		request HTTPRequestParams
+		wantErr bool
+	}{
+		{
+			name:    "Case 1: valid credentials and certificate authority",
+			request: HTTPRequestParams{URL: server.URL, Username: "developer", Password: "password", CAFile: caFile.Name()},
+		},
+		{
+			name:    "Case 2: invalid credentials",
+			request: HTTPRequestParams{URL: server.URL, Username: "developer", Password: "wrong", CAFile: caFile.Name()},
+			wantErr: true,
+		},
+		{
+			name:    "Case 3: unknown certificate authority",
+			request: HTTPRequestParams{URL: server.URL, Username: "developer", Password: "password"},
+			wantErr: true,
+		},
+		{
+			name:    "Case 4: missing certificate authority file",
+			request: HTTPRequestParams{URL: server.URL, Username: "developer", Password: "password", CAFile: caFile.Name() + ".missing"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HTTPGetRequest(tt.request, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("HTTPGetRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != "OK" {
+				t.Errorf("HTTPGetRequest() = %q, want %q", got, "OK")
+			}
+		})
+	}
+}
+
 func TestFilterIgnores(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -10,6 +10,8 @@ import (
 
 	"github.com/openshift/odo/pkg/devfile/adapters/common"
 	"github.com/openshift/odo/pkg/envinfo"
+	"github.com/openshift/odo/pkg/hooks"
+	"github.com/openshift/odo/pkg/machineoutput"
 	"github.com/openshift/odo/pkg/util"
 
 	"github.com/openshift/odo/pkg/occlient"
@@ -54,6 +56,12 @@ type WatchParameters struct {
 	DevfileRunCmd string
 	// DevfileDebugCmd takes the debug command through the command line and overwrites the devfile debug command
 	DevfileDebugCmd string
+	// Hooks runs the pre-push, post-sync, post-push and push-failed hooks around each push, no hook is run if nil
+	Hooks *hooks.Runner
+	// ProjectName is the project of the component, given to the hooks
+	ProjectName string
+	// Logger reports each sync of the changed files as FileSync events, no event is reported if nil
+	Logger machineoutput.MachineEventLoggingClient
 }
 
 // addRecursiveWatch handles adding watches recursively for the path provided
@@ -162,6 +170,9 @@ func WatchAndPush(client *occlient.Client, out io.Writer, parameters WatchParame
 	// ToDo reduce number of parameters to this function by extracting them into a struct and passing the struct instance instead of passing each of them separately
 	// delayInterval int
 	klog.V(4).Infof("starting WatchAndPush, path: %s, component: %s, ignores %s", parameters.Path, parameters.ComponentName, parameters.FileIgnores)
+	if parameters.DevfileWatchHandler == nil {
+		parameters.Hooks.WarnUnsupported(hooks.PostSync)
+	}
 
 	// these variables must be accessed while holding the changeLock
 	// mutex as they are shared between goroutines to communicate
@@ -333,11 +344,32 @@ func WatchAndPush(client *occlient.Client, out io.Writer, parameters WatchParame
 			}
 			if len(changedFiles) > 0 || len(deletedPaths) > 0 {
 				fmt.Fprintf(out, "Pushing files...\n")
+				if parameters.Logger != nil {
+					parameters.Logger.FileSync(changedFiles, deletedPaths, machineoutput.FileSyncStarted, nil, machineoutput.TimestampNow())
+				}
 				fileInfo, err := os.Stat(parameters.Path)
 				if err != nil {
 					return errors.Wrapf(err, "%s: file doesn't exist", parameters.Path)
 				}
-				if fileInfo.IsDir() {
+				hookParams := hooks.Parameters{
+					Component:    parameters.ComponentName,
+					Application:  parameters.ApplicationName,
+					Project:      parameters.ProjectName,
+					Watch:        true,
+					ChangedFiles: changedFiles,
+					DeletedFiles: deletedPaths,
+				}
+				if parameters.EnvSpecificInfo != nil {
+					hookParams.Debug = parameters.EnvSpecificInfo.GetRunMode() == envinfo.Debug
+				}
+				postSyncHook := func() error {
+					return parameters.Hooks.Run(hooks.PostSync, hookParams)
+				}
+
+				vetoErr := parameters.Hooks.Run(hooks.PrePush, hookParams)
+				if vetoErr != nil {
+					err = vetoErr
+				} else if fileInfo.IsDir() {
 					klog.V(4).Infof("Copying files %s to pod", changedFiles)
 
 					if parameters.DevfileWatchHandler != nil {
@@ -354,6 +386,7 @@ func WatchAndPush(client *occlient.Client, out io.Writer, parameters WatchParame
 							EnvSpecificInfo:          *parameters.EnvSpecificInfo,
 							Debug:                    parameters.EnvSpecificInfo.GetRunMode() == envinfo.Debug,
 							DebugPort:                parameters.EnvSpecificInfo.GetDebugPort(),
+							PostSyncHook:             postSyncHook,
 						}
 
 						err = parameters.DevfileWatchHandler(pushParams, parameters)
@@ -381,6 +414,7 @@ func WatchAndPush(client *occlient.Client, out io.Writer, parameters WatchParame
 							EnvSpecificInfo:          *parameters.EnvSpecificInfo,
 							Debug:                    parameters.EnvSpecificInfo.GetRunMode() == envinfo.Debug,
 							DebugPort:                parameters.EnvSpecificInfo.GetDebugPort(),
+							PostSyncHook:             postSyncHook,
 						}
 
 						err = parameters.DevfileWatchHandler(pushParams, parameters)
@@ -390,6 +424,21 @@ func WatchAndPush(client *occlient.Client, out io.Writer, parameters WatchParame
 					}
 
 				}
+				if vetoErr == nil {
+					if err != nil {
+						hookParams.Error = err.Error()
+						_ = parameters.Hooks.Run(hooks.PushFailed, hookParams)
+					} else {
+						err = parameters.Hooks.Run(hooks.PostPush, hookParams)
+					}
+				}
+				if parameters.Logger != nil {
+					status := machineoutput.FileSyncSucceeded
+					if err != nil {
+						status = machineoutput.FileSyncFailed
+					}
+					parameters.Logger.FileSync(changedFiles, deletedPaths, status, err, machineoutput.TimestampNow())
+				}
 				if err != nil {
 
 					// Log and output, but intentionally not exiting on error here.
@@ -22,6 +22,7 @@ import (
 	"github.com/onsi/gomega/gexec"
 	"github.com/openshift/odo/pkg/util"
 	"github.com/tidwall/gjson"
+	"github.com/xeipuuv/gojsonschema"
 )
 
 // RandString returns a random string of given length
@@ -272,6 +273,21 @@ func IsJSON(s string) bool {
 	return json.Unmarshal([]byte(s), &js) == nil
 }
 
+// MatchJSONSchema checks that each JSON document of the output, one per line when unindented is false,
+// is valid against the schema printed by "odo schema <kind>"
+func MatchJSONSchema(kind string, output string, unindented bool) {
+	schemaLoader := gojsonschema.NewStringLoader(Cmd("odo", "schema", kind).ShouldPass().Out())
+	documents := []string{output}
+	if unindented {
+		documents = strings.Split(strings.TrimSpace(output), "\n")
+	}
+	for _, document := range documents {
+		result, err := gojsonschema.Validate(schemaLoader, gojsonschema.NewStringLoader(document))
+		Expect(err).To(BeNil())
+		Expect(result.Errors()).To(BeEmpty(), "output of kind %s:\n%s", kind, document)
+	}
+}
+
 type CommonVar struct {
 	// Project is new clean project/namespace for each test
 	Project string
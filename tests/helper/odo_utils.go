@@ -66,11 +66,12 @@ func GetPreferenceValue(key string) string {
 	if odoConfigKeyValue == "" {
 		return fmt.Sprintf("%s not found", key)
 	}
-	trimKeyValue := strings.TrimSpace(odoConfigKeyValue)
-	if strings.Compare(key, trimKeyValue) != 0 {
-		return strings.TrimSpace(strings.SplitN(trimKeyValue, " ", 2)[1])
+	// the line holds the parameter, its value if it is set, and the source of the value
+	fields := strings.Fields(odoConfigKeyValue)
+	if len(fields) < 3 {
+		return ""
 	}
-	return ""
+	return strings.Join(fields[1:len(fields)-1], " ")
 }
 
 // DetermineRouteURL takes context path as argument and returns the http URL
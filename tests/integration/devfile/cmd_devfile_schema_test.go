@@ -0,0 +1,54 @@
+package devfile
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/openshift/odo/tests/helper"
+)
+
+var _ = Describe("odo devfile schema command tests", func() {
+	var cmpName string
+	var commonVar helper.CommonVar
+
+	// This is run before every Spec (It)
+	var _ = BeforeEach(func() {
+		commonVar = helper.CommonBeforeEach()
+		cmpName = helper.RandString(6)
+		helper.Chdir(commonVar.Context)
+	})
+
+	// This is run after every Spec (It)
+	var _ = AfterEach(func() {
+		helper.CommonAfterEach(commonVar)
+	})
+
+	Context("When the machine readable output of the commands is validated against the schemas", func() {
+
+		It("should match the schemas before and after the push", func() {
+			helper.Cmd("odo", "create", "nodejs", cmpName, "--project", commonVar.Project).ShouldPass()
+			helper.CopyExample(filepath.Join("source", "devfiles", "nodejs", "project"), commonVar.Context)
+			helper.CopyExampleDevFile(filepath.Join("source", "devfiles", "nodejs", "devfile.yaml"), filepath.Join(commonVar.Context, "devfile.yaml"))
+			helper.Cmd("odo", "url", "create", "example", "--port", "3000", "--host", "1.2.3.4.nip.io", "--ingress").ShouldPass()
+			helper.Cmd("odo", "storage", "create", "data", "--path", "/data", "--size", "1Gi").ShouldPass()
+
+			helper.MatchJSONSchema("url-list", helper.Cmd("odo", "url", "list", "-o", "json").ShouldPass().Out(), false)
+			helper.MatchJSONSchema("storage-list", helper.Cmd("odo", "storage", "list", "-o", "json").ShouldPass().Out(), false)
+			helper.MatchJSONSchema("component-description", helper.Cmd("odo", "describe", "-o", "json").ShouldPass().Out(), false)
+
+			helper.MatchJSONSchema("event", helper.Cmd("odo", "push", "-o", "json").ShouldPass().Out(), true)
+
+			helper.MatchJSONSchema("url-list", helper.Cmd("odo", "url", "list", "-o", "json").ShouldPass().Out(), false)
+			helper.MatchJSONSchema("storage-list", helper.Cmd("odo", "storage", "list", "-o", "json").ShouldPass().Out(), false)
+			helper.MatchJSONSchema("component-list", helper.Cmd("odo", "list", "-o", "json").ShouldPass().Out(), false)
+			helper.MatchJSONSchema("application-list", helper.Cmd("odo", "app", "list", "-o", "json").ShouldPass().Out(), false)
+		})
+
+		It("should match the schemas of the outputs not requiring a component", func() {
+			helper.MatchJSONSchema("registry-list", helper.Cmd("odo", "registry", "list", "-o", "json").ShouldPass().Out(), false)
+			helper.MatchJSONSchema("preference-list", helper.Cmd("odo", "preference", "view", "-o", "json").ShouldPass().Out(), false)
+			helper.MatchJSONSchema("catalog-component-list", helper.Cmd("odo", "catalog", "list", "components", "-o", "json").ShouldPass().Out(), false)
+			helper.MatchJSONSchema("error", helper.Cmd("odo", "describe", "-o", "json").ShouldFail().Err(), false)
+		})
+	})
+})